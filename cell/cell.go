@@ -15,6 +15,8 @@
 // Package cell implements cell options and attributes.
 package cell
 
+import "strings"
+
 // Option is used to provide options for cells on a 2-D terminal.
 type Option interface {
 	// Set sets the provided option.
@@ -25,6 +27,11 @@ type Option interface {
 type Options struct {
 	FgColor Color
 	BgColor Color
+	Attrs   AttributeMask
+
+	// Hyperlink is the URI the text in the cell links to, empty if the cell
+	// doesn't contain a link.
+	Hyperlink string
 }
 
 // Set allows existing options to be passed as an option.
@@ -62,3 +69,114 @@ func BgColor(color Color) Option {
 		co.BgColor = color
 	})
 }
+
+// Hyperlink makes the text in the cell a clickable link to the provided URI.
+// Consecutive cells with the same URI form a single link. Terminals that
+// support hyperlinks display them using the OSC 8 escape sequence, other
+// terminals display the text unchanged.
+func Hyperlink(uri string) Option {
+	return option(func(co *Options) {
+		co.Hyperlink = uri
+	})
+}
+
+// AttributeMask is a bitmask of the text attributes of a cell, e.g. bold or
+// underline. The attributes can be combined.
+type AttributeMask int
+
+// String implements fmt.Stringer()
+func (am AttributeMask) String() string {
+	if am == AttrNone {
+		return "AttrNone"
+	}
+	var names []string
+	for _, a := range attributes {
+		if am.Has(a) {
+			names = append(names, attributeNames[a])
+			am &^= a
+		}
+	}
+	if am != AttrNone {
+		names = append(names, "AttrUnknown")
+	}
+	return strings.Join(names, "|")
+}
+
+// Has returns true if all the attributes in the other mask are set.
+func (am AttributeMask) Has(other AttributeMask) bool {
+	return am&other == other
+}
+
+// attributeNames maps AttributeMask values to human readable names.
+var attributeNames = map[AttributeMask]string{
+	AttrBold:          "AttrBold",
+	AttrItalic:        "AttrItalic",
+	AttrUnderline:     "AttrUnderline",
+	AttrStrikethrough: "AttrStrikethrough",
+	AttrBlink:         "AttrBlink",
+}
+
+// attributes are all the supported attributes in the order of their bits.
+var attributes = []AttributeMask{
+	AttrBold,
+	AttrItalic,
+	AttrUnderline,
+	AttrStrikethrough,
+	AttrBlink,
+}
+
+// AttrNone means that the cell has no text attributes.
+const AttrNone AttributeMask = 0
+
+// Text attributes of a cell.
+// Terminals that don't support an attribute display the text without it.
+const (
+	AttrBold AttributeMask = 1 << iota
+	AttrItalic
+	AttrUnderline
+	AttrStrikethrough
+	AttrBlink
+)
+
+// Attributes sets the text attributes of the cell to exactly the provided
+// mask, replacing any attributes the cell had. Use AttrNone to clear them.
+func Attributes(am AttributeMask) Option {
+	return option(func(co *Options) {
+		co.Attrs = am
+	})
+}
+
+// Bold makes the text in the cell bold.
+func Bold() Option {
+	return option(func(co *Options) {
+		co.Attrs |= AttrBold
+	})
+}
+
+// Italic makes the text in the cell italic.
+func Italic() Option {
+	return option(func(co *Options) {
+		co.Attrs |= AttrItalic
+	})
+}
+
+// Underline underlines the text in the cell.
+func Underline() Option {
+	return option(func(co *Options) {
+		co.Attrs |= AttrUnderline
+	})
+}
+
+// Strikethrough strikes through the text in the cell.
+func Strikethrough() Option {
+	return option(func(co *Options) {
+		co.Attrs |= AttrStrikethrough
+	})
+}
+
+// Blink makes the text in the cell blink.
+func Blink() Option {
+	return option(func(co *Options) {
+		co.Attrs |= AttrBlink
+	})
+}
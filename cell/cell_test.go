@@ -72,6 +72,47 @@ func TestNewOptions(t *testing.T) {
 				BgColor: ColorMagenta,
 			},
 		},
+		{
+			desc: "setting text attributes",
+			opts: []Option{
+				Bold(),
+				Italic(),
+				Underline(),
+				Strikethrough(),
+				Blink(),
+			},
+			want: &Options{
+				Attrs: AttrBold | AttrItalic | AttrUnderline | AttrStrikethrough | AttrBlink,
+			},
+		},
+		{
+			desc: "attributes replace all the text attributes",
+			opts: []Option{
+				Bold(),
+				Italic(),
+				Attributes(AttrUnderline),
+			},
+			want: &Options{
+				Attrs: AttrUnderline,
+			},
+		},
+		{
+			desc: "setting a hyperlink",
+			opts: []Option{
+				Hyperlink("https://github.com/mum4k/termdash"),
+			},
+			want: &Options{
+				Hyperlink: "https://github.com/mum4k/termdash",
+			},
+		},
+		{
+			desc: "attributes can clear the text attributes",
+			opts: []Option{
+				Bold(),
+				Attributes(AttrNone),
+			},
+			want: &Options{},
+		},
 	}
 
 	for _, tc := range tests {
@@ -83,3 +124,40 @@ func TestNewOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestAttributeMaskString(t *testing.T) {
+	tests := []struct {
+		desc string
+		am   AttributeMask
+		want string
+	}{
+		{
+			desc: "no attributes",
+			am:   AttrNone,
+			want: "AttrNone",
+		},
+		{
+			desc: "single attribute",
+			am:   AttrItalic,
+			want: "AttrItalic",
+		},
+		{
+			desc: "multiple attributes",
+			am:   AttrBold | AttrUnderline | AttrBlink,
+			want: "AttrBold|AttrUnderline|AttrBlink",
+		},
+		{
+			desc: "unknown attribute",
+			am:   AttrStrikethrough | AttributeMask(1<<20),
+			want: "AttrStrikethrough|AttrUnknown",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.am.String(); got != tc.want {
+				t.Errorf("String => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
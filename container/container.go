@@ -25,7 +25,9 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"reflect"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/private/alignfor"
@@ -69,6 +71,33 @@ type Container struct {
 	// mu protects the container tree.
 	// All containers in the tree share the same lock.
 	mu *sync.Mutex
+
+	// drawHook if not nil, is called after each widget in the tree is drawn.
+	// Only set on the root container.
+	drawHook func(widgetName string, duration time.Duration)
+
+	// debugLayout indicates that the bounding boxes of widgets should be drawn
+	// over their content. Only set on the root container.
+	debugLayout bool
+
+	// minimized indicates that this container is minimized.
+	minimized bool
+
+	// maximized if not nil, is the container that is currently maximized.
+	// Only set on the root container.
+	maximized *Container
+
+	// widgetTimeout if positive, is the maximum duration of a widget's Draw
+	// call. Only set on the root container.
+	widgetTimeout time.Duration
+
+	// onWidgetError if not nil, is called when a widget's Draw call times
+	// out. Only set on the root container.
+	onWidgetError func(widgetapi.Widget, error)
+
+	// pendingDraw if not nil, receives the result of the widget's Draw call
+	// that timed out and is still running.
+	pendingDraw chan error
 }
 
 // String represents the container metadata in a human readable format.
@@ -171,6 +200,9 @@ func (c *Container) split() (image.Rectangle, image.Rectangle, error) {
 	if err != nil {
 		return image.ZR, image.ZR, err
 	}
+	if first, second, ok, err := c.minimizedSplit(ar); err != nil || ok {
+		return first, second, err
+	}
 	if c.opts.splitFixed > DefaultSplitFixed {
 		if c.opts.split == splitTypeVertical {
 			return area.VSplitCells(ar, c.opts.splitFixed)
@@ -205,6 +237,7 @@ func (c *Container) createSecond(opts []Option) error {
 }
 
 // Draw draws this container and all of its sub containers.
+// Returns a *DrawError if one of the widgets fails to draw.
 func (c *Container) Draw() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -226,6 +259,53 @@ func (c *Container) Draw() error {
 	return drawTree(c)
 }
 
+// DrawTo draws this container and all of its sub containers onto the
+// provided terminal instead of the one the container was created for, e.g. to
+// capture a single frame. The layout is computed for the size of the
+// provided terminal. The container keeps using its original terminal
+// afterwards.
+// Returns a *DrawError if one of the widgets fails to draw.
+func (c *Container) DrawTo(t terminalapi.Terminal) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	root := rootCont(c)
+	restore := swapTerm(root, t)
+	defer restore()
+
+	ar, err := area.FromSize(t.Size())
+	if err != nil {
+		return err
+	}
+	c.focusTracker.updateArea(ar)
+	return drawTree(c)
+}
+
+// swapTerm places all the containers in the tree onto the provided terminal.
+// Returns a function that places them back onto their original terminal and
+// restores their areas.
+// Caller must hold c.mu.
+func swapTerm(root *Container, t terminalapi.Terminal) func() {
+	orig := root.term
+	areas := map[*Container]image.Rectangle{}
+	var errStr string
+	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+		areas[c] = c.area
+		c.term = t
+		return nil
+	}))
+
+	return func() {
+		for c, ar := range areas {
+			c.area = ar
+			c.term = orig
+		}
+		if ar, err := area.FromSize(orig.Size()); err == nil {
+			root.focusTracker.updateArea(ar)
+		}
+	}
+}
+
 // Update updates container with the specified id by setting the provided
 // options. This can be used to perform dynamic layout changes, i.e. anything
 // between replacing the widget in the container and completely changing the
@@ -254,6 +334,37 @@ func (c *Container) Update(id string, opts ...Option) error {
 	if !c.focusTracker.reachableFrom(c) {
 		c.focusTracker.setActive(target)
 	}
+
+	// The maximized container might have been removed too, if so restore
+	// the layout.
+	if root := rootCont(c); root.maximized != nil && !reachable(root, root.maximized) {
+		root.maximized = nil
+	}
+	return nil
+}
+
+// RemoveWidget removes the provided widget from the container tree and
+// releases its resources by calling its Destroy method if it implements
+// widgetapi.Destroyable. The container that held the widget remains in the
+// layout and becomes empty.
+// Widgets replaced or removed by Update aren't destroyed, since they can be
+// placed into the layout again.
+// Returns an error if the widget isn't placed in this container or in any of
+// its sub containers, or if the widget fails to destroy.
+func (c *Container) RemoveWidget(w widgetapi.Widget) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	target, err := findWidget(c, w)
+	if err != nil {
+		return err
+	}
+	c.clearNeeded = true
+	target.opts.widget = nil
+
+	if err := widgetapi.DestroyWidget(w); err != nil {
+		return fmt.Errorf("widget %T failed to destroy: %w", w, err)
+	}
 	return nil
 }
 
@@ -309,6 +420,16 @@ func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error)
 		}, nil
 
 	case *terminalapi.Keyboard:
+		if c.focusKey(e) {
+			return func() error { return nil }, nil
+		}
+		if notify, consumed := c.minMaxKey(e); consumed {
+			return func() error {
+				notify()
+				return nil
+			}, nil
+		}
+
 		targets := c.keyEvTargets()
 		return func() error {
 			for _, w := range targets {
@@ -324,6 +445,69 @@ func (c *Container) prepareEvTargets(ev terminalapi.Event) (func() error, error)
 	}
 }
 
+// focusKey processes the keyboard event on behalf of the container and moves
+// the keyboard focus if the key is one configured with KeyFocusNext or
+// KeyFocusPrevious.
+// Returns true if the event was consumed.
+// Caller must hold c.mu.
+func (c *Container) focusKey(k *terminalapi.Keyboard) bool {
+	root := rootCont(c)
+	switch opts := root.opts; {
+	case opts.keyFocusNext != nil && k.Key == *opts.keyFocusNext:
+		c.focusTracker.next(root, opts.focusCycle)
+		return true
+	case opts.keyFocusPrevious != nil && k.Key == *opts.keyFocusPrevious:
+		c.focusTracker.previous(root, opts.focusCycle)
+		return true
+	}
+	return false
+}
+
+// FocusedWidget returns the widget in the container that currently has the
+// keyboard focus. Returns nil if the focused container doesn't have a widget.
+func (c *Container) FocusedWidget() widgetapi.Widget {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.focusTracker.container.opts.widget
+}
+
+// Widgets returns all the widgets placed in this container and its sub
+// containers in the depth-first pre-order of the container tree. Call it on
+// the root container to get all the widgets in the layout.
+// Meant for tests and diagnostics, this method doesn't acquire the lock of
+// the container, so it can be called from within a widget's Draw. It mustn't
+// be called concurrently with Update.
+func (c *Container) Widgets() []widgetapi.Widget {
+	var (
+		errStr  string
+		widgets []widgetapi.Widget
+	)
+	preOrder(c, &errStr, visitFunc(func(cur *Container) error {
+		if cur.hasWidget() {
+			widgets = append(widgets, cur.opts.widget)
+		}
+		return nil
+	}))
+	return widgets
+}
+
+// WidgetsByType is like Widgets, but only returns widgets of the provided
+// type. If the type is an interface, returns the widgets that implement it.
+// Returns nil if the type is nil.
+func (c *Container) WidgetsByType(t reflect.Type) []widgetapi.Widget {
+	if t == nil {
+		return nil
+	}
+	var widgets []widgetapi.Widget
+	for _, w := range c.Widgets() {
+		wt := reflect.TypeOf(w)
+		if wt == t || (t.Kind() == reflect.Interface && wt.Implements(t)) {
+			widgets = append(widgets, w)
+		}
+	}
+	return widgets
+}
+
 // keyEvTargets returns those widgets found in the container that should
 // receive this keyboard event.
 // Caller must hold c.mu.
@@ -336,7 +520,7 @@ func (c *Container) keyEvTargets() []widgetapi.Widget {
 	// All the widgets that should receive this event.
 	// For now stable ordering (preOrder).
 	preOrder(c, &errStr, visitFunc(func(cur *Container) error {
-		if !cur.hasWidget() {
+		if !cur.hasWidget() || !cur.visible() {
 			return nil
 		}
 
@@ -388,7 +572,7 @@ func (c *Container) mouseEvTargets(m *terminalapi.Mouse) ([]*mouseEvTarget, erro
 	// All the widgets that should receive this event.
 	// For now stable ordering (preOrder).
 	preOrder(c, &errStr, visitFunc(func(cur *Container) error {
-		if !cur.hasWidget() {
+		if !cur.hasWidget() || !cur.visible() {
 			return nil
 		}
 
@@ -452,6 +636,58 @@ func (c *Container) Subscribe(eds *event.DistributionSystem) {
 	}, event.MaxRepetitive(maxReps))
 }
 
+// SetDrawHook registers a function that is called each time a widget in the
+// container tree gets drawn, with the name of the widget and the duration of
+// its Draw call. The widget name is the ID of its container if one was
+// provided, otherwise the Go type of the widget.
+// This method is private to termdash, stability isn't guaranteed and changes
+// won't be backward compatible.
+func (c *Container) SetDrawHook(h func(widgetName string, duration time.Duration)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rootCont(c).drawHook = h
+}
+
+// SetDebugLayout enables or disables drawing of the bounding rectangle of
+// each widget canvas along with the type of the widget over the content the
+// widget draws.
+// This method is private to termdash, stability isn't guaranteed and changes
+// won't be backward compatible.
+func (c *Container) SetDebugLayout(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rootCont(c).debugLayout = enabled
+}
+
+// SetWidgetTimeout limits the duration of each widget's Draw call to the
+// provided duration. Widgets whose Draw call doesn't return in time keep
+// displaying what they drew previously and the provided function is called
+// with the widget and an error describing the timeout. A non-positive
+// duration disables the timeout.
+// This method is private to termdash, stability isn't guaranteed and changes
+// won't be backward compatible.
+func (c *Container) SetWidgetTimeout(d time.Duration, onErr func(widgetapi.Widget, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	root := rootCont(c)
+	root.widgetTimeout = d
+	root.onWidgetError = onErr
+}
+
+// widgetName returns the name of the widget in this container as reported to
+// the draw hook.
+func (c *Container) widgetName() string {
+	if c.opts.id != "" {
+		return c.opts.id
+	}
+	return c.widgetType()
+}
+
+// widgetType returns the Go type of the widget in this container.
+func (c *Container) widgetType() string {
+	return fmt.Sprintf("%T", c.opts.widget)
+}
+
 // adjustMouseEv adjusts the mouse event relative to the widget area.
 func adjustMouseEv(m *terminalapi.Mouse, wArea image.Rectangle) *terminalapi.Mouse {
 	// The sent mouse coordinate is relative to the widget canvas, i.e. zero
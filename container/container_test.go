@@ -15,8 +15,10 @@
 package container
 
 import (
+	"errors"
 	"fmt"
 	"image"
+	"reflect"
 	"sync"
 	"testing"
 	"time"
@@ -1047,6 +1049,195 @@ func TestNew(t *testing.T) {
 
 }
 
+func TestWidgets(t *testing.T) {
+	ft, err := faketerm.New(image.Point{10, 10})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	left := fakewidget.New(widgetapi.Options{})
+	top := newFillWidget('x')
+	bottom := fakewidget.New(widgetapi.Options{})
+	root, err := New(
+		ft,
+		SplitVertical(
+			Left(
+				PlaceWidget(left),
+			),
+			Right(
+				SplitHorizontal(
+					Top(
+						PlaceWidget(top),
+					),
+					Bottom(
+						PlaceWidget(bottom),
+					),
+				),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		desc string
+		got  []widgetapi.Widget
+		want []widgetapi.Widget
+	}{
+		{
+			desc: "all widgets in the root container",
+			got:  root.Widgets(),
+			want: []widgetapi.Widget{left, top, bottom},
+		},
+		{
+			desc: "all widgets in a sub container",
+			got:  root.second.Widgets(),
+			want: []widgetapi.Widget{top, bottom},
+		},
+		{
+			desc: "no widgets in an empty container",
+			got: func() []widgetapi.Widget {
+				c, err := New(ft)
+				if err != nil {
+					t.Fatalf("New => unexpected error: %v", err)
+				}
+				return c.Widgets()
+			}(),
+		},
+		{
+			desc: "widgets of a concrete type",
+			got:  root.WidgetsByType(reflect.TypeOf(&fakewidget.Mirror{})),
+			want: []widgetapi.Widget{left, bottom},
+		},
+		{
+			desc: "widgets that implement an interface",
+			got:  root.WidgetsByType(reflect.TypeOf((*widgetapi.Widget)(nil)).Elem()),
+			want: []widgetapi.Widget{left, top, bottom},
+		},
+		{
+			desc: "no widgets of a nil type",
+			got:  root.WidgetsByType(nil),
+		},
+		{
+			desc: "no widgets of a type that isn't placed",
+			got:  root.WidgetsByType(reflect.TypeOf("")),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if len(tc.got) != len(tc.want) {
+				t.Fatalf("got %d widgets %v, want %d widgets %v", len(tc.got), tc.got, len(tc.want), tc.want)
+			}
+			for i, w := range tc.want {
+				if tc.got[i] != w {
+					t.Errorf("widget at index %d => got %p, want %p", i, tc.got[i], w)
+				}
+			}
+		})
+	}
+}
+
+// destroyWidget is a widget that records calls to Destroy.
+type destroyWidget struct {
+	*fakewidget.Mirror
+
+	// err if not nil, is returned from Destroy.
+	err error
+	// destroyed counts the calls to Destroy.
+	destroyed int
+}
+
+// Destroy implements widgetapi.Destroyable.Destroy.
+func (dw *destroyWidget) Destroy() error {
+	dw.destroyed++
+	return dw.err
+}
+
+func TestRemoveWidget(t *testing.T) {
+	tests := []struct {
+		desc string
+		// remove returns the widget to remove given the placed ones.
+		remove        func(left *destroyWidget, right widgetapi.Widget) widgetapi.Widget
+		destroyErr    error
+		wantWidgets   int
+		wantDestroyed int
+		wantErr       bool
+	}{
+		{
+			desc: "removes and destroys the widget",
+			remove: func(left *destroyWidget, _ widgetapi.Widget) widgetapi.Widget {
+				return left
+			},
+			wantWidgets:   1,
+			wantDestroyed: 1,
+		},
+		{
+			desc: "returns the error when the widget fails to destroy",
+			remove: func(left *destroyWidget, _ widgetapi.Widget) widgetapi.Widget {
+				return left
+			},
+			destroyErr:    errors.New("destroy failed"),
+			wantWidgets:   1,
+			wantDestroyed: 1,
+			wantErr:       true,
+		},
+		{
+			desc: "removes a widget that isn't destroyable",
+			remove: func(_ *destroyWidget, right widgetapi.Widget) widgetapi.Widget {
+				return right
+			},
+			wantWidgets: 1,
+		},
+		{
+			desc: "fails on a widget that isn't placed",
+			remove: func(*destroyWidget, widgetapi.Widget) widgetapi.Widget {
+				return fakewidget.New(widgetapi.Options{})
+			},
+			wantWidgets: 2,
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{30, 10})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			left := &destroyWidget{Mirror: fakewidget.New(widgetapi.Options{}), err: tc.destroyErr}
+			right := fakewidget.New(widgetapi.Options{})
+			cont, err := New(
+				ft,
+				SplitVertical(
+					Left(PlaceWidget(left)),
+					Right(PlaceWidget(right)),
+				),
+			)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			err = cont.RemoveWidget(tc.remove(left, right))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("RemoveWidget => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if tc.destroyErr != nil && !errors.Is(err, tc.destroyErr) {
+				t.Errorf("RemoveWidget => got error %v, want it to wrap %v", err, tc.destroyErr)
+			}
+			if got := len(cont.Widgets()); got != tc.wantWidgets {
+				t.Errorf("RemoveWidget => got %d widgets, want %d", got, tc.wantWidgets)
+			}
+			if got := left.destroyed; got != tc.wantDestroyed {
+				t.Errorf("RemoveWidget => got %d calls to Destroy, want %d", got, tc.wantDestroyed)
+			}
+			if err := cont.Draw(); err != nil {
+				t.Errorf("Draw => unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 // errorHandler just stores the last error received.
 type errorHandler struct {
 	err error
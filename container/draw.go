@@ -20,17 +20,42 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"time"
 
+	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/alignfor"
 	"github.com/mum4k/termdash/private/area"
 	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/draw"
 	"github.com/mum4k/termdash/widgetapi"
 )
 
+// DrawError is returned when a widget placed in a container fails to draw.
+type DrawError struct {
+	// Widget is the widget that failed to draw.
+	Widget widgetapi.Widget
+	// Err is the error the widget failed with.
+	Err error
+}
+
+// Error implements error.Error.
+func (e *DrawError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error the widget failed with.
+func (e *DrawError) Unwrap() error {
+	return e.Err
+}
+
 // drawTree draws this container and all of its sub containers.
 func drawTree(c *Container) error {
-	var errStr string
+	var (
+		errStr string
+		// drawErr is the error of the container that failed to draw.
+		drawErr error
+	)
 
 	root := rootCont(c)
 	size := root.term.Size()
@@ -40,7 +65,27 @@ func drawTree(c *Container) error {
 	}
 	root.area = ar
 
-	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+	start := root
+	if m := root.maximized; m != nil {
+		ar, err := m.opts.margin.apply(image.Rect(0, 0, size.X, size.Y))
+		if err != nil {
+			return err
+		}
+		m.area = ar
+		start = m
+	}
+
+	preOrder(start, &errStr, visitFunc(func(c *Container) error {
+		if c.hidden() {
+			return nil
+		}
+		if c.minimized {
+			if max := c.area.Min.Y + minimizedHeight; c.area.Max.Y > max {
+				c.area.Max.Y = max
+			}
+			return drawMinimized(c)
+		}
+
 		first, second, err := c.split()
 		if err != nil {
 			return err
@@ -60,8 +105,15 @@ func drawTree(c *Container) error {
 			}
 			c.second.area = ar
 		}
-		return drawCont(c)
+		if err := drawCont(c); err != nil {
+			drawErr = err
+			return err
+		}
+		return nil
 	}))
+	if drawErr != nil {
+		return drawErr
+	}
 	if errStr != "" {
 		return errors.New(errStr)
 	}
@@ -84,26 +136,100 @@ func drawBorder(c *Container) error {
 		return err
 	}
 
-	var cOpts []cell.Option
+	if err := draw.Border(cvs, ar, borderOpts(c)...); err != nil {
+		return err
+	}
+	return cvs.Apply(c.term)
+}
+
+// borderCellOpts returns the cell options for the border and the title of
+// the container.
+func borderCellOpts(c *Container) []cell.Option {
 	if c.focusTracker.isActive(c) {
-		cOpts = append(cOpts, cell.FgColor(c.opts.inherited.focusedColor))
-	} else {
-		cOpts = append(cOpts, cell.FgColor(c.opts.inherited.borderColor))
+		return []cell.Option{cell.FgColor(c.opts.inherited.focusedColor)}
 	}
+	return []cell.Option{cell.FgColor(c.opts.inherited.borderColor)}
+}
 
-	if err := draw.Border(cvs, ar,
+// borderOpts returns the options for drawing the border of the container.
+func borderOpts(c *Container) []draw.BorderOption {
+	cOpts := borderCellOpts(c)
+	return []draw.BorderOption{
 		draw.BorderLineStyle(c.opts.border),
 		draw.BorderTitle(c.opts.borderTitle, draw.OverrunModeThreeDot, cOpts...),
 		draw.BorderTitleAlign(c.opts.borderTitleHAlign),
 		draw.BorderCellOpts(cOpts...),
-	); err != nil {
+	}
+}
+
+// drawMinimized draws the title bar of a minimized container.
+// If the container has a border, the title bar is the top line of the border,
+// otherwise it only contains the border title.
+func drawMinimized(c *Container) error {
+	if c.area.Dx() < 1 || c.area.Dy() < 1 {
+		return nil
+	}
+
+	cvs, err := canvas.New(c.area)
+	if err != nil {
 		return err
 	}
+
+	if c.hasBorder() && cvs.Area().Dx() >= 2 {
+		// Draw a border two cells high and keep only its top line.
+		border, err := canvas.New(image.Rect(0, 0, cvs.Area().Dx(), 2))
+		if err != nil {
+			return err
+		}
+		if err := draw.Border(border, border.Area(), borderOpts(c)...); err != nil {
+			return err
+		}
+		for x := 0; x < cvs.Area().Dx(); {
+			p := image.Point{x, 0}
+			bc, err := border.Cell(p)
+			if err != nil {
+				return err
+			}
+			cells, err := cvs.SetCell(p, bc.Rune, bc.Opts)
+			if err != nil {
+				return err
+			}
+			x += cells
+		}
+		return cvs.Apply(c.term)
+	}
+
+	if c.opts.borderTitle != "" {
+		start, err := alignfor.Text(cvs.Area(), c.opts.borderTitle, c.opts.borderTitleHAlign, align.VerticalTop)
+		if err != nil {
+			return err
+		}
+		if err := draw.Text(cvs, c.opts.borderTitle, start,
+			draw.TextCellOpts(borderCellOpts(c)...),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+	}
 	return cvs.Apply(c.term)
 }
 
 // drawWidget requests the widget to draw on the canvas.
 func drawWidget(c *Container) error {
+	if c.pendingDraw != nil {
+		select {
+		case err := <-c.pendingDraw:
+			c.pendingDraw = nil
+			if dp, ok := err.(*drawPanic); ok {
+				panic(dp.value)
+			}
+		default:
+			// The previous Draw call timed out and is still running, the
+			// widget keeps displaying its previous content.
+			return nil
+		}
+	}
+
 	widgetArea, err := c.widgetArea()
 	if err != nil {
 		return err
@@ -135,12 +261,97 @@ func drawWidget(c *Container) error {
 		Focused: c.focusTracker.isActive(c),
 	}
 
-	if err := c.opts.widget.Draw(cvs, meta); err != nil {
+	start := time.Now()
+	drawn, err := callDraw(c, cvs, meta)
+	if err != nil {
 		return err
 	}
+	if !drawn {
+		return nil
+	}
+	root := rootCont(c)
+	if hook := root.drawHook; hook != nil {
+		hook(c.widgetName(), time.Since(start))
+	}
+	if root.debugLayout {
+		if err := drawDebugLayout(c, cvs); err != nil {
+			return err
+		}
+	}
 	return cvs.Apply(c.term)
 }
 
+// drawPanic is sent instead of the result of the Draw call when the widget
+// panicked in the goroutine started by callDraw.
+type drawPanic struct {
+	value interface{}
+}
+
+// Error implements error.Error.
+func (dp *drawPanic) Error() string {
+	return fmt.Sprintf("the Draw call panicked: %v", dp.value)
+}
+
+// callDraw calls the Draw method of the widget in the container.
+// If a widget timeout is configured and the call doesn't return in time,
+// reports the timeout and returns false, in which case the canvas must not be
+// applied to the terminal.
+// A panic in the Draw call is repeated in the calling goroutine, so that it
+// behaves the same as without the timeout.
+func callDraw(c *Container, cvs *canvas.Canvas, meta *widgetapi.Meta) (bool, error) {
+	root := rootCont(c)
+	if root.widgetTimeout <= 0 {
+		return true, c.opts.widget.Draw(cvs, meta)
+	}
+
+	w := c.opts.widget
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- &drawPanic{value: r}
+			}
+		}()
+		done <- w.Draw(cvs, meta)
+	}()
+
+	timer := time.NewTimer(root.widgetTimeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		if dp, ok := err.(*drawPanic); ok {
+			panic(dp.value)
+		}
+		return true, err
+	case <-timer.C:
+		c.pendingDraw = done
+		if fn := root.onWidgetError; fn != nil {
+			fn(w, fmt.Errorf("the Draw call of widget %s didn't return within %v", c.widgetName(), root.widgetTimeout))
+		}
+		return false, nil
+	}
+}
+
+// debugLayoutColor is the color of the bounding rectangles drawn around
+// widgets when debugging the layout. Chosen to stand out, no part of termdash
+// uses it by default.
+const debugLayoutColor = cell.ColorMagenta
+
+// drawDebugLayout draws the bounding rectangle of the widget canvas and the
+// type of the widget over the content the widget has drawn.
+// Does nothing if the canvas is too small to fit the rectangle.
+func drawDebugLayout(c *Container, cvs *canvas.Canvas) error {
+	ar := cvs.Area()
+	if ar.Dx() < 2 || ar.Dy() < 2 {
+		return nil
+	}
+	cOpts := []cell.Option{cell.FgColor(debugLayoutColor)}
+	return draw.Border(cvs, ar,
+		draw.BorderTitle(c.widgetType(), draw.OverrunModeThreeDot, cOpts...),
+		draw.BorderCellOpts(cOpts...),
+	)
+}
+
 // drawResize draws an unicode character indicating that the size is too small to draw this container.
 // Does nothing if the size is smaller than one cell, leaving no space for the character.
 func drawResize(c *Container, area image.Rectangle) error {
@@ -169,7 +380,10 @@ func drawCont(c *Container) error {
 	}
 
 	if err := drawWidget(c); err != nil {
-		return fmt.Errorf("unable to draw widget %T: %v", c.opts.widget, err)
+		return &DrawError{
+			Widget: c.opts.widget,
+			Err:    fmt.Errorf("unable to draw widget %T: %v", c.opts.widget, err),
+		}
 	}
 	return nil
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/canvas/testcanvas"
 	"github.com/mum4k/termdash/private/draw"
 	"github.com/mum4k/termdash/private/draw/testdraw"
@@ -29,6 +30,29 @@ import (
 	"github.com/mum4k/termdash/widgetapi"
 )
 
+// fillWidget is a widget that fills its entire canvas with a rune.
+type fillWidget struct {
+	*fakewidget.Mirror
+
+	r    rune
+	opts []cell.Option
+}
+
+// newFillWidget returns a new fillWidget that fills the canvas with the rune
+// and the cell options.
+func newFillWidget(r rune, opts ...cell.Option) *fillWidget {
+	return &fillWidget{
+		Mirror: fakewidget.New(widgetapi.Options{}),
+		r:      r,
+		opts:   opts,
+	}
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (fw *fillWidget) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	return cvs.SetAreaCells(cvs.Area(), fw.r, fw.opts...)
+}
+
 func TestDrawWidget(t *testing.T) {
 	tests := []struct {
 		desc      string
@@ -64,6 +88,74 @@ func TestDrawWidget(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:     "draws widget bounding box in debug layout mode",
+			termSize: image.Point{12, 6},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				c, err := New(
+					ft,
+					Border(linestyle.Light),
+					PlaceWidget(fakewidget.New(widgetapi.Options{})),
+				)
+				if err != nil {
+					return nil, err
+				}
+				c.SetDebugLayout(true)
+				return c, nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				// Container border.
+				testdraw.MustBorder(
+					cvs,
+					cvs.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+
+				wCvs := testcanvas.MustNew(image.Rect(1, 1, 11, 5))
+				fakewidget.MustDraw(
+					ft,
+					wCvs,
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				// Debug layout bounding box.
+				testdraw.MustBorder(
+					wCvs,
+					wCvs.Area(),
+					draw.BorderTitle("*fakewidget.Mirror", draw.OverrunModeThreeDot, cell.FgColor(cell.ColorMagenta)),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorMagenta)),
+				)
+
+				testcanvas.MustCopyTo(wCvs, cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "draws the background set by the widget",
+			termSize: image.Point{8, 4},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					Border(linestyle.Light),
+					PlaceWidget(newFillWidget('世', cell.FgColor(cell.ColorBlue), cell.BgColor(cell.ColorRed))),
+				)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(
+					cvs,
+					cvs.Area(),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorYellow)),
+				)
+				testcanvas.MustSetAreaCells(cvs, image.Rect(1, 1, 7, 3), '世', cell.FgColor(cell.ColorBlue), cell.BgColor(cell.ColorRed))
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
 		{
 			desc:     "absolute margin on root container",
 			termSize: image.Point{20, 10},
@@ -18,6 +18,7 @@ package container
 
 import (
 	"image"
+	"sort"
 
 	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/private/button"
@@ -33,7 +34,7 @@ func pointCont(c *Container, p image.Point) *Container {
 		cont   *Container
 	)
 	postOrder(rootCont(c), &errStr, visitFunc(func(c *Container) error {
-		if p.In(c.area) && cont == nil {
+		if p.In(c.area) && cont == nil && !c.hidden() {
 			cont = c
 		}
 		return nil
@@ -102,15 +103,75 @@ func (ft *focusTracker) updateArea(ar image.Rectangle) {
 // reachableFrom asserts whether the currently focused container is reachable
 // from the provided node in the tree.
 func (ft *focusTracker) reachableFrom(node *Container) bool {
+	return reachable(node, ft.container)
+}
+
+// focusRing returns the containers that can be focused by the keys
+// configured with KeyFocusNext and KeyFocusPrevious in the order in which they
+// are traversed.
+func focusRing(root *Container) []*Container {
 	var (
-		errStr    string
-		reachable bool
+		errStr string
+		ring   []*Container
 	)
-	preOrder(node, &errStr, visitFunc(func(c *Container) error {
-		if c == ft.container {
-			reachable = true
+	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+		if c.hasWidget() && c.visible() && c.opts.tabIndex >= 0 {
+			ring = append(ring, c)
 		}
 		return nil
 	}))
-	return reachable
+	sort.SliceStable(ring, func(i, j int) bool {
+		return ring[i].opts.tabIndex < ring[j].opts.tabIndex
+	})
+	return ring
+}
+
+// next moves the focus to the next container in the focus ring of the tree
+// under root. If cycle is true, the focus moves from the last container to the
+// first one, otherwise it stays on the last one.
+func (ft *focusTracker) next(root *Container, cycle bool) {
+	ft.step(root, 1, cycle)
+}
+
+// previous moves the focus to the previous container in the focus ring of the
+// tree under root. If cycle is true, the focus moves from the first container
+// to the last one, otherwise it stays on the first one.
+func (ft *focusTracker) previous(root *Container, cycle bool) {
+	ft.step(root, -1, cycle)
+}
+
+// step moves the focus by the specified number of containers in the focus
+// ring. If the currently focused container isn't in the focus ring, the focus
+// moves to the first or the last container in the ring depending on the
+// direction.
+func (ft *focusTracker) step(root *Container, step int, cycle bool) {
+	ring := focusRing(root)
+	if len(ring) == 0 {
+		return
+	}
+
+	cur := -1
+	for i, c := range ring {
+		if c == ft.container {
+			cur = i
+			break
+		}
+	}
+
+	var next int
+	switch {
+	case cur == -1 && step > 0:
+		next = 0
+	case cur == -1:
+		next = len(ring) - 1
+	default:
+		next = cur + step
+		if next < 0 || next >= len(ring) {
+			if !cycle {
+				return
+			}
+			next = (next + len(ring)) % len(ring)
+		}
+	}
+	ft.container = ring[next]
 }
@@ -21,12 +21,15 @@ import (
 	"time"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/mouse"
 	"github.com/mum4k/termdash/private/event"
 	"github.com/mum4k/termdash/private/event/testevent"
 	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
 	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
 )
 
 // pointCase is a test case for the pointCont function.
@@ -453,3 +456,219 @@ func TestFocusTrackerMouse(t *testing.T) {
 		})
 	}
 }
+
+func TestFocusKeys(t *testing.T) {
+	const (
+		keyNext = keyboard.KeyTab
+		keyPrev = keyboard.KeyCtrlB
+	)
+
+	// threeWidgets creates a container with three widgets, the arguments are
+	// the options for the containers of the individual widgets.
+	threeWidgets := func(ft *faketerm.Terminal, w []widgetapi.Widget, rootOpts []Option, opts ...[]Option) (*Container, error) {
+		return New(
+			ft,
+			append(rootOpts,
+				SplitVertical(
+					Left(append(opts[0], PlaceWidget(w[0]))...),
+					Right(
+						SplitHorizontal(
+							Top(append(opts[1], PlaceWidget(w[1]))...),
+							Bottom(append(opts[2], PlaceWidget(w[2]))...),
+						),
+					),
+				),
+			)...,
+		)
+	}
+
+	tests := []struct {
+		desc      string
+		container func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error)
+		events    []*terminalapi.Keyboard
+		// wantFocused is the index of the focused widget or -1 if no widget
+		// should be focused.
+		wantFocused int
+		wantErr     bool
+	}{
+		{
+			desc: "initially no widget is focused",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, []Option{KeyFocusNext(keyNext)}, nil, nil, nil)
+			},
+			wantFocused: -1,
+		},
+		{
+			desc: "focus doesn't move when the keys aren't configured",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, nil, nil, nil, nil)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},
+			},
+			wantFocused: -1,
+		},
+		{
+			desc: "next key moves the focus in declaration order",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, []Option{KeyFocusNext(keyNext)}, nil, nil, nil)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},
+				{Key: keyNext},
+			},
+			wantFocused: 1,
+		},
+		{
+			desc: "next key wraps around by default",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, []Option{KeyFocusNext(keyNext)}, nil, nil, nil)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},
+				{Key: keyNext},
+				{Key: keyNext},
+				{Key: keyNext},
+			},
+			wantFocused: 0,
+		},
+		{
+			desc: "next key stops at the last widget when not cycling",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, []Option{KeyFocusNext(keyNext), FocusCycle(false)}, nil, nil, nil)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},
+				{Key: keyNext},
+				{Key: keyNext},
+				{Key: keyNext},
+			},
+			wantFocused: 2,
+		},
+		{
+			desc: "previous key starts at the last widget",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, []Option{KeyFocusPrevious(keyPrev)}, nil, nil, nil)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyPrev},
+			},
+			wantFocused: 2,
+		},
+		{
+			desc: "previous key wraps around from the first widget",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, []Option{KeyFocusNext(keyNext), KeyFocusPrevious(keyPrev)}, nil, nil, nil)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},
+				{Key: keyPrev},
+			},
+			wantFocused: 2,
+		},
+		{
+			desc: "previous key stops at the first widget when not cycling",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, []Option{KeyFocusNext(keyNext), KeyFocusPrevious(keyPrev), FocusCycle(false)}, nil, nil, nil)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},
+				{Key: keyPrev},
+			},
+			wantFocused: 0,
+		},
+		{
+			desc: "tab index determines the order",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, []Option{KeyFocusNext(keyNext)},
+					[]Option{TabIndex(2)},
+					[]Option{TabIndex(1)},
+					nil,
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},
+				{Key: keyNext},
+			},
+			wantFocused: 1,
+		},
+		{
+			desc: "negative tab index excludes the container from the focus ring",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, []Option{KeyFocusNext(keyNext)},
+					nil,
+					[]Option{TabIndex(-1)},
+					nil,
+				)
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},
+				{Key: keyNext},
+			},
+			wantFocused: 2,
+		},
+		{
+			desc: "fails on invalid tab index",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				return threeWidgets(ft, w, nil, []Option{TabIndex(-2)}, nil, nil)
+			},
+			wantErr: true,
+		},
+		{
+			desc: "skips widgets of minimized containers",
+			container: func(ft *faketerm.Terminal, w []widgetapi.Widget) (*Container, error) {
+				c, err := threeWidgets(ft, w, []Option{KeyFocusNext(keyNext)},
+					[]Option{ID("left"), Minimizable()},
+					nil,
+					nil,
+				)
+				if err != nil {
+					return nil, err
+				}
+				if err := c.ToggleMinimize("left"); err != nil {
+					return nil, err
+				}
+				return c, nil
+			},
+			events: []*terminalapi.Keyboard{
+				{Key: keyNext},
+			},
+			wantFocused: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{30, 20})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			var widgets []widgetapi.Widget
+			for i := 0; i < 3; i++ {
+				widgets = append(widgets, fakewidget.New(widgetapi.Options{}))
+			}
+			c, err := tc.container(ft, widgets)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("tc.container => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			for _, ev := range tc.events {
+				if err := c.processEvent(ev); err != nil {
+					t.Fatalf("processEvent(%v) => unexpected error: %v", ev, err)
+				}
+			}
+
+			var want widgetapi.Widget
+			if tc.wantFocused >= 0 {
+				want = widgets[tc.wantFocused]
+			}
+			if got := c.FocusedWidget(); got != want {
+				t.Errorf("FocusedWidget => %p, want %p", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+// minmax.go contains code that minimizes and maximizes containers.
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/private/area"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// minimizedHeight is the height in cells of a minimized container, i.e. the
+// height of its title bar.
+const minimizedHeight = 1
+
+// ToggleMinimize minimizes the container with the specified id or restores it
+// if it is already minimized.
+// The argument id must match exactly one container that was created with
+// matching ID() option and the Minimizable() option.
+func (c *Container) ToggleMinimize(id string) error {
+	c.mu.Lock()
+	target, err := findID(c, id)
+	if err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	if !target.opts.minimizable {
+		c.mu.Unlock()
+		return fmt.Errorf("the container with ID %q isn't minimizable, use the Minimizable() option", id)
+	}
+	notify := target.toggleMinimize()
+	c.mu.Unlock()
+
+	notify()
+	return nil
+}
+
+// ToggleMaximize maximizes the container with the specified id or restores it
+// if it is already maximized. Maximizing a container restores any other
+// container that was previously maximized.
+// The argument id must match exactly one container that was created with
+// matching ID() option and the Maximizable() option.
+func (c *Container) ToggleMaximize(id string) error {
+	c.mu.Lock()
+	target, err := findID(c, id)
+	if err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	if !target.opts.maximizable {
+		c.mu.Unlock()
+		return fmt.Errorf("the container with ID %q isn't maximizable, use the Maximizable() option", id)
+	}
+	notify := target.toggleMaximize()
+	c.mu.Unlock()
+
+	notify()
+	return nil
+}
+
+// toggleMinimize minimizes or restores this container.
+// Returns a function that notifies the user, it must be called after c.mu is
+// released.
+// Caller must hold c.mu.
+func (c *Container) toggleMinimize() func() {
+	rootCont(c).clearNeeded = true
+	c.minimized = !c.minimized
+	if fn := c.opts.onMinimize; fn != nil && c.minimized {
+		return fn
+	}
+	return func() {}
+}
+
+// toggleMaximize maximizes or restores this container.
+// Returns a function that notifies the user, it must be called after c.mu is
+// released.
+// Caller must hold c.mu.
+func (c *Container) toggleMaximize() func() {
+	root := rootCont(c)
+	root.clearNeeded = true
+	if root.maximized == c {
+		root.maximized = nil
+		return func() {}
+	}
+
+	root.maximized = c
+	c.minimized = false
+	if !c.focusTracker.reachableFrom(c) {
+		c.focusTracker.setActive(c)
+	}
+	if fn := c.opts.onMaximize; fn != nil {
+		return fn
+	}
+	return func() {}
+}
+
+// minMaxKey processes the keyboard event on behalf of the focused container
+// and minimizes, maximizes or restores it or its closest parent that allows
+// it.
+// Returns a function that notifies the user and true if the event was
+// consumed. The function must be called after c.mu is released.
+// Caller must hold c.mu.
+func (c *Container) minMaxKey(k *terminalapi.Keyboard) (func(), bool) {
+	for cur := c.focusTracker.container; cur != nil; cur = cur.parent {
+		switch {
+		case cur.opts.minimizable && k.Key == cur.opts.inherited.minimizeKey:
+			return cur.toggleMinimize(), true
+		case cur.opts.maximizable && k.Key == cur.opts.inherited.maximizeKey:
+			return cur.toggleMaximize(), true
+		}
+	}
+	return nil, false
+}
+
+// hidden determines if this container is hidden, i.e. if one of its parents
+// is minimized, or if another container that isn't its parent is maximized.
+// Hidden containers aren't drawn and their widgets don't receive events.
+// Caller must hold c.mu.
+func (c *Container) hidden() bool {
+	maximized := rootCont(c).maximized
+	if c == maximized {
+		return false
+	}
+	for p := c.parent; p != nil; p = p.parent {
+		if p.minimized {
+			return true
+		}
+		if p == maximized {
+			return false
+		}
+	}
+	return maximized != nil
+}
+
+// visible determines if the content of this container is visible, i.e. if
+// the container isn't hidden or minimized.
+// Caller must hold c.mu.
+func (c *Container) visible() bool {
+	return !c.minimized && !c.hidden()
+}
+
+// minimizedSplit splits the provided area among the sub containers of a
+// horizontally split container when any of them is minimized. Minimized sub
+// containers get only the height of their title bar and the other sub
+// container takes over the rest of the area.
+// Returns false if the container isn't split horizontally or neither of the
+// sub containers is minimized.
+func (c *Container) minimizedSplit(ar image.Rectangle) (image.Rectangle, image.Rectangle, bool, error) {
+	if c.opts.split != splitTypeHorizontal || c.first == nil || c.second == nil {
+		return image.ZR, image.ZR, false, nil
+	}
+
+	switch {
+	case c.first.minimized && c.second.minimized:
+		top, rest, err := area.HSplitCells(ar, minimizedHeight)
+		if err != nil {
+			return image.ZR, image.ZR, false, err
+		}
+		bottom, _, err := area.HSplitCells(rest, minimizedHeight)
+		if err != nil {
+			return image.ZR, image.ZR, false, err
+		}
+		return top, bottom, true, nil
+
+	case c.first.minimized:
+		top, bottom, err := area.HSplitCells(ar, minimizedHeight)
+		if err != nil {
+			return image.ZR, image.ZR, false, err
+		}
+		return top, bottom, true, nil
+
+	case c.second.minimized:
+		cells := ar.Dy() - minimizedHeight
+		if cells < 0 {
+			cells = 0
+		}
+		top, bottom, err := area.HSplitCells(ar, cells)
+		if err != nil {
+			return image.ZR, image.ZR, false, err
+		}
+		return top, bottom, true, nil
+
+	default:
+		return image.ZR, image.ZR, false, nil
+	}
+}
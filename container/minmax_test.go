@@ -0,0 +1,673 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package container
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/event"
+	"github.com/mum4k/termdash/private/event/testevent"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mustTitleBar draws the expected title bar of a minimized container with a
+// border onto the terminal.
+func mustTitleBar(ft *faketerm.Terminal, ar image.Rectangle, title string, color cell.Color) {
+	border := testcanvas.MustNew(image.Rect(0, 0, ar.Dx(), 2))
+	testdraw.MustBorder(
+		border,
+		border.Area(),
+		draw.BorderTitle(title, draw.OverrunModeThreeDot, cell.FgColor(color)),
+		draw.BorderCellOpts(cell.FgColor(color)),
+	)
+
+	cvs := testcanvas.MustNew(ar)
+	for x := 0; x < ar.Dx(); x++ {
+		bc := testcanvas.MustCell(border, image.Point{x, 0})
+		testcanvas.MustSetCell(cvs, image.Point{x, 0}, bc.Rune, bc.Opts)
+	}
+	testcanvas.MustApply(cvs, ft)
+}
+
+func TestMinimizeMaximize(t *testing.T) {
+	tests := []struct {
+		desc      string
+		termSize  image.Point
+		container func(ft *faketerm.Terminal) (*Container, error)
+		update    func(*Container) error
+		want      func(size image.Point) *faketerm.Terminal
+		wantErr   bool
+	}{
+		{
+			desc:     "fails to minimize a container that isn't minimizable",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, ID("root"))
+			},
+			update: func(c *Container) error {
+				return c.ToggleMinimize("root")
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails to maximize a container that isn't maximizable",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, ID("root"))
+			},
+			update: func(c *Container) error {
+				return c.ToggleMaximize("root")
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "fails to minimize a container that doesn't exist",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(ft, ID("root"), Minimizable())
+			},
+			update: func(c *Container) error {
+				return c.ToggleMinimize("unknown")
+			},
+			wantErr: true,
+		},
+		{
+			desc:     "minimized top container gives its space to the bottom one",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							ID("top"),
+							Minimizable(),
+							Border(linestyle.Light),
+							BorderTitle("T"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Bottom(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.ToggleMinimize("top")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustTitleBar(ft, image.Rect(0, 0, 10, 1), "T", cell.ColorDefault)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 1, 10, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "minimized bottom container gives its space to the top one",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Bottom(
+							ID("bottom"),
+							Minimizable(),
+							Border(linestyle.Light),
+							BorderTitle("B"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.ToggleMinimize("bottom")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 10, 9)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				mustTitleBar(ft, image.Rect(0, 9, 10, 10), "B", cell.ColorDefault)
+				return ft
+			},
+		},
+		{
+			desc:     "both minimized containers are stacked at the top",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							ID("top"),
+							Minimizable(),
+							Border(linestyle.Light),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Bottom(
+							ID("bottom"),
+							Minimizable(),
+							Border(linestyle.Light),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				if err := c.ToggleMinimize("top"); err != nil {
+					return err
+				}
+				return c.ToggleMinimize("bottom")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustTitleBar(ft, image.Rect(0, 0, 10, 1), "", cell.ColorDefault)
+				mustTitleBar(ft, image.Rect(0, 1, 10, 2), "", cell.ColorDefault)
+				return ft
+			},
+		},
+		{
+			desc:     "minimized container without a border only displays its title",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							Minimizable(),
+							BorderTitle("L"),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.ToggleMinimize("left")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(cvs, "L", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorDefault)))
+				testcanvas.MustApply(cvs, ft)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(10, 0, 20, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "restores the layout of a minimized container",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							ID("top"),
+							Minimizable(),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Bottom(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						SplitPercent(30),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				if err := c.ToggleMinimize("top"); err != nil {
+					return err
+				}
+				if err := c.Draw(); err != nil {
+					return err
+				}
+				return c.ToggleMinimize("top")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 10, 3)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 3, 10, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "maximized container fills the terminal and gets focus",
+			termSize: image.Point{30, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							Maximizable(),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						SplitPercent(30),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				return c.ToggleMaximize("left")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "maximized container restores a minimized parent",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitHorizontal(
+						Top(
+							ID("top"),
+							Maximizable(),
+							Minimizable(),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Bottom(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				if err := c.ToggleMinimize("top"); err != nil {
+					return err
+				}
+				return c.ToggleMaximize("top")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "restores the previous layout and split ratio of a maximized container",
+			termSize: image.Point{30, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							ID("left"),
+							Maximizable(),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						SplitPercent(30),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				if err := c.ToggleMaximize("left"); err != nil {
+					return err
+				}
+				if err := c.Draw(); err != nil {
+					return err
+				}
+				return c.ToggleMaximize("left")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 9, 10)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(9, 0, 30, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "removing the maximized container restores the layout",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal) (*Container, error) {
+				return New(
+					ft,
+					ID("root"),
+					SplitVertical(
+						Left(
+							ID("left"),
+							Maximizable(),
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{})),
+						),
+					),
+				)
+			},
+			update: func(c *Container) error {
+				if err := c.ToggleMaximize("left"); err != nil {
+					return err
+				}
+				return c.Update("root", PlaceWidget(fakewidget.New(widgetapi.Options{})))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			c, err := tc.container(got)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			err = tc.update(c)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("tc.update => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+// callbackCounter counts calls to the OnMinimize and OnMaximize callbacks.
+type callbackCounter struct {
+	mu        sync.Mutex
+	minimized int
+	maximized int
+}
+
+func (cc *callbackCounter) onMinimize() {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.minimized++
+}
+
+func (cc *callbackCounter) onMaximize() {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	cc.maximized++
+}
+
+func (cc *callbackCounter) get() (int, int) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+	return cc.minimized, cc.maximized
+}
+
+func TestMinimizeMaximizeKeys(t *testing.T) {
+	tests := []struct {
+		desc          string
+		termSize      image.Point
+		container     func(ft *faketerm.Terminal, cc *callbackCounter) (*Container, error)
+		events        []terminalapi.Event
+		want          func(size image.Point) *faketerm.Terminal
+		wantMinimized int
+		wantMaximized int
+	}{
+		{
+			desc:     "key is forwarded to the widget when the container isn't minimizable",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal, cc *callbackCounter) (*Container, error) {
+				return New(
+					ft,
+					OnMinimize(cc.onMinimize),
+					PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+				)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: DefaultMinimizeKey},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&terminalapi.Keyboard{Key: DefaultMinimizeKey},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "maximizes the parent of the focused container",
+			termSize: image.Point{20, 10},
+			container: func(ft *faketerm.Terminal, cc *callbackCounter) (*Container, error) {
+				return New(
+					ft,
+					SplitVertical(
+						Left(
+							Maximizable(),
+							OnMaximize(cc.onMaximize),
+							SplitHorizontal(
+								Top(
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+								),
+								Bottom(
+									PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+								),
+							),
+						),
+						Right(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal})),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Move focus to the top left container.
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+				&terminalapi.Keyboard{Key: DefaultMaximizeKey},
+				// Only the widgets in the maximized container receive events.
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 20, 5)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 5, 20, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeGlobal},
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				)
+				return ft
+			},
+			wantMaximized: 1,
+		},
+		{
+			desc:     "custom keys minimize and restore the focused container",
+			termSize: image.Point{10, 10},
+			container: func(ft *faketerm.Terminal, cc *callbackCounter) (*Container, error) {
+				return New(
+					ft,
+					MinimizeKey(keyboard.KeyCtrlN),
+					SplitHorizontal(
+						Top(
+							Minimizable(),
+							OnMinimize(cc.onMinimize),
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+						Bottom(
+							PlaceWidget(fakewidget.New(widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused})),
+						),
+					),
+				)
+			},
+			events: []terminalapi.Event{
+				// Move focus to the top container.
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRelease},
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlN},
+				&terminalapi.Keyboard{Key: keyboard.KeyCtrlN},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 0, 10, 5)),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(image.Rect(0, 5, 10, 10)),
+					&widgetapi.Meta{},
+					widgetapi.Options{WantKeyboard: widgetapi.KeyScopeFocused},
+				)
+				return ft
+			},
+			wantMinimized: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := faketerm.New(tc.termSize)
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			cc := &callbackCounter{}
+			c, err := tc.container(got, cc)
+			if err != nil {
+				t.Fatalf("tc.container => unexpected error: %v", err)
+			}
+
+			eds := event.NewDistributionSystem()
+			c.Subscribe(eds)
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			for _, ev := range tc.events {
+				eds.Event(ev)
+			}
+			if err := testevent.WaitFor(5*time.Second, func() error {
+				if got, want := eds.Processed(), len(tc.events); got != want {
+					return fmt.Errorf("the event distribution system processed %d events, want %d", got, want)
+				}
+				return nil
+			}); err != nil {
+				t.Fatalf("testevent.WaitFor => %v", err)
+			}
+
+			if err := c.Draw(); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(tc.termSize), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			gotMin, gotMax := cc.get()
+			if gotMin != tc.wantMinimized || gotMax != tc.wantMaximized {
+				t.Errorf("callbacks called (minimized: %d, maximized: %d), want (minimized: %d, maximized: %d)", gotMin, gotMax, tc.wantMinimized, tc.wantMaximized)
+			}
+		})
+	}
+}
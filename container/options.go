@@ -23,6 +23,7 @@ import (
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/linestyle"
 	"github.com/mum4k/termdash/private/area"
 	"github.com/mum4k/termdash/widgetapi"
@@ -123,6 +124,31 @@ type options struct {
 
 	// margin is a space reserved on the outside of the container.
 	margin margin
+
+	// minimizable indicates if the container can be minimized.
+	minimizable bool
+	// maximizable indicates if the container can be maximized.
+	maximizable bool
+
+	// onMinimize if not nil, is called when the container gets minimized.
+	onMinimize func()
+	// onMaximize if not nil, is called when the container gets maximized.
+	onMaximize func()
+
+	// tabIndex determines the position of the container in the focus ring.
+	tabIndex int
+
+	// Global options, only applied when provided to the root container.
+
+	// keyFocusNext if not nil, is the key that moves the keyboard focus to
+	// the next container in the focus ring.
+	keyFocusNext *keyboard.Key
+	// keyFocusPrevious if not nil, is the key that moves the keyboard focus
+	// to the previous container in the focus ring.
+	keyFocusPrevious *keyboard.Key
+	// focusCycle indicates if the focus wraps around at the ends of the
+	// focus ring.
+	focusCycle bool
 }
 
 // margin stores the configured margin for the container.
@@ -179,6 +205,10 @@ type inherited struct {
 	borderColor cell.Color
 	// focusedColor is the color used for the border when focused.
 	focusedColor cell.Color
+	// minimizeKey is the key that minimizes or restores the focused container.
+	minimizeKey keyboard.Key
+	// maximizeKey is the key that maximizes or restores the focused container.
+	maximizeKey keyboard.Key
 }
 
 // newOptions returns a new options instance with the default values.
@@ -188,11 +218,14 @@ func newOptions(parent *options) *options {
 	opts := &options{
 		inherited: inherited{
 			focusedColor: cell.ColorYellow,
+			minimizeKey:  DefaultMinimizeKey,
+			maximizeKey:  DefaultMaximizeKey,
 		},
 		hAlign:       align.HorizontalCenter,
 		vAlign:       align.VerticalMiddle,
 		splitPercent: DefaultSplitPercent,
 		splitFixed:   DefaultSplitFixed,
+		focusCycle:   true,
 	}
 	if parent != nil {
 		opts.inherited = parent.inherited
@@ -694,6 +727,139 @@ func FocusedColor(color cell.Color) Option {
 	})
 }
 
+// Minimizable allows the container to be minimized, i.e. reduced to the
+// height of its title bar. Sub containers and widgets of a minimized container
+// aren't drawn and don't receive any events. When the container is in the top
+// or bottom half of a horizontal split, the other half takes over the freed
+// space.
+// The container is minimized or restored by pressing the MinimizeKey while it
+// or any of its sub containers has keyboard focus or by calling
+// Container.ToggleMinimize.
+func Minimizable() Option {
+	return option(func(c *Container) error {
+		c.opts.minimizable = true
+		return nil
+	})
+}
+
+// Maximizable allows the container to be maximized, i.e. expanded to fill the
+// entire terminal, hiding all the other containers. The layout of the hidden
+// containers, including their split ratios, is kept and restored once the
+// container is restored.
+// The container is maximized or restored by pressing the MaximizeKey while it
+// or any of its sub containers has keyboard focus or by calling
+// Container.ToggleMaximize.
+func Maximizable() Option {
+	return option(func(c *Container) error {
+		c.opts.maximizable = true
+		return nil
+	})
+}
+
+// DefaultMinimizeKey is the default value for the MinimizeKey option.
+const DefaultMinimizeKey = keyboard.KeyF10
+
+// MinimizeKey sets the key that minimizes or restores the focused container,
+// or its closest parent that is Minimizable. The key press isn't delivered to
+// any widgets when it minimizes or restores a container.
+// This option is inherited to sub containers created by container splits.
+func MinimizeKey(k keyboard.Key) Option {
+	return option(func(c *Container) error {
+		c.opts.inherited.minimizeKey = k
+		return nil
+	})
+}
+
+// DefaultMaximizeKey is the default value for the MaximizeKey option.
+const DefaultMaximizeKey = keyboard.KeyF11
+
+// MaximizeKey sets the key that maximizes or restores the focused container,
+// or its closest parent that is Maximizable. The key press isn't delivered to
+// any widgets when it maximizes or restores a container.
+// This option is inherited to sub containers created by container splits.
+func MaximizeKey(k keyboard.Key) Option {
+	return option(func(c *Container) error {
+		c.opts.inherited.maximizeKey = k
+		return nil
+	})
+}
+
+// OnMinimize sets a function that is called each time the container gets
+// minimized. The function isn't called when the container is restored.
+// The function is called without holding the container lock, so it can
+// safely call methods of the container.
+func OnMinimize(fn func()) Option {
+	return option(func(c *Container) error {
+		c.opts.onMinimize = fn
+		return nil
+	})
+}
+
+// OnMaximize sets a function that is called each time the container gets
+// maximized. The function isn't called when the container is restored.
+// The function is called without holding the container lock, so it can
+// safely call methods of the container.
+func OnMaximize(fn func()) Option {
+	return option(func(c *Container) error {
+		c.opts.onMaximize = fn
+		return nil
+	})
+}
+
+// KeyFocusNext configures a key that moves the keyboard focus to the next
+// container in the focus ring. The focus ring contains all the visible
+// containers that have a widget, ordered by their TabIndex and then in the
+// order in which they were declared. The key press isn't delivered to any
+// widgets.
+// This option is global and only has effect when provided to the root
+// container. If not provided, the focus can only be moved by the mouse.
+func KeyFocusNext(k keyboard.Key) Option {
+	return option(func(c *Container) error {
+		c.opts.keyFocusNext = &k
+		return nil
+	})
+}
+
+// KeyFocusPrevious configures a key that moves the keyboard focus to the
+// previous container in the focus ring. See KeyFocusNext for details.
+// This option is global and only has effect when provided to the root
+// container.
+func KeyFocusPrevious(k keyboard.Key) Option {
+	return option(func(c *Container) error {
+		c.opts.keyFocusPrevious = &k
+		return nil
+	})
+}
+
+// FocusCycle determines whether the keys configured by KeyFocusNext and
+// KeyFocusPrevious wrap around when moving past the last or the first
+// container in the focus ring. When set to false, the focus stops at either
+// end of the focus ring. Defaults to true.
+// This option is global and only has effect when provided to the root
+// container.
+func FocusCycle(cycle bool) Option {
+	return option(func(c *Container) error {
+		c.opts.focusCycle = cycle
+		return nil
+	})
+}
+
+// TabIndex sets the position of the container in the focus ring traversed by
+// the keys configured by KeyFocusNext and KeyFocusPrevious. Containers with
+// lower values are focused first, containers with the same value are focused
+// in the order in which they were declared. Setting the index to -1 excludes
+// the container from the focus ring, it can still be focused by the mouse.
+// The index must be a value in the range -1 <= idx, defaults to zero.
+func TabIndex(idx int) Option {
+	return option(func(c *Container) error {
+		if min := -1; idx < min {
+			return fmt.Errorf("invalid TabIndex(%d), must be in range %d <= value", idx, min)
+		}
+		c.opts.tabIndex = idx
+		return nil
+	})
+}
+
 // splitType identifies how a container is split.
 type splitType int
 
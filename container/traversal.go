@@ -17,6 +17,8 @@ package container
 import (
 	"errors"
 	"fmt"
+
+	"github.com/mum4k/termdash/widgetapi"
 )
 
 // traversal.go provides functions that navigate the container tree.
@@ -84,3 +86,38 @@ func findID(root *Container, id string) (*Container, error) {
 	}
 	return cont, nil
 }
+
+// findWidget finds the container that holds the provided widget.
+// Returns an error if no container in the tree holds the widget.
+func findWidget(root *Container, w widgetapi.Widget) (*Container, error) {
+	var (
+		errStr string
+		cont   *Container
+	)
+	preOrder(root, &errStr, visitFunc(func(c *Container) error {
+		if c.hasWidget() && c.opts.widget == w {
+			cont = c
+		}
+		return nil
+	}))
+	if cont == nil {
+		return nil, fmt.Errorf("cannot find container with widget %T", w)
+	}
+	return cont, nil
+}
+
+// reachable asserts whether the target container is reachable from the
+// provided node in the tree.
+func reachable(node, target *Container) bool {
+	var (
+		errStr string
+		found  bool
+	)
+	preOrder(node, &errStr, visitFunc(func(c *Container) error {
+		if c == target {
+			found = true
+		}
+		return nil
+	}))
+	return found
+}
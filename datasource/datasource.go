@@ -0,0 +1,103 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+// Package datasource defines adapters that decouple producers of data from
+// the widgets that display it.
+//
+// The producer updates the data on the adapter and widgets that were given
+// the adapter receive the new data. The widgets display it on the next redraw
+// of the dashboard.
+//
+// This package requires Go 1.18 or newer.
+package datasource
+
+import "sync"
+
+// Adapter provides data of type T.
+type Adapter[T any] interface {
+	// Data returns the current data.
+	Data() T
+
+	// Subscribe registers a function that is called with the new data each
+	// time the data changes.
+	// The provided function must be thread-safe.
+	Subscribe(func(T))
+}
+
+// Source is an Adapter that holds data set by the producer.
+//
+// This object is thread-safe.
+type Source[T any] struct {
+	// data is the current data.
+	data T
+	// subscribers are the registered subscribers.
+	subscribers []func(T)
+
+	// mu protects the Source.
+	mu sync.Mutex
+}
+
+// New returns a new Source with the initial data.
+func New[T any](data T) *Source[T] {
+	return &Source[T]{
+		data: data,
+	}
+}
+
+// Data implements Adapter.Data.
+func (s *Source[T]) Data() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.data
+}
+
+// Subscribe implements Adapter.Subscribe.
+func (s *Source[T]) Subscribe(f func(T)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, f)
+}
+
+// Set replaces the data and calls all the subscribers with it.
+// The subscribers are called synchronously, in the order they subscribed.
+func (s *Source[T]) Set(data T) {
+	s.mu.Lock()
+	s.data = data
+	subs := make([]func(T), len(s.subscribers))
+	copy(subs, s.subscribers)
+	s.mu.Unlock()
+
+	for _, f := range subs {
+		f(data)
+	}
+}
+
+// Series is a Source of the values of a series, e.g. for the LineChart.
+type Series = Source[[]float64]
+
+// NewSeries returns a new Series with the initial values.
+func NewSeries(values []float64) *Series {
+	return New(values)
+}
+
+// Bars is a Source of labeled values, e.g. for the BarChart.
+type Bars = Source[map[string]float64]
+
+// NewBars returns a new Bars with the initial labeled values.
+func NewBars(values map[string]float64) *Bars {
+	return New(values)
+}
@@ -0,0 +1,65 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package datasource
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestSource(t *testing.T) {
+	s := NewSeries([]float64{1, 2})
+	if diff := pretty.Compare([]float64{1, 2}, s.Data()); diff != "" {
+		t.Errorf("Data => unexpected diff (-want, +got):\n%s", diff)
+	}
+
+	var first, second [][]float64
+	s.Subscribe(func(v []float64) {
+		first = append(first, v)
+	})
+	s.Set([]float64{3})
+	s.Subscribe(func(v []float64) {
+		second = append(second, v)
+	})
+	s.Set([]float64{4, 5})
+
+	if diff := pretty.Compare([]float64{4, 5}, s.Data()); diff != "" {
+		t.Errorf("Data => unexpected diff (-want, +got):\n%s", diff)
+	}
+	if diff := pretty.Compare([][]float64{{3}, {4, 5}}, first); diff != "" {
+		t.Errorf("first subscriber => unexpected diff (-want, +got):\n%s", diff)
+	}
+	if diff := pretty.Compare([][]float64{{4, 5}}, second); diff != "" {
+		t.Errorf("second subscriber => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestSourceSubscriberCanReadData(t *testing.T) {
+	b := NewBars(nil)
+	var got map[string]float64
+	b.Subscribe(func(map[string]float64) {
+		// Must not deadlock.
+		got = b.Data()
+	})
+	b.Set(map[string]float64{"a": 1})
+
+	if diff := pretty.Compare(map[string]float64{"a": 1}, got); diff != "" {
+		t.Errorf("Data => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package datasource
+
+// fft.go contains an adapter that turns a stream of samples into a frequency
+// spectrum.
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strconv"
+)
+
+// FFTOption is used to provide options to NewFFTAdapter.
+type FFTOption interface {
+	// set sets the provided option.
+	set(*fftOptions)
+}
+
+// fftOptions stores the provided options.
+type fftOptions struct {
+	size       int
+	sampleRate float64
+}
+
+// validate validates the provided options.
+func (o *fftOptions) validate() error {
+	if o.size < 2 || o.size&(o.size-1) != 0 {
+		return fmt.Errorf("invalid FFTSize %d, must be a power of two and at least 2", o.size)
+	}
+	if math.IsNaN(o.sampleRate) || math.IsInf(o.sampleRate, 0) || o.sampleRate <= 0 {
+		return fmt.Errorf("invalid SampleRate %v, must be a finite positive number", o.sampleRate)
+	}
+	return nil
+}
+
+// fftOption implements FFTOption.
+type fftOption func(*fftOptions)
+
+// set implements FFTOption.set.
+func (fo fftOption) set(opts *fftOptions) {
+	fo(opts)
+}
+
+// DefaultFFTSize is the default value for the FFTSize option.
+const DefaultFFTSize = 512
+
+// FFTSize sets the number of samples the spectrum is computed from. The
+// spectrum has FFTSize/2+1 frequency bins. Must be a power of two.
+// Defaults to DefaultFFTSize.
+func FFTSize(size int) FFTOption {
+	return fftOption(func(opts *fftOptions) {
+		opts.size = size
+	})
+}
+
+// DefaultSampleRate is the default value for the SampleRate option.
+const DefaultSampleRate = 1
+
+// SampleRate sets the number of samples per second in the stream, which
+// determines the frequencies of the bins. Must be a positive number.
+// Defaults to DefaultSampleRate, i.e. the frequencies are in cycles per
+// sample.
+func SampleRate(hz float64) FFTOption {
+	return fftOption(func(opts *fftOptions) {
+		opts.sampleRate = hz
+	})
+}
+
+// FFTAdapter is an Adapter that provides the magnitude spectrum of a stream of
+// samples, e.g. for the LineChart. Each value is the magnitude of one
+// frequency bin, starting from zero Hz.
+//
+// The spectrum is recomputed from the last FFTSize samples each time new
+// samples arrive, once at least FFTSize samples were received. The samples are
+// multiplied by the Hann window before the transform.
+//
+// This object is thread-safe.
+type FFTAdapter struct {
+	// spectrum provides the magnitudes.
+	spectrum *Series
+	// peaks provides the largest magnitudes seen at each bin.
+	peaks *Series
+
+	// window are the coefficients of the Hann window.
+	window []float64
+	// windowSum is the sum of the window coefficients.
+	windowSum float64
+
+	// buf holds the last samples, at most opts.size of them.
+	buf []float64
+
+	// opts are the provided options.
+	opts *fftOptions
+}
+
+// NewFFTAdapter returns a new FFTAdapter that reads samples from the channel
+// until it is closed or the context expires.
+func NewFFTAdapter(ctx context.Context, samples <-chan []float64, opts ...FFTOption) (*FFTAdapter, error) {
+	opt := &fftOptions{
+		size:       DefaultFFTSize,
+		sampleRate: DefaultSampleRate,
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+
+	window := hannWindow(opt.size)
+	var sum float64
+	for _, w := range window {
+		sum += w
+	}
+	fa := &FFTAdapter{
+		spectrum:  NewSeries(nil),
+		peaks:     NewSeries(nil),
+		window:    window,
+		windowSum: sum,
+		opts:      opt,
+	}
+	go fa.run(ctx, samples)
+	return fa, nil
+}
+
+// Data implements Adapter.Data.
+func (fa *FFTAdapter) Data() []float64 {
+	return fa.spectrum.Data()
+}
+
+// Subscribe implements Adapter.Subscribe.
+func (fa *FFTAdapter) Subscribe(f func([]float64)) {
+	fa.spectrum.Subscribe(f)
+}
+
+// PeakHold returns an Adapter with the largest magnitude seen at each
+// frequency bin so far. It can be displayed as a second series over the
+// spectrum.
+func (fa *FFTAdapter) PeakHold() Adapter[[]float64] {
+	return fa.peaks
+}
+
+// Frequency returns the frequency in Hz represented by the bin at the
+// provided index of the data.
+func (fa *FFTAdapter) Frequency(bin int) float64 {
+	return float64(bin) * fa.opts.sampleRate / float64(fa.opts.size)
+}
+
+// XLabels returns labels with the frequency in Hz of each bin, e.g. for
+// linechart.SeriesXLabels.
+func (fa *FFTAdapter) XLabels() map[int]string {
+	labels := map[int]string{}
+	for bin := 0; bin <= fa.opts.size/2; bin++ {
+		labels[bin] = strconv.FormatFloat(fa.Frequency(bin), 'g', 4, 64)
+	}
+	return labels
+}
+
+// run processes the samples until the channel is closed or the context
+// expires.
+func (fa *FFTAdapter) run(ctx context.Context, samples <-chan []float64) {
+	for {
+		select {
+		case s, ok := <-samples:
+			if !ok {
+				return
+			}
+			fa.process(s)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// process adds the samples to the buffer and updates the spectrum once the
+// buffer is full.
+func (fa *FFTAdapter) process(samples []float64) {
+	fa.buf = append(fa.buf, samples...)
+	if over := len(fa.buf) - fa.opts.size; over > 0 {
+		fa.buf = append(fa.buf[:0], fa.buf[over:]...)
+	}
+	if len(fa.buf) < fa.opts.size {
+		return
+	}
+
+	mags := fa.magnitudes(fa.buf)
+	peaks := append([]float64(nil), fa.peaks.Data()...)
+	if len(peaks) != len(mags) {
+		peaks = make([]float64, len(mags))
+	}
+	for i, m := range mags {
+		peaks[i] = math.Max(peaks[i], m)
+	}
+	fa.spectrum.Set(mags)
+	fa.peaks.Set(peaks)
+}
+
+// magnitudes returns the single-sided magnitude spectrum of the samples,
+// normalized so that a sine wave that falls into a single bin has the
+// magnitude of its amplitude.
+func (fa *FFTAdapter) magnitudes(samples []float64) []float64 {
+	x := make([]complex128, len(samples))
+	for i, s := range samples {
+		x[i] = complex(s*fa.window[i], 0)
+	}
+	fft(x)
+
+	n := len(samples)
+	mags := make([]float64, n/2+1)
+	for i := range mags {
+		m := cmplx.Abs(x[i]) / fa.windowSum
+		if i != 0 && i != n/2 {
+			// The energy of the negative frequencies.
+			m *= 2
+		}
+		mags[i] = m
+	}
+	return mags
+}
+
+// hannWindow returns the coefficients of the periodic Hann window of the
+// provided size.
+func hannWindow(size int) []float64 {
+	w := make([]float64, size)
+	for i := range w {
+		w[i] = 0.5 * (1 - math.Cos(2*math.Pi*float64(i)/float64(size)))
+	}
+	return w
+}
+
+// fft performs an in-place radix-2 fast Fourier transform.
+// The length of x must be a power of two.
+func fft(x []complex128) {
+	n := len(x)
+	// Bit-reversal permutation.
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				even := x[start+k]
+				odd := x[start+k+size/2] * w
+				x[start+k] = even + odd
+				x[start+k+size/2] = even - odd
+				w *= step
+			}
+		}
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package datasource
+
+import (
+	"context"
+	"math"
+	"math/cmplx"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// sine returns size samples of a sine wave.
+func sine(size int, amplitude, freq, sampleRate float64) []float64 {
+	s := make([]float64, size)
+	for i := range s {
+		s[i] = amplitude * math.Sin(2*math.Pi*freq*float64(i)/sampleRate)
+	}
+	return s
+}
+
+// roundAll rounds the values to the provided number of decimal places.
+func roundAll(values []float64, places int) []float64 {
+	mult := math.Pow(10, float64(places))
+	res := make([]float64, len(values))
+	for i, v := range values {
+		res[i] = math.Round(v*mult) / mult
+	}
+	return res
+}
+
+func TestFFT(t *testing.T) {
+	tests := []struct {
+		desc  string
+		input []complex128
+		want  []complex128
+	}{
+		{
+			desc:  "impulse has a flat spectrum",
+			input: []complex128{1, 0, 0, 0},
+			want:  []complex128{1, 1, 1, 1},
+		},
+		{
+			desc:  "constant is only in the zero bin",
+			input: []complex128{1, 1, 1, 1, 1, 1, 1, 1},
+			want:  []complex128{8, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			desc:  "alternating values are in the middle bin",
+			input: []complex128{1, -1, 1, -1},
+			want:  []complex128{0, 0, 4, 0},
+		},
+		{
+			desc:  "matches the discrete Fourier transform",
+			input: []complex128{1, 2, 3, 4},
+			want:  []complex128{10, complex(-2, 2), -2, complex(-2, -2)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := append([]complex128(nil), tc.input...)
+			fft(got)
+			for i := range got {
+				if cmplx.Abs(got[i]-tc.want[i]) > 1e-9 {
+					t.Errorf("fft => got %v, want %v", got, tc.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestNewFFTAdapter(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []FFTOption
+		wantErr bool
+	}{
+		{
+			desc: "default options",
+		},
+		{
+			desc: "valid options",
+			opts: []FFTOption{FFTSize(16), SampleRate(44100)},
+		},
+		{
+			desc:    "fails on size that isn't a power of two",
+			opts:    []FFTOption{FFTSize(100)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on size too small",
+			opts:    []FFTOption{FFTSize(1)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on zero sample rate",
+			opts:    []FFTOption{SampleRate(0)},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on NaN sample rate",
+			opts:    []FFTOption{SampleRate(math.NaN())},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			_, err := NewFFTAdapter(ctx, make(chan []float64), tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewFFTAdapter => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestFFTAdapter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	samples := make(chan []float64)
+	fa, err := NewFFTAdapter(ctx, samples, FFTSize(16), SampleRate(16))
+	if err != nil {
+		t.Fatalf("NewFFTAdapter => unexpected error: %v", err)
+	}
+	updates := make(chan []float64, 10)
+	fa.Subscribe(func(v []float64) {
+		updates <- v
+	})
+
+	recv := func() []float64 {
+		t.Helper()
+		select {
+		case v := <-updates:
+			return v
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for the spectrum")
+		}
+		return nil
+	}
+
+	// The spectrum isn't computed until the buffer is full.
+	samples <- sine(8, 2, 4, 16)
+	samples <- sine(8, 2, 4, 16)
+	got := recv()
+	// The Hann window leaks half of the magnitude of a sine centered on a bin
+	// into each of the neighbouring bins.
+	want := []float64{0, 0, 0, 1, 2, 1, 0, 0, 0}
+	if diff := pretty.Compare(want, roundAll(got, 9)); diff != "" {
+		t.Errorf("Data => unexpected diff (-want, +got):\n%s", diff)
+	}
+
+	samples <- make([]float64, 16)
+	got = recv()
+	if diff := pretty.Compare(make([]float64, 9), roundAll(got, 9)); diff != "" {
+		t.Errorf("Data => unexpected diff (-want, +got):\n%s", diff)
+	}
+	if diff := pretty.Compare(want, roundAll(fa.PeakHold().Data(), 9)); diff != "" {
+		t.Errorf("PeakHold => unexpected diff (-want, +got):\n%s", diff)
+	}
+	if diff := pretty.Compare(got, fa.Data()); diff != "" {
+		t.Errorf("Data => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestFFTAdapterFrequency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fa, err := NewFFTAdapter(ctx, make(chan []float64), FFTSize(4), SampleRate(1000))
+	if err != nil {
+		t.Fatalf("NewFFTAdapter => unexpected error: %v", err)
+	}
+	if got, want := fa.Frequency(1), 250.0; got != want {
+		t.Errorf("Frequency(1) => %v, want %v", got, want)
+	}
+
+	want := map[int]string{
+		0: "0",
+		1: "250",
+		2: "500",
+	}
+	if diff := pretty.Compare(want, fa.XLabels()); diff != "" {
+		t.Errorf("XLabels => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
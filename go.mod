@@ -3,8 +3,9 @@ module github.com/mum4k/termdash
 go 1.14
 
 require (
-	github.com/gdamore/tcell v1.3.0
+	github.com/gdamore/tcell v1.4.0
 	github.com/kylelemons/godebug v1.1.0
 	github.com/mattn/go-runewidth v0.0.9
 	github.com/nsf/termbox-go v0.0.0-20200204031403-4d2b513ad8be
+	rsc.io/qr v0.2.0
 )
@@ -75,6 +75,27 @@ var pixelRunes = map[image.Point]rune{
 	{0, 3}: 0x40, {1, 3}: 0x80,
 }
 
+// Coords translates the position of a dot within a cell into the point that
+// addresses the dot (pixel) on the braille canvas and into the bit of the
+// braille pattern rune that represents the dot. The dotRow must be in range
+// 0 <= dotRow < RowMult and the dotCol in range 0 <= dotCol < ColMult.
+// Setting the bit on brailleCharOffset (U+2800) results in the braille
+// pattern rune with just this dot set.
+func Coords(cellPos image.Point, dotRow, dotCol int) (image.Point, uint8, error) {
+	if dotRow < 0 || dotRow >= RowMult {
+		return image.ZP, 0, fmt.Errorf("invalid dotRow %d, must be in range 0 <= dotRow < %d", dotRow, RowMult)
+	}
+	if dotCol < 0 || dotCol >= ColMult {
+		return image.ZP, 0, fmt.Errorf("invalid dotCol %d, must be in range 0 <= dotCol < %d", dotCol, ColMult)
+	}
+	if cellPos.X < 0 || cellPos.Y < 0 {
+		return image.ZP, 0, fmt.Errorf("cells cannot have negative coordinates: %v", cellPos)
+	}
+
+	p := image.Point{cellPos.X*ColMult + dotCol, cellPos.Y*RowMult + dotRow}
+	return p, uint8(pixelRunes[image.Point{dotCol, dotRow}]), nil
+}
+
 // Canvas is a canvas that uses the braille patterns. It is two times wider
 // and four times taller than a regular canvas that uses just plain characters,
 // since each cell now has 2x4 pixels that can be independently set.
@@ -202,6 +223,18 @@ func (c *Canvas) TogglePixel(p image.Point, opts ...cell.Option) error {
 	return c.SetPixel(p, opts...)
 }
 
+// SetDot turns the pixel at the specified coordinates on or off, x and y
+// address pixels of the braille canvas, not cells.
+// The provided cell options will be applied to the entire cell (all of its
+// pixels). This method is idempotent.
+func (c *Canvas) SetDot(x, y int, on bool, opts ...cell.Option) error {
+	p := image.Point{x, y}
+	if on {
+		return c.SetPixel(p, opts...)
+	}
+	return c.ClearPixel(p, opts...)
+}
+
 // SetCellOpts sets options on the specified cell of the braille canvas without
 // modifying the content of the cell.
 // Sets the default cell options if no options are provided.
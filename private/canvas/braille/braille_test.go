@@ -937,6 +937,37 @@ func TestBraille(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc: "SetDot turns pixels on and off",
+			ar:   image.Rect(0, 0, 1, 1),
+			pixelOps: func(c *Canvas) error {
+				if err := c.SetDot(0, 0, true); err != nil {
+					return err
+				}
+				if err := c.SetDot(1, 3, true); err != nil {
+					return err
+				}
+				return c.SetDot(0, 0, false)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '⢀')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "SetDot fails on point outside of the canvas",
+			ar:   image.Rect(0, 0, 1, 1),
+			pixelOps: func(c *Canvas) error {
+				return c.SetDot(2, 0, true)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -990,3 +1021,76 @@ func TestBraille(t *testing.T) {
 		})
 	}
 }
+
+func TestCoords(t *testing.T) {
+	tests := []struct {
+		desc    string
+		cellPos image.Point
+		dotRow  int
+		dotCol  int
+		want    image.Point
+		wantBit uint8
+		wantErr bool
+	}{
+		{
+			desc:    "fails on negative dotRow",
+			dotRow:  -1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on dotRow too large",
+			dotRow:  RowMult,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on negative dotCol",
+			dotCol:  -1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on dotCol too large",
+			dotCol:  ColMult,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on negative cell",
+			cellPos: image.Point{-1, 0},
+			wantErr: true,
+		},
+		{
+			desc:    "first dot in the first cell",
+			want:    image.Point{0, 0},
+			wantBit: 0x01,
+		},
+		{
+			desc:    "last dot in the first cell",
+			dotRow:  3,
+			dotCol:  1,
+			want:    image.Point{1, 3},
+			wantBit: 0x80,
+		},
+		{
+			desc:    "dot in another cell",
+			cellPos: image.Point{2, 1},
+			dotRow:  2,
+			dotCol:  1,
+			want:    image.Point{5, 6},
+			wantBit: 0x20,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, gotBit, err := Coords(tc.cellPos, tc.dotRow, tc.dotCol)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Coords => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got != tc.want || gotBit != tc.wantBit {
+				t.Errorf("Coords => (%v, %#x), want (%v, %#x)", got, gotBit, tc.want, tc.wantBit)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package buffer
 import (
 	"fmt"
 	"image"
+	"unicode"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/area"
@@ -38,15 +39,28 @@ type Cell struct {
 	// Rune is the rune stored in the cell.
 	Rune rune
 
+	// Combining are the combining characters (e.g. diacritics) that modify
+	// the rune stored in the cell, in the order they were added.
+	Combining []rune
+
 	// Opts are the cell options.
 	Opts *cell.Options
 }
 
 // String implements fmt.Stringer.
 func (c *Cell) String() string {
+	if len(c.Combining) > 0 {
+		return fmt.Sprintf("{%q}", string(append([]rune{c.Rune}, c.Combining...)))
+	}
 	return fmt.Sprintf("{%q}", c.Rune)
 }
 
+// IsCombining determines if the rune is a combining character, i.e. a mark
+// that modifies the preceding rune instead of occupying a cell on its own.
+func IsCombining(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
 // NewCell returns a new cell.
 func NewCell(r rune, opts ...cell.Option) *Cell {
 	return &Cell{
@@ -57,9 +71,14 @@ func NewCell(r rune, opts ...cell.Option) *Cell {
 
 // Copy returns a copy the cell.
 func (c *Cell) Copy() *Cell {
+	var combining []rune
+	if len(c.Combining) > 0 {
+		combining = append(combining, c.Combining...)
+	}
 	return &Cell{
-		Rune: c.Rune,
-		Opts: cell.NewOptions(c.Opts),
+		Rune:      c.Rune,
+		Combining: combining,
+		Opts:      cell.NewOptions(c.Opts),
 	}
 }
 
@@ -101,7 +120,12 @@ func New(size image.Point) (Buffer, error) {
 // printed on the terminal. See http://www.unicode.org/reports/tr11/.
 // Use the options to specify which attributes to modify, if an attribute
 // option isn't specified, the attribute retains its previous value.
+// Setting a rune removes any combining characters the cell held. Returns an
+// error if the rune is a combining character, use SetCellCombine to add those.
 func (b Buffer) SetCell(p image.Point, r rune, opts ...cell.Option) (int, error) {
+	if IsCombining(r) {
+		return -1, fmt.Errorf("cannot set rune %q at point %v, it is a combining character, use SetCellCombine to add it to the rune in the cell", r, p)
+	}
 	partial, err := b.IsPartial(p)
 	if err != nil {
 		return -1, err
@@ -126,10 +150,38 @@ func (b Buffer) SetCell(p image.Point, r rune, opts ...cell.Option) (int, error)
 
 	c := b[p.X][p.Y]
 	c.Rune = r
+	c.Combining = nil
 	c.Apply(opts...)
 	return rw, nil
 }
 
+// SetCellCombine appends the combining character to the rune of the specified
+// cell in the buffer. Multiple calls stack the combining characters, e.g. to
+// apply more than one diacritic. The cell must already hold a rune set by
+// SetCell.
+// Use the options to specify which attributes to modify, if an attribute
+// option isn't specified, the attribute retains its previous value.
+func (b Buffer) SetCellCombine(p image.Point, r rune, opts ...cell.Option) error {
+	if !IsCombining(r) {
+		return fmt.Errorf("cannot combine rune %q at point %v, it isn't a combining character, use SetCell instead", r, p)
+	}
+	partial, err := b.IsPartial(p)
+	if err != nil {
+		return err
+	}
+	if partial {
+		return fmt.Errorf("cannot combine rune %q at point %v, it is a partial cell occupied by a wide rune in the previous cell", r, p)
+	}
+
+	c := b[p.X][p.Y]
+	if c.Rune == 0 {
+		return fmt.Errorf("cannot combine rune %q at point %v, the cell doesn't hold a rune to combine with", r, p)
+	}
+	c.Combining = append(c.Combining, r)
+	c.Apply(opts...)
+	return nil
+}
+
 // IsPartial returns true if the cell at the specified point holds a part of a
 // full width rune from a previous cell. See
 // http://www.unicode.org/reports/tr11/.
@@ -159,6 +159,19 @@ func TestCellCopy(t *testing.T) {
 				cell.BgColor(cell.ColorBlack),
 			),
 		},
+		{
+			desc: "copies cell with combining characters",
+			cell: &Cell{
+				Rune:      'e',
+				Combining: []rune{'\u0323', '\u0302'},
+				Opts:      cell.NewOptions(),
+			},
+			want: &Cell{
+				Rune:      'e',
+				Combining: []rune{'\u0323', '\u0302'},
+				Opts:      cell.NewOptions(),
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -463,6 +476,31 @@ func TestSetCell(t *testing.T) {
 				return b
 			}(),
 		},
+		{
+			desc:    "fails on a combining character",
+			buffer:  mustNew(size),
+			point:   image.Point{0, 0},
+			r:       '\u0300',
+			wantErr: true,
+		},
+		{
+			desc: "setting a rune removes combining characters",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				c := b[0][0]
+				c.Rune = 'e'
+				c.Combining = []rune{'\u0301'}
+				return b
+			}(),
+			point:     image.Point{0, 0},
+			r:         'A',
+			wantCells: 1,
+			want: func() Buffer {
+				b := mustNew(size)
+				b[0][0].Rune = 'A'
+				return b
+			}(),
+		},
 	}
 
 	for _, tc := range tests {
@@ -487,6 +525,223 @@ func TestSetCell(t *testing.T) {
 	}
 }
 
+func TestSetCellCombine(t *testing.T) {
+	size := image.Point{3, 3}
+	tests := []struct {
+		desc    string
+		buffer  Buffer
+		point   image.Point
+		runes   []rune
+		opts    []cell.Option
+		want    Buffer
+		wantErr bool
+	}{
+		{
+			desc: "point falls outside of the buffer",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				b[0][0].Rune = 'e'
+				return b
+			}(),
+			point:   image.Point{3, 3},
+			runes:   []rune{'\u0300'},
+			wantErr: true,
+		},
+		{
+			desc: "fails on a rune that isn't a combining character",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				b[0][0].Rune = 'e'
+				return b
+			}(),
+			point:   image.Point{0, 0},
+			runes:   []rune{'a'},
+			wantErr: true,
+		},
+		{
+			desc:    "fails on an empty cell",
+			buffer:  mustNew(size),
+			point:   image.Point{0, 0},
+			runes:   []rune{'\u0300'},
+			wantErr: true,
+		},
+		{
+			desc: "fails on cell with partial rune",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				b[0][0].Rune = '世'
+				return b
+			}(),
+			point:   image.Point{1, 0},
+			runes:   []rune{'\u0300'},
+			wantErr: true,
+		},
+		{
+			desc: "combines grave accent",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				b[1][1].Rune = 'a'
+				return b
+			}(),
+			point: image.Point{1, 1},
+			runes: []rune{'\u0300'},
+			want: func() Buffer {
+				b := mustNew(size)
+				c := b[1][1]
+				c.Rune = 'a'
+				c.Combining = []rune{'\u0300'}
+				return b
+			}(),
+		},
+		{
+			desc: "combines with a full-width rune",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				b[0][0].Rune = '世'
+				return b
+			}(),
+			point: image.Point{0, 0},
+			runes: []rune{'\u20dd'},
+			want: func() Buffer {
+				b := mustNew(size)
+				c := b[0][0]
+				c.Rune = '世'
+				c.Combining = []rune{'\u20dd'}
+				return b
+			}(),
+		},
+		{
+			desc: "stacks diacritics",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				b[0][0].Rune = 'e'
+				return b
+			}(),
+			point: image.Point{0, 0},
+			runes: []rune{'\u0323', '\u0302', '\u0301'},
+			want: func() Buffer {
+				b := mustNew(size)
+				c := b[0][0]
+				c.Rune = 'e'
+				c.Combining = []rune{'\u0323', '\u0302', '\u0301'}
+				return b
+			}(),
+		},
+		{
+			desc: "combines Hebrew points",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				b[0][0].Rune = 'ש'
+				return b
+			}(),
+			point: image.Point{0, 0},
+			runes: []rune{'\u05b8', '\u05c1'},
+			want: func() Buffer {
+				b := mustNew(size)
+				c := b[0][0]
+				c.Rune = 'ש'
+				c.Combining = []rune{'\u05b8', '\u05c1'}
+				return b
+			}(),
+		},
+		{
+			desc: "sets the provided options",
+			buffer: func() Buffer {
+				b := mustNew(size)
+				b[0][0].Rune = 'e'
+				return b
+			}(),
+			point: image.Point{0, 0},
+			runes: []rune{'\u0301'},
+			opts: []cell.Option{
+				cell.FgColor(cell.ColorRed),
+			},
+			want: func() Buffer {
+				b := mustNew(size)
+				c := b[0][0]
+				c.Rune = 'e'
+				c.Combining = []rune{'\u0301'}
+				c.Opts = cell.NewOptions(cell.FgColor(cell.ColorRed))
+				return b
+			}(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var err error
+			for _, r := range tc.runes {
+				if err = tc.buffer.SetCellCombine(tc.point, r, tc.opts...); err != nil {
+					break
+				}
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("SetCellCombine => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got := tc.buffer
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("SetCellCombine => unexpected buffer, diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsCombining(t *testing.T) {
+	tests := []struct {
+		desc string
+		r    rune
+		want bool
+	}{
+		{
+			desc: "half-width rune",
+			r:    'a',
+			want: false,
+		},
+		{
+			desc: "full-width rune",
+			r:    '世',
+			want: false,
+		},
+		{
+			desc: "zero value rune",
+			r:    0,
+			want: false,
+		},
+		{
+			desc: "combining grave accent",
+			r:    '\u0300',
+			want: true,
+		},
+		{
+			desc: "combining dot below",
+			r:    '\u0323',
+			want: true,
+		},
+		{
+			desc: "combining enclosing circle",
+			r:    '\u20dd',
+			want: true,
+		},
+		{
+			desc: "Hebrew point",
+			r:    '\u05b8',
+			want: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := IsCombining(tc.r); got != tc.want {
+				t.Errorf("IsCombining(%q) => %v, want %v", tc.r, got, tc.want)
+			}
+		})
+	}
+}
+
 func TestIsPartial(t *testing.T) {
 	tests := []struct {
 		desc    string
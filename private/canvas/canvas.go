@@ -35,6 +35,25 @@ type Canvas struct {
 
 	// buffer is where the drawing happens.
 	buffer buffer.Buffer
+
+	// clipboard is text that should be placed into the clipboard of the
+	// terminal when the canvas is applied, nil if no copy was requested.
+	clipboard *string
+}
+
+// OutOfBoundsError is returned when a point falls outside of the area
+// occupied by the canvas. Callers that intentionally draw past the edges of
+// the canvas can detect it with errors.As.
+type OutOfBoundsError struct {
+	// Pos is the point that falls outside of the canvas.
+	Pos image.Point
+	// Bounds is the area occupied by the canvas as returned by Area.
+	Bounds image.Rectangle
+}
+
+// Error implements error.Error.
+func (e *OutOfBoundsError) Error() string {
+	return fmt.Sprintf("point %v falls outside of the area %v occupied by the canvas", e.Pos, e.Bounds)
 }
 
 // New returns a new Canvas with a buffer for the provided area.
@@ -64,6 +83,18 @@ func (c *Canvas) Area() image.Rectangle {
 	return image.Rect(0, 0, s.X, s.Y)
 }
 
+// Width returns the width of the canvas in cells.
+// Equivalent to Area().Dx().
+func (c *Canvas) Width() int {
+	return c.buffer.Size().X
+}
+
+// Height returns the height of the canvas in cells.
+// Equivalent to Area().Dy().
+func (c *Canvas) Height() int {
+	return c.buffer.Size().Y
+}
+
 // Clear clears all the content on the canvas.
 func (c *Canvas) Clear() error {
 	b, err := buffer.New(c.Size())
@@ -74,25 +105,80 @@ func (c *Canvas) Clear() error {
 	return nil
 }
 
+// Fill sets all the cells on the canvas to the rune and the cell options.
+// Unlike SetCell, the cells don't retain any of their previous attributes,
+// attributes without an option are reset to their default values.
+// Full-width runes occupy multiple cells, the cells at the end of each line
+// that cannot fit the whole rune are left empty, but still get the options.
+// Returns an error if the rune is a combining character.
+func (c *Canvas) Fill(r rune, opts ...cell.Option) error {
+	if buffer.IsCombining(r) {
+		return fmt.Errorf("cannot fill the canvas with rune %q, it is a combining character", r)
+	}
+	rw := runewidth.RuneWidth(r)
+	if rw == 0 {
+		rw = 1
+	}
+
+	o := cell.NewOptions(opts...)
+	width := c.buffer.Size().X
+	for col, cells := range c.buffer {
+		fr := r
+		if col%rw != 0 || col-col%rw+rw > width {
+			// The remaining part of a full-width rune or a cell that
+			// cannot fit it.
+			fr = 0
+		}
+		for _, bc := range cells {
+			bc.Rune = fr
+			bc.Combining = nil
+			*bc.Opts = *o
+		}
+	}
+	return nil
+}
+
 // SetCell sets the rune of the specified cell on the canvas. Returns the
 // number of cells the rune occupies, wide runes can occupy multiple cells when
 // printed on the terminal. See http://www.unicode.org/reports/tr11/.
 // Use the options to specify which attributes to modify, if an attribute
 // option isn't specified, the attribute retains its previous value.
+// Returns an *OutOfBoundsError if the point falls outside of the canvas.
 func (c *Canvas) SetCell(p image.Point, r rune, opts ...cell.Option) (int, error) {
+	if err := c.checkBounds(p); err != nil {
+		return -1, err
+	}
 	return c.buffer.SetCell(p, r, opts...)
 }
 
+// SetCellCombine appends the combining character (e.g. a diacritic) to the
+// rune of the specified cell. The cell must already hold a rune set by
+// SetCell. Combining characters don't occupy any cells on their own.
+// Use the options to specify which attributes to modify, if an attribute
+// option isn't specified, the attribute retains its previous value.
+// Returns an *OutOfBoundsError if the point falls outside of the canvas.
+func (c *Canvas) SetCellCombine(p image.Point, r rune, opts ...cell.Option) error {
+	if err := c.checkBounds(p); err != nil {
+		return err
+	}
+	return c.buffer.SetCellCombine(p, r, opts...)
+}
+
+// checkBounds returns an *OutOfBoundsError if the point falls outside of the
+// canvas.
+func (c *Canvas) checkBounds(p image.Point) error {
+	if ar := c.Area(); !p.In(ar) {
+		return &OutOfBoundsError{Pos: p, Bounds: ar}
+	}
+	return nil
+}
+
 // Cell returns a copy of the specified cell.
+// Returns an *OutOfBoundsError if the point falls outside of the canvas.
 func (c *Canvas) Cell(p image.Point) (*buffer.Cell, error) {
-	ar, err := area.FromSize(c.Size())
-	if err != nil {
+	if err := c.checkBounds(p); err != nil {
 		return nil, err
 	}
-	if !p.In(ar) {
-		return nil, fmt.Errorf("point %v falls outside of the area %v occupied by the canvas", p, ar)
-	}
-
 	return c.buffer[p.X][p.Y].Copy(), nil
 }
 
@@ -100,6 +186,7 @@ func (c *Canvas) Cell(p image.Point) (*buffer.Cell, error) {
 // modifying the content of the cell.
 // Sets the default cell options if no options are provided.
 // This method is idempotent.
+// Returns an *OutOfBoundsError if the point falls outside of the canvas.
 func (c *Canvas) SetCellOpts(p image.Point, opts ...cell.Option) error {
 	curCell, err := c.Cell(p)
 	if err != nil {
@@ -111,11 +198,17 @@ func (c *Canvas) SetCellOpts(p image.Point, opts ...cell.Option) error {
 		opts = []cell.Option{
 			cell.FgColor(cell.ColorDefault),
 			cell.BgColor(cell.ColorDefault),
+			cell.Attributes(cell.AttrNone),
 		}
 	}
 	if _, err := c.SetCell(p, curCell.Rune, opts...); err != nil {
 		return err
 	}
+	for _, r := range curCell.Combining {
+		if err := c.SetCellCombine(p, r); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -162,8 +255,17 @@ func (c *Canvas) SetAreaCellOpts(cellArea image.Rectangle, opts ...cell.Option)
 	return nil
 }
 
+// CopyToClipboard requests the text to be placed into the system clipboard
+// when this canvas is applied to a terminal. Only the last requested text is
+// copied. Terminals that don't implement terminalapi.ClipboardTerminal ignore
+// the request.
+func (c *Canvas) CopyToClipboard(text string) {
+	c.clipboard = &text
+}
+
 // setCellFunc is a function that sets cell content on a terminal or a canvas.
-type setCellFunc func(image.Point, rune, ...cell.Option) error
+// The combining characters are applied to the rune after it is set.
+type setCellFunc func(image.Point, rune, []rune, ...cell.Option) error
 
 // copyTo is the internal implementation of code that copies the content of a
 // canvas. If a non zero offset is provided, all the copied points are offset by
@@ -186,7 +288,7 @@ func (c *Canvas) copyTo(offset image.Point, dstSetCell setCellFunc) error {
 			}
 			cell := c.buffer[col][row]
 			p := image.Point{col, row}.Add(offset)
-			if err := dstSetCell(p, cell.Rune, cell.Opts); err != nil {
+			if err := dstSetCell(p, cell.Rune, cell.Combining, cell.Opts); err != nil {
 				return fmt.Errorf("setCellFunc%v => error: %v", p, err)
 			}
 		}
@@ -215,7 +317,27 @@ func (c *Canvas) Apply(t terminalapi.Terminal) error {
 	// image.Point{0, 0} on the terminal.
 	// Depends on area assigned by the container.
 	offset := c.area.Min
-	return c.copyTo(offset, t.SetCell)
+	fn := setCellFunc(func(p image.Point, r rune, combining []rune, opts ...cell.Option) error {
+		if ct, ok := t.(terminalapi.CombiningTerminal); ok && len(combining) > 0 {
+			return ct.SetCellCombining(p, r, combining, opts...)
+		}
+		// Terminals that cannot display combining characters only display
+		// the rune they modify.
+		return t.SetCell(p, r, opts...)
+	})
+	if err := c.copyTo(offset, fn); err != nil {
+		return err
+	}
+
+	if c.clipboard != nil {
+		if ct, ok := t.(terminalapi.ClipboardTerminal); ok {
+			if err := ct.SetClipboard(*c.clipboard); err != nil {
+				return fmt.Errorf("SetClipboard => %v", err)
+			}
+		}
+		c.clipboard = nil
+	}
+	return nil
 }
 
 // CopyTo copies the content of this canvas onto the destination canvas.
@@ -226,10 +348,15 @@ func (c *Canvas) CopyTo(dst *Canvas) error {
 		return fmt.Errorf("the canvas area %v doesn't fit or lie inside the destination canvas area %v", c.area, dst.Area())
 	}
 
-	fn := setCellFunc(func(p image.Point, r rune, opts ...cell.Option) error {
+	fn := setCellFunc(func(p image.Point, r rune, combining []rune, opts ...cell.Option) error {
 		if _, err := dst.SetCell(p, r, opts...); err != nil {
 			return fmt.Errorf("dst.SetCell => %v", err)
 		}
+		for _, cr := range combining {
+			if err := dst.SetCellCombine(p, cr); err != nil {
+				return fmt.Errorf("dst.SetCellCombine => %v", err)
+			}
+		}
 		return nil
 	})
 
@@ -243,5 +370,11 @@ func (c *Canvas) CopyTo(dst *Canvas) error {
 	// canvas. Copying this sub-canvas back onto the parent accounts for this
 	// offset.
 	offset := c.area.Min
-	return c.copyTo(offset, fn)
+	if err := c.copyTo(offset, fn); err != nil {
+		return err
+	}
+	if c.clipboard != nil {
+		dst.clipboard = c.clipboard
+	}
+	return nil
 }
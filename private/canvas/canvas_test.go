@@ -15,6 +15,7 @@
 package canvas
 
 import (
+	"errors"
 	"image"
 	"testing"
 
@@ -97,6 +98,13 @@ func TestNew(t *testing.T) {
 			if diff := pretty.Compare(tc.wantArea, gotArea); diff != "" {
 				t.Errorf("Area => unexpected diff (-want, +got):\n%s", diff)
 			}
+
+			if got, want := c.Width(), gotArea.Dx(); got != want {
+				t.Errorf("Width => got %d, want %d", got, want)
+			}
+			if got, want := c.Height(), gotArea.Dy(); got != want {
+				t.Errorf("Height => got %d, want %d", got, want)
+			}
 		})
 	}
 }
@@ -862,6 +870,113 @@ func TestClear(t *testing.T) {
 	}
 }
 
+func TestFill(t *testing.T) {
+	tests := []struct {
+		desc    string
+		ar      image.Rectangle
+		r       rune
+		opts    []cell.Option
+		want    buffer.Buffer
+		wantErr bool
+	}{
+		{
+			desc:    "fails on a combining character",
+			ar:      image.Rect(0, 0, 2, 2),
+			r:       '\u0301',
+			wantErr: true,
+		},
+		{
+			desc: "fills the canvas with the rune",
+			ar:   image.Rect(1, 1, 3, 3),
+			r:    'x',
+			want: buffer.Buffer{
+				{
+					buffer.NewCell('x'),
+					buffer.NewCell('x'),
+				},
+				{
+					buffer.NewCell('x'),
+					buffer.NewCell('x'),
+				},
+			},
+		},
+		{
+			desc: "fills the canvas with the rune and resets previous cell options",
+			ar:   image.Rect(0, 0, 2, 1),
+			r:    'x',
+			opts: []cell.Option{
+				cell.FgColor(cell.ColorRed),
+			},
+			want: buffer.Buffer{
+				{
+					buffer.NewCell('x', cell.FgColor(cell.ColorRed)),
+				},
+				{
+					buffer.NewCell('x', cell.FgColor(cell.ColorRed)),
+				},
+			},
+		},
+		{
+			desc: "fills the canvas with a full-width rune",
+			ar:   image.Rect(0, 0, 5, 1),
+			r:    '世',
+			opts: []cell.Option{
+				cell.BgColor(cell.ColorBlue),
+			},
+			want: buffer.Buffer{
+				{
+					buffer.NewCell('世', cell.BgColor(cell.ColorBlue)),
+				},
+				{
+					buffer.NewCell(0, cell.BgColor(cell.ColorBlue)),
+				},
+				{
+					buffer.NewCell('世', cell.BgColor(cell.ColorBlue)),
+				},
+				{
+					buffer.NewCell(0, cell.BgColor(cell.ColorBlue)),
+				},
+				{
+					buffer.NewCell(0, cell.BgColor(cell.ColorBlue)),
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := New(tc.ar)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			// Cell options and combining characters set before are replaced.
+			if _, err := c.SetCell(image.Point{0, 0}, 'a', cell.BgColor(cell.ColorGreen)); err != nil {
+				t.Fatalf("SetCell => unexpected error: %v", err)
+			}
+			if err := c.SetCellCombine(image.Point{0, 0}, '\u0301'); err != nil {
+				t.Fatalf("SetCellCombine => unexpected error: %v", err)
+			}
+
+			err = c.Fill(tc.r, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Fill => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if diff := pretty.Compare(tc.want, c.buffer); diff != "" {
+				t.Errorf("Fill => unexpected diff (-want, +got):\n%s", diff)
+			}
+
+			// The cells of the filled canvas can be set again.
+			if _, err := c.SetCell(image.Point{0, 0}, 'y'); err != nil {
+				t.Errorf("SetCell after Fill => unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 // TestApplyFullWidthRunes verifies that when applying a full-width rune to the
 // terminal, canvas doesn't touch the neighbor cell that holds the remaining
 // part of the full-width rune.
@@ -903,6 +1018,132 @@ func TestApplyFullWidthRunes(t *testing.T) {
 	}
 }
 
+func TestApplyCombiningCharacters(t *testing.T) {
+	ar := image.Rect(0, 0, 3, 3)
+	c, err := New(ar)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	p := image.Point{1, 1}
+	if _, err := c.SetCell(p, 'e'); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	for _, r := range []rune{'\u0323', '\u0302'} {
+		if err := c.SetCellCombine(p, r); err != nil {
+			t.Fatalf("SetCellCombine => unexpected error: %v", err)
+		}
+	}
+	// Setting options must preserve the combining characters.
+	if err := c.SetCellOpts(p, cell.FgColor(cell.ColorRed)); err != nil {
+		t.Fatalf("SetCellOpts => unexpected error: %v", err)
+	}
+
+	ft, err := faketerm.New(area.Size(ar))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	if err := c.Apply(ft); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+
+	want, err := buffer.New(area.Size(ar))
+	if err != nil {
+		t.Fatalf("buffer.New => unexpected error: %v", err)
+	}
+	want[p.X][p.Y].Rune = 'e'
+	want[p.X][p.Y].Combining = []rune{'\u0323', '\u0302'}
+	want[p.X][p.Y].Opts = cell.NewOptions(cell.FgColor(cell.ColorRed))
+
+	got := ft.BackBuffer()
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("faketerm.BackBuffer => unexpected diff (-want, +got):\n%s", diff)
+	}
+
+	dst, err := New(ar)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := c.CopyTo(dst); err != nil {
+		t.Fatalf("CopyTo => unexpected error: %v", err)
+	}
+	gotCell, err := dst.Cell(p)
+	if err != nil {
+		t.Fatalf("Cell => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare(want[p.X][p.Y], gotCell); diff != "" {
+		t.Errorf("CopyTo => unexpected cell diff (-want, +got):\n%s", diff)
+	}
+}
+
+// clipboardTerm is a fake terminal that records the clipboard requests.
+type clipboardTerm struct {
+	*faketerm.Terminal
+
+	// copied is the text of each clipboard request.
+	copied []string
+}
+
+// SetClipboard implements terminalapi.ClipboardTerminal.SetClipboard.
+func (ct *clipboardTerm) SetClipboard(text string) error {
+	ct.copied = append(ct.copied, text)
+	return nil
+}
+
+func TestCopyToClipboard(t *testing.T) {
+	ar := image.Rect(1, 1, 3, 3)
+	c, err := New(ar)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	ft, err := faketerm.New(image.Point{3, 3})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	ct := &clipboardTerm{Terminal: ft}
+
+	// Nothing is copied until requested.
+	if err := c.Apply(ct); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+
+	c.CopyToClipboard("first")
+	c.CopyToClipboard("second")
+	if err := c.Apply(ct); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+	// The request is only applied once.
+	if err := c.Apply(ct); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare([]string{"second"}, ct.copied); diff != "" {
+		t.Errorf("Apply => unexpected clipboard diff (-want, +got):\n%s", diff)
+	}
+
+	// Terminals without clipboard support ignore the request.
+	c.CopyToClipboard("ignored")
+	if err := c.Apply(ft); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+
+	// CopyTo carries the request to the destination canvas.
+	c.CopyToClipboard("copied")
+	dst, err := New(image.Rect(0, 0, 3, 3))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := c.CopyTo(dst); err != nil {
+		t.Fatalf("CopyTo => unexpected error: %v", err)
+	}
+	ct.copied = nil
+	if err := dst.Apply(ct); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare([]string{"copied"}, ct.copied); diff != "" {
+		t.Errorf("CopyTo => unexpected clipboard diff (-want, +got):\n%s", diff)
+	}
+}
+
 func TestCell(t *testing.T) {
 	tests := []struct {
 		desc    string
@@ -1000,6 +1241,70 @@ func mustSetCell(c *Canvas, p image.Point, r rune, opts ...cell.Option) {
 	}
 }
 
+func TestOutOfBoundsError(t *testing.T) {
+	c, err := New(image.Rect(1, 1, 3, 3))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	p := image.Point{2, 0}
+
+	tests := []struct {
+		desc string
+		call func() error
+	}{
+		{
+			desc: "SetCell",
+			call: func() error {
+				_, err := c.SetCell(p, 'x')
+				return err
+			},
+		},
+		{
+			desc: "SetCellCombine",
+			call: func() error {
+				return c.SetCellCombine(p, '\u0301')
+			},
+		},
+		{
+			desc: "SetCellOpts",
+			call: func() error {
+				return c.SetCellOpts(p, cell.FgColor(cell.ColorRed))
+			},
+		},
+		{
+			desc: "Cell",
+			call: func() error {
+				_, err := c.Cell(p)
+				return err
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.call()
+			var oob *OutOfBoundsError
+			if !errors.As(err, &oob) {
+				t.Fatalf("%s => got error %v, want an *OutOfBoundsError", tc.desc, err)
+			}
+			want := &OutOfBoundsError{
+				Pos:    p,
+				Bounds: image.Rect(0, 0, 2, 2),
+			}
+			if diff := pretty.Compare(want, oob); diff != "" {
+				t.Errorf("%s => unexpected diff (-want, +got):\n%s", tc.desc, diff)
+			}
+		})
+	}
+
+	// Other errors aren't reported as out of bounds.
+	_, err = c.SetCell(image.Point{0, 0}, '\u0301')
+	var oob *OutOfBoundsError
+	if err == nil || errors.As(err, &oob) {
+		t.Errorf("SetCell => got error %v, want an error that isn't an *OutOfBoundsError", err)
+	}
+}
+
 func TestCopyTo(t *testing.T) {
 	tests := []struct {
 		desc    string
@@ -52,6 +52,13 @@ func MustSetCell(c *canvas.Canvas, p image.Point, r rune, opts ...cell.Option) i
 	return cells
 }
 
+// MustSetCellCombine combines the rune with the cell or panics.
+func MustSetCellCombine(c *canvas.Canvas, p image.Point, r rune, opts ...cell.Option) {
+	if err := c.SetCellCombine(p, r, opts...); err != nil {
+		panic(fmt.Sprintf("canvas.SetCellCombine => unexpected error: %v", err))
+	}
+}
+
 // MustSetAreaCells sets the cells in the area  or panics.
 func MustSetAreaCells(c *canvas.Canvas, cellArea image.Rectangle, r rune, opts ...cell.Option) {
 	if err := c.SetAreaCells(cellArea, r, opts...); err != nil {
@@ -23,6 +23,7 @@ import (
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/buffer"
 	"github.com/mum4k/termdash/private/runewidth"
 )
 
@@ -178,11 +179,27 @@ func Text(c *canvas.Canvas, text string, start image.Point, opts ...TextOption)
 	}
 
 	cur := start
+	prev := start
+	// Indicates if a rune was drawn that combining characters can modify.
+	var drawn bool
 	for _, r := range trimmed {
+		if buffer.IsCombining(r) {
+			if !drawn {
+				// A leading combining character has no rune to modify.
+				continue
+			}
+			// Combining characters modify the previous rune.
+			if err := c.SetCellCombine(prev, r, opt.cellOpts...); err != nil {
+				return err
+			}
+			continue
+		}
 		cells, err := c.SetCell(cur, r, opt.cellOpts...)
 		if err != nil {
 			return err
 		}
+		prev = cur
+		drawn = true
 		cur = image.Point{cur.X + cells, cur.Y}
 	}
 	return nil
@@ -503,6 +503,39 @@ func TestText(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "draws text with combining characters",
+			canvas: image.Rect(0, 0, 3, 2),
+			text:   "e\u0323\u0302a\u0300",
+			start:  image.Point{1, 1},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{1, 1}, 'e')
+				testcanvas.MustSetCellCombine(c, image.Point{1, 1}, '\u0323')
+				testcanvas.MustSetCellCombine(c, image.Point{1, 1}, '\u0302')
+				testcanvas.MustSetCell(c, image.Point{2, 1}, 'a')
+				testcanvas.MustSetCellCombine(c, image.Point{2, 1}, '\u0300')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "skips combining characters at the start of the text",
+			canvas: image.Rect(0, 0, 3, 2),
+			text:   "\u0301\u0300ab",
+			start:  image.Point{1, 1},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{1, 1}, 'a')
+				testcanvas.MustSetCell(c, image.Point{2, 1}, 'b')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "draws text with cell options",
 			canvas: image.Rect(0, 0, 3, 2),
@@ -321,6 +321,21 @@ func TestVerticalText(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "skips combining characters at the start of the text",
+			canvas: image.Rect(0, 0, 2, 3),
+			text:   "\u0301ab",
+			start:  image.Point{1, 1},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{1, 1}, 'a')
+				testcanvas.MustSetCell(c, image.Point{1, 2}, 'b')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "draws text with cell options",
 			canvas: image.Rect(0, 0, 2, 3),
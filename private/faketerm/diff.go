@@ -66,13 +66,16 @@ func Diff(want, got *Terminal) string {
 			gotCell := got.BackBuffer()[col][row]
 			wantCell := want.BackBuffer()[col][row]
 			r := gotCell.Rune
-			if r != wantCell.Rune {
+			combining := string(gotCell.Combining)
+			if r != wantCell.Rune || combining != string(wantCell.Combining) {
 				r = '࿃'
+				combining = ""
 				cellsDiffer = true
 			} else if r == 0 && !partial {
 				r = ' '
 			}
 			b.WriteRune(r)
+			b.WriteString(combining)
 
 			if !reflect.DeepEqual(gotCell.Opts, wantCell.Opts) {
 				optDiffs = append(optDiffs, &optDiff{
@@ -98,9 +101,15 @@ func Diff(want, got *Terminal) string {
 		b.WriteString("  Found differences in some of the cell runes:\n")
 		for row := 0; row < size.Y; row++ {
 			for col := 0; col < size.X; col++ {
-				got := got.BackBuffer()[col][row].Rune
-				want := want.BackBuffer()[col][row].Rune
-				b.WriteString(fmt.Sprintf("  cell(%v, %v) => got '%c' (rune %d), want '%c' (rune %d)\n", col, row, got, got, want, want))
+				gotCell := got.BackBuffer()[col][row]
+				wantCell := want.BackBuffer()[col][row]
+				got := gotCell.Rune
+				want := wantCell.Rune
+				b.WriteString(fmt.Sprintf("  cell(%v, %v) => got '%c' (rune %d), want '%c' (rune %d)", col, row, got, got, want, want))
+				if len(gotCell.Combining) > 0 || len(wantCell.Combining) > 0 {
+					b.WriteString(fmt.Sprintf(", got combining %q, want combining %q", string(gotCell.Combining), string(wantCell.Combining)))
+				}
+				b.WriteString("\n")
 			}
 		}
 	}
@@ -131,6 +131,7 @@ func (t *Terminal) String() string {
 				r = ' '
 			}
 			b.WriteRune(r)
+			b.WriteString(string(t.buffer[col][row].Combining))
 		}
 		b.WriteRune('\n')
 	}
@@ -190,6 +191,22 @@ func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
 	return nil
 }
 
+// SetCellCombining implements terminalapi.CombiningTerminal.SetCellCombining.
+func (t *Terminal) SetCellCombining(p image.Point, r rune, combining []rune, opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, err := t.buffer.SetCell(p, r, opts...); err != nil {
+		return err
+	}
+	for _, c := range combining {
+		if err := t.buffer.SetCellCombine(p, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Event implements terminalapi.Terminal.Event.
 func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
 	if t.events == nil {
@@ -0,0 +1,42 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osc2 encodes the OSC 2 escape sequence that requests the terminal
+// to set the title of its window or tab.
+package osc2
+
+import (
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Sequence returns the OSC 2 escape sequence that sets the title.
+// Control characters are removed from the title, since they could terminate
+// the sequence early.
+func Sequence(title string) string {
+	clean := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, title)
+	return "\x1b]2;" + clean + "\a"
+}
+
+// Write writes the OSC 2 escape sequence for the title to the writer.
+func Write(w io.Writer, title string) error {
+	_, err := io.WriteString(w, Sequence(title))
+	return err
+}
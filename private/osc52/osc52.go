@@ -0,0 +1,34 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osc52 encodes the OSC 52 escape sequence that requests the terminal
+// to set the content of the system clipboard.
+package osc52
+
+import (
+	"encoding/base64"
+	"io"
+)
+
+// Sequence returns the OSC 52 escape sequence that places the text into the
+// clipboard selection.
+func Sequence(text string) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a"
+}
+
+// Write writes the OSC 52 escape sequence for the text to the writer.
+func Write(w io.Writer, text string) error {
+	_, err := io.WriteString(w, Sequence(text))
+	return err
+}
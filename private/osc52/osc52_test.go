@@ -0,0 +1,74 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osc52
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSequence(t *testing.T) {
+	tests := []struct {
+		desc string
+		text string
+		want string
+	}{
+		{
+			desc: "empty text",
+			want: "\x1b]52;c;\a",
+		},
+		{
+			desc: "encodes the text in base64",
+			text: "hello",
+			want: "\x1b]52;c;aGVsbG8=\a",
+		},
+		{
+			desc: "encodes unicode text",
+			text: "⇄",
+			want: "\x1b]52;c;4oeE\a",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := Sequence(tc.text); got != tc.want {
+				t.Errorf("Sequence(%q) => %q, want %q", tc.text, got, tc.want)
+			}
+		})
+	}
+}
+
+// failingWriter is a writer that always fails.
+type failingWriter struct{}
+
+// Write implements io.Writer.Write.
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWrite(t *testing.T) {
+	var b bytes.Buffer
+	if err := Write(&b, "hello"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	if got, want := b.String(), "\x1b]52;c;aGVsbG8=\a"; got != want {
+		t.Errorf("Write => wrote %q, want %q", got, want)
+	}
+
+	if err := Write(failingWriter{}, "hello"); err == nil {
+		t.Errorf("Write => got nil error, want an error")
+	}
+}
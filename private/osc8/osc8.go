@@ -0,0 +1,217 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package osc8 encodes the OSC 8 escape sequences that display hyperlinks on
+// terminals.
+//
+// The terminal libraries termdash uses can't emit these sequences, so the
+// terminals track the cells that contain hyperlinks and after each flush
+// write them again, this time wrapped in the OSC 8 sequences. Terminals that
+// don't support OSC 8 ignore the sequences and display the text unchanged.
+package osc8
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// linkCell is a cell that contains a hyperlink.
+type linkCell struct {
+	r         rune
+	combining []rune
+	opts      cell.Options
+}
+
+// Links tracks the cells of the terminal that contain hyperlinks.
+// This object is not thread-safe.
+type Links struct {
+	cells map[image.Point]*linkCell
+}
+
+// NewLinks returns a new Links instance.
+func NewLinks() *Links {
+	return &Links{
+		cells: map[image.Point]*linkCell{},
+	}
+}
+
+// Set records the content of the cell at the point. Forgets the cell if the
+// options don't contain a hyperlink.
+func (l *Links) Set(p image.Point, r rune, combining []rune, opts *cell.Options) {
+	if opts.Hyperlink == "" {
+		delete(l.cells, p)
+		return
+	}
+	l.cells[p] = &linkCell{
+		r:         r,
+		combining: append([]rune(nil), combining...),
+		opts:      *opts,
+	}
+}
+
+// Clear forgets all the cells.
+func (l *Links) Clear() {
+	l.cells = map[image.Point]*linkCell{}
+}
+
+// Sequence returns the escape sequences that write the cells with hyperlinks
+// onto the terminal. Consecutive cells on a line with the same URI share a
+// single pair of OSC 8 sequences. The cursor position and the text
+// attributes of the terminal are saved before and restored after the
+// sequences. The colors of the cells are converted for the color mode.
+// Returns an empty string if there are no cells with hyperlinks.
+func (l *Links) Sequence(cm terminalapi.ColorMode) string {
+	if len(l.cells) == 0 {
+		return ""
+	}
+
+	var points []image.Point
+	for p := range l.cells {
+		points = append(points, p)
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Y != points[j].Y {
+			return points[i].Y < points[j].Y
+		}
+		return points[i].X < points[j].X
+	})
+
+	var b strings.Builder
+	b.WriteString("\x1b7") // Save the cursor and the text attributes.
+	var (
+		uri   string
+		style string      // The SGR sequence of the last written cell.
+		next  image.Point // Where the current link continues.
+	)
+	for i, p := range points {
+		c := l.cells[p]
+		if i == 0 || p != next || c.opts.Hyperlink != uri {
+			if i > 0 {
+				b.WriteString(endLink())
+			}
+			uri = c.opts.Hyperlink
+			fmt.Fprintf(&b, "\x1b[%d;%dH", p.Y+1, p.X+1)
+			b.WriteString(startLink(uri))
+			style = ""
+		}
+		if st := sgr(&c.opts, cm); st != style {
+			b.WriteString(st)
+			style = st
+		}
+		b.WriteRune(c.r)
+		for _, cr := range c.combining {
+			b.WriteRune(cr)
+		}
+
+		w := runewidth.RuneWidth(c.r)
+		if w < 1 {
+			w = 1
+		}
+		next = image.Point{p.X + w, p.Y}
+	}
+	b.WriteString(endLink())
+	b.WriteString("\x1b8") // Restore the cursor and the text attributes.
+	return b.String()
+}
+
+// Write writes the escape sequences returned by Sequence to the writer.
+// Writes nothing if there are no cells with hyperlinks.
+func (l *Links) Write(w io.Writer, cm terminalapi.ColorMode) error {
+	seq := l.Sequence(cm)
+	if seq == "" {
+		return nil
+	}
+	_, err := io.WriteString(w, seq)
+	return err
+}
+
+// startLink returns the OSC 8 sequence that starts a link to the URI.
+// Control characters are removed from the URI, since they could terminate
+// the sequence early.
+func startLink(uri string) string {
+	clean := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, uri)
+	return "\x1b]8;;" + clean + "\a"
+}
+
+// endLink returns the OSC 8 sequence that ends a link.
+func endLink() string {
+	return "\x1b]8;;\a"
+}
+
+// sgr returns the SGR escape sequence that sets the colors and the text
+// attributes of the cell.
+func sgr(opts *cell.Options, cm terminalapi.ColorMode) string {
+	params := []string{"0"}
+	for _, a := range []struct {
+		attr  cell.AttributeMask
+		param string
+	}{
+		{cell.AttrBold, "1"},
+		{cell.AttrItalic, "3"},
+		{cell.AttrUnderline, "4"},
+		{cell.AttrBlink, "5"},
+		{cell.AttrStrikethrough, "9"},
+	} {
+		if opts.Attrs.Has(a.attr) {
+			params = append(params, a.param)
+		}
+	}
+	if p := colorParam(opts.FgColor, cm, 30); p != "" {
+		params = append(params, p)
+	}
+	if p := colorParam(opts.BgColor, cm, 40); p != "" {
+		params = append(params, p)
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// colorParam returns the SGR parameter that sets the color, base is 30 for
+// the foreground and 40 for the background color.
+// Returns an empty string for the default color.
+func colorParam(c cell.Color, cm terminalapi.ColorMode, base int) string {
+	if c == cell.ColorDefault {
+		return ""
+	}
+	n := int(c&0x1ff) - 1 // Colors are off-by-one due to ColorDefault.
+	switch cm {
+	case terminalapi.ColorModeNormal:
+		n %= 16
+		if n < 8 {
+			return fmt.Sprintf("%d", base+n)
+		}
+		return fmt.Sprintf("%d", base+60+n-8)
+	case terminalapi.ColorMode256:
+		n %= 256
+	case terminalapi.ColorMode216:
+		n = n%216 + 16
+	case terminalapi.ColorModeGrayscale:
+		n = n%24 + 232
+	default:
+		return ""
+	}
+	return fmt.Sprintf("%d;5;%d", base+8, n)
+}
@@ -0,0 +1,218 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package osc8
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// cellSet is a call to Links.Set.
+type cellSet struct {
+	p         image.Point
+	r         rune
+	combining []rune
+	opts      []cell.Option
+}
+
+func TestSequence(t *testing.T) {
+	const (
+		start  = "\x1b7"
+		end    = "\x1b8"
+		unlink = "\x1b]8;;\a"
+	)
+
+	tests := []struct {
+		desc  string
+		cm    terminalapi.ColorMode
+		sets  []cellSet
+		clear bool
+		want  string
+	}{
+		{
+			desc: "no cells",
+			want: "",
+		},
+		{
+			desc: "ignores cells without a hyperlink",
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: 'a', opts: []cell.Option{cell.FgColor(cell.ColorRed)}},
+			},
+			want: "",
+		},
+		{
+			desc: "consecutive cells share one link",
+			cm:   terminalapi.ColorMode256,
+			sets: []cellSet{
+				{p: image.Point{2, 1}, r: 'b', opts: []cell.Option{cell.Hyperlink("https://a")}},
+				{p: image.Point{1, 1}, r: 'a', opts: []cell.Option{cell.Hyperlink("https://a")}},
+			},
+			want: start + "\x1b[2;2H\x1b]8;;https://a\a\x1b[0mab" + unlink + end,
+		},
+		{
+			desc: "sets the style when it changes within a link",
+			cm:   terminalapi.ColorMode256,
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: 'a', opts: []cell.Option{cell.Hyperlink("https://a")}},
+				{p: image.Point{1, 0}, r: 'b', opts: []cell.Option{cell.Hyperlink("https://a"), cell.Bold()}},
+			},
+			want: start + "\x1b[1;1H\x1b]8;;https://a\a\x1b[0ma\x1b[0;1mb" + unlink + end,
+		},
+		{
+			desc: "different URIs and gaps start new links",
+			cm:   terminalapi.ColorMode256,
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: 'a', opts: []cell.Option{cell.Hyperlink("https://a")}},
+				{p: image.Point{1, 0}, r: 'b', opts: []cell.Option{cell.Hyperlink("https://b")}},
+				{p: image.Point{3, 0}, r: 'c', opts: []cell.Option{cell.Hyperlink("https://b")}},
+				{p: image.Point{0, 1}, r: 'd', opts: []cell.Option{cell.Hyperlink("https://b")}},
+			},
+			want: start +
+				"\x1b[1;1H\x1b]8;;https://a\a\x1b[0ma" + unlink +
+				"\x1b[1;2H\x1b]8;;https://b\a\x1b[0mb" + unlink +
+				"\x1b[1;4H\x1b]8;;https://b\a\x1b[0mc" + unlink +
+				"\x1b[2;1H\x1b]8;;https://b\a\x1b[0md" + unlink +
+				end,
+		},
+		{
+			desc: "full-width runes continue the link",
+			cm:   terminalapi.ColorMode256,
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: '世', opts: []cell.Option{cell.Hyperlink("https://a")}},
+				{p: image.Point{2, 0}, r: '界', opts: []cell.Option{cell.Hyperlink("https://a")}},
+			},
+			want: start + "\x1b[1;1H\x1b]8;;https://a\a\x1b[0m世界" + unlink + end,
+		},
+		{
+			desc: "writes combining characters",
+			cm:   terminalapi.ColorMode256,
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: 'e', combining: []rune{'́'}, opts: []cell.Option{cell.Hyperlink("https://a")}},
+			},
+			want: start + "\x1b[1;1H\x1b]8;;https://a\a\x1b[0mé" + unlink + end,
+		},
+		{
+			desc: "overwriting a cell without a hyperlink forgets it",
+			cm:   terminalapi.ColorMode256,
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: 'a', opts: []cell.Option{cell.Hyperlink("https://a")}},
+				{p: image.Point{0, 0}, r: 'b'},
+			},
+			want: "",
+		},
+		{
+			desc: "clear forgets all cells",
+			cm:   terminalapi.ColorMode256,
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: 'a', opts: []cell.Option{cell.Hyperlink("https://a")}},
+			},
+			clear: true,
+			want:  "",
+		},
+		{
+			desc: "removes control characters from the URI",
+			cm:   terminalapi.ColorMode256,
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: 'a', opts: []cell.Option{cell.Hyperlink("https://a\a\x1b]8;;b")}},
+			},
+			want: start + "\x1b[1;1H\x1b]8;;https://a]8;;b\a\x1b[0ma" + unlink + end,
+		},
+		{
+			desc: "sets the text attributes and colors in the 256 color mode",
+			cm:   terminalapi.ColorMode256,
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: 'a', opts: []cell.Option{
+					cell.Hyperlink("https://a"),
+					cell.Bold(),
+					cell.Underline(),
+					cell.FgColor(cell.ColorNumber(100)),
+					cell.BgColor(cell.ColorBlue),
+				}},
+			},
+			want: start + "\x1b[1;1H\x1b]8;;https://a\a\x1b[0;1;4;38;5;100;48;5;4ma" + unlink + end,
+		},
+		{
+			desc: "sets the colors in the normal color mode",
+			cm:   terminalapi.ColorModeNormal,
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: 'a', opts: []cell.Option{
+					cell.Hyperlink("https://a"),
+					cell.FgColor(cell.ColorRed),
+					cell.BgColor(cell.ColorNumber(9)),
+				}},
+			},
+			want: start + "\x1b[1;1H\x1b]8;;https://a\a\x1b[0;31;101ma" + unlink + end,
+		},
+		{
+			desc: "sets the colors in the grayscale color mode",
+			cm:   terminalapi.ColorModeGrayscale,
+			sets: []cellSet{
+				{p: image.Point{0, 0}, r: 'a', opts: []cell.Option{
+					cell.Hyperlink("https://a"),
+					cell.FgColor(cell.ColorNumber(1)),
+				}},
+			},
+			want: start + "\x1b[1;1H\x1b]8;;https://a\a\x1b[0;38;5;233ma" + unlink + end,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			l := NewLinks()
+			for _, s := range tc.sets {
+				l.Set(s.p, s.r, s.combining, cell.NewOptions(s.opts...))
+			}
+			if tc.clear {
+				l.Clear()
+			}
+			if got := l.Sequence(tc.cm); got != tc.want {
+				t.Errorf("Sequence => %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// failingWriter is a writer that always fails.
+type failingWriter struct{}
+
+// Write implements io.Writer.Write.
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWrite(t *testing.T) {
+	l := NewLinks()
+	if err := l.Write(failingWriter{}, terminalapi.ColorMode256); err != nil {
+		t.Errorf("Write => unexpected error without links: %v", err)
+	}
+
+	l.Set(image.Point{0, 0}, 'a', nil, cell.NewOptions(cell.Hyperlink("https://a")))
+	var b bytes.Buffer
+	if err := l.Write(&b, terminalapi.ColorMode256); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	if got, want := b.String(), l.Sequence(terminalapi.ColorMode256); got != want {
+		t.Errorf("Write => wrote %q, want %q", got, want)
+	}
+
+	if err := l.Write(failingWriter{}, terminalapi.ColorMode256); err == nil {
+		t.Errorf("Write => got nil error, want an error")
+	}
+}
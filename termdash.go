@@ -27,12 +27,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"image"
 	"sync"
 	"time"
 
 	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/event"
+	"github.com/mum4k/termdash/terminal/headless"
 	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
 )
 
 // DefaultRedrawInterval is the default for the RedrawInterval option.
@@ -89,6 +93,71 @@ func MouseSubscriber(f func(*terminalapi.Mouse)) Option {
 	})
 }
 
+// RenderHook is a function that gets called with timing information as the
+// dashboard renders.
+// The widgetName is the ID of the container holding the widget if one was
+// provided, otherwise the Go type of the widget. The widgetName is empty when
+// the hook reports the duration of rendering the whole frame.
+// The provided function must be thread-safe and must not call back into
+// termdash or the container.
+type RenderHook func(widgetName string, duration time.Duration)
+
+// AddRenderHook registers a hook that is called after each widget gets drawn
+// and after each complete frame is rendered. Can be specified multiple times
+// to register multiple hooks.
+func AddRenderHook(h RenderHook) Option {
+	return option(func(td *termdash) {
+		td.renderHooks = append(td.renderHooks, h)
+	})
+}
+
+// DebugLayout instructs termdash to draw the bounding rectangle of each widget
+// over its content, with the type of the widget in the top left corner.
+// Useful when debugging the layout of the dashboard.
+func DebugLayout() Option {
+	return option(func(td *termdash) {
+		td.debugLayout = true
+	})
+}
+
+// WithPanicRecovery when enabled, recovers from panics that occur while
+// termdash redraws the widgets. This covers the periodic redraws, redraws
+// triggered by keyboard and mouse events and Controller.Redraw. The terminal
+// is flushed so it displays whatever was drawn before the panic and Run
+// returns the panic as an error. When using the Controller, the panic is
+// returned from the next call to Controller.Redraw.
+// Panics in event subscribers and in the widgets' event handlers aren't
+// recovered.
+// Defaults to disabled.
+func WithPanicRecovery(enabled bool) Option {
+	return option(func(td *termdash) {
+		td.panicRecovery = enabled
+	})
+}
+
+// WithWidgetTimeout when set to a positive duration, limits how long the Draw
+// call of each widget can take, so that a widget that blocks doesn't freeze
+// the entire dashboard. A widget whose Draw call doesn't return in time keeps
+// displaying what it drew in the previous frame and isn't drawn again until
+// the pending call returns. The timeouts are reported to the function
+// provided via OnWidgetError.
+// Defaults to no timeout.
+func WithWidgetTimeout(d time.Duration) Option {
+	return option(func(td *termdash) {
+		td.widgetTimeout = d
+	})
+}
+
+// OnWidgetError sets a function that is called with the widget and the error
+// when the Draw call of a widget times out, see WithWidgetTimeout.
+// The provided function must be thread-safe and must not call back into
+// termdash or the container.
+func OnWidgetError(f func(widgetapi.Widget, error)) Option {
+	return option(func(td *termdash) {
+		td.onWidgetError = f
+	})
+}
+
 // withEDS indicates that termdash should run with the provided event
 // distribution system instead of creating one.
 // Useful for tests.
@@ -98,10 +167,71 @@ func withEDS(eds *event.DistributionSystem) Option {
 	})
 }
 
+// RunErrorKind identifies the cause of an error returned by termdash.
+type RunErrorKind int
+
+// String implements fmt.Stringer()
+func (k RunErrorKind) String() string {
+	if n, ok := runErrorKindNames[k]; ok {
+		return n
+	}
+	return "RunErrorKindUnknown"
+}
+
+// runErrorKindNames maps RunErrorKind values to human readable names.
+var runErrorKindNames = map[RunErrorKind]string{
+	RunErrorKindDraw:     "RunErrorKindDraw",
+	RunErrorKindTerminal: "RunErrorKindTerminal",
+	RunErrorKindPanic:    "RunErrorKindPanic",
+	RunErrorKindDestroy:  "RunErrorKindDestroy",
+}
+
+const (
+	// RunErrorKindDraw indicates that the container or one of its widgets
+	// failed to draw.
+	RunErrorKindDraw RunErrorKind = iota
+
+	// RunErrorKindTerminal indicates that an operation on the terminal failed,
+	// e.g. clearing or flushing it.
+	RunErrorKindTerminal
+
+	// RunErrorKindPanic indicates that termdash recovered from a panic while
+	// redrawing, see WithPanicRecovery.
+	RunErrorKindPanic
+
+	// RunErrorKindDestroy indicates that a widget failed to release its
+	// resources, see widgetapi.Destroyable.
+	RunErrorKindDestroy
+)
+
+// RunError is the error returned from Run and the Controller.
+// Use errors.As to access it and to distinguish the cause of the error.
+type RunError struct {
+	// Kind is the cause of the error.
+	Kind RunErrorKind
+	// Widget is the widget that caused the error, nil if the error wasn't
+	// caused by a widget or if the widget isn't known, e.g. after a panic.
+	Widget widgetapi.Widget
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements error.Error.
+func (e *RunError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *RunError) Unwrap() error {
+	return e.Err
+}
+
 // Run runs the terminal dashboard with the provided container on the terminal.
 // Redraws the terminal periodically. If you prefer a manual redraw, use the
 // Controller instead.
-// Blocks until the context expires.
+// Blocks until the context expires, returns a *RunError if termdash fails.
+// Before returning, calls Destroy on all the widgets in the container that
+// implement widgetapi.Destroyable.
 func Run(ctx context.Context, t terminalapi.Terminal, c *container.Container, opts ...Option) error {
 	td := newTermdash(t, c, opts...)
 
@@ -109,9 +239,60 @@ func Run(ctx context.Context, t terminalapi.Terminal, c *container.Container, op
 	// Only return the status (error or nil) after the termdash event
 	// processing goroutine actually exits.
 	td.stop()
+	if dErr := destroyWidgets(c); err == nil {
+		err = dErr
+	}
 	return err
 }
 
+// destroyWidgets releases the resources of all the widgets in the container.
+// Destroys all the widgets even if some of them fail, returns a *RunError for
+// the first failure.
+func destroyWidgets(c *container.Container) error {
+	var firstErr error
+	for _, w := range c.Widgets() {
+		if err := widgetapi.DestroyWidget(w); err != nil && firstErr == nil {
+			firstErr = &RunError{
+				Kind:   RunErrorKindDestroy,
+				Widget: w,
+				Err:    fmt.Errorf("widget %T failed to destroy: %w", w, err),
+			}
+		}
+	}
+	return firstErr
+}
+
+// Render draws the container and its widgets once onto a headless terminal
+// of the provided size and returns the resulting canvas. No input events are
+// processed. Useful for non-interactive use cases, e.g. capturing a single
+// frame in tests or for screenshots.
+// The container doesn't need to be created for a terminal of the same size,
+// it keeps drawing onto its own terminal afterwards.
+// Returns the error of the context if it is already done, otherwise returns
+// a *RunError if drawing fails.
+func Render(ctx context.Context, c *container.Container, width, height int) (*canvas.Canvas, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ht, err := headless.New(image.Point{width, height})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.DrawTo(ht); err != nil {
+		re := &RunError{
+			Kind: RunErrorKindDraw,
+			Err:  fmt.Errorf("container.DrawTo => error: %w", err),
+		}
+		var de *container.DrawError
+		if errors.As(err, &de) {
+			re.Widget = de.Widget
+		}
+		return nil, re
+	}
+	return ht.Canvas()
+}
+
 // Controller controls a termdash instance.
 // The controller instance is only valid until Close() is called.
 // The controller is not thread-safe.
@@ -145,6 +326,12 @@ func (c *Controller) Redraw() error {
 		return errors.New("the termdash instance is no longer running, this controller is now invalid")
 	}
 
+	select {
+	case err := <-c.td.panicCh:
+		return err
+	default:
+	}
+
 	c.td.mu.Lock()
 	defer c.td.mu.Unlock()
 	return c.td.redraw()
@@ -174,6 +361,9 @@ type termdash struct {
 	closeCh chan struct{}
 	// exitCh gets closed when the event collecting goroutine actually exits.
 	exitCh chan struct{}
+	// panicCh receives the panic recovered during a redraw triggered by an
+	// input event, see WithPanicRecovery.
+	panicCh chan error
 
 	// clearNeeded indicates if the terminal needs to be cleared next time
 	// we're drawing it. Terminal needs to be cleared if its sized changed.
@@ -187,6 +377,11 @@ type termdash struct {
 	errorHandler       func(error)
 	mouseSubscriber    func(*terminalapi.Mouse)
 	keyboardSubscriber func(*terminalapi.Keyboard)
+	renderHooks        []RenderHook
+	debugLayout        bool
+	panicRecovery      bool
+	widgetTimeout      time.Duration
+	onWidgetError      func(widgetapi.Widget, error)
 }
 
 // newTermdash creates a new termdash.
@@ -197,6 +392,7 @@ func newTermdash(t terminalapi.Terminal, c *container.Container, opts ...Option)
 		eds:            event.NewDistributionSystem(),
 		closeCh:        make(chan struct{}),
 		exitCh:         make(chan struct{}),
+		panicCh:        make(chan error, 1),
 		redrawInterval: DefaultRedrawInterval,
 	}
 
@@ -205,6 +401,15 @@ func newTermdash(t terminalapi.Terminal, c *container.Container, opts ...Option)
 	}
 	td.subscribers()
 	c.Subscribe(td.eds)
+	if len(td.renderHooks) > 0 {
+		c.SetDrawHook(td.runRenderHooks)
+	}
+	if td.debugLayout {
+		c.SetDebugLayout(true)
+	}
+	if td.widgetTimeout > 0 {
+		c.SetWidgetTimeout(td.widgetTimeout, td.onWidgetError)
+	}
 	return td
 }
 
@@ -227,7 +432,12 @@ func (td *termdash) subscribers() {
 		&terminalapi.Keyboard{},
 		&terminalapi.Mouse{},
 	}, func(terminalapi.Event) {
-		td.evRedraw()
+		if err := td.evRedraw(); err != nil {
+			var re *RunError
+			if errors.As(err, &re) && re.Kind == RunErrorKindPanic {
+				td.reportPanic(re)
+			}
+		}
 	}, event.MaxRepetitive(0)) // No repetitive events that cause terminal redraw.
 
 	// Keyboard and Mouse subscribers specified via options.
@@ -261,26 +471,79 @@ func (td *termdash) setClearNeeded() {
 	td.clearNeeded = true
 }
 
+// reportPanic reports a panic recovered while redrawing on an input event.
+// Only the first panic is kept until it is consumed.
+func (td *termdash) reportPanic(err error) {
+	select {
+	case td.panicCh <- err:
+	default:
+	}
+}
+
+// recoverRedraw returns the recovered panic as an error after flushing
+// whatever was drawn to the terminal before the panic.
+func (td *termdash) recoverRedraw(r interface{}) error {
+	pErr := fmt.Errorf("termdash panicked while redrawing: %v", r)
+	if fErr := td.term.Flush(); fErr != nil {
+		pErr = fmt.Errorf("%v, term.Flush => error: %w", pErr, fErr)
+	}
+	return &RunError{
+		Kind: RunErrorKindPanic,
+		Err:  pErr,
+	}
+}
+
 // redraw redraws the container and its widgets.
 // The caller must hold td.mu.
-func (td *termdash) redraw() error {
+func (td *termdash) redraw() (err error) {
+	if td.panicRecovery {
+		defer func() {
+			if r := recover(); r != nil {
+				err = td.recoverRedraw(r)
+			}
+		}()
+	}
+
 	if td.clearNeeded {
 		if err := td.term.Clear(); err != nil {
-			return fmt.Errorf("term.Clear => error: %v", err)
+			return &RunError{
+				Kind: RunErrorKindTerminal,
+				Err:  fmt.Errorf("term.Clear => error: %w", err),
+			}
 		}
 		td.clearNeeded = false
 	}
 
+	start := time.Now()
 	if err := td.container.Draw(); err != nil {
-		return fmt.Errorf("container.Draw => error: %v", err)
+		re := &RunError{
+			Kind: RunErrorKindDraw,
+			Err:  fmt.Errorf("container.Draw => error: %w", err),
+		}
+		var de *container.DrawError
+		if errors.As(err, &de) {
+			re.Widget = de.Widget
+		}
+		return re
 	}
 
 	if err := td.term.Flush(); err != nil {
-		return fmt.Errorf("term.Flush => error: %v", err)
+		return &RunError{
+			Kind: RunErrorKindTerminal,
+			Err:  fmt.Errorf("term.Flush => error: %w", err),
+		}
 	}
+	td.runRenderHooks("", time.Since(start))
 	return nil
 }
 
+// runRenderHooks calls all the registered render hooks.
+func (td *termdash) runRenderHooks(widgetName string, duration time.Duration) {
+	for _, h := range td.renderHooks {
+		h(widgetName, duration)
+	}
+}
+
 // evRedraw redraws the container and its widgets.
 func (td *termdash) evRedraw() error {
 	td.mu.Lock()
@@ -322,6 +585,7 @@ func (td *termdash) processEvents(ctx context.Context) {
 
 // start starts the terminal dashboard. Blocks until the context expires or
 // until stop() is called.
+// Renders and flushes the final frame when the context expires.
 func (td *termdash) start(ctx context.Context) error {
 	// Redraw once to initialize the container sizes.
 	if err := td.periodicRedraw(); err != nil {
@@ -346,7 +610,10 @@ func (td *termdash) start(ctx context.Context) error {
 			}
 
 		case <-ctx.Done():
-			return nil
+			return td.periodicRedraw()
+
+		case err := <-td.panicCh:
+			return err
 
 		case <-td.closeCh:
 			return nil
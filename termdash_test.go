@@ -16,9 +16,11 @@ package termdash
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"image"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,6 +28,7 @@ import (
 	"github.com/mum4k/termdash/container"
 	"github.com/mum4k/termdash/keyboard"
 	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
 	"github.com/mum4k/termdash/private/canvas/testcanvas"
 	"github.com/mum4k/termdash/private/event"
 	"github.com/mum4k/termdash/private/event/eventqueue"
@@ -661,3 +664,526 @@ func TestController(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderHook(t *testing.T) {
+	ft, err := faketerm.New(image.Point{60, 10}, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+
+	cont, err := container.New(
+		ft,
+		container.SplitVertical(
+			container.Left(
+				container.ID("left"),
+				container.PlaceWidget(fakewidget.New(widgetapi.Options{})),
+			),
+			container.Right(
+				container.PlaceWidget(fakewidget.New(widgetapi.Options{})),
+			),
+		),
+	)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	var (
+		mu  sync.Mutex
+		got []string
+	)
+	hook := func(widgetName string, duration time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		if duration < 0 {
+			t.Errorf("hook(%q) called with a negative duration %v", widgetName, duration)
+		}
+		got = append(got, widgetName)
+	}
+
+	ctrl, err := NewController(ft, cont, AddRenderHook(hook))
+	if err != nil {
+		t.Fatalf("NewController => unexpected error: %v", err)
+	}
+	defer ctrl.Close()
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"left", "*fakewidget.Mirror", ""}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("render hook called with unexpected widget names, diff (-want, +got):\n%s", diff)
+	}
+}
+
+// flushCounter is a fake terminal that counts calls to Flush.
+type flushCounter struct {
+	*faketerm.Terminal
+
+	// err if not nil, is returned from all calls to Flush.
+	err error
+
+	mu      sync.Mutex
+	flushes int
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+func (fc *flushCounter) Flush() error {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.flushes++
+	if fc.err != nil {
+		return fc.err
+	}
+	return fc.Terminal.Flush()
+}
+
+// count returns the number of calls to Flush.
+func (fc *flushCounter) count() int {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.flushes
+}
+
+// errorWidget is a widget that fails to draw.
+type errorWidget struct {
+	*fakewidget.Mirror
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (*errorWidget) Draw(*canvas.Canvas, *widgetapi.Meta) error {
+	return errors.New("errorWidget.Draw")
+}
+
+// destroyErrWidget is a widget that fails to destroy.
+type destroyErrWidget struct {
+	*fakewidget.Mirror
+}
+
+// Destroy implements widgetapi.Destroyable.Destroy.
+func (*destroyErrWidget) Destroy() error {
+	return errors.New("destroyErrWidget.Destroy")
+}
+
+// panicWidget is a widget that panics when drawn.
+type panicWidget struct {
+	*fakewidget.Mirror
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (*panicWidget) Draw(*canvas.Canvas, *widgetapi.Meta) error {
+	panic("panicWidget.Draw")
+}
+
+// secondDrawPanicWidget is a widget that draws once and panics on any
+// following draw.
+type secondDrawPanicWidget struct {
+	*fakewidget.Mirror
+	draws int32
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (w *secondDrawPanicWidget) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	if atomic.AddInt32(&w.draws, 1) > 1 {
+		panic("secondDrawPanicWidget.Draw")
+	}
+	return w.Mirror.Draw(cvs, meta)
+}
+
+func TestShutdown(t *testing.T) {
+	tests := []struct {
+		desc   string
+		widget widgetapi.Widget
+		opts   []Option
+		// cancel indicates if the context should be canceled once the first
+		// frame is flushed.
+		cancel bool
+		// event if not nil, is sent to the terminal once the first frame is
+		// flushed.
+		event terminalapi.Event
+		// flushErr if not nil, is returned from all calls to Flush.
+		flushErr    error
+		wantFlushes int
+		wantErr     bool
+		// wantKind is the kind of the returned RunError when wantErr is set.
+		wantKind RunErrorKind
+		// wantWidget indicates if the RunError should report the widget.
+		wantWidget bool
+	}{
+		{
+			desc:        "flushes the final frame once when the context expires",
+			widget:      fakewidget.New(widgetapi.Options{}),
+			cancel:      true,
+			wantFlushes: 2,
+		},
+		{
+			desc:   "flushes the terminal when recovering from a panic",
+			widget: &panicWidget{fakewidget.New(widgetapi.Options{})},
+			opts: []Option{
+				WithPanicRecovery(true),
+			},
+			wantFlushes: 1,
+			wantErr:     true,
+			wantKind:    RunErrorKindPanic,
+		},
+		{
+			desc:   "recovers from a panic in a Draw call limited by the widget timeout",
+			widget: &panicWidget{fakewidget.New(widgetapi.Options{})},
+			opts: []Option{
+				WithPanicRecovery(true),
+				WithWidgetTimeout(time.Minute),
+			},
+			wantFlushes: 1,
+			wantErr:     true,
+			wantKind:    RunErrorKindPanic,
+		},
+		{
+			desc:   "flushes the terminal when recovering from a panic in a redraw triggered by the keyboard",
+			widget: &secondDrawPanicWidget{Mirror: fakewidget.New(widgetapi.Options{})},
+			opts: []Option{
+				WithPanicRecovery(true),
+			},
+			event:       &terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			wantFlushes: 2,
+			wantErr:     true,
+			wantKind:    RunErrorKindPanic,
+		},
+		{
+			desc:        "returns the error when the terminal fails to flush",
+			widget:      fakewidget.New(widgetapi.Options{}),
+			flushErr:    errors.New("flush failed"),
+			wantFlushes: 1,
+			wantErr:     true,
+			wantKind:    RunErrorKindTerminal,
+		},
+		{
+			desc:       "returns the error and the widget that failed to draw",
+			widget:     &errorWidget{fakewidget.New(widgetapi.Options{})},
+			wantErr:    true,
+			wantKind:   RunErrorKindDraw,
+			wantWidget: true,
+		},
+		{
+			desc:        "destroys the widgets when the context expires",
+			widget:      &destroyErrWidget{fakewidget.New(widgetapi.Options{})},
+			cancel:      true,
+			wantFlushes: 2,
+			wantErr:     true,
+			wantKind:    RunErrorKindDestroy,
+			wantWidget:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			eq := eventqueue.New()
+			ft, err := faketerm.New(image.Point{30, 10}, faketerm.WithEventQueue(eq))
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			fc := &flushCounter{Terminal: ft, err: tc.flushErr}
+
+			cont, err := container.New(fc, container.PlaceWidget(tc.widget))
+			if err != nil {
+				t.Fatalf("container.New => unexpected error: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			errCh := make(chan error)
+			go func() {
+				opts := append(tc.opts, RedrawInterval(time.Hour))
+				errCh <- Run(ctx, fc, cont, opts...)
+			}()
+
+			if tc.cancel || tc.event != nil {
+				if err := testevent.WaitFor(5*time.Second, func() error {
+					if got, want := fc.count(), 1; got != want {
+						return fmt.Errorf("got %d flushes, want %d", got, want)
+					}
+					return nil
+				}); err != nil {
+					t.Fatalf("testevent.WaitFor => %v", err)
+				}
+			}
+			if tc.event != nil {
+				eq.Push(tc.event)
+			}
+			if tc.cancel {
+				cancel()
+			}
+
+			err = <-errCh
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Run => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				var re *RunError
+				if !errors.As(err, &re) {
+					t.Fatalf("Run => got error %v, want a *RunError", err)
+				}
+				if re.Kind != tc.wantKind {
+					t.Errorf("Run => got RunError.Kind %v, want %v", re.Kind, tc.wantKind)
+				}
+				if gotWidget := re.Widget == tc.widget; gotWidget != tc.wantWidget {
+					t.Errorf("Run => got RunError.Widget %v, want the widget: %v", re.Widget, tc.wantWidget)
+				}
+				if tc.flushErr != nil && !errors.Is(err, tc.flushErr) {
+					t.Errorf("Run => got error %v, want it to wrap %v", err, tc.flushErr)
+				}
+			}
+			if got := fc.count(); got != tc.wantFlushes {
+				t.Errorf("Run => got %d calls to Flush, want %d", got, tc.wantFlushes)
+			}
+		})
+	}
+}
+
+func TestControllerPanicRecovery(t *testing.T) {
+	ft, err := faketerm.New(image.Point{30, 10}, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	fc := &flushCounter{Terminal: ft}
+
+	w := &secondDrawPanicWidget{Mirror: fakewidget.New(widgetapi.Options{})}
+	cont, err := container.New(fc, container.PlaceWidget(w))
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	ctrl, err := NewController(fc, cont, WithPanicRecovery(true))
+	if err != nil {
+		t.Fatalf("NewController => unexpected error: %v", err)
+	}
+	defer ctrl.Close()
+
+	err = ctrl.Redraw()
+	var re *RunError
+	if !errors.As(err, &re) || re.Kind != RunErrorKindPanic {
+		t.Errorf("Redraw => got error %v, want a *RunError of kind %v", err, RunErrorKindPanic)
+	}
+	if got, want := fc.count(), 2; got != want {
+		t.Errorf("Redraw => got %d calls to Flush, want %d", got, want)
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		desc     string
+		widget   widgetapi.Widget
+		size     image.Point
+		canceled bool
+		want     func(size image.Point) *faketerm.Terminal
+		wantErr  bool
+		// wantWidget indicates if the RunError should report the widget.
+		wantWidget bool
+	}{
+		{
+			desc:   "draws the container once in the requested size",
+			widget: fakewidget.New(widgetapi.Options{}),
+			size:   image.Point{20, 5},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(
+					ft,
+					testcanvas.MustNew(ft.Area()),
+					&widgetapi.Meta{Focused: true},
+					widgetapi.Options{},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "doesn't draw when the context is already done",
+			widget:   fakewidget.New(widgetapi.Options{}),
+			size:     image.Point{20, 5},
+			canceled: true,
+			wantErr:  true,
+		},
+		{
+			desc:    "fails on an invalid size",
+			widget:  fakewidget.New(widgetapi.Options{}),
+			size:    image.Point{0, 5},
+			wantErr: true,
+		},
+		{
+			desc:       "returns the error and the widget that failed to draw",
+			widget:     &errorWidget{fakewidget.New(widgetapi.Options{})},
+			size:       image.Point{20, 5},
+			wantErr:    true,
+			wantWidget: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft, err := faketerm.New(image.Point{30, 10})
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			fc := &flushCounter{Terminal: ft}
+
+			cont, err := container.New(fc, container.PlaceWidget(tc.widget))
+			if err != nil {
+				t.Fatalf("container.New => unexpected error: %v", err)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.canceled {
+				cancel()
+			}
+
+			cvs, err := Render(ctx, cont, tc.size.X, tc.size.Y)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Render => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if tc.wantWidget {
+				var re *RunError
+				if !errors.As(err, &re) || re.Widget != tc.widget {
+					t.Errorf("Render => got error %v, want a *RunError with the widget", err)
+				}
+			}
+
+			// The terminal of the container must not be used.
+			if got := fc.count(); got != 0 {
+				t.Errorf("Render => got %d calls to Flush, want 0", got)
+			}
+			if diff := faketerm.Diff(faketerm.MustNew(ft.Size()), ft); diff != "" {
+				t.Errorf("Render => drew onto the terminal of the container %v", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			got := faketerm.MustNew(cvs.Size())
+			testcanvas.MustApply(cvs, got)
+			if diff := faketerm.Diff(tc.want(tc.size), got); diff != "" {
+				t.Errorf("Render => %v", diff)
+			}
+		})
+	}
+}
+
+func TestRenderKeepsContainerTerminal(t *testing.T) {
+	ft, err := faketerm.New(image.Point{30, 10})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	cont, err := container.New(ft, container.PlaceWidget(fakewidget.New(widgetapi.Options{})))
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	if _, err := Render(context.Background(), cont, 20, 5); err != nil {
+		t.Fatalf("Render => unexpected error: %v", err)
+	}
+	if err := cont.Draw(); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	want := faketerm.MustNew(ft.Size())
+	fakewidget.MustDraw(
+		want,
+		testcanvas.MustNew(want.Area()),
+		&widgetapi.Meta{Focused: true},
+		widgetapi.Options{},
+	)
+	if diff := faketerm.Diff(want, ft); diff != "" {
+		t.Errorf("Draw after Render => %v", diff)
+	}
+}
+
+// blockingWidget is a widget whose Draw call blocks until released.
+type blockingWidget struct {
+	*fakewidget.Mirror
+
+	release chan struct{}
+}
+
+// Draw implements widgetapi.Widget.Draw.
+func (bw *blockingWidget) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	<-bw.release
+	return bw.Mirror.Draw(cvs, meta)
+}
+
+func TestWidgetTimeout(t *testing.T) {
+	ft, err := faketerm.New(image.Point{30, 10}, faketerm.WithEventQueue(eventqueue.New()))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+
+	bw := &blockingWidget{
+		Mirror:  fakewidget.New(widgetapi.Options{}),
+		release: make(chan struct{}),
+	}
+	cont, err := container.New(
+		ft,
+		container.PlaceWidget(bw),
+	)
+	if err != nil {
+		t.Fatalf("container.New => unexpected error: %v", err)
+	}
+
+	var (
+		mu      sync.Mutex
+		gotErrs []error
+	)
+	onErr := func(w widgetapi.Widget, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if w != bw {
+			t.Errorf("OnWidgetError called with widget %v, want %v", w, bw)
+		}
+		gotErrs = append(gotErrs, err)
+	}
+	getErrs := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(gotErrs)
+	}
+
+	ctrl, err := NewController(ft, cont, WithWidgetTimeout(10*time.Millisecond), OnWidgetError(onErr))
+	if err != nil {
+		t.Fatalf("NewController => unexpected error: %v", err)
+	}
+	defer ctrl.Close()
+
+	if got, want := getErrs(), 1; got != want {
+		t.Fatalf("OnWidgetError called %d times after the initial draw, want %d", got, want)
+	}
+	if diff := faketerm.Diff(faketerm.MustNew(ft.Size()), ft); diff != "" {
+		t.Errorf("the widget that timed out drew on the terminal => %v", diff)
+	}
+
+	// The widget isn't drawn again while the Draw call is pending.
+	if err := ctrl.Redraw(); err != nil {
+		t.Fatalf("Redraw => unexpected error: %v", err)
+	}
+	if got, want := getErrs(), 1; got != want {
+		t.Errorf("OnWidgetError called %d times while the Draw call is pending, want %d", got, want)
+	}
+
+	close(bw.release)
+	// Wait for the pending call to return.
+	if err := testevent.WaitFor(5*time.Second, func() error {
+		if err := ctrl.Redraw(); err != nil {
+			return err
+		}
+		want := faketerm.MustNew(ft.Size())
+		fakewidget.MustDraw(
+			want,
+			testcanvas.MustNew(want.Area()),
+			&widgetapi.Meta{Focused: true},
+			widgetapi.Options{},
+		)
+		if diff := faketerm.Diff(want, ft); diff != "" {
+			return fmt.Errorf("unexpected terminal content => %v", diff)
+		}
+		return nil
+	}); err != nil {
+		t.Errorf("testevent.WaitFor => %v", err)
+	}
+	if got, want := getErrs(), 1; got != want {
+		t.Errorf("OnWidgetError called %d times after the widget was released, want %d", got, want)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package headless implements a terminal that keeps its content in memory
+// instead of displaying it. Useful for drawing the dashboard without a real
+// terminal, e.g. to capture a single frame.
+package headless
+
+import (
+	"context"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Terminal is a terminal without a display.
+// The terminal doesn't produce any input events.
+// This implementation is thread-safe.
+type Terminal struct {
+	// buffer holds the terminal cells.
+	buffer buffer.Buffer
+
+	// mu protects the buffer.
+	mu sync.Mutex
+}
+
+// New returns a new headless Terminal of the provided size.
+func New(size image.Point) (*Terminal, error) {
+	b, err := buffer.New(size)
+	if err != nil {
+		return nil, err
+	}
+	return &Terminal{
+		buffer: b,
+	}, nil
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (t *Terminal) Size() image.Point {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.buffer.Size()
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+func (t *Terminal) Clear(opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	b, err := buffer.New(t.buffer.Size())
+	if err != nil {
+		return err
+	}
+	for _, col := range b {
+		for _, c := range col {
+			c.Apply(opts...)
+		}
+	}
+	t.buffer = b
+	return nil
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+func (t *Terminal) Flush() error {
+	return nil // Nowhere to flush to.
+}
+
+// SetCursor implements terminalapi.Terminal.SetCursor.
+// The headless terminal doesn't have a cursor, this is a no-op.
+func (t *Terminal) SetCursor(p image.Point) {}
+
+// HideCursor implements terminalapi.Terminal.HideCursor.
+// The headless terminal doesn't have a cursor, this is a no-op.
+func (t *Terminal) HideCursor() {}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	_, err := t.buffer.SetCell(p, r, opts...)
+	return err
+}
+
+// SetCellCombining implements terminalapi.CombiningTerminal.SetCellCombining.
+func (t *Terminal) SetCellCombining(p image.Point, r rune, combining []rune, opts ...cell.Option) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, err := t.buffer.SetCell(p, r, opts...); err != nil {
+		return err
+	}
+	for _, c := range combining {
+		if err := t.buffer.SetCellCombine(p, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Event implements terminalapi.Terminal.Event.
+// The headless terminal doesn't produce any events, this blocks until the
+// context expires and returns nil.
+func (t *Terminal) Event(ctx context.Context) terminalapi.Event {
+	<-ctx.Done()
+	return nil
+}
+
+// Close implements terminalapi.Terminal.Close.
+// This is a no-op on the headless terminal.
+func (t *Terminal) Close() {}
+
+// Canvas returns a canvas with a copy of the content of the terminal.
+func (t *Terminal) Canvas() (*canvas.Canvas, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	size := t.buffer.Size()
+	cvs, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		return nil, err
+	}
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; {
+			p := image.Point{x, y}
+			bc := t.buffer[x][y]
+			cells, err := cvs.SetCell(p, bc.Rune, bc.Opts)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range bc.Combining {
+				if err := cvs.SetCellCombine(p, r); err != nil {
+					return nil, err
+				}
+			}
+			x += cells
+		}
+	}
+	return cvs, nil
+}
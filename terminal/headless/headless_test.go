@@ -0,0 +1,144 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package headless
+
+import (
+	"context"
+	"image"
+	"testing"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/faketerm"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New(image.Point{0, 1}); err == nil {
+		t.Errorf("New => got nil error for a zero width terminal, want an error")
+	}
+
+	ht, err := New(image.Point{3, 2})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if got, want := ht.Size(), (image.Point{3, 2}); got != want {
+		t.Errorf("Size => got %v, want %v", got, want)
+	}
+}
+
+func TestCanvas(t *testing.T) {
+	tests := []struct {
+		desc string
+		// set sets the cells of the terminal.
+		set  func(*Terminal) error
+		want func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc: "empty terminal",
+			set: func(*Terminal) error {
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "copies runes with their options",
+			set: func(ht *Terminal) error {
+				if err := ht.SetCell(image.Point{0, 0}, 'a', cell.FgColor(cell.ColorRed)); err != nil {
+					return err
+				}
+				return ht.SetCell(image.Point{2, 1}, 'b', cell.Bold())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 0}, 'a', cell.FgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{2, 1}, 'b', cell.Bold())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "copies full-width runes and combining characters",
+			set: func(ht *Terminal) error {
+				if err := ht.SetCell(image.Point{0, 0}, '世'); err != nil {
+					return err
+				}
+				return ht.SetCellCombining(image.Point{2, 0}, 'e', []rune{'\u0301'})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '世')
+				testcanvas.MustSetCell(c, image.Point{2, 0}, 'e')
+				testcanvas.MustSetCellCombine(c, image.Point{2, 0}, '\u0301')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "clear removes the content and applies the options",
+			set: func(ht *Terminal) error {
+				if err := ht.SetCell(image.Point{0, 0}, 'a'); err != nil {
+					return err
+				}
+				return ht.Clear(cell.BgColor(cell.ColorBlue))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetAreaCells(c, c.Area(), 0, cell.BgColor(cell.ColorBlue))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			size := image.Point{3, 2}
+			ht, err := New(size)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := tc.set(ht); err != nil {
+				t.Fatalf("set => unexpected error: %v", err)
+			}
+
+			cvs, err := ht.Canvas()
+			if err != nil {
+				t.Fatalf("Canvas => unexpected error: %v", err)
+			}
+			got := faketerm.MustNew(size)
+			testcanvas.MustApply(cvs, got)
+			if diff := faketerm.Diff(tc.want(size), got); diff != "" {
+				t.Errorf("Canvas => %v", diff)
+			}
+		})
+	}
+}
+
+func TestEvent(t *testing.T) {
+	ht, err := New(image.Point{1, 1})
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := ht.Event(ctx); got != nil {
+		t.Errorf("Event => got %v, want nil", got)
+	}
+}
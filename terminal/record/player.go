@@ -0,0 +1,145 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package record
+
+// player.go contains the Player.
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Option is used to provide options to the Player.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	speedFactor float64
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		speedFactor: DefaultSpeedFactor,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if got := o.speedFactor; got <= 0 {
+		return fmt.Errorf("invalid SpeedFactor %v, must be a positive number", got)
+	}
+	return nil
+}
+
+// DefaultSpeedFactor is the default value for the SpeedFactor option.
+// Plays the recording at the wall-clock speed it was recorded at.
+const DefaultSpeedFactor = 1.0
+
+// SpeedFactor sets how fast the Player replays the frames compared to the
+// speed they were recorded at, e.g. a factor of 2 replays twice as fast.
+// Defaults to DefaultSpeedFactor. Must be a positive number.
+func SpeedFactor(f float64) Option {
+	return option(func(opts *options) {
+		opts.speedFactor = f
+	})
+}
+
+// Player reads a recording made by the Recorder and replays its frames.
+//
+// This object is not thread-safe.
+type Player struct {
+	// dec decodes the recorded frames.
+	dec *gob.Decoder
+
+	// opts are the provided options.
+	opts *options
+}
+
+// NewPlayer returns a new Player that reads the recording from the reader.
+func NewPlayer(r io.Reader, opts ...Option) (*Player, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Player{
+		dec:  gob.NewDecoder(r),
+		opts: opt,
+	}, nil
+}
+
+// Next reads the next frame of the recording.
+// Returns io.EOF when there are no more frames.
+func (p *Player) Next() (*Frame, error) {
+	var f Frame
+	if err := p.dec.Decode(&f); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("unable to read the recorded frame: %v", err)
+	}
+	return &f, nil
+}
+
+// Play replays the remaining frames of the recording onto the terminal,
+// keeping the delays between frames adjusted by the SpeedFactor.
+// Blocks until all the frames are replayed or the context expires, returns
+// the error of the context in the later case.
+func (p *Player) Play(ctx context.Context, t terminalapi.Terminal) error {
+	var last *Frame
+	for {
+		f, err := p.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if last != nil {
+			delay := time.Duration(float64(f.Time-last.Time) / p.opts.speedFactor)
+			if err := wait(ctx, delay); err != nil {
+				return err
+			}
+		} else if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := f.Apply(t); err != nil {
+			return err
+		}
+		last = f
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package record records the frames rendered on a terminal and plays them
+// back.
+//
+// The Recorder wraps a terminal and serializes every flushed frame into a
+// stream of gob encoded values. The Player reads the stream and replays the
+// frames onto another terminal, which allows comparing the output of a
+// dashboard against a recording made earlier.
+package record
+
+import (
+	"context"
+	"image"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/area"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Cell is the content of one cell of a recorded frame.
+type Cell struct {
+	// Rune is the rune displayed in the cell, the zero value when the cell is
+	// empty.
+	Rune rune
+	// Combining are the combining characters that modify the rune.
+	Combining []rune
+	// Opts are the options of the cell.
+	Opts cell.Options
+}
+
+// isZero asserts whether the cell wasn't modified since the terminal was
+// cleared with no options.
+func (c *Cell) isZero() bool {
+	return c.Rune == 0 && len(c.Combining) == 0 && c.Opts == cell.Options{}
+}
+
+// Frame is a single frame of the recording, i.e. the content of the terminal
+// at the time it got flushed.
+type Frame struct {
+	// Time is the time elapsed since the start of the recording when the frame
+	// was flushed.
+	Time time.Duration
+	// Size is the size of the terminal.
+	Size image.Point
+	// Cells are the cells of the terminal, indexed as Cells[x][y].
+	Cells [][]Cell
+}
+
+// newCells returns empty cells for the terminal of the specified size.
+func newCells(size image.Point) [][]Cell {
+	cells := make([][]Cell, size.X)
+	for x := range cells {
+		cells[x] = make([]Cell, size.Y)
+	}
+	return cells
+}
+
+// Apply clears the terminal and draws the frame onto it.
+// Cells of the frame that fall outside of the terminal aren't drawn.
+func (f *Frame) Apply(t terminalapi.Terminal) error {
+	if err := t.Clear(); err != nil {
+		return err
+	}
+	ar, err := area.FromSize(t.Size())
+	if err != nil {
+		return err
+	}
+
+	for x, col := range f.Cells {
+		for y := range col {
+			c := &col[y]
+			p := image.Point{x, y}
+			if c.isZero() || !p.In(ar) {
+				// Cells following wide runes aren't set either, the terminal
+				// keeps its cleared cells in their place.
+				continue
+			}
+
+			opts := []cell.Option{
+				cell.FgColor(c.Opts.FgColor),
+				cell.BgColor(c.Opts.BgColor),
+				cell.Attributes(c.Opts.Attrs),
+				cell.Hyperlink(c.Opts.Hyperlink),
+			}
+			if ct, ok := t.(terminalapi.CombiningTerminal); ok && len(c.Combining) > 0 {
+				err = ct.SetCellCombining(p, c.Rune, c.Combining, opts...)
+			} else {
+				err = t.SetCell(p, c.Rune, opts...)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return t.Flush()
+}
+
+// wait blocks for the duration or until the context expires.
+// Returns the error of the context if it expired.
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package record
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// drawText draws the text onto the terminal and flushes it.
+func drawText(t *testing.T, term terminalapi.Terminal, text string, opts ...cell.Option) {
+	t.Helper()
+	if err := term.Clear(); err != nil {
+		t.Fatalf("Clear => unexpected error: %v", err)
+	}
+
+	size := term.Size()
+	c := testcanvas.MustNew(image.Rect(0, 0, size.X, size.Y))
+	testdraw.MustText(c, text, image.Point{0, 0}, draw.TextCellOpts(opts...))
+	if err := c.Apply(term); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+}
+
+// snapshot returns a copy of the content of the fake terminal.
+func snapshot(t *testing.T, ft *faketerm.Terminal) *faketerm.Terminal {
+	t.Helper()
+	res := faketerm.MustNew(ft.Size())
+	bb := ft.BackBuffer()
+	for x, col := range bb {
+		for y, c := range col {
+			p := image.Point{x, y}
+			partial, err := bb.IsPartial(p)
+			if err != nil {
+				t.Fatalf("IsPartial => unexpected error: %v", err)
+			}
+			if partial {
+				continue
+			}
+			if err := res.SetCellCombining(p, c.Rune, c.Combining, cell.FgColor(c.Opts.FgColor), cell.BgColor(c.Opts.BgColor), cell.Attributes(c.Opts.Attrs)); err != nil {
+				t.Fatalf("SetCellCombining => unexpected error: %v", err)
+			}
+		}
+	}
+	return res
+}
+
+func TestRecordAndPlay(t *testing.T) {
+	tests := []struct {
+		desc string
+		// draw draws frames onto the recorded terminal and returns the
+		// expected content of the terminal after each frame.
+		draw func(t *testing.T, ft *faketerm.Terminal, rec *Recorder) []*faketerm.Terminal
+	}{
+		{
+			desc: "records no frames",
+			draw: func(t *testing.T, ft *faketerm.Terminal, rec *Recorder) []*faketerm.Terminal {
+				return nil
+			},
+		},
+		{
+			desc: "records frames with cell options",
+			draw: func(t *testing.T, ft *faketerm.Terminal, rec *Recorder) []*faketerm.Terminal {
+				var want []*faketerm.Terminal
+				drawText(t, rec, "hello", cell.FgColor(cell.ColorRed), cell.Bold())
+				want = append(want, snapshot(t, ft))
+				drawText(t, rec, "world", cell.BgColor(cell.ColorBlue))
+				want = append(want, snapshot(t, ft))
+				return want
+			},
+		},
+		{
+			desc: "records wide and combining runes",
+			draw: func(t *testing.T, ft *faketerm.Terminal, rec *Recorder) []*faketerm.Terminal {
+				drawText(t, rec, "世界 e\u0301")
+				return []*faketerm.Terminal{snapshot(t, ft)}
+			},
+		},
+		{
+			desc: "records terminal resize",
+			draw: func(t *testing.T, ft *faketerm.Terminal, rec *Recorder) []*faketerm.Terminal {
+				var want []*faketerm.Terminal
+				drawText(t, rec, "before")
+				want = append(want, snapshot(t, ft))
+				if err := ft.Resize(image.Point{4, 2}); err != nil {
+					t.Fatalf("Resize => unexpected error: %v", err)
+				}
+				drawText(t, rec, "aft")
+				want = append(want, snapshot(t, ft))
+				return want
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ft := faketerm.MustNew(image.Point{10, 3})
+			var buf bytes.Buffer
+			rec := NewRecorder(ft, &buf)
+			want := tc.draw(t, ft, rec)
+
+			recording := buf.Bytes()
+			p, err := NewPlayer(bytes.NewReader(recording))
+			if err != nil {
+				t.Fatalf("NewPlayer => unexpected error: %v", err)
+			}
+			var lastTime time.Duration
+			for i, w := range want {
+				f, err := p.Next()
+				if err != nil {
+					t.Fatalf("Next => unexpected error on frame %d: %v", i, err)
+				}
+				if f.Time < lastTime {
+					t.Errorf("Next => frame %d has time %v, before the previous frame at %v", i, f.Time, lastTime)
+				}
+				lastTime = f.Time
+
+				got := faketerm.MustNew(w.Size())
+				if err := f.Apply(got); err != nil {
+					t.Fatalf("Apply => unexpected error on frame %d: %v", i, err)
+				}
+				if diff := faketerm.Diff(w, got); diff != "" {
+					t.Errorf("frame %d => %v", i, diff)
+				}
+			}
+			if _, err := p.Next(); err != io.EOF {
+				t.Errorf("Next => got error %v, want %v", err, io.EOF)
+			}
+
+			if len(want) == 0 {
+				return
+			}
+			p, err = NewPlayer(bytes.NewReader(recording), SpeedFactor(1000))
+			if err != nil {
+				t.Fatalf("NewPlayer => unexpected error: %v", err)
+			}
+			last := want[len(want)-1]
+			got := faketerm.MustNew(last.Size())
+			if err := p.Play(context.Background(), got); err != nil {
+				t.Fatalf("Play => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(last, got); diff != "" {
+				t.Errorf("Play => %v", diff)
+			}
+		})
+	}
+}
+
+func TestRecorderForwards(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{3, 3})
+	rec := NewRecorder(ft, ioutil.Discard)
+
+	if got, want := rec.Size(), ft.Size(); got != want {
+		t.Errorf("Size => %v, want %v", got, want)
+	}
+	if err := rec.SetCell(image.Point{3, 3}, 'x'); err == nil {
+		t.Errorf("SetCell => expected an error for a cell outside of the terminal, got nil")
+	}
+	if err := rec.SetCell(image.Point{1, 1}, 'x', cell.FgColor(cell.ColorGreen)); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+
+	want := faketerm.MustNew(ft.Size())
+	c := testcanvas.MustNew(want.Area())
+	testcanvas.MustSetCell(c, image.Point{1, 1}, 'x', cell.FgColor(cell.ColorGreen))
+	testcanvas.MustApply(c, want)
+	if diff := faketerm.Diff(want, ft); diff != "" {
+		t.Errorf("SetCell => %v", diff)
+	}
+}
+
+func TestNewPlayer(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		want    *options
+		wantErr bool
+	}{
+		{
+			desc: "default options",
+			want: &options{
+				speedFactor: DefaultSpeedFactor,
+			},
+		},
+		{
+			desc: "custom speed factor",
+			opts: []Option{
+				SpeedFactor(2.5),
+			},
+			want: &options{
+				speedFactor: 2.5,
+			},
+		},
+		{
+			desc: "fails on zero speed factor",
+			opts: []Option{
+				SpeedFactor(0),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative speed factor",
+			opts: []Option{
+				SpeedFactor(-1),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := NewPlayer(&bytes.Buffer{}, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewPlayer => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, p.opts); diff != "" {
+				t.Errorf("NewPlayer => unexpected options, diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPlayStopsWhenContextExpires(t *testing.T) {
+	ft := faketerm.MustNew(image.Point{5, 1})
+	var buf bytes.Buffer
+	rec := NewRecorder(ft, &buf)
+	drawText(t, rec, "one")
+	time.Sleep(50 * time.Millisecond)
+	drawText(t, rec, "two")
+
+	p, err := NewPlayer(&buf, SpeedFactor(0.001))
+	if err != nil {
+		t.Fatalf("NewPlayer => unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	got := faketerm.MustNew(ft.Size())
+	if err := p.Play(ctx, got); err != context.DeadlineExceeded {
+		t.Errorf("Play => got error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	want := faketerm.MustNew(ft.Size())
+	c := testcanvas.MustNew(want.Area())
+	testdraw.MustText(c, "one", image.Point{0, 0})
+	testcanvas.MustApply(c, want)
+	if diff := faketerm.Diff(want, got); diff != "" {
+		t.Errorf("Play => %v", diff)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package record
+
+// recorder.go contains the Recorder.
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"image"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// Recorder wraps a terminal and records each frame flushed to it.
+// Implements terminalapi.Terminal and terminalapi.CombiningTerminal, all
+// calls are forwarded to the wrapped terminal.
+//
+// This object is thread-safe.
+type Recorder struct {
+	// t is the wrapped terminal.
+	t terminalapi.Terminal
+
+	// enc encodes the recorded frames.
+	enc *gob.Encoder
+
+	// start is the time when the recording started.
+	start time.Time
+
+	// cells mirror the back buffer of the wrapped terminal.
+	cells [][]Cell
+	// size is the size of cells.
+	size image.Point
+
+	// mu protects the Recorder.
+	mu sync.Mutex
+}
+
+// NewRecorder returns a new Recorder that records the frames flushed to the
+// terminal and writes them to the writer.
+func NewRecorder(t terminalapi.Terminal, w io.Writer) *Recorder {
+	size := t.Size()
+	return &Recorder{
+		t:     t,
+		enc:   gob.NewEncoder(w),
+		start: time.Now(),
+		cells: newCells(size),
+		size:  size,
+	}
+}
+
+// resize resizes the recorded cells if the size of the wrapped terminal
+// changed, keeping the cells that still fit.
+// r.mu must be held when calling this method.
+func (r *Recorder) resize() {
+	size := r.t.Size()
+	if size == r.size {
+		return
+	}
+
+	cells := newCells(size)
+	for x := 0; x < size.X && x < r.size.X; x++ {
+		copy(cells[x], r.cells[x])
+	}
+	r.cells = cells
+	r.size = size
+}
+
+// Size implements terminalapi.Terminal.Size.
+func (r *Recorder) Size() image.Point {
+	return r.t.Size()
+}
+
+// Clear implements terminalapi.Terminal.Clear.
+func (r *Recorder) Clear(opts ...cell.Option) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.t.Clear(opts...); err != nil {
+		return err
+	}
+	r.size = r.t.Size()
+	r.cells = newCells(r.size)
+	cOpts := cell.NewOptions(opts...)
+	for x := range r.cells {
+		for y := range r.cells[x] {
+			r.cells[x][y].Opts = *cOpts
+		}
+	}
+	return nil
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+// Records the flushed frame.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.t.Flush(); err != nil {
+		return err
+	}
+	r.resize()
+
+	f := &Frame{
+		Time:  time.Since(r.start),
+		Size:  r.size,
+		Cells: r.cells,
+	}
+	if err := r.enc.Encode(f); err != nil {
+		return fmt.Errorf("unable to record the frame: %v", err)
+	}
+	return nil
+}
+
+// SetCursor implements terminalapi.Terminal.SetCursor.
+func (r *Recorder) SetCursor(p image.Point) {
+	r.t.SetCursor(p)
+}
+
+// HideCursor implements terminalapi.Terminal.HideCursor.
+func (r *Recorder) HideCursor() {
+	r.t.HideCursor()
+}
+
+// setCell records the value of the cell.
+// r.mu must be held when calling this method.
+func (r *Recorder) setCell(p image.Point, rn rune, combining []rune, opts ...cell.Option) {
+	r.resize()
+	if p.X < 0 || p.X >= r.size.X || p.Y < 0 || p.Y >= r.size.Y {
+		return
+	}
+
+	c := &r.cells[p.X][p.Y]
+	c.Rune = rn
+	c.Combining = append([]rune(nil), combining...)
+	for _, opt := range opts {
+		opt.Set(&c.Opts)
+	}
+}
+
+// SetCell implements terminalapi.Terminal.SetCell.
+func (r *Recorder) SetCell(p image.Point, rn rune, opts ...cell.Option) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.t.SetCell(p, rn, opts...); err != nil {
+		return err
+	}
+	r.setCell(p, rn, nil, opts...)
+	return nil
+}
+
+// SetCellCombining implements terminalapi.CombiningTerminal.SetCellCombining.
+// If the wrapped terminal cannot display combining characters, only the rune
+// is set and recorded.
+func (r *Recorder) SetCellCombining(p image.Point, rn rune, combining []rune, opts ...cell.Option) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ct, ok := r.t.(terminalapi.CombiningTerminal)
+	if !ok {
+		combining = nil
+		if err := r.t.SetCell(p, rn, opts...); err != nil {
+			return err
+		}
+	} else if err := ct.SetCellCombining(p, rn, combining, opts...); err != nil {
+		return err
+	}
+	r.setCell(p, rn, combining, opts...)
+	return nil
+}
+
+// SetClipboard implements terminalapi.ClipboardTerminal.SetClipboard.
+// The request is passed to the wrapped terminal if it supports the clipboard,
+// it isn't recorded.
+func (r *Recorder) SetClipboard(text string) error {
+	if ct, ok := r.t.(terminalapi.ClipboardTerminal); ok {
+		return ct.SetClipboard(text)
+	}
+	return nil
+}
+
+// SetTitle implements terminalapi.TitleTerminal.SetTitle.
+// The request is passed to the wrapped terminal if it can set its title, it
+// isn't recorded.
+func (r *Recorder) SetTitle(title string) error {
+	if tt, ok := r.t.(terminalapi.TitleTerminal); ok {
+		return tt.SetTitle(title)
+	}
+	return nil
+}
+
+// Event implements terminalapi.Terminal.Event.
+func (r *Recorder) Event(ctx context.Context) terminalapi.Event {
+	return r.t.Event(ctx)
+}
+
+// Close implements terminalapi.Terminal.Close.
+func (r *Recorder) Close() {
+	r.t.Close()
+}
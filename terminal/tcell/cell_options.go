@@ -41,6 +41,8 @@ func fixColor(c tcell.Color, colorMode terminalapi.ColorMode) tcell.Color {
 	case terminalapi.ColorModeGrayscale:
 		c %= tcell.Color(24)
 		c += tcell.Color(232)
+	case terminalapi.ColorModeNone:
+		c = tcell.ColorDefault
 	default:
 		c = tcell.ColorDefault
 	}
@@ -58,5 +60,18 @@ func cellOptsToStyle(opts *cell.Options, colorMode terminalapi.ColorMode) tcell.
 	bg = fixColor(bg, colorMode)
 
 	st = st.Foreground(fg).Background(bg)
+	// Strikethrough isn't supported by tcell and is ignored.
+	st = st.Bold(opts.Attrs.Has(cell.AttrBold)).
+		Italic(opts.Attrs.Has(cell.AttrItalic)).
+		Underline(opts.Attrs.Has(cell.AttrUnderline)).
+		Blink(opts.Attrs.Has(cell.AttrBlink))
 	return st
 }
+
+// linkOpts returns the cell options used when writing the cell with a
+// hyperlink, without the attributes tcell doesn't support.
+func linkOpts(opts *cell.Options) *cell.Options {
+	lo := *opts
+	lo.Attrs &^= cell.AttrStrikethrough
+	return &lo
+}
@@ -89,6 +89,10 @@ func TestFixColor(t *testing.T) {
 		{terminalapi.ColorModeGrayscale, cell.ColorCyan, tcell.Color238},
 		{terminalapi.ColorModeGrayscale, cell.ColorWhite, tcell.Color239},
 		{terminalapi.ColorModeGrayscale, cell.ColorNumber(42), tcell.Color(250)},
+		// No colors
+		{terminalapi.ColorModeNone, cell.ColorDefault, tcell.ColorDefault},
+		{terminalapi.ColorModeNone, cell.ColorRed, tcell.ColorDefault},
+		{terminalapi.ColorModeNone, cell.ColorNumber(42), tcell.ColorDefault},
 		// 216 colors (16 to 231)
 		{terminalapi.ColorMode216, cell.ColorDefault, tcell.ColorDefault},
 		{terminalapi.ColorMode216, cell.ColorBlack, tcell.Color16},
@@ -141,16 +145,26 @@ func TestCellOptsToStyle(t *testing.T) {
 			opts:      cell.Options{FgColor: cell.ColorWhite, BgColor: cell.ColorBlack},
 			want:      tcell.StyleDefault.Foreground(tcell.Color23).Background(tcell.Color16),
 		},
+		{
+			colorMode: terminalapi.ColorMode256,
+			opts:      cell.Options{FgColor: cell.ColorWhite, BgColor: cell.ColorBlack, Attrs: cell.AttrBold | cell.AttrItalic},
+			want:      tcell.StyleDefault.Foreground(tcell.ColorSilver).Background(tcell.ColorBlack).Bold(true).Italic(true),
+		},
+		{
+			colorMode: terminalapi.ColorMode256,
+			opts:      cell.Options{FgColor: cell.ColorWhite, BgColor: cell.ColorBlack, Attrs: cell.AttrUnderline | cell.AttrBlink | cell.AttrStrikethrough},
+			want:      tcell.StyleDefault.Foreground(tcell.ColorSilver).Background(tcell.ColorBlack).Underline(true).Blink(true),
+		},
 	}
 
 	for _, tc := range tests {
-		t.Run(tc.opts.FgColor.String()+"+"+tc.opts.BgColor.String(), func(t *testing.T) {
+		t.Run(tc.opts.FgColor.String()+"+"+tc.opts.BgColor.String()+"+"+tc.opts.Attrs.String(), func(t *testing.T) {
 			got := cellOptsToStyle(&tc.opts, tc.colorMode)
 			if got != tc.want {
-				fg, bg, _ := got.Decompose()
-				wantFg, wantBg, _ := tc.want.Decompose()
-				t.Errorf("cellOptsToStyle(%v, fg=%v, bg=%v) => got (fg=%X, bg=%X), want (fg=%X, bg=%X)",
-					tc.colorMode, tc.opts.FgColor, tc.opts.BgColor, fg, bg, wantFg, wantBg)
+				fg, bg, attrs := got.Decompose()
+				wantFg, wantBg, wantAttrs := tc.want.Decompose()
+				t.Errorf("cellOptsToStyle(%v, fg=%v, bg=%v, attrs=%v) => got (fg=%X, bg=%X, attrs=%X), want (fg=%X, bg=%X, attrs=%X)",
+					tc.colorMode, tc.opts.FgColor, tc.opts.BgColor, tc.opts.Attrs, fg, bg, attrs, wantFg, wantBg, wantAttrs)
 			}
 		})
 	}
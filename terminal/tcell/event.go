@@ -82,6 +82,7 @@ var tcellToTd = map[tcell.Key]keyboard.Key{
 	tcell.KeyCtrlRightSq:    keyboard.KeyCtrlRsqBracket,
 	tcell.KeyCtrlUnderscore: keyboard.KeyCtrlUnderscore,
 	tcell.KeyBackspace2:     keyboard.KeyBackspace2,
+	tcell.KeyBacktab:        keyboard.KeyBacktab,
 	tcell.KeyCtrlSpace:      keyboard.KeyCtrlSpace,
 }
 
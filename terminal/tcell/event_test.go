@@ -247,6 +247,7 @@ func TestKeyboardKeys(t *testing.T) {
 		{key: tcell.KeyCtrlRightSq, want: keyboard.KeyCtrl5},
 		{key: tcell.KeyCtrlUnderscore, want: keyboard.KeyCtrlUnderscore},
 		{key: tcell.KeyBackspace2, want: keyboard.KeyBackspace2},
+		{key: tcell.KeyBacktab, want: keyboard.KeyBacktab},
 	}
 
 	for _, tc := range tests {
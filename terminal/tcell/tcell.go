@@ -18,11 +18,16 @@ import (
 	"context"
 	"fmt"
 	"image"
+	"io"
+	"os"
 
 	"github.com/gdamore/tcell"
 	"github.com/gdamore/tcell/encoding"
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/event/eventqueue"
+	"github.com/mum4k/termdash/private/osc2"
+	"github.com/mum4k/termdash/private/osc52"
+	"github.com/mum4k/termdash/private/osc8"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 )
 
@@ -44,6 +49,7 @@ func (o option) set(t *Terminal) {
 const DefaultColorMode = terminalapi.ColorMode256
 
 // ColorMode sets the terminal color mode.
+// Use terminalapi.DetectColorMode to pick the mode based on the environment.
 // Defaults to DefaultColorMode.
 func ColorMode(cm terminalapi.ColorMode) Option {
 	return option(func(t *Terminal) {
@@ -62,6 +68,28 @@ func ClearStyle(fg, bg cell.Color) Option {
 	})
 }
 
+// DefaultClipboardSupport is the default value for the WithClipboardSupport
+// option.
+const DefaultClipboardSupport = false
+
+// WithClipboardSupport enables or disables the OSC 52 escape sequences that
+// place copied text into the system clipboard. Some terminals ignore or block
+// these sequences. Defaults to DefaultClipboardSupport.
+func WithClipboardSupport(enabled bool) Option {
+	return option(func(t *Terminal) {
+		t.clipboard = enabled
+	})
+}
+
+// WithTitle sets the title of the terminal window or tab when the terminal is
+// created. Use SetTitle to change it later.
+// Defaults to no title, the title of the terminal isn't modified.
+func WithTitle(title string) Option {
+	return option(func(t *Terminal) {
+		t.title = title
+	})
+}
+
 // Terminal provides input and output to a real terminal. Wraps the
 // gdamore/tcell terminal implementation. This object is not thread-safe.
 // Implements terminalapi.Terminal.
@@ -78,6 +106,18 @@ type Terminal struct {
 	// Options.
 	colorMode  terminalapi.ColorMode
 	clearStyle *cell.Options
+	clipboard  bool
+	title      string
+
+	// clipboardOut is where the OSC 52 escape sequences are written.
+	clipboardOut io.Writer
+	// titleOut is where the OSC 2 escape sequences are written.
+	titleOut io.Writer
+
+	// links tracks the cells that contain hyperlinks.
+	links *osc8.Links
+	// linkOut is where the OSC 8 escape sequences are written.
+	linkOut io.Writer
 }
 
 // tcellNewScreen can be overridden from tests.
@@ -98,7 +138,12 @@ func newTerminal(opts ...Option) (*Terminal, error) {
 			FgColor: cell.ColorDefault,
 			BgColor: cell.ColorDefault,
 		},
-		screen: screen,
+		clipboard:    DefaultClipboardSupport,
+		clipboardOut: os.Stdout,
+		titleOut:     os.Stdout,
+		links:        osc8.NewLinks(),
+		linkOut:      os.Stdout,
+		screen:       screen,
 	}
 	for _, opt := range opts {
 		opt.set(t)
@@ -125,6 +170,13 @@ func New(opts ...Option) (*Terminal, error) {
 	t.screen.EnableMouse()
 	t.screen.SetStyle(clearStyle)
 
+	if t.title != "" {
+		if err := t.SetTitle(t.title); err != nil {
+			t.Close()
+			return nil, fmt.Errorf("unable to set the terminal title: %v", err)
+		}
+	}
+
 	go t.pollEvents() // Stops when Close() is called.
 	return t, nil
 }
@@ -143,12 +195,18 @@ func (t *Terminal) Clear(opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
 	st := cellOptsToStyle(o, t.colorMode)
 	t.screen.Fill(' ', st)
+	t.links.Clear()
 	return nil
 }
 
 // Flush implements terminalapi.Terminal.Flush.
+// Cells with hyperlinks are written again wrapped in the OSC 8 escape
+// sequences, since tcell can't emit them.
 func (t *Terminal) Flush() error {
 	t.screen.Show()
+	if err := t.links.Write(t.linkOut, t.colorMode); err != nil {
+		return fmt.Errorf("unable to write the hyperlinks: %v", err)
+	}
 	return nil
 }
 
@@ -167,9 +225,38 @@ func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
 	st := cellOptsToStyle(o, t.colorMode)
 	t.screen.SetContent(p.X, p.Y, r, nil, st)
+	t.links.Set(p, r, nil, linkOpts(o))
 	return nil
 }
 
+// SetCellCombining implements terminalapi.CombiningTerminal.SetCellCombining.
+func (t *Terminal) SetCellCombining(p image.Point, r rune, combining []rune, opts ...cell.Option) error {
+	o := cell.NewOptions(opts...)
+	st := cellOptsToStyle(o, t.colorMode)
+	t.screen.SetContent(p.X, p.Y, r, combining, st)
+	t.links.Set(p, r, combining, linkOpts(o))
+	return nil
+}
+
+// SetClipboard implements terminalapi.ClipboardTerminal.SetClipboard.
+// Does nothing unless enabled with the WithClipboardSupport option.
+func (t *Terminal) SetClipboard(text string) error {
+	if !t.clipboard {
+		return nil
+	}
+	return osc52.Write(t.clipboardOut, text)
+}
+
+// ColorMode implements terminalapi.ColorModeTerminal.ColorMode.
+func (t *Terminal) ColorMode() terminalapi.ColorMode {
+	return t.colorMode
+}
+
+// SetTitle implements terminalapi.TitleTerminal.SetTitle.
+func (t *Terminal) SetTitle(title string) error {
+	return osc2.Write(t.titleOut, title)
+}
+
 // pollEvents polls and enqueues the input events.
 func (t *Terminal) pollEvents() {
 	for {
@@ -15,6 +15,9 @@
 package tcell
 
 import (
+	"bytes"
+	"errors"
+	"image"
 	"testing"
 
 	"github.com/gdamore/tcell"
@@ -44,6 +47,16 @@ func TestNewTerminalColorMode(t *testing.T) {
 				colorMode: terminalapi.ColorModeNormal,
 			},
 		},
+		{
+			desc: "sets the title",
+			opts: []Option{
+				WithTitle("dashboard"),
+			},
+			want: &Terminal{
+				colorMode: terminalapi.ColorMode256,
+				title:     "dashboard",
+			},
+		},
 	}
 
 	tcellNewScreen = func() (tcell.Screen, error) { return nil, nil }
@@ -55,10 +68,18 @@ func TestNewTerminalColorMode(t *testing.T) {
 				return
 			}
 
+			if got, want := got.ColorMode(), tc.want.colorMode; got != want {
+				t.Errorf("ColorMode => got %v, want %v", got, want)
+			}
+
 			// Ignore these fields.
 			got.screen = nil
 			got.events = nil
 			got.done = nil
+			got.clipboardOut = nil
+			got.titleOut = nil
+			got.links = nil
+			got.linkOut = nil
 			got.clearStyle = nil
 
 			if diff := pretty.Compare(tc.want, got); diff != "" {
@@ -112,6 +133,10 @@ func TestNewTerminalClearStyle(t *testing.T) {
 			got.screen = nil
 			got.events = nil
 			got.done = nil
+			got.clipboardOut = nil
+			got.titleOut = nil
+			got.links = nil
+			got.linkOut = nil
 
 			if diff := pretty.Compare(tc.want, got); diff != "" {
 				t.Errorf("newTerminal => unexpected diff (-want, +got):\n%s", diff)
@@ -119,3 +144,138 @@ func TestNewTerminalClearStyle(t *testing.T) {
 		})
 	}
 }
+
+func TestSetClipboard(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want string
+	}{
+		{
+			desc: "emits nothing by default",
+		},
+		{
+			desc: "emits nothing when disabled",
+			opts: []Option{
+				WithClipboardSupport(false),
+			},
+		},
+		{
+			desc: "emits the OSC 52 sequence when enabled",
+			opts: []Option{
+				WithClipboardSupport(true),
+			},
+			want: "\x1b]52;c;aGVsbG8=\a",
+		},
+	}
+
+	tcellNewScreen = func() (tcell.Screen, error) { return nil, nil }
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			term, err := newTerminal(tc.opts...)
+			if err != nil {
+				t.Fatalf("newTerminal => unexpected error:\n%v", err)
+			}
+			var out bytes.Buffer
+			term.clipboardOut = &out
+
+			if err := term.SetClipboard("hello"); err != nil {
+				t.Fatalf("SetClipboard => unexpected error: %v", err)
+			}
+			if got := out.String(); got != tc.want {
+				t.Errorf("SetClipboard => wrote %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetTitle(t *testing.T) {
+	tcellNewScreen = func() (tcell.Screen, error) { return nil, nil }
+	term, err := newTerminal()
+	if err != nil {
+		t.Fatalf("newTerminal => unexpected error:\n%v", err)
+	}
+	var out bytes.Buffer
+	term.titleOut = &out
+
+	if err := term.SetTitle("hello"); err != nil {
+		t.Fatalf("SetTitle => unexpected error: %v", err)
+	}
+	if got, want := out.String(), "\x1b]2;hello\a"; got != want {
+		t.Errorf("SetTitle => wrote %q, want %q", got, want)
+	}
+}
+
+// errWriter is an io.Writer that always fails.
+type errWriter struct{}
+
+// Write implements io.Writer.Write.
+func (errWriter) Write([]byte) (int, error) {
+	return 0, errors.New("errWriter.Write")
+}
+
+// finiScreen is a tcell.Screen that records calls to Fini.
+type finiScreen struct {
+	tcell.Screen
+	finis int
+}
+
+// Fini implements tcell.Screen.Fini.
+func (s *finiScreen) Fini() {
+	s.finis++
+	s.Screen.Fini()
+}
+
+func TestNewClosesOnTitleError(t *testing.T) {
+	screen := &finiScreen{Screen: tcell.NewSimulationScreen("")}
+	tcellNewScreen = func() (tcell.Screen, error) { return screen, nil }
+	failingTitle := option(func(t *Terminal) {
+		t.titleOut = errWriter{}
+	})
+
+	if _, err := New(WithTitle("hello"), failingTitle); err == nil {
+		t.Fatalf("New => got nil error, want an error")
+	}
+	if got, want := screen.finis, 1; got != want {
+		t.Errorf("New => called Fini %d times, want %d", got, want)
+	}
+}
+
+func TestFlushHyperlinks(t *testing.T) {
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("screen.Init => unexpected error: %v", err)
+	}
+	defer screen.Fini()
+	tcellNewScreen = func() (tcell.Screen, error) { return screen, nil }
+	term, err := newTerminal()
+	if err != nil {
+		t.Fatalf("newTerminal => unexpected error:\n%v", err)
+	}
+	var out bytes.Buffer
+	term.linkOut = &out
+
+	if err := term.SetCell(image.Point{0, 0}, 'a', cell.Hyperlink("https://a"), cell.Strikethrough()); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if err := term.SetCell(image.Point{1, 0}, 'b'); err != nil {
+		t.Fatalf("SetCell => unexpected error: %v", err)
+	}
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+	if got, want := out.String(), "\x1b7\x1b[1;1H\x1b]8;;https://a\a\x1b[0ma\x1b]8;;\a\x1b8"; got != want {
+		t.Errorf("Flush => wrote %q, want %q", got, want)
+	}
+
+	out.Reset()
+	if err := term.Clear(); err != nil {
+		t.Fatalf("Clear => unexpected error: %v", err)
+	}
+	if err := term.Flush(); err != nil {
+		t.Fatalf("Flush => unexpected error: %v", err)
+	}
+	if got := out.String(); got != "" {
+		t.Errorf("Flush after Clear => wrote %q, want nothing", got)
+	}
+}
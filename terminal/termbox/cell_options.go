@@ -18,6 +18,7 @@ package termbox
 
 import (
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
 	tbx "github.com/nsf/termbox-go"
 )
 
@@ -27,11 +28,35 @@ func cellColor(c cell.Color) tbx.Attribute {
 }
 
 // cellOptsToFg converts the cell options to the termbox foreground attribute.
-func cellOptsToFg(opts *cell.Options) tbx.Attribute {
-	return cellColor(opts.FgColor)
+// Termbox only supports the bold and underline text attributes, others are
+// ignored. The color is ignored in terminalapi.ColorModeNone.
+func cellOptsToFg(opts *cell.Options, cm terminalapi.ColorMode) tbx.Attribute {
+	fg := tbx.ColorDefault
+	if cm != terminalapi.ColorModeNone {
+		fg = cellColor(opts.FgColor)
+	}
+	if opts.Attrs.Has(cell.AttrBold) {
+		fg |= tbx.AttrBold
+	}
+	if opts.Attrs.Has(cell.AttrUnderline) {
+		fg |= tbx.AttrUnderline
+	}
+	return fg
 }
 
 // cellOptsToBg converts the cell options to the termbox background attribute.
-func cellOptsToBg(opts *cell.Options) tbx.Attribute {
+// The color is ignored in terminalapi.ColorModeNone.
+func cellOptsToBg(opts *cell.Options, cm terminalapi.ColorMode) tbx.Attribute {
+	if cm == terminalapi.ColorModeNone {
+		return tbx.ColorDefault
+	}
 	return cellColor(opts.BgColor)
 }
+
+// linkOpts returns the cell options used when writing the cell with a
+// hyperlink, without the attributes termbox doesn't support.
+func linkOpts(opts *cell.Options) *cell.Options {
+	lo := *opts
+	lo.Attrs &= cell.AttrBold | cell.AttrUnderline
+	return &lo
+}
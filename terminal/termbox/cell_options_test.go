@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/terminal/terminalapi"
 	tbx "github.com/nsf/termbox-go"
 )
 
@@ -48,3 +49,62 @@ func TestCellColor(t *testing.T) {
 		})
 	}
 }
+
+func TestCellOptsToFg(t *testing.T) {
+	tests := []struct {
+		desc      string
+		opts      cell.Options
+		colorMode terminalapi.ColorMode
+		want      tbx.Attribute
+	}{
+		{
+			desc: "color only",
+			opts: cell.Options{FgColor: cell.ColorRed},
+			want: tbx.ColorRed,
+		},
+		{
+			desc:      "color is ignored on terminals without colors",
+			opts:      cell.Options{FgColor: cell.ColorRed, Attrs: cell.AttrBold},
+			colorMode: terminalapi.ColorModeNone,
+			want:      tbx.ColorDefault | tbx.AttrBold,
+		},
+		{
+			desc: "bold and underline",
+			opts: cell.Options{FgColor: cell.ColorRed, Attrs: cell.AttrBold | cell.AttrUnderline},
+			want: tbx.ColorRed | tbx.AttrBold | tbx.AttrUnderline,
+		},
+		{
+			desc: "unsupported attributes are ignored",
+			opts: cell.Options{FgColor: cell.ColorRed, Attrs: cell.AttrItalic | cell.AttrStrikethrough | cell.AttrBlink},
+			want: tbx.ColorRed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := cellOptsToFg(&tc.opts, tc.colorMode)
+			if got != tc.want {
+				t.Errorf("cellOptsToFg(%v, %v) => got %v, want %v", tc.opts, tc.colorMode, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLinkOpts(t *testing.T) {
+	opts := &cell.Options{
+		FgColor:   cell.ColorRed,
+		Attrs:     cell.AttrBold | cell.AttrItalic | cell.AttrUnderline | cell.AttrBlink,
+		Hyperlink: "https://a",
+	}
+	want := cell.Options{
+		FgColor:   cell.ColorRed,
+		Attrs:     cell.AttrBold | cell.AttrUnderline,
+		Hyperlink: "https://a",
+	}
+	if got := linkOpts(opts); *got != want {
+		t.Errorf("linkOpts(%v) => got %v, want %v", opts, *got, want)
+	}
+	if opts.Attrs != cell.AttrBold|cell.AttrItalic|cell.AttrUnderline|cell.AttrBlink {
+		t.Errorf("linkOpts modified the provided options to %v", opts)
+	}
+}
@@ -32,6 +32,9 @@ func colorMode(cm terminalapi.ColorMode) (tbx.OutputMode, error) {
 		return tbx.Output216, nil
 	case terminalapi.ColorModeGrayscale:
 		return tbx.OutputGrayscale, nil
+	case terminalapi.ColorModeNone:
+		// Colors are dropped when converting the cell options.
+		return tbx.OutputNormal, nil
 	default:
 		return -1, fmt.Errorf("don't know how to convert color mode %v to the termbox format", cm)
 	}
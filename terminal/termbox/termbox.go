@@ -17,10 +17,16 @@ package termbox
 
 import (
 	"context"
+	"fmt"
 	"image"
+	"io"
+	"os"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/private/event/eventqueue"
+	"github.com/mum4k/termdash/private/osc2"
+	"github.com/mum4k/termdash/private/osc52"
+	"github.com/mum4k/termdash/private/osc8"
 	"github.com/mum4k/termdash/terminal/terminalapi"
 	tbx "github.com/nsf/termbox-go"
 )
@@ -43,6 +49,7 @@ func (o option) set(t *Terminal) {
 const DefaultColorMode = terminalapi.ColorMode256
 
 // ColorMode sets the terminal color mode.
+// Use terminalapi.DetectColorMode to pick the mode based on the environment.
 // Defaults to DefaultColorMode.
 func ColorMode(cm terminalapi.ColorMode) Option {
 	return option(func(t *Terminal) {
@@ -50,6 +57,28 @@ func ColorMode(cm terminalapi.ColorMode) Option {
 	})
 }
 
+// DefaultClipboardSupport is the default value for the WithClipboardSupport
+// option.
+const DefaultClipboardSupport = false
+
+// WithClipboardSupport enables or disables the OSC 52 escape sequences that
+// place copied text into the system clipboard. Some terminals ignore or block
+// these sequences. Defaults to DefaultClipboardSupport.
+func WithClipboardSupport(enabled bool) Option {
+	return option(func(t *Terminal) {
+		t.clipboard = enabled
+	})
+}
+
+// WithTitle sets the title of the terminal window or tab when the terminal is
+// created. Use SetTitle to change it later.
+// Defaults to no title, the title of the terminal isn't modified.
+func WithTitle(title string) Option {
+	return option(func(t *Terminal) {
+		t.title = title
+	})
+}
+
 // Terminal provides input and output to a real terminal. Wraps the
 // nsf/termbox-go terminal implementation. This object is not thread-safe.
 // Implements terminalapi.Terminal.
@@ -62,14 +91,31 @@ type Terminal struct {
 
 	// Options.
 	colorMode terminalapi.ColorMode
+	clipboard bool
+	title     string
+
+	// clipboardOut is where the OSC 52 escape sequences are written.
+	clipboardOut io.Writer
+	// titleOut is where the OSC 2 escape sequences are written.
+	titleOut io.Writer
+
+	// links tracks the cells that contain hyperlinks.
+	links *osc8.Links
+	// linkOut is where the OSC 8 escape sequences are written.
+	linkOut io.Writer
 }
 
 // newTerminal creates the terminal and applies the options.
 func newTerminal(opts ...Option) *Terminal {
 	t := &Terminal{
-		events:    eventqueue.New(),
-		done:      make(chan struct{}),
-		colorMode: DefaultColorMode,
+		events:       eventqueue.New(),
+		done:         make(chan struct{}),
+		colorMode:    DefaultColorMode,
+		clipboard:    DefaultClipboardSupport,
+		clipboardOut: os.Stdout,
+		titleOut:     os.Stdout,
+		links:        osc8.NewLinks(),
+		linkOut:      os.Stdout,
 	}
 	for _, opt := range opts {
 		opt.set(t)
@@ -92,6 +138,13 @@ func New(opts ...Option) (*Terminal, error) {
 	}
 	tbx.SetOutputMode(om)
 
+	if t.title != "" {
+		if err := t.SetTitle(t.title); err != nil {
+			t.Close()
+			return nil, fmt.Errorf("unable to set the terminal title: %v", err)
+		}
+	}
+
 	go t.pollEvents() // Stops when Close() is called.
 	return t, nil
 }
@@ -105,12 +158,21 @@ func (t *Terminal) Size() image.Point {
 // Clear implements terminalapi.Terminal.Clear.
 func (t *Terminal) Clear(opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	return tbx.Clear(cellOptsToFg(o), cellOptsToBg(o))
+	t.links.Clear()
+	return tbx.Clear(cellOptsToFg(o, t.colorMode), cellOptsToBg(o, t.colorMode))
 }
 
 // Flush implements terminalapi.Terminal.Flush.
+// Cells with hyperlinks are written again wrapped in the OSC 8 escape
+// sequences, since termbox can't emit them.
 func (t *Terminal) Flush() error {
-	return tbx.Flush()
+	if err := tbx.Flush(); err != nil {
+		return err
+	}
+	if err := t.links.Write(t.linkOut, t.colorMode); err != nil {
+		return fmt.Errorf("unable to write the hyperlinks: %v", err)
+	}
+	return nil
 }
 
 // SetCursor implements terminalapi.Terminal.SetCursor.
@@ -126,10 +188,30 @@ func (t *Terminal) HideCursor() {
 // SetCell implements terminalapi.Terminal.SetCell.
 func (t *Terminal) SetCell(p image.Point, r rune, opts ...cell.Option) error {
 	o := cell.NewOptions(opts...)
-	tbx.SetCell(p.X, p.Y, r, cellOptsToFg(o), cellOptsToBg(o))
+	tbx.SetCell(p.X, p.Y, r, cellOptsToFg(o, t.colorMode), cellOptsToBg(o, t.colorMode))
+	t.links.Set(p, r, nil, linkOpts(o))
 	return nil
 }
 
+// SetClipboard implements terminalapi.ClipboardTerminal.SetClipboard.
+// Does nothing unless enabled with the WithClipboardSupport option.
+func (t *Terminal) SetClipboard(text string) error {
+	if !t.clipboard {
+		return nil
+	}
+	return osc52.Write(t.clipboardOut, text)
+}
+
+// ColorMode implements terminalapi.ColorModeTerminal.ColorMode.
+func (t *Terminal) ColorMode() terminalapi.ColorMode {
+	return t.colorMode
+}
+
+// SetTitle implements terminalapi.TitleTerminal.SetTitle.
+func (t *Terminal) SetTitle(title string) error {
+	return osc2.Write(t.titleOut, title)
+}
+
 // pollEvents polls and enqueues the input events.
 func (t *Terminal) pollEvents() {
 	for {
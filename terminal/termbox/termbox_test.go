@@ -15,6 +15,7 @@
 package termbox
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
@@ -42,15 +43,32 @@ func TestNewTerminal(t *testing.T) {
 				colorMode: terminalapi.ColorModeNormal,
 			},
 		},
+		{
+			desc: "sets the title",
+			opts: []Option{
+				WithTitle("dashboard"),
+			},
+			want: &Terminal{
+				colorMode: terminalapi.ColorMode256,
+				title:     "dashboard",
+			},
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
 			got := newTerminal(tc.opts...)
+			if got, want := got.ColorMode(), tc.want.colorMode; got != want {
+				t.Errorf("ColorMode => got %v, want %v", got, want)
+			}
 
 			// Ignore these fields.
 			got.events = nil
 			got.done = nil
+			got.clipboardOut = nil
+			got.titleOut = nil
+			got.links = nil
+			got.linkOut = nil
 
 			if diff := pretty.Compare(tc.want, got); diff != "" {
 				t.Errorf("newTerminal => unexpected diff (-want, +got):\n%s", diff)
@@ -58,3 +76,50 @@ func TestNewTerminal(t *testing.T) {
 		})
 	}
 }
+
+func TestSetClipboard(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want string
+	}{
+		{
+			desc: "emits nothing by default",
+		},
+		{
+			desc: "emits the OSC 52 sequence when enabled",
+			opts: []Option{
+				WithClipboardSupport(true),
+			},
+			want: "\x1b]52;c;aGVsbG8=\a",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			term := newTerminal(tc.opts...)
+			var out bytes.Buffer
+			term.clipboardOut = &out
+
+			if err := term.SetClipboard("hello"); err != nil {
+				t.Fatalf("SetClipboard => unexpected error: %v", err)
+			}
+			if got := out.String(); got != tc.want {
+				t.Errorf("SetClipboard => wrote %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetTitle(t *testing.T) {
+	term := newTerminal()
+	var out bytes.Buffer
+	term.titleOut = &out
+
+	if err := term.SetTitle("hello"); err != nil {
+		t.Fatalf("SetTitle => unexpected error: %v", err)
+	}
+	if got, want := out.String(), "\x1b]2;hello\a"; got != want {
+		t.Errorf("SetTitle => wrote %q, want %q", got, want)
+	}
+}
@@ -16,6 +16,13 @@ package terminalapi
 
 // color_mode.go defines the terminal color modes.
 
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
 // ColorMode represents a color mode of a terminal.
 type ColorMode int
 
@@ -33,6 +40,7 @@ var colorModeNames = map[ColorMode]string{
 	ColorMode256:       "ColorMode256",
 	ColorMode216:       "ColorMode216",
 	ColorModeGrayscale: "ColorModeGrayscale",
+	ColorModeNone:      "ColorModeNone",
 }
 
 // Supported color modes.
@@ -57,4 +65,61 @@ const (
 	// i.e the 24 different shades of grey. However in this mode the colors are
 	// zero based, so the caller doesn't need to provide an offset.
 	ColorModeGrayscale
+
+	// ColorModeNone is used on monochrome terminals, all colors are ignored
+	// and cells use the default colors of the terminal.
+	ColorModeNone
 )
+
+// DetectColorMode returns the color mode the terminal the program runs in
+// most likely supports. The detection is based on the COLORTERM and TERM
+// environment variables. When these don't identify the mode, the number of
+// colors is queried by running "tput colors" if tput is available.
+// Returns ColorModeNone for terminals without colors (e.g. TERM=dumb) and
+// ColorModeNormal when the number of colors can't be determined.
+// The result can be provided to the ColorMode option of the terminal
+// implementations.
+func DetectColorMode() ColorMode {
+	return detectColorMode(os.Getenv, tputColors)
+}
+
+// tputColors returns the number of colors the terminal supports as reported
+// by "tput colors".
+func tputColors() (int, error) {
+	out, err := exec.Command("tput", "colors").Output()
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(out)))
+}
+
+// detectColorMode implements DetectColorMode, getenv returns the value of
+// the environment variable and colors the number of colors reported by tput.
+func detectColorMode(getenv func(string) string, colors func() (int, error)) ColorMode {
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		// Terminals that support true colors also support 256 colors.
+		return ColorMode256
+	}
+
+	term := strings.ToLower(getenv("TERM"))
+	switch {
+	case term == "" || term == "dumb":
+		return ColorModeNone
+	case strings.Contains(term, "256color") || strings.Contains(term, "truecolor"):
+		return ColorMode256
+	}
+
+	n, err := colors()
+	switch {
+	case err != nil:
+		return ColorModeNormal
+	case n >= 256:
+		return ColorMode256
+	case n >= 8:
+		return ColorModeNormal
+	default:
+		// tput reports -1 or 0 for terminals without colors.
+		return ColorModeNone
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package terminalapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDetectColorMode(t *testing.T) {
+	tests := []struct {
+		desc string
+		env  map[string]string
+		// colors is the number of colors reported by tput.
+		colors int
+		// colorsErr is the error returned when running tput.
+		colorsErr error
+		want      ColorMode
+	}{
+		{
+			desc: "no colors without any environment variables",
+			want: ColorModeNone,
+		},
+		{
+			desc: "no colors on a dumb terminal",
+			env: map[string]string{
+				"TERM": "dumb",
+			},
+			colors: 8,
+			want:   ColorModeNone,
+		},
+		{
+			desc: "256 colors when TERM advertises them",
+			env: map[string]string{
+				"TERM": "xterm-256color",
+			},
+			want: ColorMode256,
+		},
+		{
+			desc: "256 colors when COLORTERM advertises true colors",
+			env: map[string]string{
+				"TERM":      "xterm",
+				"COLORTERM": "truecolor",
+			},
+			want: ColorMode256,
+		},
+		{
+			desc: "256 colors when COLORTERM advertises 24bit colors",
+			env: map[string]string{
+				"COLORTERM": "24BIT",
+			},
+			want: ColorMode256,
+		},
+		{
+			desc: "normal colors when tput reports eight colors",
+			env: map[string]string{
+				"TERM": "xterm",
+			},
+			colors: 8,
+			want:   ColorModeNormal,
+		},
+		{
+			desc: "normal colors when tput reports sixteen colors",
+			env: map[string]string{
+				"TERM": "xterm",
+			},
+			colors: 16,
+			want:   ColorModeNormal,
+		},
+		{
+			desc: "256 colors when tput reports them",
+			env: map[string]string{
+				"TERM": "screen",
+			},
+			colors: 256,
+			want:   ColorMode256,
+		},
+		{
+			desc: "no colors when tput reports none",
+			env: map[string]string{
+				"TERM": "vt100",
+			},
+			colors: -1,
+			want:   ColorModeNone,
+		},
+		{
+			desc: "normal colors when tput isn't available",
+			env: map[string]string{
+				"TERM": "xterm",
+			},
+			colorsErr: errors.New("tput not found"),
+			want:      ColorModeNormal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			getenv := func(key string) string {
+				return tc.env[key]
+			}
+			colors := func() (int, error) {
+				return tc.colors, tc.colorsErr
+			}
+			if got := detectColorMode(getenv, colors); got != tc.want {
+				t.Errorf("detectColorMode => got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -33,6 +33,8 @@ type Terminal interface {
 	// on all the cell.
 	Clear(opts ...cell.Option) error
 	// Flush flushes the internal back buffer to the terminal.
+	// Termdash calls it once at the end of each frame, an error returned
+	// from Flush stops the dashboard and is returned from termdash.Run.
 	Flush() error
 
 	// SetCursor sets the position of the cursor.
@@ -54,3 +56,45 @@ type Terminal interface {
 	// the terminal isn't required anymore to return the screen to a sane state.
 	Close()
 }
+
+// CombiningTerminal is implemented by terminals that can display combining
+// characters (e.g. diacritics) together with the rune they modify.
+// Terminals that don't implement it only display the modified rune.
+type CombiningTerminal interface {
+	Terminal
+
+	// SetCellCombining is like SetCell, but also sets the combining
+	// characters that modify the provided rune.
+	SetCellCombining(p image.Point, r rune, combining []rune, opts ...cell.Option) error
+}
+
+// ClipboardTerminal is implemented by terminals that can place text into the
+// system clipboard, e.g. using the OSC 52 escape sequence.
+// Terminals that don't implement it ignore requests to copy text.
+type ClipboardTerminal interface {
+	Terminal
+
+	// SetClipboard requests the terminal to place the text into the system
+	// clipboard.
+	SetClipboard(text string) error
+}
+
+// TitleTerminal is implemented by terminals that can set the title of their
+// window or tab, e.g. using the OSC 2 escape sequence.
+// Terminals that don't implement it ignore requests to set the title.
+type TitleTerminal interface {
+	Terminal
+
+	// SetTitle requests the terminal to set the title of its window or tab.
+	SetTitle(title string) error
+}
+
+// ColorModeTerminal is implemented by terminals that report the color mode
+// they were configured with, e.g. so that widgets can pick colors the
+// terminal can display.
+type ColorModeTerminal interface {
+	Terminal
+
+	// ColorMode returns the color mode of the terminal.
+	ColorMode() ColorMode
+}
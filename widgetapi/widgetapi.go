@@ -16,6 +16,7 @@
 package widgetapi
 
 import (
+	"fmt"
 	"image"
 
 	"github.com/mum4k/termdash/private/canvas"
@@ -183,3 +184,41 @@ type Widget interface {
 	// Draw.
 	Options() Options
 }
+
+// Cloneable is implemented by widgets that can be cloned, e.g. when
+// instantiating multiple identical widgets from a single template.
+type Cloneable interface {
+	// Clone returns a new widget of the same type with identical options, but
+	// without any of the data displayed by the original widget. The returned
+	// widget must not share any mutable state with the original.
+	Clone() (Widget, error)
+}
+
+// CloneWidget returns a new widget of the same type as the provided widget
+// with identical options, but without any data.
+// Returns an error if the widget doesn't implement the Cloneable interface.
+func CloneWidget(w Widget) (Widget, error) {
+	c, ok := w.(Cloneable)
+	if !ok {
+		return nil, fmt.Errorf("the widget %T cannot be cloned, it doesn't implement widgetapi.Cloneable", w)
+	}
+	return c.Clone()
+}
+
+// Destroyable is implemented by widgets that hold resources, e.g. goroutines
+// or connections, that must be released once the widget isn't used anymore.
+type Destroyable interface {
+	// Destroy releases the resources held by the widget. The widget won't be
+	// drawn or receive any events after Destroy is called.
+	Destroy() error
+}
+
+// DestroyWidget releases the resources held by the widget.
+// Does nothing if the widget doesn't implement the Destroyable interface.
+func DestroyWidget(w Widget) error {
+	d, ok := w.(Destroyable)
+	if !ok {
+		return nil
+	}
+	return d.Destroy()
+}
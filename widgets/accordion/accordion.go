@@ -0,0 +1,350 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package accordion implements a widget that groups other widgets into
+// collapsible sections.
+package accordion
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// The symbols displayed before the titles of the sections.
+const (
+	expandedSymbol  = '▼'
+	collapsedSymbol = '▶'
+)
+
+// section is one section of the accordion.
+type section struct {
+	title    string
+	widget   widgetapi.Widget
+	expanded bool
+}
+
+// sectionArea are the areas of a section on the canvas.
+type sectionArea struct {
+	// title is the area of the title bar.
+	title image.Rectangle
+	// content is the area of the widget, empty if the section is collapsed.
+	content image.Rectangle
+}
+
+// Accordion displays widgets in sections stacked vertically. Each section has
+// a title bar and can be expanded to display its widget or collapsed to only
+// display the title bar. The expanded sections share the height that remains
+// after all the title bars are displayed.
+//
+// Clicking on a title bar or pressing the ToggleKey expands or collapses the
+// section. The SelectKeys move the selection between the sections. Other
+// keyboard events are forwarded to the widget in the selected section and
+// mouse events to the widget under the cursor if the widgets want them.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Accordion struct {
+	// sections are the sections in the order they were added.
+	sections []*section
+	// selected is the index of the selected section.
+	selected int
+	// lastAreas are the areas of the sections during the last call to Draw.
+	lastAreas []sectionArea
+
+	// mu protects the Accordion.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Accordion.
+func New(opts ...Option) (*Accordion, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &Accordion{
+		opts: o,
+	}, nil
+}
+
+// AddSection appends a collapsed section with the title that displays the
+// widget when expanded.
+func (a *Accordion) AddSection(title string, w widgetapi.Widget) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if w == nil {
+		return fmt.Errorf("the widget of section %q cannot be nil", title)
+	}
+	a.sections = append(a.sections, &section{
+		title:  title,
+		widget: w,
+	})
+	return nil
+}
+
+// Toggle expands or collapses the section at the index and selects it.
+func (a *Accordion) Toggle(idx int) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if idx < 0 || idx >= len(a.sections) {
+		return fmt.Errorf("invalid section index %d, must be 0 <= idx < %d", idx, len(a.sections))
+	}
+	a.toggle(idx)
+	return nil
+}
+
+// Expanded returns true if the section at the index is expanded.
+func (a *Accordion) Expanded(idx int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if idx < 0 || idx >= len(a.sections) {
+		return false
+	}
+	return a.sections[idx].expanded
+}
+
+// toggle expands or collapses the section at the index and selects it.
+// Caller must hold a.mu.
+func (a *Accordion) toggle(idx int) {
+	a.selected = idx
+	s := a.sections[idx]
+	s.expanded = !s.expanded
+	if !s.expanded || a.opts.allowMultiple {
+		return
+	}
+	for i, other := range a.sections {
+		if i != idx {
+			other.expanded = false
+		}
+	}
+}
+
+// layout returns the areas of the sections on the canvas.
+// Caller must hold a.mu.
+func (a *Accordion) layout(ar image.Rectangle) []sectionArea {
+	var expanded int
+	for _, s := range a.sections {
+		if s.expanded {
+			expanded++
+		}
+	}
+	free := ar.Dy() - len(a.sections)
+	if free < 0 {
+		free = 0
+	}
+
+	var res []sectionArea
+	y := ar.Min.Y
+	for _, s := range a.sections {
+		if y >= ar.Max.Y {
+			res = append(res, sectionArea{})
+			continue
+		}
+		sa := sectionArea{
+			title: image.Rect(ar.Min.X, y, ar.Max.X, y+1),
+		}
+		y++
+		if s.expanded && free > 0 {
+			h := free / expanded
+			if free%expanded != 0 {
+				h++
+			}
+			free -= h
+			expanded--
+			sa.content = image.Rect(ar.Min.X, y, ar.Max.X, y+h)
+			y += h
+		}
+		res = append(res, sa)
+	}
+	return res
+}
+
+// drawTitle draws the title bar of the section.
+// Caller must hold a.mu.
+func (a *Accordion) drawTitle(cvs *canvas.Canvas, idx int, ar image.Rectangle) error {
+	cOpts := a.opts.titleCellOpts
+	if idx == a.selected {
+		cOpts = a.opts.selectedTitleCellOpts
+	}
+	if err := cvs.SetAreaCells(ar, ' ', cOpts...); err != nil {
+		return err
+	}
+
+	s := a.sections[idx]
+	symbol := collapsedSymbol
+	if s.expanded {
+		symbol = expandedSymbol
+	}
+	return draw.Text(cvs, fmt.Sprintf("%c %s", symbol, s.title), ar.Min,
+		draw.TextMaxX(ar.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(cOpts...),
+	)
+}
+
+// drawContent draws the widget of the section into its area.
+func drawContent(cvs *canvas.Canvas, w widgetapi.Widget, ar image.Rectangle, meta *widgetapi.Meta) error {
+	wCvs, err := canvas.New(ar)
+	if err != nil {
+		return err
+	}
+
+	needSize := image.Point{1, 1}
+	if min := w.Options().MinimumSize; min.X > 0 && min.Y > 0 {
+		needSize = min
+	}
+	if ar.Dx() < needSize.X || ar.Dy() < needSize.Y {
+		if err := draw.ResizeNeeded(wCvs); err != nil {
+			return err
+		}
+		return wCvs.CopyTo(cvs)
+	}
+
+	if err := w.Draw(wCvs, meta); err != nil {
+		return err
+	}
+	return wCvs.CopyTo(cvs)
+}
+
+// Draw draws the Accordion widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (a *Accordion) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.lastAreas = a.layout(cvs.Area())
+	for i, sa := range a.lastAreas {
+		if sa.title.Empty() {
+			continue
+		}
+		if err := a.drawTitle(cvs, i, sa.title); err != nil {
+			return err
+		}
+		if sa.content.Empty() {
+			continue
+		}
+		wMeta := &widgetapi.Meta{
+			Focused: meta.Focused && i == a.selected,
+		}
+		if err := drawContent(cvs, a.sections[i].widget, sa.content, wMeta); err != nil {
+			return fmt.Errorf("failed to draw the widget of section %q: %v", a.sections[i].title, err)
+		}
+	}
+	return nil
+}
+
+// Keyboard processes keyboard events.
+// Implements widgetapi.Widget.Keyboard.
+func (a *Accordion) Keyboard(k *terminalapi.Keyboard) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(a.sections) == 0 {
+		return nil
+	}
+
+	switch k.Key {
+	case a.opts.toggleKey:
+		a.toggle(a.selected)
+	case a.opts.prevKey:
+		if a.selected > 0 {
+			a.selected--
+		}
+	case a.opts.nextKey:
+		if a.selected < len(a.sections)-1 {
+			a.selected++
+		}
+	default:
+		s := a.sections[a.selected]
+		if s.expanded && s.widget.Options().WantKeyboard != widgetapi.KeyScopeNone {
+			return s.widget.Keyboard(k)
+		}
+	}
+	return nil
+}
+
+// Mouse processes mouse events.
+// Implements widgetapi.Widget.Mouse.
+func (a *Accordion) Mouse(m *terminalapi.Mouse) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, sa := range a.lastAreas {
+		switch {
+		case m.Position.In(sa.title):
+			if m.Button == mouse.ButtonLeft {
+				a.toggle(i)
+			}
+			return nil
+
+		case m.Position.In(sa.content):
+			w := a.sections[i].widget
+			if w.Options().WantMouse == widgetapi.MouseScopeNone {
+				return nil
+			}
+			return w.Mouse(&terminalapi.Mouse{
+				Position: m.Position.Sub(sa.content.Min),
+				Button:   m.Button,
+			})
+		}
+	}
+	return nil
+}
+
+// Options of the widget.
+// Implements widgetapi.Widget.Options.
+// The minimum size reflects the title bars of all the sections and the
+// minimum sizes of the widgets in the expanded sections.
+func (a *Accordion) Options() widgetapi.Options {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	minSize := image.Point{1, len(a.sections)}
+	for _, s := range a.sections {
+		if !s.expanded {
+			continue
+		}
+		wMin := s.widget.Options().MinimumSize
+		if wMin.X > minSize.X {
+			minSize.X = wMin.X
+		}
+		if wMin.Y > 0 {
+			minSize.Y += wMin.Y
+		} else {
+			minSize.Y++
+		}
+	}
+	if minSize.Y < 1 {
+		minSize.Y = 1
+	}
+	return widgetapi.Options{
+		MinimumSize:  minSize,
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
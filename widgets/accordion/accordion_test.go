@@ -0,0 +1,311 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accordion
+
+import (
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mirrorOpts are the options of the widgets placed into the sections.
+var mirrorOpts = widgetapi.Options{
+	MinimumSize:  image.Point{2, 2},
+	WantKeyboard: widgetapi.KeyScopeFocused,
+	WantMouse:    widgetapi.MouseScopeWidget,
+}
+
+// mustDrawTitle draws the title bar with the text on the row of the canvas.
+func mustDrawTitle(c *canvas.Canvas, y int, text string, cOpts ...cell.Option) {
+	ar := image.Rect(0, y, c.Area().Dx(), y+1)
+	testcanvas.MustSetAreaCells(c, ar, ' ', cOpts...)
+	testdraw.MustText(c, text, ar.Min, draw.TextCellOpts(cOpts...))
+}
+
+// titleOpts and selectedOpts are the default cell options of the title bars.
+var (
+	titleOpts    = newOptions().titleCellOpts
+	selectedOpts = newOptions().selectedTitleCellOpts
+)
+
+func TestAccordion(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		// sections is the number of sections added, each with a fake widget.
+		sections int
+		// events are delivered to the Accordion after the first draw.
+		events  []terminalapi.Event
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc: "fails on duplicate keys",
+			opts: []Option{
+				ToggleKey(keyboard.KeyArrowUp),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws nothing without sections",
+			canvas: image.Rect(0, 0, 10, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:     "draws collapsed sections",
+			canvas:   image.Rect(0, 0, 10, 4),
+			sections: 2,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, 0, "▶ s0", selectedOpts...)
+				mustDrawTitle(c, 1, "▶ s1", titleOpts...)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:     "toggle key expands the selected section",
+			canvas:   image.Rect(0, 0, 10, 6),
+			sections: 2,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, 0, "▶ s0", titleOpts...)
+				mustDrawTitle(c, 1, "▼ s1", selectedOpts...)
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 2, 10, 6)), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:     "expanding a section collapses the others",
+			canvas:   image.Rect(0, 0, 10, 6),
+			sections: 2,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Mouse{Position: image.Point{0, 5}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, 0, "▶ s0", titleOpts...)
+				mustDrawTitle(c, 1, "▼ s1", selectedOpts...)
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 2, 10, 6)), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:     "multiple sections share the height",
+			opts:     []Option{AllowMultiple()},
+			canvas:   image.Rect(0, 0, 10, 9),
+			sections: 2,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, 0, "▼ s0", titleOpts...)
+				mustDrawTitle(c, 5, "▼ s1", selectedOpts...)
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 10, 5)), &widgetapi.Meta{}, mirrorOpts)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 6, 10, 9)), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:     "forwards events to the widget in the expanded section",
+			canvas:   image.Rect(0, 0, 20, 7),
+			sections: 1,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Mouse{Position: image.Point{1, 2}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, 0, "▼ s0", selectedOpts...)
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 20, 7)), &widgetapi.Meta{}, mirrorOpts,
+					&terminalapi.Keyboard{Key: 'a'},
+					&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+		},
+		{
+			desc:     "draws resize needed when the widget doesn't fit",
+			canvas:   image.Rect(0, 0, 10, 2),
+			sections: 1,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, 0, "▼ s0", selectedOpts...)
+				testcanvas.MustApply(c, ft)
+
+				wc := testcanvas.MustNew(image.Rect(0, 1, 10, 2))
+				testdraw.MustResizeNeeded(wc)
+				testcanvas.MustApply(wc, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			for i := 0; i < tc.sections; i++ {
+				if err := a.AddSection(fmt.Sprintf("s%d", i), fakewidget.New(mirrorOpts)); err != nil {
+					t.Fatalf("AddSection => unexpected error: %v", err)
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := a.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					if err := a.Keyboard(e); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+				case *terminalapi.Mouse:
+					if err := a.Mouse(e); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				// The layout changes when a section is toggled.
+				if err := a.Draw(c, &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := a.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestToggle(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := a.AddSection("s0", fakewidget.New(mirrorOpts)); err != nil {
+		t.Fatalf("AddSection => unexpected error: %v", err)
+	}
+	if err := a.AddSection("nil", nil); err == nil {
+		t.Errorf("AddSection => got nil err for a nil widget, wanted one")
+	}
+	if err := a.Toggle(1); err == nil {
+		t.Errorf("Toggle(1) => got nil err for an invalid index, wanted one")
+	}
+	if err := a.Toggle(0); err != nil {
+		t.Fatalf("Toggle(0) => unexpected error: %v", err)
+	}
+	if !a.Expanded(0) {
+		t.Errorf("Expanded(0) => got false after Toggle, want true")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	a, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	for _, title := range []string{"s0", "s1"} {
+		if err := a.AddSection(title, fakewidget.New(mirrorOpts)); err != nil {
+			t.Fatalf("AddSection => unexpected error: %v", err)
+		}
+	}
+
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 2},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, a.Options()); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+
+	if err := a.Toggle(0); err != nil {
+		t.Fatalf("Toggle => unexpected error: %v", err)
+	}
+	want.MinimumSize = image.Point{2, 4}
+	if diff := pretty.Compare(want, a.Options()); diff != "" {
+		t.Errorf("Options after expanding => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
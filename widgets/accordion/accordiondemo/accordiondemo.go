@@ -0,0 +1,97 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary accordiondemo displays the Accordion widget.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/accordion"
+	"github.com/mum4k/termdash/widgets/clock"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	a, err := accordion.New()
+	if err != nil {
+		panic(err)
+	}
+
+	general, err := text.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := general.Write("Use the arrow keys to select a section and press Enter to expand it."); err != nil {
+		panic(err)
+	}
+	if err := a.AddSection("General", general); err != nil {
+		panic(err)
+	}
+
+	now, err := clock.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := a.AddSection("Clock", now); err != nil {
+		panic(err)
+	}
+
+	about, err := text.New(text.WrapAtWords())
+	if err != nil {
+		panic(err)
+	}
+	if err := about.Write("Sections can also be toggled by clicking on their titles."); err != nil {
+		panic(err)
+	}
+	if err := a.AddSection("About", about); err != nil {
+		panic(err)
+	}
+	if err := a.Toggle(0); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(a),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accordion
+
+// options.go contains configurable options for Accordion.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	allowMultiple         bool
+	toggleKey             keyboard.Key
+	prevKey               keyboard.Key
+	nextKey               keyboard.Key
+	titleCellOpts         []cell.Option
+	selectedTitleCellOpts []cell.Option
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		toggleKey: DefaultToggleKey,
+		prevKey:   DefaultPrevKey,
+		nextKey:   DefaultNextKey,
+		titleCellOpts: []cell.Option{
+			cell.BgColor(cell.ColorNumber(DefaultTitleColorNumber)),
+		},
+		selectedTitleCellOpts: []cell.Option{
+			cell.FgColor(cell.ColorBlack),
+			cell.BgColor(cell.ColorNumber(DefaultSelectedTitleColorNumber)),
+		},
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	keys := map[keyboard.Key]bool{
+		o.toggleKey: true,
+		o.prevKey:   true,
+		o.nextKey:   true,
+	}
+	if len(keys) != 3 {
+		return fmt.Errorf("invalid keys ToggleKey(%v) and SelectKeys(prev:%v, next:%v), the keys must be unique", o.toggleKey, o.prevKey, o.nextKey)
+	}
+	return nil
+}
+
+// AllowMultiple allows more than one section to be expanded at the same time.
+// By default expanding a section collapses all the other sections.
+func AllowMultiple() Option {
+	return option(func(opts *options) {
+		opts.allowMultiple = true
+	})
+}
+
+// DefaultToggleKey is the default value for the ToggleKey option.
+const DefaultToggleKey = keyboard.KeyEnter
+
+// ToggleKey sets the key that expands or collapses the selected section.
+// Defaults to DefaultToggleKey.
+func ToggleKey(k keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.toggleKey = k
+	})
+}
+
+// The default keys that select the previous and the next section.
+const (
+	DefaultPrevKey = keyboard.KeyArrowUp
+	DefaultNextKey = keyboard.KeyArrowDown
+)
+
+// SelectKeys sets the keys that select the previous and the next section.
+// All the other keys are forwarded to the widget in the selected section if
+// it is expanded.
+// The provided keys must be unique and different from the ToggleKey.
+func SelectKeys(prev, next keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.prevKey = prev
+		opts.nextKey = next
+	})
+}
+
+// DefaultTitleColorNumber is the default color number for the background of
+// the section titles.
+const DefaultTitleColorNumber = 238
+
+// TitleCellOpts sets cell options on the cells of the title bars of the
+// sections that aren't selected.
+func TitleCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.titleCellOpts = cOpts
+	})
+}
+
+// DefaultSelectedTitleColorNumber is the default color number for the
+// background of the title of the selected section.
+const DefaultSelectedTitleColorNumber = 117
+
+// SelectedTitleCellOpts sets cell options on the cells of the title bar of
+// the selected section.
+func SelectedTitleCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.selectedTitleCellOpts = cOpts
+	})
+}
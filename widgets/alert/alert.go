@@ -0,0 +1,223 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package alert contains a widget that displays queued alerts one at a time
+// as modal dialogs over another widget.
+package alert
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/dialog"
+)
+
+// Level is the severity of an alert.
+type Level int
+
+// String implements fmt.Stringer()
+func (l Level) String() string {
+	if n, ok := levelNames[l]; ok {
+		return n
+	}
+	return "UNKNOWN"
+}
+
+// levelNames maps Level values to the titles of the alerts.
+var levelNames = map[Level]string{
+	LevelInfo:  "INFO",
+	LevelWarn:  "WARN",
+	LevelError: "ERROR",
+}
+
+const (
+	// LevelInfo is an informational alert.
+	LevelInfo Level = iota
+	// LevelWarn is a warning.
+	LevelWarn
+	// LevelError reports an error.
+	LevelError
+)
+
+// queued is an alert waiting to be displayed.
+type queued struct {
+	d       *dialog.Dialog
+	timeout time.Duration
+}
+
+// Alerter displays alerts as modal dialogs over the wrapped widget. The
+// alerts are queued and displayed one at a time in the order they were
+// added. Each alert has an OK button that dismisses it, see dialog.Dialog
+// for the keyboard and mouse controls.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Alerter struct {
+	// base draws the wrapped widget while no alert is displayed.
+	base *dialog.Dialog
+	// widget is the wrapped widget, can be nil.
+	widget widgetapi.Widget
+
+	// current is the displayed alert, nil if none.
+	current *dialog.Dialog
+	// timer dismisses the displayed alert after its timeout, nil if the alert
+	// doesn't have a timeout.
+	timer *time.Timer
+	// pending are the alerts waiting to be displayed.
+	pending []*queued
+
+	// mu protects the Alerter.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Alerter that displays the alerts over the wrapped widget.
+// The widget can be nil, the area around the alerts is empty in that case.
+func New(w widgetapi.Widget, opts ...Option) (*Alerter, error) {
+	base, err := dialog.New(w)
+	if err != nil {
+		return nil, err
+	}
+	return &Alerter{
+		base:   base,
+		widget: w,
+		opts:   newOptions(opts...),
+	}, nil
+}
+
+// Alert queues an alert with the message. The title and the border color of
+// the alert are derived from the level. If the timeout is positive, the
+// alert is dismissed automatically after being displayed for that duration.
+// Returns an error if the message is invalid.
+func (a *Alerter) Alert(msg string, level Level, timeout time.Duration) error {
+	d, err := dialog.New(a.widget,
+		dialog.Title(level.String()),
+		dialog.Message(msg),
+		dialog.BorderColor(a.opts.colors[level]),
+	)
+	if err != nil {
+		return fmt.Errorf("invalid alert: %v", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.pending = append(a.pending, &queued{d: d, timeout: timeout})
+	if a.current == nil {
+		a.showNext()
+	}
+	return nil
+}
+
+// DismissAll dismisses the displayed alert and removes all the pending ones.
+func (a *Alerter) DismissAll() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.pending = nil
+	if a.current != nil {
+		a.current.Hide()
+	}
+}
+
+// Visible returns true if an alert is displayed.
+func (a *Alerter) Visible() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.current != nil
+}
+
+// Pending returns the number of alerts waiting to be displayed, excluding
+// the displayed one.
+func (a *Alerter) Pending() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.pending)
+}
+
+// showNext displays the next pending alert, if any.
+// The caller must hold a.mu.
+func (a *Alerter) showNext() {
+	a.current = nil
+	a.timer = nil
+	if len(a.pending) == 0 {
+		return
+	}
+
+	q := a.pending[0]
+	a.pending = a.pending[1:]
+	a.current = q.d
+	result := dialog.Show(q.d)
+	if q.timeout > 0 {
+		a.timer = time.AfterFunc(q.timeout, q.d.Hide)
+	}
+	go a.waitDismissed(q.d, result)
+}
+
+// waitDismissed waits until the alert is dismissed and displays the next one.
+func (a *Alerter) waitDismissed(d *dialog.Dialog, result chan int) {
+	for range result {
+		// Drains the index of the activated button until the channel closes.
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.current != d {
+		return
+	}
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+	a.showNext()
+}
+
+// target returns the dialog that receives the calls to the widget API.
+func (a *Alerter) target() *dialog.Dialog {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.current != nil {
+		return a.current
+	}
+	return a.base
+}
+
+// Draw draws the wrapped widget and the displayed alert over it.
+// Implements widgetapi.Widget.Draw.
+func (a *Alerter) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	return a.target().Draw(cvs, meta)
+}
+
+// Keyboard is forwarded to the displayed alert or the wrapped widget.
+// Implements widgetapi.Widget.Keyboard.
+func (a *Alerter) Keyboard(k *terminalapi.Keyboard) error {
+	return a.target().Keyboard(k)
+}
+
+// Mouse is forwarded to the displayed alert or the wrapped widget.
+// Implements widgetapi.Widget.Mouse.
+func (a *Alerter) Mouse(m *terminalapi.Mouse) error {
+	return a.target().Mouse(m)
+}
+
+// Options of the widget.
+// Implements widgetapi.Widget.Options.
+// Reflects the options of the wrapped widget, while an alert is displayed it
+// also requests all keyboard events and the mouse events on its canvas.
+func (a *Alerter) Options() widgetapi.Options {
+	return a.target().Options()
+}
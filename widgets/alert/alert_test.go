@@ -0,0 +1,203 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"errors"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/event/testevent"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/dialog"
+)
+
+// mirrorOpts are the options of the wrapped widget.
+var mirrorOpts = widgetapi.Options{
+	MinimumSize:  image.Point{2, 2},
+	WantKeyboard: widgetapi.KeyScopeFocused,
+	WantMouse:    widgetapi.MouseScopeWidget,
+}
+
+// waitFor waits until the Alerter displays an alert and has the number of
+// pending alerts.
+func waitFor(t *testing.T, a *Alerter, visible bool, pending int) {
+	t.Helper()
+	if err := testevent.WaitFor(5*time.Second, func() error {
+		if got := a.Visible(); got != visible {
+			return errors.New("unexpected visibility")
+		}
+		if got := a.Pending(); got != pending {
+			return errors.New("unexpected number of pending alerts")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("waitFor(visible:%v, pending:%d) => got visible:%v, pending:%d", visible, pending, a.Visible(), a.Pending())
+	}
+}
+
+// mustDraw draws the Alerter on a new canvas of the size and returns the
+// result.
+func mustDraw(t *testing.T, a *Alerter, size image.Point) *faketerm.Terminal {
+	t.Helper()
+	c, err := canvas.New(image.Rect(0, 0, size.X, size.Y))
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	if err := a.Draw(c, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	got, err := faketerm.New(c.Size())
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	if err := c.Apply(got); err != nil {
+		t.Fatalf("Apply => unexpected error: %v", err)
+	}
+	return got
+}
+
+func TestAlertDrawsLevelTitleAndColor(t *testing.T) {
+	a, err := New(nil, LevelColor(LevelWarn, cell.ColorMagenta))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := a.Alert("Hi", LevelWarn, 0); err != nil {
+		t.Fatalf("Alert => unexpected error: %v", err)
+	}
+
+	size := image.Point{10, 6}
+	want := faketerm.MustNew(size)
+	c := testcanvas.MustNew(want.Area())
+	box := image.Rect(1, 0, 9, 5)
+	borderOpts := cell.FgColor(cell.ColorMagenta)
+	testcanvas.MustSetAreaCells(c, box, ' ', cell.FgColor(cell.ColorDefault), cell.BgColor(cell.ColorDefault))
+	testdraw.MustBorder(c, box,
+		draw.BorderCellOpts(borderOpts),
+		draw.BorderTitle("WARN", draw.OverrunModeThreeDot, borderOpts),
+		draw.BorderTitleAlign(align.HorizontalCenter),
+	)
+	testdraw.MustText(c, "Hi", image.Point{3, 1})
+	bOpts := []cell.Option{cell.FgColor(cell.ColorBlack), cell.BgColor(cell.ColorNumber(dialog.DefaultFocusedButtonColorNumber))}
+	testcanvas.MustSetAreaCells(c, image.Rect(3, 3, 7, 4), ' ', bOpts...)
+	testdraw.MustText(c, "OK", image.Point{4, 3}, draw.TextCellOpts(bOpts...))
+	testcanvas.MustApply(c, want)
+
+	if diff := faketerm.Diff(want, mustDraw(t, a, size)); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+}
+
+func TestAlertQueue(t *testing.T) {
+	mirror := fakewidget.New(mirrorOpts)
+	a, err := New(mirror)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare(mirrorOpts, a.Options()); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+
+	for _, msg := range []string{"first", "second", "third"} {
+		if err := a.Alert(msg, LevelInfo, 0); err != nil {
+			t.Fatalf("Alert => unexpected error: %v", err)
+		}
+	}
+	waitFor(t, a, true, 2)
+	if got, want := a.Options().WantKeyboard, widgetapi.KeyScopeGlobal; got != want {
+		t.Errorf("Options => got WantKeyboard %v, want %v", got, want)
+	}
+
+	// The OK button dismisses the displayed alert.
+	if err := a.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnter}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	waitFor(t, a, true, 1)
+
+	a.DismissAll()
+	waitFor(t, a, false, 0)
+
+	// Without alerts the events reach the wrapped widget.
+	ev := &terminalapi.Keyboard{Key: keyboard.KeyEnter}
+	if err := a.Keyboard(ev); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	size := image.Point{20, 5}
+	want := faketerm.MustNew(size)
+	fakewidget.MustDraw(want, testcanvas.MustNew(want.Area()), &widgetapi.Meta{}, mirrorOpts, ev)
+	if diff := faketerm.Diff(want, mustDraw(t, a, size)); diff != "" {
+		t.Errorf("Draw => %v", diff)
+	}
+}
+
+func TestAlertTimeout(t *testing.T) {
+	a, err := New(nil)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := a.Alert("timed", LevelError, 10*time.Millisecond); err != nil {
+		t.Fatalf("Alert => unexpected error: %v", err)
+	}
+	if err := a.Alert("stays", LevelInfo, 0); err != nil {
+		t.Fatalf("Alert => unexpected error: %v", err)
+	}
+	// The first alert times out and the second one is displayed until
+	// dismissed.
+	waitFor(t, a, true, 0)
+	time.Sleep(20 * time.Millisecond)
+	waitFor(t, a, true, 0)
+}
+
+func TestAlertFailsOnInvalidMessage(t *testing.T) {
+	a, err := New(nil)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := a.Alert("a\tb", LevelInfo, 0); err == nil {
+		t.Errorf("Alert => got nil error, want an error")
+	}
+	if a.Visible() {
+		t.Errorf("Visible => got true, want false")
+	}
+}
+
+func TestLevelString(t *testing.T) {
+	tests := []struct {
+		level Level
+		want  string
+	}{
+		{LevelInfo, "INFO"},
+		{LevelWarn, "WARN"},
+		{LevelError, "ERROR"},
+		{Level(-1), "UNKNOWN"},
+	}
+	for _, tc := range tests {
+		if got := tc.level.String(); got != tc.want {
+			t.Errorf("Level(%d).String => %q, want %q", tc.level, got, tc.want)
+		}
+	}
+}
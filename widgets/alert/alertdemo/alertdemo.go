@@ -0,0 +1,81 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary alertdemo displays the Alerter widget.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/alert"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	txt, err := text.New(text.WrapAtWords())
+	if err != nil {
+		panic(err)
+	}
+	if err := txt.Write("Press I, W or E to queue an alert, D to dismiss all of them."); err != nil {
+		panic(err)
+	}
+
+	a, err := alert.New(txt)
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(a),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	keys := func(k *terminalapi.Keyboard) {
+		switch k.Key {
+		case 'q', 'Q':
+			cancel()
+		case 'i', 'I':
+			a.Alert("The backup finished, this alert disappears in three seconds.", alert.LevelInfo, 3*time.Second)
+		case 'w', 'W':
+			a.Alert("The disk is 90% full.", alert.LevelWarn, 0)
+		case 'e', 'E':
+			a.Alert("The connection to the database was lost.", alert.LevelError, 0)
+		case 'd', 'D':
+			a.DismissAll()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(keys)); err != nil {
+		panic(err)
+	}
+}
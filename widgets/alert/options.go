@@ -0,0 +1,71 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+// options.go contains configurable options for Alerter.
+
+import (
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	colors map[Level]cell.Color
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		colors: map[Level]cell.Color{
+			LevelInfo:  DefaultInfoColor,
+			LevelWarn:  DefaultWarnColor,
+			LevelError: DefaultErrorColor,
+		},
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// The default border colors of the alerts.
+const (
+	DefaultInfoColor  = cell.ColorBlue
+	DefaultWarnColor  = cell.ColorYellow
+	DefaultErrorColor = cell.ColorRed
+)
+
+// LevelColor sets the color of the border and the title of alerts of the
+// level. Defaults to DefaultInfoColor, DefaultWarnColor and DefaultErrorColor
+// respectively.
+func LevelColor(l Level, c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.colors[l] = c
+	})
+}
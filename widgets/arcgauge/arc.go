@@ -0,0 +1,146 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arcgauge
+
+// arc.go assists in calculation of points and angles on the arc.
+
+import (
+	"image"
+	"math"
+
+	"github.com/mum4k/termdash/private/canvas/braille"
+)
+
+// arc is a part of the circle between two angles in degrees. The arc spans
+// counter-clockwise from the start to the end.
+type arc struct {
+	start int
+	end   int
+}
+
+// ccwArcs returns the arcs that span counter-clockwise from angle a to angle
+// b where a <= b. The returned arcs have angles in range 0 <= angle <= 360 as
+// required by draw.BrailleCircleArcOnly, so an arc that crosses the zero angle
+// is split in two. Returns no arcs if the angles round to the same value.
+func ccwArcs(a, b float64) []arc {
+	start := int(math.Round(a))
+	end := int(math.Round(b))
+	if start >= end {
+		return nil
+	}
+	if end-start >= 360 {
+		return []arc{{0, 360}}
+	}
+
+	// Move the start into range 0 <= start < 360.
+	shift := start / 360 * 360
+	if start < 0 {
+		shift -= 360
+	}
+	start -= shift
+	end -= shift
+	if start == 360 {
+		start, end = 0, end-360
+	}
+
+	if end <= 360 {
+		return []arc{{start, end}}
+	}
+	return []arc{{start, 360}, {0, end - 360}}
+}
+
+// arcsBetween returns the arcs that span from angle a to angle b in either
+// direction.
+func arcsBetween(a, b float64) []arc {
+	if a > b {
+		a, b = b, a
+	}
+	return ccwArcs(a, b)
+}
+
+// progressAngle returns the angle on the arc between start and end that
+// represents the percentage.
+func progressAngle(start, end, percent float64) float64 {
+	return start + (end-start)*percent/100
+}
+
+// midAndRadius given an area of a braille canvas, determines the mid point in
+// pixels and radius to draw the largest circle that fits.
+// The circle's mid point is always positioned on the {0,1} pixel in the chosen
+// cell so that the percentage inside of it can be visually centered.
+func midAndRadius(ar image.Rectangle) (image.Point, int) {
+	mid := image.Point{ar.Dx() / 2, ar.Dy() / 2}
+	if mid.X%2 != 0 {
+		mid.X--
+	}
+	switch mid.Y % 4 {
+	case 0:
+		mid.Y++
+	case 2:
+		mid.Y--
+	case 3:
+		mid.Y -= 2
+	}
+
+	// Calculate radius based on the smaller axis.
+	var radius int
+	if ar.Dx() < ar.Dy() {
+		if mid.X < ar.Dx()/2 {
+			radius = mid.X
+		} else {
+			radius = ar.Dx() - mid.X - 1
+		}
+	} else {
+		if mid.Y < ar.Dy()/2 {
+			radius = mid.Y
+		} else {
+			radius = ar.Dy() - mid.Y - 1
+		}
+	}
+	return mid, radius
+}
+
+// holeRadius returns the radius of the empty circle inside the arc of the
+// specified thickness in rows of cells. Returns zero if the arc should be
+// drawn as a full circle sector.
+func holeRadius(radius, thickness int) int {
+	r := radius - thickness*braille.RowMult
+	if r < 2 { // Smallest possible circle radius.
+		return 0
+	}
+	return r
+}
+
+// textArea given the mid point and the radius of the hole returns the area of
+// the cells on the middle row of the hole that don't contain any of the
+// pixels of the arc. The area is for a normal (non-braille) canvas, since
+// normal characters and braille characters cannot share the same cell.
+// Returns an empty area if the hole is too small.
+func textArea(mid image.Point, holeR int) image.Rectangle {
+	if holeR < 3 {
+		return image.ZR
+	}
+
+	// The pixels on the circle itself are excluded.
+	minPixel := mid.X - holeR + 1
+	maxPixel := mid.X + holeR - 1
+	minCell := (minPixel + braille.ColMult - 1) / braille.ColMult
+	maxCell := (maxPixel+1)/braille.ColMult - 1
+	if maxCell < minCell {
+		return image.ZR
+	}
+	y := mid.Y / braille.RowMult
+	return image.Rect(minCell, y, maxCell+1, y+1)
+}
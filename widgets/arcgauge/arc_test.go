@@ -0,0 +1,207 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arcgauge
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestArcsBetween(t *testing.T) {
+	tests := []struct {
+		desc string
+		a    float64
+		b    float64
+		want []arc
+	}{
+		{
+			desc: "no arcs for equal angles",
+			a:    90,
+			b:    90,
+		},
+		{
+			desc: "no arcs for angles that round to the same value",
+			a:    90.2,
+			b:    89.9,
+		},
+		{
+			desc: "arc within the first turn",
+			a:    0,
+			b:    90,
+			want: []arc{{0, 90}},
+		},
+		{
+			desc: "angles in reverse order",
+			a:    180,
+			b:    90,
+			want: []arc{{90, 180}},
+		},
+		{
+			desc: "arc ending on the full circle",
+			a:    270,
+			b:    360,
+			want: []arc{{270, 360}},
+		},
+		{
+			desc: "arc crossing the zero angle is split",
+			a:    -45,
+			b:    45,
+			want: []arc{{315, 360}, {0, 45}},
+		},
+		{
+			desc: "negative angles",
+			a:    -90,
+			b:    -45,
+			want: []arc{{270, 315}},
+		},
+		{
+			desc: "full circle",
+			a:    -45,
+			b:    315,
+			want: []arc{{0, 360}},
+		},
+		{
+			desc: "start on the full circle",
+			a:    360,
+			b:    300,
+			want: []arc{{300, 360}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := arcsBetween(tc.a, tc.b)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("arcsBetween => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProgressAngle(t *testing.T) {
+	tests := []struct {
+		desc    string
+		start   float64
+		end     float64
+		percent float64
+		want    float64
+	}{
+		{
+			desc:    "zero percent is the start",
+			start:   225,
+			end:     -45,
+			percent: 0,
+			want:    225,
+		},
+		{
+			desc:    "clockwise arc",
+			start:   225,
+			end:     -45,
+			percent: 50,
+			want:    90,
+		},
+		{
+			desc:    "counter-clockwise arc",
+			start:   0,
+			end:     180,
+			percent: 25,
+			want:    45,
+		},
+		{
+			desc:    "one hundred percent is the end",
+			start:   225,
+			end:     -45,
+			percent: 100,
+			want:    -45,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := progressAngle(tc.start, tc.end, tc.percent); got != tc.want {
+				t.Errorf("progressAngle => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHoleRadius(t *testing.T) {
+	tests := []struct {
+		desc      string
+		radius    int
+		thickness int
+		want      int
+	}{
+		{
+			desc:      "leaves a hole",
+			radius:    10,
+			thickness: 1,
+			want:      6,
+		},
+		{
+			desc:      "smallest hole",
+			radius:    10,
+			thickness: 2,
+			want:      2,
+		},
+		{
+			desc:      "no hole when too thick",
+			radius:    10,
+			thickness: 3,
+			want:      0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := holeRadius(tc.radius, tc.thickness); got != tc.want {
+				t.Errorf("holeRadius => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTextArea(t *testing.T) {
+	tests := []struct {
+		desc  string
+		mid   image.Point
+		holeR int
+		want  image.Rectangle
+	}{
+		{
+			desc:  "hole too small",
+			mid:   image.Point{10, 9},
+			holeR: 2,
+			want:  image.ZR,
+		},
+		{
+			desc:  "cells fully inside the hole",
+			mid:   image.Point{10, 9},
+			holeR: 6,
+			want:  image.Rect(3, 2, 8, 3),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := textArea(tc.mid, tc.holeR)
+			if got != tc.want {
+				t.Errorf("textArea => %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
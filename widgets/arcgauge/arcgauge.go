@@ -0,0 +1,187 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package arcgauge implements a widget that displays the progress as a
+// circular arc.
+package arcgauge
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"sync"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/alignfor"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/braille"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// ArcGauge displays the progress as an arc of a circle.
+//
+// The arc spans from the start angle to the end angle, the part that
+// represents the progress is drawn in one color and the remainder in another.
+// The percentage is displayed in the middle of the circle if it fits.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type ArcGauge struct {
+	// percent is the displayed progress in percent.
+	percent float64
+
+	// mu protects the ArcGauge.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new ArcGauge.
+func New(opts ...Option) (*ArcGauge, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &ArcGauge{
+		opts: opt,
+	}, nil
+}
+
+// Percent sets the current progress in percentage.
+// The provided value must be between 0 and 100.
+// Provided options override values set when New() was called.
+func (ag *ArcGauge) Percent(p float64, opts ...Option) error {
+	ag.mu.Lock()
+	defer ag.mu.Unlock()
+
+	if math.IsNaN(p) || p < 0 || p > 100 {
+		return fmt.Errorf("invalid percentage, p(%v) must be 0 <= p <= 100", p)
+	}
+
+	for _, opt := range opts {
+		opt.set(ag.opts)
+	}
+	if err := ag.opts.validate(); err != nil {
+		return err
+	}
+	ag.percent = p
+	return nil
+}
+
+// drawArcs draws the arcs between the two angles in the specified color.
+func drawArcs(bc *braille.Canvas, mid image.Point, r int, from, to float64, color cell.Color) error {
+	for _, a := range arcsBetween(from, to) {
+		if err := draw.BrailleCircle(bc, mid, r,
+			draw.BrailleCircleFilled(),
+			draw.BrailleCircleArcOnly(a.start, a.end),
+			draw.BrailleCircleCellOpts(cell.FgColor(color)),
+		); err != nil {
+			return fmt.Errorf("failed to draw the arc: %v", err)
+		}
+	}
+	return nil
+}
+
+// drawText draws the percentage in the middle of the circle.
+func (ag *ArcGauge) drawText(cvs *canvas.Canvas, mid image.Point, holeR int) error {
+	ar := textArea(mid, holeR)
+	t := fmt.Sprintf("%.0f%%", ag.percent)
+	if ar.Dx() < runewidth.StringWidth(t) {
+		return nil
+	}
+
+	start, err := alignfor.Text(ar, t, align.HorizontalCenter, align.VerticalMiddle)
+	if err != nil {
+		return fmt.Errorf("alignfor.Text => %v", err)
+	}
+	if err := draw.Text(cvs, t, start, draw.TextMaxX(ar.Max.X), draw.TextCellOpts(ag.opts.textCellOpts...)); err != nil {
+		return fmt.Errorf("draw.Text => %v", err)
+	}
+	return nil
+}
+
+// Draw draws the ArcGauge widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (ag *ArcGauge) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	ag.mu.Lock()
+	defer ag.mu.Unlock()
+
+	ar := cvs.Area()
+	if ar.Dx() < minSize.X || ar.Dy() < minSize.Y {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	bc, err := braille.New(ar)
+	if err != nil {
+		return fmt.Errorf("braille.New => %v", err)
+	}
+
+	mid, r := midAndRadius(bc.Area())
+	progress := progressAngle(ag.opts.startAngle, ag.opts.endAngle, ag.percent)
+	if err := drawArcs(bc, mid, r, progress, ag.opts.endAngle, ag.opts.emptyColor); err != nil {
+		return err
+	}
+	if err := drawArcs(bc, mid, r, ag.opts.startAngle, progress, ag.opts.color); err != nil {
+		return err
+	}
+
+	holeR := holeRadius(r, ag.opts.thickness)
+	if holeR != 0 {
+		if err := draw.BrailleCircle(bc, mid, holeR,
+			draw.BrailleCircleFilled(),
+			draw.BrailleCircleClearPixels(),
+		); err != nil {
+			return fmt.Errorf("failed to clear the inside of the arc: %v", err)
+		}
+	}
+	if err := bc.CopyTo(cvs); err != nil {
+		return err
+	}
+	return ag.drawText(cvs, mid, holeR)
+}
+
+// Keyboard input isn't supported on the ArcGauge widget.
+func (*ArcGauge) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the ArcGauge widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the ArcGauge widget.
+func (*ArcGauge) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the ArcGauge widget doesn't support mouse events")
+}
+
+// minSize is the smallest area we can draw the arc on.
+var minSize = image.Point{3, 3}
+
+// Options implements widgetapi.Widget.Options.
+func (ag *ArcGauge) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// We are drawing a circle, ensure equal amount of horizontal and
+		// vertical braille points.
+		// One cell consists of 2x4 braille points.
+		Ratio: image.Point{braille.RowMult, braille.ColMult},
+
+		MinimumSize:  minSize,
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arcgauge
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/braille/testbraille"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestArcGauge(t *testing.T) {
+	tests := []struct {
+		desc          string
+		opts          []Option
+		update        func(*ArcGauge) error // update gets called before drawing of the widget.
+		canvas        image.Rectangle
+		want          func(size image.Point) *faketerm.Terminal
+		wantNewErr    bool
+		wantUpdateErr bool
+	}{
+		{
+			desc:       "fails on angle out of range",
+			opts:       []Option{StartAngle(361)},
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on equal angles",
+			opts:       []Option{StartAngle(90), EndAngle(90)},
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on arc larger than the full circle",
+			opts:       []Option{StartAngle(270), EndAngle(-180)},
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on invalid thickness",
+			opts:       []Option{Thickness(0)},
+			wantNewErr: true,
+		},
+		{
+			desc:   "fails on percent out of range",
+			canvas: image.Rect(0, 0, 3, 3),
+			update: func(ag *ArcGauge) error {
+				return ag.Percent(101)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "fails on invalid options in Percent",
+			canvas: image.Rect(0, 0, 3, 3),
+			update: func(ag *ArcGauge) error {
+				return ag.Percent(50, Thickness(-1))
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "draws resize needed character when canvas is smaller than requested",
+			canvas: image.Rect(0, 0, 2, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				cvs := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(cvs)
+				testcanvas.MustApply(cvs, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws the empty arc at zero percent",
+			canvas: image.Rect(0, 0, 3, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+				empty := draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorNumber(DefaultEmptyColorNumber)))
+				testdraw.MustBrailleCircle(bc, image.Point{2, 5}, 2, draw.BrailleCircleFilled(), draw.BrailleCircleArcOnly(315, 360), empty)
+				testdraw.MustBrailleCircle(bc, image.Point{2, 5}, 2, draw.BrailleCircleFilled(), draw.BrailleCircleArcOnly(0, 225), empty)
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the progress and the remainder in custom colors",
+			opts: []Option{
+				StartAngle(180),
+				EndAngle(0),
+				Color(cell.ColorRed),
+				EmptyColor(cell.ColorBlue),
+			},
+			canvas: image.Rect(0, 0, 3, 3),
+			update: func(ag *ArcGauge) error {
+				return ag.Percent(50)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+				testdraw.MustBrailleCircle(bc, image.Point{2, 5}, 2,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleArcOnly(0, 90),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{2, 5}, 2,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleArcOnly(90, 180),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testbraille.MustApply(bc, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the percentage inside the arc",
+			opts: []Option{
+				StartAngle(0),
+				EndAngle(360),
+				TextCellOpts(cell.FgColor(cell.ColorYellow)),
+			},
+			canvas: image.Rect(0, 0, 10, 5),
+			update: func(ag *ArcGauge) error {
+				return ag.Percent(100)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				bc := testbraille.MustNew(ft.Area())
+				testdraw.MustBrailleCircle(bc, image.Point{10, 9}, 9,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleCellOpts(cell.FgColor(DefaultColor)),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{10, 9}, 5,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleClearPixels(),
+				)
+				c := testcanvas.MustNew(ft.Area())
+				testbraille.MustCopyTo(bc, c)
+				testdraw.MustText(c, "100%", image.Point{3, 2}, draw.TextCellOpts(cell.FgColor(cell.ColorYellow)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ag, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				err := tc.update(ag)
+				if (err != nil) != tc.wantUpdateErr {
+					t.Errorf("update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := ag.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	ag, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := ag.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	ag, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := ag.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	ag, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := ag.Options()
+	want := widgetapi.Options{
+		Ratio:        image.Point{4, 2},
+		MinimumSize:  image.Point{3, 3},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
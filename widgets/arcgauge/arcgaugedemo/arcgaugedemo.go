@@ -0,0 +1,113 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary arcgaugedemo displays the ArcGauge widgets.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/arcgauge"
+)
+
+// playArcGauge continuously changes the displayed percent value on the gauge
+// by the step once every delay. Exits when the context expires.
+func playArcGauge(ctx context.Context, ag *arcgauge.ArcGauge, step float64, delay time.Duration) {
+	progress := 0.0
+	mult := 1.0
+
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := ag.Percent(progress); err != nil {
+				panic(err)
+			}
+
+			progress += step * mult
+			if progress >= 100 {
+				progress = 100
+				mult = -1
+			} else if progress <= 0 {
+				progress = 0
+				mult = 1
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	speedometer, err := arcgauge.New()
+	if err != nil {
+		panic(err)
+	}
+	go playArcGauge(ctx, speedometer, 1, 100*time.Millisecond)
+
+	halfCircle, err := arcgauge.New(
+		arcgauge.StartAngle(180),
+		arcgauge.EndAngle(0),
+		arcgauge.Color(cell.ColorRed),
+		arcgauge.Thickness(2),
+	)
+	if err != nil {
+		panic(err)
+	}
+	go playArcGauge(ctx, halfCircle, 2.5, 250*time.Millisecond)
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.SplitVertical(
+			container.Left(
+				container.PlaceWidget(speedometer),
+			),
+			container.Right(
+				container.PlaceWidget(halfCircle),
+			),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arcgauge
+
+// options.go contains configurable options for ArcGauge.
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	startAngle   float64
+	endAngle     float64
+	color        cell.Color
+	emptyColor   cell.Color
+	thickness    int
+	textCellOpts []cell.Option
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		startAngle: DefaultStartAngle,
+		endAngle:   DefaultEndAngle,
+		color:      DefaultColor,
+		emptyColor: cell.ColorNumber(DefaultEmptyColorNumber),
+		thickness:  DefaultThickness,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	for _, a := range []float64{o.startAngle, o.endAngle} {
+		if math.IsNaN(a) || a < -360 || a > 360 {
+			return fmt.Errorf("invalid angle %v, must be -360 <= angle <= 360", a)
+		}
+	}
+	if sweep := math.Abs(o.endAngle - o.startAngle); sweep == 0 || sweep > 360 {
+		return fmt.Errorf("invalid StartAngle(%v) and EndAngle(%v), the arc between them must be larger than zero and at most 360 degrees", o.startAngle, o.endAngle)
+	}
+	if got, min := o.thickness, 1; got < min {
+		return fmt.Errorf("invalid Thickness %d, must be %d <= Thickness", got, min)
+	}
+	return nil
+}
+
+// The default angles of the arc, the arc sweeps clockwise over 270 degrees
+// leaving the bottom of the circle open.
+const (
+	DefaultStartAngle = 225
+	DefaultEndAngle   = -45
+)
+
+// StartAngle sets the angle in degrees where the arc starts, i.e. the position
+// of zero percent. The zero angle is on the X axis and angles grow
+// counter-clockwise. Must be in range -360 <= angle <= 360.
+// Defaults to DefaultStartAngle.
+func StartAngle(degrees float64) Option {
+	return option(func(opts *options) {
+		opts.startAngle = degrees
+	})
+}
+
+// EndAngle sets the angle in degrees where the arc ends, i.e. the position of
+// one hundred percent. The arc is filled clockwise if the end angle is smaller
+// than the start angle and counter-clockwise otherwise. Must be in range
+// -360 <= angle <= 360 and the arc between the start and the end angle must be
+// at most 360 degrees.
+// Defaults to DefaultEndAngle.
+func EndAngle(degrees float64) Option {
+	return option(func(opts *options) {
+		opts.endAngle = degrees
+	})
+}
+
+// DefaultColor is the default value for the Color option.
+const DefaultColor = cell.ColorGreen
+
+// Color sets the color of the part of the arc that represents the progress.
+// Defaults to DefaultColor.
+func Color(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.color = c
+	})
+}
+
+// DefaultEmptyColorNumber is the default color number for the EmptyColor
+// option.
+const DefaultEmptyColorNumber = 240
+
+// EmptyColor sets the color of the remaining part of the arc.
+// Defaults to DefaultEmptyColorNumber.
+func EmptyColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.emptyColor = c
+	})
+}
+
+// DefaultThickness is the default value for the Thickness option.
+const DefaultThickness = 1
+
+// Thickness sets the thickness of the arc in rows of cells. Must be a
+// positive integer. The arc is drawn as a full circle sector if the thickness
+// exceeds the radius.
+// Defaults to DefaultThickness.
+func Thickness(rows int) Option {
+	return option(func(opts *options) {
+		opts.thickness = rows
+	})
+}
+
+// TextCellOpts sets cell options on cells that contain the displayed
+// percentage.
+func TextCellOpts(opts ...cell.Option) Option {
+	return option(func(o *options) {
+		o.textCellOpts = opts
+	})
+}
@@ -50,6 +50,10 @@ type BarChart struct {
 	// lastWidth is the width of the canvas as of the last time when Draw was called.
 	lastWidth int
 
+	// sourceErr is the error that occurred when the data source last emitted
+	// values, reported on the next call to Draw.
+	sourceErr error
+
 	// mu protects the BarChart.
 	mu sync.Mutex
 
@@ -77,6 +81,10 @@ func (bc *BarChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 
+	if bc.sourceErr != nil {
+		return fmt.Errorf("invalid values from the data source: %v", bc.sourceErr)
+	}
+
 	bc.lastWidth = cvs.Area().Dx()
 	needAr, err := area.FromSize(bc.minSize())
 	if err != nil {
@@ -309,6 +317,18 @@ func (bc *BarChart) Options() widgetapi.Options {
 	}
 }
 
+// Clone returns a new BarChart with the same options as this one, but without
+// any values. The clone doesn't share any mutable state with this BarChart.
+// Implements widgetapi.Cloneable.
+func (bc *BarChart) Clone() (widgetapi.Widget, error) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	return &BarChart{
+		opts: bc.opts.clone(),
+	}, nil
+}
+
 // minBarWidth determines the minimum possible width of a bar based on the
 // options.
 func (bc *BarChart) minBarWidth() int {
@@ -852,3 +852,50 @@ func TestValueCapacity(t *testing.T) {
 		})
 	}
 }
+
+func TestClone(t *testing.T) {
+	colors := []cell.Color{cell.ColorRed}
+	bc, err := New(
+		BarColors(colors),
+		Labels([]string{"a"}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	w, err := widgetapi.CloneWidget(bc)
+	if err != nil {
+		t.Fatalf("CloneWidget => unexpected error: %v", err)
+	}
+	clone := w.(*BarChart)
+	if err := clone.Values([]int{5}, 10); err != nil {
+		t.Fatalf("Values => unexpected error: %v", err)
+	}
+
+	draw := func() *faketerm.Terminal {
+		c, err := canvas.New(image.Rect(0, 0, 3, 10))
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := clone.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		ft, err := faketerm.New(c.Size())
+		if err != nil {
+			t.Fatalf("faketerm.New => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+	want := draw()
+
+	colors[0] = cell.ColorBlue
+	if err := bc.Values([]int{10}, 10, Labels([]string{"b"}), BarWidth(2)); err != nil {
+		t.Fatalf("Values => unexpected error: %v", err)
+	}
+	if diff := faketerm.Diff(want, draw()); diff != "" {
+		t.Errorf("Draw => the clone changed after modifying the original %s", diff)
+	}
+}
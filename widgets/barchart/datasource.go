@@ -0,0 +1,72 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package barchart
+
+// datasource.go contains code that feeds the bar chart from data sources.
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/mum4k/termdash/datasource"
+)
+
+// SetDataSource sets the values of the bars from the data source and keeps
+// them updated whenever the source emits new data. The new values are
+// displayed on the next redraw.
+// Each key of the data becomes the label of one bar, the bars are ordered by
+// their labels. The values are rounded to the nearest integer and the largest
+// value becomes the maximum, so it is displayed as a full bar. The values must
+// not be negative, Draw returns an error if the source emits negative values.
+func (bc *BarChart) SetDataSource(a datasource.Adapter[map[string]float64]) error {
+	if err := bc.setLabeledValues(a.Data()); err != nil {
+		return err
+	}
+	a.Subscribe(func(data map[string]float64) {
+		err := bc.setLabeledValues(data)
+
+		bc.mu.Lock()
+		defer bc.mu.Unlock()
+		bc.sourceErr = err
+	})
+	return nil
+}
+
+// setLabeledValues sets the labeled values from a data source.
+func (bc *BarChart) setLabeledValues(data map[string]float64) error {
+	labels := make([]string, 0, len(data))
+	for l := range data {
+		labels = append(labels, l)
+	}
+	sort.Strings(labels)
+
+	values := make([]int, len(labels))
+	max := 1
+	for i, l := range labels {
+		v := data[l]
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("invalid value %v for label %q, the values must be finite and not negative", v, l)
+		}
+		values[i] = int(math.Round(v))
+		if values[i] > max {
+			max = values[i]
+		}
+	}
+	return bc.Values(values, max, Labels(labels))
+}
@@ -0,0 +1,77 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package barchart
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/datasource"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestSetDataSource(t *testing.T) {
+	bc, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	if err := bc.SetDataSource(datasource.NewBars(map[string]float64{"a": -1})); err == nil {
+		t.Errorf("SetDataSource => expected an error for a negative value, got nil")
+	}
+
+	src := datasource.NewBars(map[string]float64{
+		"b": 2.6,
+		"a": 1.2,
+	})
+	if err := bc.SetDataSource(src); err != nil {
+		t.Fatalf("SetDataSource => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare([]int{1, 3}, bc.values); diff != "" {
+		t.Errorf("SetDataSource => unexpected values, diff (-want, +got):\n%s", diff)
+	}
+	if diff := pretty.Compare([]string{"a", "b"}, bc.opts.labels); diff != "" {
+		t.Errorf("SetDataSource => unexpected labels, diff (-want, +got):\n%s", diff)
+	}
+	if got, want := bc.max, 3; got != want {
+		t.Errorf("SetDataSource => max %d, want %d", got, want)
+	}
+
+	src.Set(map[string]float64{"c": 0})
+	if diff := pretty.Compare([]int{0}, bc.values); diff != "" {
+		t.Errorf("Set => unexpected values, diff (-want, +got):\n%s", diff)
+	}
+	if got, want := bc.max, 1; got != want {
+		t.Errorf("Set => max %d, want %d", got, want)
+	}
+
+	cvs, err := canvas.New(image.Rect(0, 0, 10, 10))
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	src.Set(map[string]float64{"c": -1})
+	if err := bc.Draw(cvs, &widgetapi.Meta{}); err == nil {
+		t.Errorf("Draw => expected an error after the source emitted negative values, got nil")
+	}
+	src.Set(map[string]float64{"c": 1})
+	if err := bc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Errorf("Draw => unexpected error: %v", err)
+	}
+}
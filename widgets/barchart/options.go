@@ -60,6 +60,16 @@ func (o *options) validate() error {
 	return nil
 }
 
+// clone returns a deep copy of the options.
+func (o *options) clone() *options {
+	c := *o
+	c.barColors = append([]cell.Color(nil), o.barColors...)
+	c.labelColors = append([]cell.Color(nil), o.labelColors...)
+	c.valueColors = append([]cell.Color(nil), o.valueColors...)
+	c.labels = append([]string(nil), o.labels...)
+	return &c
+}
+
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
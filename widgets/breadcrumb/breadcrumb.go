@@ -0,0 +1,272 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package breadcrumb contains a widget that displays a path-like navigation
+// bar.
+package breadcrumb
+
+import (
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// placed is a segment of the path positioned on the canvas.
+type placed struct {
+	// level is the index of the segment in the path.
+	level int
+	// text is the text of the segment, possibly trimmed.
+	text string
+	// x is the column where the segment starts.
+	x int
+	// width is the number of cells the segment occupies.
+	width int
+}
+
+// layout positions the segments of the path into the width. The segments are
+// separated by the separator with a space on each side. Segments are trimmed
+// longest first when the path doesn't fit, leading segments are omitted if it
+// doesn't fit even when each segment occupies a single cell.
+func layout(path []string, width int, sep rune) []placed {
+	sepWidth := runewidth.RuneWidth(sep) + 2
+	first := 0
+	for first < len(path)-1 && len(path)-first+(len(path)-first-1)*sepWidth > width {
+		first++
+	}
+	segs := path[first:]
+	if len(segs) == 0 {
+		return nil
+	}
+
+	widths := make([]int, len(segs))
+	total := (len(segs) - 1) * sepWidth
+	for i, s := range segs {
+		widths[i] = runewidth.StringWidth(s)
+		total += widths[i]
+	}
+	for total > width {
+		longest := 0
+		for i, w := range widths {
+			if w > widths[longest] {
+				longest = i
+			}
+		}
+		if widths[longest] <= 1 {
+			break
+		}
+		widths[longest]--
+		total--
+	}
+
+	var res []placed
+	x := 0
+	for i, s := range segs {
+		if widths[i] < 1 || x >= width {
+			break
+		}
+		w := widths[i]
+		if max := width - x; w > max {
+			w = max
+		}
+		text, err := draw.TrimText(s, w, draw.OverrunModeThreeDot)
+		if err != nil {
+			// Cannot happen, the width is positive.
+			panic(err)
+		}
+		res = append(res, placed{
+			level: first + i,
+			text:  text,
+			x:     x,
+			width: w,
+		})
+		x += widths[i] + sepWidth
+	}
+	return res
+}
+
+// Breadcrumb displays a path-like navigation bar in a single row, e.g.:
+//
+//	Home > Section > Subsection
+//
+// Segments are trimmed with the horizontal ellipsis when the path doesn't fit
+// the canvas. Clicking on a segment with the mouse calls the function
+// provided with the OnClick option. When the widget is focused, the left and
+// right arrow keys select a segment and the Enter key activates it.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Breadcrumb struct {
+	// path are the segments of the path.
+	path []string
+	// selected is the index of the segment selected with the keyboard.
+	selected int
+	// placed are the segments as positioned on the last call to Draw.
+	placed []placed
+	// ar is the area of the canvas on the last call to Draw.
+	ar image.Rectangle
+
+	// mu protects the Breadcrumb.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Breadcrumb.
+func New(opts ...Option) (*Breadcrumb, error) {
+	o := newOptions(opts...)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &Breadcrumb{
+		opts: o,
+	}, nil
+}
+
+// SetPath replaces the displayed path. The last segment is selected.
+func (b *Breadcrumb) SetPath(path []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.path = append([]string(nil), path...)
+	b.selected = len(path) - 1
+	if b.selected < 0 {
+		b.selected = 0
+	}
+}
+
+// Path returns a copy of the displayed path.
+func (b *Breadcrumb) Path() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.path...)
+}
+
+// Draw draws the Breadcrumb widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (b *Breadcrumb) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.ar = cvs.Area()
+	b.placed = layout(b.path, b.ar.Dx(), b.opts.separator)
+	y := b.ar.Min.Y
+	for i, p := range b.placed {
+		if i > 0 {
+			sepX := b.ar.Min.X + p.x - runewidth.RuneWidth(b.opts.separator) - 1
+			if _, err := cvs.SetCell(image.Point{sepX, y}, b.opts.separator, cell.FgColor(b.opts.separatorColor)); err != nil {
+				return err
+			}
+		}
+
+		cOpts := []cell.Option{cell.FgColor(b.opts.textColor)}
+		if meta.Focused && p.level == b.selected {
+			cOpts = append(cOpts, cell.BgColor(b.opts.focusedColor))
+		}
+		if err := draw.Text(cvs, p.text, image.Point{b.ar.Min.X + p.x, y},
+			draw.TextCellOpts(cOpts...),
+			draw.TextMaxX(b.ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onClick calls the function provided via the OnClick option, if any.
+func (b *Breadcrumb) onClick(level int, ok bool) error {
+	if !ok || b.opts.onClick == nil {
+		return nil
+	}
+	// Mutex must be released when calling the callback.
+	// Users might call container methods from the callback like the
+	// Container.Update, see #205.
+	return b.opts.onClick(level)
+}
+
+// keyboard processes the keyboard event and returns the level of the
+// activated segment and a bool indicating if a segment was activated.
+func (b *Breadcrumb) keyboard(k *terminalapi.Keyboard) (int, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch k.Key {
+	case keyboard.KeyArrowLeft:
+		if b.selected > 0 {
+			b.selected--
+		}
+	case keyboard.KeyArrowRight:
+		if b.selected < len(b.path)-1 {
+			b.selected++
+		}
+	case keyboard.KeyHome:
+		b.selected = 0
+	case keyboard.KeyEnd:
+		if len(b.path) > 0 {
+			b.selected = len(b.path) - 1
+		}
+	case keyboard.KeyEnter:
+		return b.selected, len(b.path) > 0
+	}
+	return 0, false
+}
+
+// Keyboard selects a segment with the arrow keys and activates it with Enter.
+// Implements widgetapi.Widget.Keyboard.
+func (b *Breadcrumb) Keyboard(k *terminalapi.Keyboard) error {
+	return b.onClick(b.keyboard(k))
+}
+
+// mouse processes the mouse event and returns the level of the clicked
+// segment and a bool indicating if a segment was clicked.
+func (b *Breadcrumb) mouse(m *terminalapi.Mouse) (int, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if m.Button != mouse.ButtonLeft || m.Position.Y != b.ar.Min.Y {
+		return 0, false
+	}
+	x := m.Position.X - b.ar.Min.X
+	for _, p := range b.placed {
+		if x >= p.x && x < p.x+p.width {
+			b.selected = p.level
+			return p.level, true
+		}
+	}
+	return 0, false
+}
+
+// Mouse activates the clicked segment.
+// Implements widgetapi.Widget.Mouse.
+func (b *Breadcrumb) Mouse(m *terminalapi.Mouse) error {
+	return b.onClick(b.mouse(m))
+}
+
+// Options implements widgetapi.Widget.Options.
+func (b *Breadcrumb) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		MaximumSize:  image.Point{0, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
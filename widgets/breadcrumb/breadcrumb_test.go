@@ -0,0 +1,355 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package breadcrumb
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestLayout(t *testing.T) {
+	path := []string{"Home", "Section", "Subsection"}
+	tests := []struct {
+		desc  string
+		path  []string
+		width int
+		sep   rune
+		want  []placed
+	}{
+		{
+			desc:  "empty path",
+			width: 10,
+			sep:   '>',
+		},
+		{
+			desc:  "path fits",
+			path:  path,
+			width: 40,
+			sep:   '>',
+			want: []placed{
+				{level: 0, text: "Home", x: 0, width: 4},
+				{level: 1, text: "Section", x: 7, width: 7},
+				{level: 2, text: "Subsection", x: 17, width: 10},
+			},
+		},
+		{
+			desc:  "trims the longest segments first",
+			path:  path,
+			width: 20,
+			sep:   '>',
+			want: []placed{
+				{level: 0, text: "Home", x: 0, width: 4},
+				{level: 1, text: "Sect…", x: 7, width: 5},
+				{level: 2, text: "Subs…", x: 15, width: 5},
+			},
+		},
+		{
+			desc:  "accounts for a full-width separator",
+			path:  []string{"a", "b"},
+			width: 10,
+			sep:   '界',
+			want: []placed{
+				{level: 0, text: "a", x: 0, width: 1},
+				{level: 1, text: "b", x: 5, width: 1},
+			},
+		},
+		{
+			desc:  "omits leading segments that don't fit",
+			path:  path,
+			width: 5,
+			sep:   '>',
+			want: []placed{
+				{level: 1, text: "…", x: 0, width: 1},
+				{level: 2, text: "…", x: 4, width: 1},
+			},
+		},
+		{
+			desc:  "trims the only segment that fits",
+			path:  path,
+			width: 3,
+			sep:   '>',
+			want: []placed{
+				{level: 2, text: "Su…", x: 0, width: 3},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := layout(tc.path, tc.width, tc.sep)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("layout => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// focused are the cell options of the focused segment.
+var focused = cell.BgColor(cell.ColorNumber(DefaultFocusedColorNumber))
+
+// text returns text options with the default foreground color and the extra
+// cell options.
+func text(cOpts ...cell.Option) draw.TextOption {
+	return draw.TextCellOpts(append([]cell.Option{cell.FgColor(cell.ColorDefault)}, cOpts...)...)
+}
+
+func TestBreadcrumb(t *testing.T) {
+	path := []string{"Home", "Section", "Subsection"}
+	tests := []struct {
+		desc        string
+		opts        []Option
+		path        []string
+		canvas      image.Rectangle
+		meta        *widgetapi.Meta
+		events      []terminalapi.Event
+		want        func(size image.Point) *faketerm.Terminal
+		wantClicked []int
+		wantNewErr  bool
+		wantErr     bool
+	}{
+		{
+			desc:       "fails on a control character separator",
+			opts:       []Option{Separator('\n')},
+			canvas:     image.Rect(0, 0, 10, 1),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on a zero width separator",
+			opts:       []Option{Separator('́')},
+			canvas:     image.Rect(0, 0, 10, 1),
+			wantNewErr: true,
+		},
+		{
+			desc:   "draws nothing without a path",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta:   &widgetapi.Meta{},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws the path",
+			path:   path,
+			canvas: image.Rect(0, 0, 30, 1),
+			meta:   &widgetapi.Meta{},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Home", image.Point{0, 0}, text())
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '>', cell.FgColor(cell.ColorDefault))
+				testdraw.MustText(c, "Section", image.Point{7, 0}, text())
+				testcanvas.MustSetCell(c, image.Point{15, 0}, '>', cell.FgColor(cell.ColorDefault))
+				testdraw.MustText(c, "Subsection", image.Point{17, 0}, text())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims the path and highlights the selected segment when focused",
+			opts:   []Option{Separator('/'), TextColor(cell.ColorRed), SeparatorColor(cell.ColorBlue)},
+			path:   path,
+			canvas: image.Rect(0, 0, 20, 1),
+			meta:   &widgetapi.Meta{Focused: true},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Home", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '/', cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "Sect…", image.Point{7, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustSetCell(c, image.Point{13, 0}, '/', cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "Subs…", image.Point{15, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed), focused))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "arrow keys select a segment and enter activates it",
+			path:   path,
+			canvas: image.Rect(0, 0, 30, 1),
+			meta:   &widgetapi.Meta{Focused: true},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Home", image.Point{0, 0}, text())
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '>', cell.FgColor(cell.ColorDefault))
+				testdraw.MustText(c, "Section", image.Point{7, 0}, text(focused))
+				testcanvas.MustSetCell(c, image.Point{15, 0}, '>', cell.FgColor(cell.ColorDefault))
+				testdraw.MustText(c, "Subsection", image.Point{17, 0}, text())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantClicked: []int{2, 0},
+		},
+		{
+			desc:   "mouse clicks on segments activate them",
+			path:   path,
+			canvas: image.Rect(0, 0, 30, 1),
+			meta:   &widgetapi.Meta{},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+				// Clicks on the separators are ignored.
+				&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonRight},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "Home", image.Point{0, 0}, text())
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '>', cell.FgColor(cell.ColorDefault))
+				testdraw.MustText(c, "Section", image.Point{7, 0}, text())
+				testcanvas.MustSetCell(c, image.Point{15, 0}, '>', cell.FgColor(cell.ColorDefault))
+				testdraw.MustText(c, "Subsection", image.Point{17, 0}, text())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantClicked: []int{1, 0},
+		},
+		{
+			desc:   "returns the error from OnClick",
+			opts:   []Option{OnClick(func(int) error { return errors.New("click failed") })},
+			path:   path,
+			canvas: image.Rect(0, 0, 30, 1),
+			meta:   &widgetapi.Meta{},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var clicked []int
+			opts := append([]Option{OnClick(func(level int) error {
+				clicked = append(clicked, level)
+				return nil
+			})}, tc.opts...)
+			b, err := New(opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+			b.SetPath(tc.path)
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// The first draw positions the segments for the mouse events.
+			if err := b.Draw(c, tc.meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					err = b.Keyboard(e)
+				case *terminalapi.Mouse:
+					err = b.Mouse(e)
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				if (err != nil) != tc.wantErr {
+					t.Errorf("event => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := b.Draw(c, tc.meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+			if diff := pretty.Compare(tc.wantClicked, clicked); diff != "" {
+				t.Errorf("OnClick => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPath(t *testing.T) {
+	b, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	path := []string{"a", "b"}
+	b.SetPath(path)
+	path[0] = "changed"
+
+	if diff := pretty.Compare([]string{"a", "b"}, b.Path()); diff != "" {
+		t.Errorf("Path => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	b, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := b.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		MaximumSize:  image.Point{0, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package breadcrumb
+
+// options.go contains configurable options for Breadcrumb.
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/private/wrap"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	separator      rune
+	textColor      cell.Color
+	separatorColor cell.Color
+	focusedColor   cell.Color
+	onClick        ClickFn
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		separator:      DefaultSeparator,
+		textColor:      cell.ColorDefault,
+		separatorColor: cell.ColorDefault,
+		focusedColor:   cell.ColorNumber(DefaultFocusedColorNumber),
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if err := wrap.ValidText(string(o.separator)); err != nil {
+		return fmt.Errorf("invalid Separator: %v", err)
+	}
+	if runewidth.RuneWidth(o.separator) < 1 {
+		return errors.New("invalid Separator, the rune must occupy at least one cell")
+	}
+	return nil
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// DefaultSeparator is the default value for the Separator option.
+const DefaultSeparator = '>'
+
+// Separator sets the rune displayed between the segments of the path.
+// Defaults to DefaultSeparator.
+func Separator(r rune) Option {
+	return option(func(opts *options) {
+		opts.separator = r
+	})
+}
+
+// TextColor sets the color of the segments.
+// Defaults to cell.ColorDefault.
+func TextColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.textColor = c
+	})
+}
+
+// SeparatorColor sets the color of the separators.
+// Defaults to cell.ColorDefault.
+func SeparatorColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.separatorColor = c
+	})
+}
+
+// DefaultFocusedColorNumber is the default background color of the focused
+// segment, this is an Xterm color number, see cell.ColorNumber.
+const DefaultFocusedColorNumber = 238
+
+// FocusedColor sets the background color of the segment selected with the
+// keyboard while the widget is focused.
+// Defaults to DefaultFocusedColorNumber.
+func FocusedColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.focusedColor = c
+	})
+}
+
+// ClickFn if provided is called with the level of the segment the user
+// clicked on, the first segment is at level zero.
+//
+// The callback function must be thread-safe as the keyboard or mouse event
+// that activates the segment comes from a separate goroutine.
+type ClickFn func(level int) error
+
+// OnClick sets a function that will be called when the user clicks on a
+// segment with the mouse or presses Enter while the segment is selected.
+func OnClick(fn ClickFn) Option {
+	return option(func(opts *options) {
+		opts.onClick = fn
+	})
+}
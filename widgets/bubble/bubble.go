@@ -0,0 +1,294 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bubble implements a widget that plots points of variable size.
+package bubble
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// Point is one bubble on the chart.
+type Point struct {
+	// X is the position of the point on the horizontal axis.
+	X float64
+	// Y is the position of the point on the vertical axis.
+	Y float64
+	// Size determines the character that represents the point, see
+	// SizeRange.
+	Size float64
+	// Label is an optional text displayed right of the point.
+	Label string
+}
+
+// The characters that represent the bubbles from the smallest to the largest.
+var sizeRunes = []rune{'·', '●', '⬤'}
+
+// seriesValues represent points stored in the series.
+type seriesValues struct {
+	// points are the points in the series.
+	points []Point
+
+	seriesCellOpts []cell.Option
+}
+
+// newSeriesValues returns a new seriesValues instance.
+func newSeriesValues(points []Point) *seriesValues {
+	// Copy to avoid external modifications.
+	p := make([]Point, len(points))
+	copy(p, points)
+	return &seriesValues{
+		points: p,
+	}
+}
+
+// SeriesOption is used to provide options to Series.
+type SeriesOption interface {
+	// set sets the provided option.
+	set(*seriesValues)
+}
+
+// seriesOption implements SeriesOption.
+type seriesOption func(*seriesValues)
+
+// set implements SeriesOption.set.
+func (so seriesOption) set(sv *seriesValues) {
+	so(sv)
+}
+
+// SeriesCellOpts sets the cell options for the points and labels of this
+// series. Where points of multiple series share a cell, the last drawn series
+// wins. Series are drawn in alphabetical order based on their name.
+func SeriesCellOpts(co ...cell.Option) SeriesOption {
+	return seriesOption(func(opts *seriesValues) {
+		opts.seriesCellOpts = co
+	})
+}
+
+// Bubble plots points at their X and Y coordinates, representing the size of
+// each point with a character of matching size.
+//
+// Each series has an identifying label and a set of points. The extent of both
+// axes is determined from the points of all the series, so that all the points
+// are visible.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Bubble struct {
+	// series are the series that will be plotted.
+	// Keyed by the name of the series and updated by calling Series.
+	series map[string]*seriesValues
+
+	// mu protects the Bubble.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Bubble chart.
+func New(opts ...Option) (*Bubble, error) {
+	opt := newOptions(opts...)
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Bubble{
+		series: map[string]*seriesValues{},
+		opts:   opt,
+	}, nil
+}
+
+// Series sets the points that should be plotted under the provided label.
+// Setting points under an existing label replaces them.
+// Providing no points removes the series.
+func (b *Bubble) Series(label string, points []Point, opts ...SeriesOption) error {
+	if label == "" {
+		return errors.New("the label cannot be empty")
+	}
+	for i, p := range points {
+		for _, v := range []float64{p.X, p.Y, p.Size} {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return fmt.Errorf("invalid point at index %d: %+v, the coordinates and the size must be finite numbers", i, p)
+			}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(points) == 0 {
+		delete(b.series, label)
+		return nil
+	}
+	series := newSeriesValues(points)
+	for _, opt := range opts {
+		opt.set(series)
+	}
+	b.series[label] = series
+	return nil
+}
+
+// bounds are the smallest and the largest values among all the points.
+type bounds struct {
+	xMin, xMax       float64
+	yMin, yMax       float64
+	sizeMin, sizeMax float64
+}
+
+// bounds determines the bounds of the points in all the series.
+// b.mu must be held when calling this method.
+func (b *Bubble) bounds() *bounds {
+	var bd *bounds
+	for _, sv := range b.series {
+		for _, p := range sv.points {
+			if bd == nil {
+				bd = &bounds{p.X, p.X, p.Y, p.Y, p.Size, p.Size}
+				continue
+			}
+			bd.xMin, bd.xMax = math.Min(bd.xMin, p.X), math.Max(bd.xMax, p.X)
+			bd.yMin, bd.yMax = math.Min(bd.yMin, p.Y), math.Max(bd.yMax, p.Y)
+			bd.sizeMin, bd.sizeMax = math.Min(bd.sizeMin, p.Size), math.Max(bd.sizeMax, p.Size)
+		}
+	}
+	if sr := b.opts.sizeRange; sr != nil && bd != nil {
+		bd.sizeMin, bd.sizeMax = sr.min, sr.max
+	}
+	return bd
+}
+
+// position maps the value from the range onto one of the cells that span it.
+// If the range is empty, the value is positioned into the middle.
+func position(v, min, max float64, cells int) int {
+	if min == max {
+		return cells / 2
+	}
+	return int(math.Round((v - min) / (max - min) * float64(cells-1)))
+}
+
+// sizeRune returns the character that represents a bubble of the provided
+// size within the range.
+func sizeRune(size, min, max float64) rune {
+	if min == max {
+		return sizeRunes[len(sizeRunes)/2]
+	}
+	idx := int((size - min) / (max - min) * float64(len(sizeRunes)))
+	switch {
+	case idx < 0:
+		idx = 0
+	case idx >= len(sizeRunes):
+		idx = len(sizeRunes) - 1
+	}
+	return sizeRunes[idx]
+}
+
+// point returns the cell on the canvas that represents the point.
+func (bd *bounds) point(ar image.Rectangle, p Point) image.Point {
+	return image.Point{
+		ar.Min.X + position(p.X, bd.xMin, bd.xMax, ar.Dx()),
+		ar.Max.Y - 1 - position(p.Y, bd.yMin, bd.yMax, ar.Dy()),
+	}
+}
+
+// Draw draws the Bubble chart onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (b *Bubble) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	bd := b.bounds()
+	if bd == nil {
+		return nil
+	}
+
+	var names []string
+	for k := range b.series {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	ar := cvs.Area()
+	// The labels are drawn first so that they never hide any of the bubbles.
+	for _, name := range names {
+		sv := b.series[name]
+		for _, p := range sv.points {
+			if p.Label == "" {
+				continue
+			}
+			start := bd.point(ar, p).Add(image.Point{2, 0})
+			if start.X >= ar.Max.X {
+				continue
+			}
+			if err := draw.Text(cvs, p.Label, start,
+				draw.TextCellOpts(sv.seriesCellOpts...),
+				draw.TextMaxX(ar.Max.X),
+				draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			); err != nil {
+				return fmt.Errorf("failed to draw the label of point %+v in series %q: %v", p, name, err)
+			}
+		}
+	}
+	for _, name := range names {
+		sv := b.series[name]
+		for _, p := range sv.points {
+			r := sizeRune(p.Size, bd.sizeMin, bd.sizeMax)
+			if _, err := cvs.SetCell(bd.point(ar, p), r, sv.seriesCellOpts...); err != nil {
+				return fmt.Errorf("failed to draw point %+v in series %q: %v", p, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Keyboard input isn't supported on the Bubble widget.
+func (*Bubble) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the Bubble widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the Bubble widget.
+func (*Bubble) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the Bubble widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (b *Bubble) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
+
+// Clone returns a new Bubble chart with the same options as this one, but
+// without any series. The clone doesn't share any mutable state with this
+// chart.
+// Implements widgetapi.Cloneable.
+func (b *Bubble) Clone() (widgetapi.Widget, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return &Bubble{
+		series: map[string]*seriesValues{},
+		opts:   b.opts.clone(),
+	}, nil
+}
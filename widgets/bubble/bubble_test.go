@@ -0,0 +1,312 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bubble
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestBubble(t *testing.T) {
+	tests := []struct {
+		desc          string
+		opts          []Option
+		update        func(*Bubble) error // update gets called before drawing of the widget.
+		canvas        image.Rectangle
+		want          func(size image.Point) *faketerm.Terminal
+		wantNewErr    bool
+		wantUpdateErr bool
+	}{
+		{
+			desc: "fails when the size range min isn't less than the max",
+			opts: []Option{
+				SizeRange(1, 1),
+			},
+			canvas: image.Rect(0, 0, 5, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantNewErr: true,
+		},
+		{
+			desc:   "fails on an empty series label",
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(b *Bubble) error {
+				return b.Series("", []Point{{X: 1, Y: 1, Size: 1}})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "fails on a point that isn't finite",
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(b *Bubble) error {
+				return b.Series("s", []Point{{X: 1, Y: math.Inf(1), Size: 1}})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "draws nothing without series",
+			canvas: image.Rect(0, 0, 5, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "single point is placed in the middle",
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(b *Bubble) error {
+				return b.Series("s", []Point{{X: 10, Y: 10, Size: 1}})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{2, 1}, '●')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "points span the canvas and sizes map to characters",
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(b *Bubble) error {
+				return b.Series("s", []Point{
+					{X: 0, Y: 0, Size: 0},
+					{X: 2, Y: 1, Size: 5},
+					{X: 4, Y: 2, Size: 10},
+				}, SeriesCellOpts(cell.FgColor(cell.ColorRed)))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 2}, '·', cell.FgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{2, 1}, '●', cell.FgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{4, 0}, '⬤', cell.FgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "sizes outside of the size range use the nearest character",
+			opts: []Option{
+				SizeRange(10, 20),
+			},
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(b *Bubble) error {
+				return b.Series("s", []Point{
+					{X: 0, Y: 0, Size: 0},
+					{X: 4, Y: 2, Size: 100},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 2}, '·')
+				testcanvas.MustSetCell(c, image.Point{4, 0}, '⬤')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "multiple series share the bounds",
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(b *Bubble) error {
+				if err := b.Series("a", []Point{{X: 0, Y: 0, Size: 1}}, SeriesCellOpts(cell.FgColor(cell.ColorRed))); err != nil {
+					return err
+				}
+				return b.Series("b", []Point{{X: 4, Y: 2, Size: 1}}, SeriesCellOpts(cell.FgColor(cell.ColorBlue)))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{0, 2}, '●', cell.FgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{4, 0}, '●', cell.FgColor(cell.ColorBlue))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "setting no points removes the series",
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(b *Bubble) error {
+				if err := b.Series("a", []Point{{X: 0, Y: 0, Size: 1}}); err != nil {
+					return err
+				}
+				return b.Series("a", nil)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws labels right of the points, trimmed at the edge",
+			canvas: image.Rect(0, 0, 7, 3),
+			update: func(b *Bubble) error {
+				return b.Series("s", []Point{
+					{X: 0, Y: 0, Size: 1, Label: "abcdef"},
+					{X: 6, Y: 2, Size: 1, Label: "hidden"},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "abcd…", image.Point{2, 2})
+				testcanvas.MustSetCell(c, image.Point{0, 2}, '●')
+				testcanvas.MustSetCell(c, image.Point{6, 0}, '●')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "bubbles are drawn over labels",
+			canvas: image.Rect(0, 0, 5, 1),
+			update: func(b *Bubble) error {
+				return b.Series("s", []Point{
+					{X: 0, Y: 0, Size: 1, Label: "abc"},
+					{X: 2, Y: 0, Size: 1},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "abc", image.Point{2, 0})
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '●')
+				testcanvas.MustSetCell(c, image.Point{4, 0}, '●')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			b, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				err := tc.update(b)
+				if (err != nil) != tc.wantUpdateErr {
+					t.Errorf("update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := b.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	b, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := b.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	b, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := b.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	b, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := b.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestClone(t *testing.T) {
+	b, err := New(SizeRange(1, 2))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := b.Series("s", []Point{{X: 1, Y: 1, Size: 1}}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+
+	w, err := widgetapi.CloneWidget(b)
+	if err != nil {
+		t.Fatalf("CloneWidget => unexpected error: %v", err)
+	}
+	got := w.(*Bubble)
+	if diff := pretty.Compare(b.opts, got.opts); diff != "" {
+		t.Errorf("Clone => unexpected options, diff (-want, +got):\n%s", diff)
+	}
+	if len(got.series) != 0 {
+		t.Errorf("Clone => got series %v, want none", got.series)
+	}
+
+	b.opts.sizeRange.max = 10
+	if want := 2.0; got.opts.sizeRange.max != want {
+		t.Errorf("Clone => the clone shares the size range with the original, got %v, want %v", got.opts.sizeRange.max, want)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary bubbledemo displays the Bubble widget.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/bubble"
+)
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	b, err := bubble.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := b.Series("cities", []bubble.Point{
+		{X: 1, Y: 2, Size: 10, Label: "Oslo"},
+		{X: 4, Y: 8, Size: 90, Label: "Paris"},
+		{X: 7, Y: 5, Size: 40, Label: "Milan"},
+	}, bubble.SeriesCellOpts(cell.FgColor(cell.ColorBlue))); err != nil {
+		panic(err)
+	}
+	if err := b.Series("villages", []bubble.Point{
+		{X: 2, Y: 6, Size: 2, Label: "Hallstatt"},
+		{X: 6, Y: 1, Size: 5, Label: "Giethoorn"},
+	}, bubble.SeriesCellOpts(cell.FgColor(cell.ColorGreen))); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(b),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bubble
+
+// options.go contains configurable options for Bubble.
+
+import (
+	"fmt"
+	"math"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	sizeRange *sizeRange
+}
+
+// sizeRange is the range of sizes mapped onto the bubble characters.
+type sizeRange struct {
+	min float64
+	max float64
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.sizeRange != nil {
+		if math.IsNaN(o.sizeRange.min) || math.IsNaN(o.sizeRange.max) {
+			return fmt.Errorf("both the min(%v) and the max(%v) provided as the size range must be valid numbers", o.sizeRange.min, o.sizeRange.max)
+		}
+		if o.sizeRange.min >= o.sizeRange.max {
+			return fmt.Errorf("the min(%v) must be less than the max(%v) provided as the size range", o.sizeRange.min, o.sizeRange.max)
+		}
+	}
+	return nil
+}
+
+// clone returns a deep copy of the options.
+func (o *options) clone() *options {
+	c := *o
+	if o.sizeRange != nil {
+		sr := *o.sizeRange
+		c.sizeRange = &sr
+	}
+	return &c
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// SizeRange sets the range of sizes that is mapped onto the characters that
+// represent the bubbles. Sizes in the lower third of the range are drawn as
+// '·', sizes in the middle third as '●' and sizes in the upper third as '⬤'.
+// Sizes outside of the range are drawn as the nearest end of the range.
+// The min must be less than the max.
+// Defaults to the range between the smallest and the largest size of all the
+// points in all the series.
+func SizeRange(min, max float64) Option {
+	return option(func(opts *options) {
+		opts.sizeRange = &sizeRange{
+			min: min,
+			max: max,
+		}
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package clock is a widget that displays the current time, a countdown or
+// the elapsed time.
+package clock
+
+import (
+	"errors"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/private/alignfor"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mode determines what the clock displays.
+type mode int
+
+// String implements fmt.Stringer()
+func (m mode) String() string {
+	if n, ok := modeNames[m]; ok {
+		return n
+	}
+	return "modeUnknown"
+}
+
+// modeNames maps mode values to human readable names.
+var modeNames = map[mode]string{
+	modeClock:     "modeClock",
+	modeCountdown: "modeCountdown",
+	modeElapsed:   "modeElapsed",
+}
+
+const (
+	modeClock mode = iota
+	modeCountdown
+	modeElapsed
+)
+
+// Clock displays the current time, the time remaining until a target time or
+// the time elapsed since a start time.
+//
+// The displayed time is determined each time the Clock is drawn, so it stays
+// current as long as the dashboard redraws at least once a second.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Clock struct {
+	// mode determines what the clock displays.
+	mode mode
+	// ref is the target time of a countdown or the start time of the
+	// elapsed time.
+	ref time.Time
+
+	// pausedAt is the time displayed while the clock is paused, the zero
+	// value if the clock isn't paused.
+	pausedAt time.Time
+
+	// now returns the current time.
+	now func() time.Time
+
+	// mu protects the Clock.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// newClock returns a new Clock in the specified mode.
+func newClock(m mode, ref time.Time, opts ...Option) (*Clock, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Clock{
+		mode: m,
+		ref:  ref,
+		now:  time.Now,
+		opts: opt,
+	}, nil
+}
+
+// New returns a new Clock that displays the current time.
+func New(opts ...Option) (*Clock, error) {
+	return newClock(modeClock, time.Time{}, opts...)
+}
+
+// NewCountdown returns a new Clock that displays the time remaining until the
+// target time. Once the target time is reached, the Clock displays zero and
+// calls the function provided via the OnExpiry option.
+func NewCountdown(target time.Time, opts ...Option) (*Clock, error) {
+	c, err := newClock(modeCountdown, target, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if f := c.opts.onExpiry; f != nil {
+		time.AfterFunc(time.Until(target), f)
+	}
+	return c, nil
+}
+
+// NewElapsed returns a new Clock that displays the time elapsed since the
+// start time.
+func NewElapsed(start time.Time, opts ...Option) (*Clock, error) {
+	return newClock(modeElapsed, start, opts...)
+}
+
+// SetPaused pauses or resumes the Clock. A paused Clock keeps displaying the
+// time it displayed when it got paused. Time keeps passing while the Clock is
+// paused, e.g. a countdown still expires at its target time.
+func (c *Clock) SetPaused(paused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case paused && c.pausedAt.IsZero():
+		c.pausedAt = c.now()
+	case !paused:
+		c.pausedAt = time.Time{}
+	}
+}
+
+// Paused asserts whether the Clock is paused.
+func (c *Clock) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.pausedAt.IsZero()
+}
+
+// text returns the formatted time the clock displays.
+// c.mu must be held when calling this method.
+func (c *Clock) text() string {
+	now := c.now()
+	if !c.pausedAt.IsZero() {
+		now = c.pausedAt
+	}
+
+	switch c.mode {
+	case modeCountdown:
+		remaining := c.ref.Sub(now)
+		if remaining < 0 {
+			remaining = 0
+		}
+		return formatDuration(remaining, c.opts.format)
+
+	case modeElapsed:
+		elapsed := now.Sub(c.ref)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		return formatDuration(elapsed, c.opts.format)
+
+	default:
+		return now.Format(c.opts.format)
+	}
+}
+
+// formatDuration formats the duration as a time of the day.
+func formatDuration(d time.Duration, layout string) string {
+	// Round up, so that a countdown displays zero only once it expired.
+	if rem := d % time.Second; rem > 0 {
+		d += time.Second - rem
+	}
+	return time.Time{}.Add(d).Format(layout)
+}
+
+// Draw draws the Clock widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (c *Clock) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	text, err := draw.TrimText(c.text(), cvs.Area().Dx(), draw.OverrunModeThreeDot)
+	if err != nil {
+		return err
+	}
+	start, err := alignfor.Text(cvs.Area(), text, c.opts.hTextAlign, c.opts.vTextAlign)
+	if err != nil {
+		return err
+	}
+	return draw.Text(cvs, text, start, draw.TextCellOpts(c.opts.cellOpts...))
+}
+
+// Keyboard input isn't supported on the Clock widget.
+func (*Clock) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the Clock widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the Clock widget.
+func (*Clock) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the Clock widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (c *Clock) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// The time is trimmed if it doesn't fit.
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// now is the current time used in the tests.
+var now = time.Date(2020, time.March, 14, 13, 37, 42, 0, time.UTC)
+
+func TestClock(t *testing.T) {
+	tests := []struct {
+		desc   string
+		clock  func() (*Clock, error)
+		update func(*Clock) // update gets called before drawing of the widget.
+		canvas image.Rectangle
+		// now is the current time when the clock is drawn.
+		now     time.Time
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc: "fails on empty format",
+			clock: func() (*Clock, error) {
+				return New(Format(""))
+			},
+			wantErr: true,
+		},
+		{
+			desc: "displays the current time",
+			clock: func() (*Clock, error) {
+				return New()
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			now:    now,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "13:37:42", image.Point{1, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "displays the current time in custom format, alignment and cell options",
+			clock: func() (*Clock, error) {
+				return New(
+					Format("15:04"),
+					HorizontalTextAlign(align.HorizontalLeft),
+					VerticalTextAlign(align.VerticalTop),
+					CellOpts(cell.FgColor(cell.ColorRed)),
+				)
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			now:    now,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "13:37", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "trims the time that doesn't fit",
+			clock: func() (*Clock, error) {
+				return New()
+			},
+			canvas: image.Rect(0, 0, 5, 1),
+			now:    now,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "13:3…", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "displays the remaining time of a countdown rounded up",
+			clock: func() (*Clock, error) {
+				return NewCountdown(now.Add(90*time.Minute + 5*time.Second))
+			},
+			canvas: image.Rect(0, 0, 8, 1),
+			now:    now.Add(500 * time.Millisecond),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "01:30:05", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "expired countdown displays zero",
+			clock: func() (*Clock, error) {
+				return NewCountdown(now)
+			},
+			canvas: image.Rect(0, 0, 8, 1),
+			now:    now.Add(time.Hour),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "00:00:00", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "displays the elapsed time",
+			clock: func() (*Clock, error) {
+				return NewElapsed(now, Format("04:05"))
+			},
+			canvas: image.Rect(0, 0, 5, 1),
+			now:    now.Add(2*time.Minute + 3*time.Second),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "02:03", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "paused clock displays the time when it was paused",
+			clock: func() (*Clock, error) {
+				return NewElapsed(now, Format("04:05"))
+			},
+			update: func(c *Clock) {
+				c.now = func() time.Time { return now.Add(5 * time.Second) }
+				c.SetPaused(true)
+				// Pausing again doesn't move the paused time.
+				c.now = func() time.Time { return now.Add(7 * time.Second) }
+				c.SetPaused(true)
+			},
+			canvas: image.Rect(0, 0, 5, 1),
+			now:    now.Add(time.Minute),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "00:05", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "resumed clock displays the current time",
+			clock: func() (*Clock, error) {
+				return NewElapsed(now, Format("04:05"))
+			},
+			update: func(c *Clock) {
+				c.SetPaused(true)
+				c.SetPaused(false)
+			},
+			canvas: image.Rect(0, 0, 5, 1),
+			now:    now.Add(time.Minute),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "01:00", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			cl, err := tc.clock()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("tc.clock => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if tc.update != nil {
+				tc.update(cl)
+			}
+			cl.now = func() time.Time { return tc.now }
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := cl.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestOnExpiry(t *testing.T) {
+	expired := make(chan struct{})
+	if _, err := NewCountdown(time.Now().Add(10*time.Millisecond), OnExpiry(func() {
+		close(expired)
+	})); err != nil {
+		t.Fatalf("NewCountdown => unexpected error: %v", err)
+	}
+
+	select {
+	case <-expired:
+	case <-time.After(5 * time.Second):
+		t.Errorf("OnExpiry => the callback wasn't called after the countdown expired")
+	}
+}
+
+func TestPaused(t *testing.T) {
+	cl, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if cl.Paused() {
+		t.Errorf("Paused => got true for a new clock, want false")
+	}
+	cl.SetPaused(true)
+	if !cl.Paused() {
+		t.Errorf("Paused => got false after SetPaused(true), want true")
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	cl, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cl.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	cl, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := cl.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	cl, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := cl.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
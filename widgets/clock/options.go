@@ -0,0 +1,118 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clock
+
+// options.go contains configurable options for Clock.
+
+import (
+	"errors"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	format     string
+	onExpiry   func()
+	cellOpts   []cell.Option
+	hTextAlign align.Horizontal
+	vTextAlign align.Vertical
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		format:     DefaultFormat,
+		hTextAlign: DefaultHorizontalTextAlign,
+		vTextAlign: DefaultVerticalTextAlign,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.format == "" {
+		return errors.New("the Format cannot be empty")
+	}
+	return nil
+}
+
+// DefaultFormat is the default value for the Format option.
+const DefaultFormat = "15:04:05"
+
+// Format sets the layout used to format the displayed time, see the
+// documentation of time.Format for the syntax.
+// The countdown and elapsed time are formatted as a time of the day, i.e.
+// durations of 24 hours or more wrap around.
+// Defaults to DefaultFormat.
+func Format(layout string) Option {
+	return option(func(opts *options) {
+		opts.format = layout
+	})
+}
+
+// OnExpiry sets a function that is called once when a countdown reaches zero.
+// The function is called from a separate goroutine, so it must be
+// thread-safe. Has no effect on clocks that aren't countdowns.
+func OnExpiry(f func()) Option {
+	return option(func(opts *options) {
+		opts.onExpiry = f
+	})
+}
+
+// CellOpts sets the cell options of the displayed time.
+func CellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.cellOpts = cOpts
+	})
+}
+
+// DefaultHorizontalTextAlign is the default value for the HorizontalTextAlign
+// option.
+const DefaultHorizontalTextAlign = align.HorizontalCenter
+
+// HorizontalTextAlign sets the horizontal alignment of the displayed time.
+// Defaults to DefaultHorizontalTextAlign.
+func HorizontalTextAlign(h align.Horizontal) Option {
+	return option(func(opts *options) {
+		opts.hTextAlign = h
+	})
+}
+
+// DefaultVerticalTextAlign is the default value for the VerticalTextAlign
+// option.
+const DefaultVerticalTextAlign = align.VerticalMiddle
+
+// VerticalTextAlign sets the vertical alignment of the displayed time.
+// Defaults to DefaultVerticalTextAlign.
+func VerticalTextAlign(v align.Vertical) Option {
+	return option(func(opts *options) {
+		opts.vTextAlign = v
+	})
+}
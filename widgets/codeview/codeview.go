@@ -0,0 +1,303 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package codeview contains a widget that displays source code with syntax
+// highlighting.
+package codeview
+
+import (
+	"fmt"
+	"image"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/private/wrap"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// Languages returns the names of the languages supported by SetLanguage.
+func Languages() []string {
+	var res []string
+	for name := range languages {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// CodeView displays source code with syntax highlighting and line numbers.
+//
+// The code is split into tokens by a minimal tokenizer of the selected
+// language, each line is tokenized on its own. Lines longer than the width of
+// the widget are trimmed. The content can be scrolled using the keyboard or
+// the mouse.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type CodeView struct {
+	// lines are the lines of the content.
+	lines []string
+	// lang is the selected language or nil for plain text.
+	lang *language
+
+	// offset is the index of the first visible line.
+	offset int
+	// height is the height of the canvas during the last Draw.
+	height int
+
+	// cursor is the position highlighted by SetCursor, X is the column and Y
+	// is the line. Only valid when hasCursor is true.
+	cursor    image.Point
+	hasCursor bool
+	// showCursor indicates that the next Draw should scroll to the cursor.
+	showCursor bool
+
+	// mu protects the CodeView.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new CodeView. The content is displayed as plain text until a
+// language is selected with SetLanguage.
+func New(opts ...Option) (*CodeView, error) {
+	o := newOptions(opts...)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &CodeView{
+		opts: o,
+	}, nil
+}
+
+// SetLanguage selects the tokenizer used to highlight the content. The lang
+// must be one of the names returned by Languages or an empty string which
+// displays the content as plain text.
+func (cv *CodeView) SetLanguage(lang string) error {
+	var l *language
+	if lang != "" {
+		var ok bool
+		if l, ok = languages[strings.ToLower(lang)]; !ok {
+			return fmt.Errorf("unsupported language %q, supported languages are %v", lang, Languages())
+		}
+	}
+
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	cv.lang = l
+	return nil
+}
+
+// SetContent replaces the displayed source code. The content is split into
+// lines at newline characters, tabs are replaced with spaces according to the
+// TabWidth option. The content cannot contain other control characters.
+// Scrolls back to the first line and removes the cursor.
+func (cv *CodeView) SetContent(s string) error {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\t", strings.Repeat(" ", cv.opts.tabWidth))
+	var lines []string
+	if s != "" {
+		if err := wrap.ValidText(s); err != nil {
+			return err
+		}
+		lines = strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	}
+
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+	cv.lines = lines
+	cv.offset = 0
+	cv.hasCursor = false
+	cv.showCursor = false
+	return nil
+}
+
+// SetCursor highlights the position at the specified zero-based line and
+// column and scrolls the content so that the line is visible. The column
+// counts runes after tabs were replaced with spaces and can be one past the
+// last rune of the line.
+func (cv *CodeView) SetCursor(line, col int) error {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+
+	if line < 0 || line >= len(cv.lines) {
+		return fmt.Errorf("invalid line %d, the content has %d lines", line, len(cv.lines))
+	}
+	if n := len([]rune(cv.lines[line])); col < 0 || col > n {
+		return fmt.Errorf("invalid column %d, line %d has %d runes", col, line, n)
+	}
+	cv.cursor = image.Point{col, line}
+	cv.hasCursor = true
+	cv.showCursor = true
+	return nil
+}
+
+// scroll moves the first visible line by the specified number of lines, the
+// offset is kept within the content.
+// The caller must hold cv.mu.
+func (cv *CodeView) scroll(by int) {
+	cv.offset += by
+	if max := len(cv.lines) - cv.height; cv.offset > max {
+		cv.offset = max
+	}
+	if cv.offset < 0 {
+		cv.offset = 0
+	}
+}
+
+// gutterWidth returns the width of the left margin with the line numbers,
+// including a space that separates them from the code.
+// The caller must hold cv.mu.
+func (cv *CodeView) gutterWidth() int {
+	return len(fmt.Sprint(len(cv.lines))) + 1
+}
+
+// Draw draws the CodeView widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (cv *CodeView) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+
+	ar := cvs.Area()
+	cv.height = ar.Dy()
+	if cv.showCursor {
+		switch {
+		case cv.cursor.Y < cv.offset:
+			cv.offset = cv.cursor.Y
+		case cv.cursor.Y >= cv.offset+cv.height:
+			cv.offset = cv.cursor.Y - cv.height + 1
+		}
+		cv.showCursor = false
+	}
+	cv.scroll(0)
+
+	gw := cv.gutterWidth()
+	for y := ar.Min.Y; y < ar.Max.Y && cv.offset+y-ar.Min.Y < len(cv.lines); y++ {
+		idx := cv.offset + y - ar.Min.Y
+		num := fmt.Sprintf("%*d", gw-1, idx+1)
+		if err := draw.Text(cvs, num, image.Point{ar.Min.X, y},
+			draw.TextCellOpts(cv.opts.lineNumberCellOpts...),
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeTrim),
+		); err != nil {
+			return err
+		}
+		if err := cv.drawLine(cvs, idx, image.Point{ar.Min.X + gw, y}, ar.Max.X); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawLine draws the highlighted line at the index starting at the point,
+// the line is trimmed at maxX.
+// The caller must hold cv.mu.
+func (cv *CodeView) drawLine(cvs *canvas.Canvas, idx int, start image.Point, maxX int) error {
+	x := start.X
+	col := 0
+	for _, t := range tokenize(cv.lang, cv.lines[idx]) {
+		cOpts := cv.cellOpts(t.kind)
+		for _, r := range t.text {
+			if x+runewidth.RuneWidth(r) > maxX {
+				return nil
+			}
+			opts := cOpts
+			if cv.hasCursor && cv.cursor == (image.Point{col, idx}) {
+				opts = cv.opts.cursorCellOpts
+			}
+			cells, err := cvs.SetCell(image.Point{x, start.Y}, r, opts...)
+			if err != nil {
+				return err
+			}
+			x += cells
+			col++
+		}
+	}
+
+	// The cursor can be placed after the last rune of the line.
+	if cv.hasCursor && cv.cursor == (image.Point{col, idx}) && x < maxX {
+		if _, err := cvs.SetCell(image.Point{x, start.Y}, ' ', cv.opts.cursorCellOpts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cellOpts returns the cell options for tokens of the kind.
+func (cv *CodeView) cellOpts(k kind) []cell.Option {
+	switch k {
+	case kindKeyword:
+		return cv.opts.keywordCellOpts
+	case kindString:
+		return cv.opts.stringCellOpts
+	case kindNumber:
+		return cv.opts.numberCellOpts
+	case kindComment:
+		return cv.opts.commentCellOpts
+	case kindKey:
+		return cv.opts.keyCellOpts
+	default:
+		return nil
+	}
+}
+
+// Keyboard scrolls the content.
+// Implements widgetapi.Widget.Keyboard.
+func (cv *CodeView) Keyboard(k *terminalapi.Keyboard) error {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+
+	switch k.Key {
+	case cv.opts.keyUp:
+		cv.scroll(-1)
+	case cv.opts.keyDown:
+		cv.scroll(1)
+	case cv.opts.keyPgUp:
+		cv.scroll(-cv.height)
+	case cv.opts.keyPgDown:
+		cv.scroll(cv.height)
+	}
+	return nil
+}
+
+// Mouse scrolls the content.
+// Implements widgetapi.Widget.Mouse.
+func (cv *CodeView) Mouse(m *terminalapi.Mouse) error {
+	cv.mu.Lock()
+	defer cv.mu.Unlock()
+
+	switch m.Button {
+	case cv.opts.mouseUpButton:
+		cv.scroll(-1)
+	case cv.opts.mouseDownButton:
+		cv.scroll(1)
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (cv *CodeView) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// The line number, the separating space and one rune of the code.
+		MinimumSize:  image.Point{3, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
@@ -0,0 +1,335 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeview
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mustDrawLineNumber draws the line number into the left margin.
+func mustDrawLineNumber(c *canvas.Canvas, num string, y int) {
+	testdraw.MustText(c, num, image.Point{0, y}, draw.TextCellOpts(newOptions().lineNumberCellOpts...))
+}
+
+func TestCodeView(t *testing.T) {
+	tests := []struct {
+		desc          string
+		opts          []Option
+		lang          string
+		content       string
+		cursor        *image.Point
+		canvas        image.Rectangle
+		events        []terminalapi.Event
+		want          func(size image.Point) *faketerm.Terminal
+		wantNewErr    bool
+		wantLangErr   bool
+		wantSetErr    bool
+		wantCursorErr bool
+	}{
+		{
+			desc:       "fails on invalid TabWidth",
+			opts:       []Option{TabWidth(0)},
+			canvas:     image.Rect(0, 0, 10, 3),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on duplicate scroll keys",
+			opts:       []Option{ScrollKeys('a', 'b', 'a', 'c')},
+			canvas:     image.Rect(0, 0, 10, 3),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on duplicate scroll mouse buttons",
+			opts:       []Option{ScrollMouseButtons(mouse.ButtonWheelUp, mouse.ButtonWheelUp)},
+			canvas:     image.Rect(0, 0, 10, 3),
+			wantNewErr: true,
+		},
+		{
+			desc:        "fails on unsupported language",
+			lang:        "cobol",
+			canvas:      image.Rect(0, 0, 10, 3),
+			wantLangErr: true,
+		},
+		{
+			desc:       "fails on content with control characters",
+			content:    "a\x00b",
+			canvas:     image.Rect(0, 0, 10, 3),
+			wantSetErr: true,
+		},
+		{
+			desc:          "fails on cursor outside of the content",
+			content:       "ab",
+			cursor:        &image.Point{0, 1},
+			canvas:        image.Rect(0, 0, 10, 3),
+			wantCursorErr: true,
+		},
+		{
+			desc:          "fails on cursor past the end of the line",
+			content:       "ab",
+			cursor:        &image.Point{3, 0},
+			canvas:        image.Rect(0, 0, 10, 3),
+			wantCursorErr: true,
+		},
+		{
+			desc:   "draws nothing without content",
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:    "draws plain text with line numbers",
+			content: "a := 1\n\nb\n",
+			canvas:  image.Rect(0, 0, 10, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawLineNumber(c, "1", 0)
+				mustDrawLineNumber(c, "2", 1)
+				mustDrawLineNumber(c, "3", 2)
+				testdraw.MustText(c, "a := 1", image.Point{2, 0})
+				testdraw.MustText(c, "b", image.Point{2, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "right aligns line numbers",
+			content: "a\nb\nc\nd\ne\nf\ng\nh\ni\nj",
+			canvas:  image.Rect(0, 0, 10, 1),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawLineNumber(c, " 2", 0)
+				testdraw.MustText(c, "b", image.Point{3, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "highlights tokens",
+			lang:    "Go",
+			content: `if x { // "y"`,
+			canvas:  image.Rect(0, 0, 20, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				opts := newOptions()
+				mustDrawLineNumber(c, "1", 0)
+				testdraw.MustText(c, "if", image.Point{2, 0}, draw.TextCellOpts(opts.keywordCellOpts...))
+				testdraw.MustText(c, " x { ", image.Point{4, 0})
+				testdraw.MustText(c, `// "y"`, image.Point{9, 0}, draw.TextCellOpts(opts.commentCellOpts...))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "replaces tabs and trims long lines",
+			opts:    []Option{TabWidth(2)},
+			content: "\tabcdef",
+			canvas:  image.Rect(0, 0, 6, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawLineNumber(c, "1", 0)
+				testdraw.MustText(c, "  ab", image.Point{2, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "highlights the cursor and scrolls to it",
+			content: "a\nb\ncd",
+			cursor:  &image.Point{1, 2},
+			canvas:  image.Rect(0, 0, 10, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawLineNumber(c, "2", 0)
+				mustDrawLineNumber(c, "3", 1)
+				testdraw.MustText(c, "b", image.Point{2, 0})
+				testdraw.MustText(c, "c", image.Point{2, 1})
+				testdraw.MustText(c, "d", image.Point{3, 1}, draw.TextCellOpts(newOptions().cursorCellOpts...))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "highlights the cursor after the end of the line",
+			opts:    []Option{CursorCellOpts(cell.BgColor(cell.ColorRed))},
+			content: "ab",
+			cursor:  &image.Point{2, 0},
+			canvas:  image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawLineNumber(c, "1", 0)
+				testdraw.MustText(c, "ab", image.Point{2, 0})
+				testcanvas.MustSetCell(c, image.Point{4, 0}, ' ', cell.BgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "scrolls with the keyboard and the mouse",
+			content: "a\nb\nc\nd",
+			canvas:  image.Rect(0, 0, 10, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawLineNumber(c, "2", 0)
+				mustDrawLineNumber(c, "3", 1)
+				testdraw.MustText(c, "b", image.Point{2, 0})
+				testdraw.MustText(c, "c", image.Point{2, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			cv, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			err = cv.SetLanguage(tc.lang)
+			if (err != nil) != tc.wantLangErr {
+				t.Errorf("SetLanguage => unexpected error: %v, wantLangErr: %v", err, tc.wantLangErr)
+			}
+			if err != nil {
+				return
+			}
+
+			err = cv.SetContent(tc.content)
+			if (err != nil) != tc.wantSetErr {
+				t.Errorf("SetContent => unexpected error: %v, wantSetErr: %v", err, tc.wantSetErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.cursor != nil {
+				err := cv.SetCursor(tc.cursor.Y, tc.cursor.X)
+				if (err != nil) != tc.wantCursorErr {
+					t.Errorf("SetCursor => unexpected error: %v, wantCursorErr: %v", err, tc.wantCursorErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// The first draw determines the page size used when scrolling.
+			if err := cv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					if err := cv.Keyboard(e); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+				case *terminalapi.Mouse:
+					if err := cv.Mouse(e); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := cv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	want := []string{"go", "json", "shell", "yaml"}
+	if diff := pretty.Compare(want, Languages()); diff != "" {
+		t.Errorf("Languages => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	cv, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := cv.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{3, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
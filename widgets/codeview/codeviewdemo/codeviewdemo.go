@@ -0,0 +1,110 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary codeviewdemo displays the CodeView widget.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/codeview"
+)
+
+const goCode = `// Package main prints a greeting.
+package main
+
+import "fmt"
+
+func main() {
+	for i := 0; i < 3; i++ {
+		fmt.Println("Hello", i)
+	}
+}
+`
+
+const yamlCode = `# Server configuration.
+server:
+  host: "localhost"
+  port: 8080
+  tls: false
+limits:
+  - max-connections: 100
+`
+
+// newCodeView returns a CodeView displaying the code in the language.
+func newCodeView(lang, code string) *codeview.CodeView {
+	cv, err := codeview.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := cv.SetLanguage(lang); err != nil {
+		panic(err)
+	}
+	if err := cv.SetContent(code); err != nil {
+		panic(err)
+	}
+	return cv
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	gc := newCodeView("go", goCode)
+	if err := gc.SetCursor(7, 14); err != nil {
+		panic(err)
+	}
+	yc := newCodeView("yaml", yamlCode)
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.SplitVertical(
+			container.Left(
+				container.Border(linestyle.Light),
+				container.BorderTitle("main.go"),
+				container.PlaceWidget(gc),
+			),
+			container.Right(
+				container.Border(linestyle.Light),
+				container.BorderTitle("config.yaml"),
+				container.PlaceWidget(yc),
+			),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
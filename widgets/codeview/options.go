@@ -0,0 +1,223 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeview
+
+// options.go contains configurable options for CodeView.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	tabWidth           int
+	keywordCellOpts    []cell.Option
+	stringCellOpts     []cell.Option
+	numberCellOpts     []cell.Option
+	commentCellOpts    []cell.Option
+	keyCellOpts        []cell.Option
+	lineNumberCellOpts []cell.Option
+	cursorCellOpts     []cell.Option
+	mouseUpButton      mouse.Button
+	mouseDownButton    mouse.Button
+	keyUp              keyboard.Key
+	keyDown            keyboard.Key
+	keyPgUp            keyboard.Key
+	keyPgDown          keyboard.Key
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		tabWidth:           DefaultTabWidth,
+		keywordCellOpts:    []cell.Option{cell.FgColor(DefaultKeywordColor), cell.Bold()},
+		stringCellOpts:     []cell.Option{cell.FgColor(DefaultStringColor)},
+		numberCellOpts:     []cell.Option{cell.FgColor(DefaultNumberColor)},
+		commentCellOpts:    []cell.Option{cell.FgColor(cell.ColorNumber(DefaultCommentColorNumber))},
+		keyCellOpts:        []cell.Option{cell.FgColor(DefaultKeyColor)},
+		lineNumberCellOpts: []cell.Option{cell.FgColor(cell.ColorNumber(DefaultLineNumberColorNumber))},
+		cursorCellOpts: []cell.Option{
+			cell.FgColor(cell.ColorBlack),
+			cell.BgColor(DefaultCursorColor),
+		},
+		mouseUpButton:   DefaultScrollMouseButtonUp,
+		mouseDownButton: DefaultScrollMouseButtonDown,
+		keyUp:           DefaultScrollKeyUp,
+		keyDown:         DefaultScrollKeyDown,
+		keyPgUp:         DefaultScrollKeyPageUp,
+		keyPgDown:       DefaultScrollKeyPageDown,
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.tabWidth < 1 {
+		return fmt.Errorf("invalid TabWidth(%d), must be a positive number", o.tabWidth)
+	}
+	keys := map[keyboard.Key]bool{
+		o.keyUp:     true,
+		o.keyDown:   true,
+		o.keyPgUp:   true,
+		o.keyPgDown: true,
+	}
+	if len(keys) != 4 {
+		return fmt.Errorf("invalid ScrollKeys(up:%v, down:%v, pageUp:%v, pageDown:%v), the keys must be unique", o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown)
+	}
+	if o.mouseUpButton == o.mouseDownButton {
+		return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the buttons must be unique", o.mouseUpButton, o.mouseDownButton)
+	}
+	return nil
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// DefaultTabWidth is the default value for the TabWidth option.
+const DefaultTabWidth = 4
+
+// TabWidth sets the number of spaces each tab character in the content is
+// replaced with. Must be a positive number. Defaults to DefaultTabWidth.
+func TabWidth(width int) Option {
+	return option(func(opts *options) {
+		opts.tabWidth = width
+	})
+}
+
+// The default colors of the highlighted tokens.
+const (
+	DefaultKeywordColor = cell.ColorMagenta
+	DefaultStringColor  = cell.ColorGreen
+	DefaultNumberColor  = cell.ColorCyan
+	DefaultKeyColor     = cell.ColorBlue
+	DefaultCursorColor  = cell.ColorWhite
+)
+
+// The default colors of comments and line numbers, these are Xterm color
+// numbers, see cell.ColorNumber.
+const (
+	DefaultCommentColorNumber    = 244
+	DefaultLineNumberColorNumber = 240
+)
+
+// KeywordCellOpts sets the cell options of keywords and literals like true or
+// false. Defaults to a bold DefaultKeywordColor foreground.
+func KeywordCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.keywordCellOpts = cOpts
+	})
+}
+
+// StringCellOpts sets the cell options of quoted strings.
+// Defaults to a DefaultStringColor foreground.
+func StringCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.stringCellOpts = cOpts
+	})
+}
+
+// NumberCellOpts sets the cell options of numbers.
+// Defaults to a DefaultNumberColor foreground.
+func NumberCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.numberCellOpts = cOpts
+	})
+}
+
+// CommentCellOpts sets the cell options of comments.
+// Defaults to a DefaultCommentColorNumber foreground.
+func CommentCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.commentCellOpts = cOpts
+	})
+}
+
+// KeyCellOpts sets the cell options of object keys in JSON and YAML and of
+// variables in shell scripts. Defaults to a DefaultKeyColor foreground.
+func KeyCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.keyCellOpts = cOpts
+	})
+}
+
+// LineNumberCellOpts sets the cell options of the line numbers in the left
+// margin. Defaults to a DefaultLineNumberColorNumber foreground.
+func LineNumberCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.lineNumberCellOpts = cOpts
+	})
+}
+
+// CursorCellOpts sets the cell options of the position highlighted by
+// SetCursor. Defaults to black text on a DefaultCursorColor background.
+func CursorCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.cursorCellOpts = cOpts
+	})
+}
+
+// The default mouse buttons for content scrolling.
+const (
+	DefaultScrollMouseButtonUp   = mouse.ButtonWheelUp
+	DefaultScrollMouseButtonDown = mouse.ButtonWheelDown
+)
+
+// ScrollMouseButtons configures the mouse buttons that scroll the content.
+// The provided buttons must be unique, e.g. the same button cannot be both up
+// and down.
+func ScrollMouseButtons(up, down mouse.Button) Option {
+	return option(func(opts *options) {
+		opts.mouseUpButton = up
+		opts.mouseDownButton = down
+	})
+}
+
+// The default keys for content scrolling.
+const (
+	DefaultScrollKeyUp       = keyboard.KeyArrowUp
+	DefaultScrollKeyDown     = keyboard.KeyArrowDown
+	DefaultScrollKeyPageUp   = keyboard.KeyPgUp
+	DefaultScrollKeyPageDown = keyboard.KeyPgDn
+)
+
+// ScrollKeys configures the keyboard keys that scroll the content.
+// The provided keys must be unique, e.g. the same key cannot be both up and
+// down.
+func ScrollKeys(up, down, pageUp, pageDown keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keyUp = up
+		opts.keyDown = down
+		opts.keyPgUp = pageUp
+		opts.keyPgDown = pageDown
+	})
+}
@@ -0,0 +1,221 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeview
+
+// token.go splits lines of source code into highlighted tokens.
+
+import (
+	"strings"
+	"unicode"
+)
+
+// kind is the kind of a token, it determines its highlighting.
+type kind int
+
+const (
+	kindPlain kind = iota
+	kindKeyword
+	kindString
+	kindNumber
+	kindComment
+	kindKey
+)
+
+// token is a part of a line that is highlighted the same way.
+type token struct {
+	kind kind
+	text string
+}
+
+// language describes the syntax of a supported language.
+type language struct {
+	// keywords are the words highlighted as keywords.
+	keywords map[string]bool
+	// lineComment starts a comment that runs until the end of the line.
+	lineComment string
+	// blockComments indicates if the language has /* */ comments.
+	blockComments bool
+	// quotes are the runes that start and end strings.
+	quotes string
+	// keys indicates if quoted strings or words followed by a colon are keys.
+	keys bool
+	// variables indicates if words starting with $ are variables.
+	variables bool
+	// wordRunes are the runes other than letters, digits and underscores that
+	// can be part of a word.
+	wordRunes string
+}
+
+// words returns a set of the words.
+func words(ws ...string) map[string]bool {
+	res := map[string]bool{}
+	for _, w := range ws {
+		res[w] = true
+	}
+	return res
+}
+
+// languages are the supported languages keyed by their names.
+var languages = map[string]*language{
+	"go": {
+		keywords: words(
+			"break", "case", "chan", "const", "continue", "default", "defer",
+			"else", "fallthrough", "for", "func", "go", "goto", "if",
+			"import", "interface", "map", "package", "range", "return",
+			"select", "struct", "switch", "type", "var",
+			"true", "false", "nil", "iota",
+		),
+		lineComment:   "//",
+		blockComments: true,
+		quotes:        "\"'`",
+	},
+	"json": {
+		keywords: words("true", "false", "null"),
+		quotes:   `"`,
+		keys:     true,
+	},
+	"yaml": {
+		keywords:    words("true", "false", "null", "yes", "no", "on", "off"),
+		lineComment: "#",
+		quotes:      `"'`,
+		keys:        true,
+		wordRunes:   "-.",
+	},
+	"shell": {
+		keywords: words(
+			"if", "then", "else", "elif", "fi", "for", "while", "until", "do",
+			"done", "case", "esac", "in", "function", "return", "export",
+			"local", "readonly", "shift", "exit", "break", "continue",
+		),
+		lineComment: "#",
+		quotes:      `"'`,
+		variables:   true,
+		wordRunes:   "-",
+	},
+}
+
+// isWordRune determines if the rune can be a part of a word.
+func (l *language) isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(l.wordRunes, r)
+}
+
+// tokenize splits the line into tokens. Each line is tokenized on its own,
+// so strings and block comments that span multiple lines are only highlighted
+// on their first line. A nil language returns a single plain token.
+func tokenize(l *language, line string) []token {
+	if l == nil {
+		return []token{{kindPlain, line}}
+	}
+
+	rs := []rune(line)
+	var res []token
+	add := func(k kind, text string) {
+		if last := len(res) - 1; k == kindPlain && last >= 0 && res[last].kind == kindPlain {
+			res[last].text += text
+			return
+		}
+		res = append(res, token{k, text})
+	}
+	// afterWord determines if the rune at index i directly follows a word.
+	afterWord := func(i int) bool {
+		return i > 0 && l.isWordRune(rs[i-1])
+	}
+	// colonFollows determines if the next non-space rune from index i is a
+	// colon.
+	colonFollows := func(i int) bool {
+		for ; i < len(rs) && rs[i] == ' '; i++ {
+		}
+		return i < len(rs) && rs[i] == ':'
+	}
+
+	for i := 0; i < len(rs); {
+		rest := string(rs[i:])
+		r := rs[i]
+		switch {
+		case l.lineComment != "" && strings.HasPrefix(rest, l.lineComment) && (l.lineComment != "#" || i == 0 || rs[i-1] == ' '):
+			add(kindComment, rest)
+			i = len(rs)
+
+		case l.blockComments && strings.HasPrefix(rest, "/*"):
+			end := len(rs)
+			if idx := strings.Index(rest[2:], "*/"); idx >= 0 {
+				end = i + 2 + len([]rune(rest[2:2+idx])) + 2
+			}
+			add(kindComment, string(rs[i:end]))
+			i = end
+
+		case strings.ContainsRune(l.quotes, r):
+			end := i + 1
+			for ; end < len(rs) && rs[end] != r; end++ {
+				if rs[end] == '\\' && r != '`' {
+					end++
+				}
+			}
+			if end < len(rs) {
+				end++ // The closing quote.
+			} else {
+				end = len(rs)
+			}
+			k := kindString
+			if l.keys && colonFollows(end) {
+				k = kindKey
+			}
+			add(k, string(rs[i:end]))
+			i = end
+
+		case l.variables && r == '$' && i+1 < len(rs) && (rs[i+1] == '{' || l.isWordRune(rs[i+1])):
+			end := i + 1
+			if rs[end] == '{' {
+				for ; end < len(rs) && rs[end] != '}'; end++ {
+				}
+				if end < len(rs) {
+					end++
+				}
+			} else {
+				for ; end < len(rs) && l.isWordRune(rs[end]); end++ {
+				}
+			}
+			add(kindKey, string(rs[i:end]))
+			i = end
+
+		case unicode.IsDigit(r) && !afterWord(i):
+			end := i
+			for ; end < len(rs) && (l.isWordRune(rs[end]) || rs[end] == '.'); end++ {
+			}
+			add(kindNumber, string(rs[i:end]))
+			i = end
+
+		case l.isWordRune(r) && !afterWord(i):
+			end := i
+			for ; end < len(rs) && l.isWordRune(rs[end]); end++ {
+			}
+			w := string(rs[i:end])
+			switch {
+			case l.keys && colonFollows(end):
+				add(kindKey, w)
+			case l.keywords[w]:
+				add(kindKeyword, w)
+			default:
+				add(kindPlain, w)
+			}
+			i = end
+
+		default:
+			add(kindPlain, string(r))
+			i++
+		}
+	}
+	return res
+}
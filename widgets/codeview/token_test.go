@@ -0,0 +1,153 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codeview
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		desc string
+		lang string
+		line string
+		want []token
+	}{
+		{
+			desc: "plain text without a language",
+			line: `func main() {} // "x"`,
+			want: []token{
+				{kindPlain, `func main() {} // "x"`},
+			},
+		},
+		{
+			desc: "empty line",
+			lang: "go",
+		},
+		{
+			desc: "go keywords and identifiers",
+			lang: "go",
+			line: "func main() {",
+			want: []token{
+				{kindKeyword, "func"},
+				{kindPlain, " main() {"},
+			},
+		},
+		{
+			desc: "doesn't highlight keywords inside identifiers",
+			lang: "go",
+			line: "format",
+			want: []token{
+				{kindPlain, "format"},
+			},
+		},
+		{
+			desc: "go strings, numbers and comments",
+			lang: "go",
+			line: `x := "a\"b" + 'c' + 42 // done`,
+			want: []token{
+				{kindPlain, "x := "},
+				{kindString, `"a\"b"`},
+				{kindPlain, " + "},
+				{kindString, "'c'"},
+				{kindPlain, " + "},
+				{kindNumber, "42"},
+				{kindPlain, " "},
+				{kindComment, "// done"},
+			},
+		},
+		{
+			desc: "go raw strings and block comments",
+			lang: "go",
+			line: "/* a */ x := `b`",
+			want: []token{
+				{kindComment, "/* a */"},
+				{kindPlain, " x := "},
+				{kindString, "`b`"},
+			},
+		},
+		{
+			desc: "unterminated string and block comment",
+			lang: "go",
+			line: `x /* a "b`,
+			want: []token{
+				{kindPlain, "x "},
+				{kindComment, `/* a "b`},
+			},
+		},
+		{
+			desc: "json keys and values",
+			lang: "json",
+			line: `{"name": "x", "ok" : true, "n": 1.5}`,
+			want: []token{
+				{kindPlain, "{"},
+				{kindKey, `"name"`},
+				{kindPlain, ": "},
+				{kindString, `"x"`},
+				{kindPlain, ", "},
+				{kindKey, `"ok"`},
+				{kindPlain, " : "},
+				{kindKeyword, "true"},
+				{kindPlain, ", "},
+				{kindKey, `"n"`},
+				{kindPlain, ": "},
+				{kindNumber, "1.5"},
+				{kindPlain, "}"},
+			},
+		},
+		{
+			desc: "yaml keys, lists and comments",
+			lang: "yaml",
+			line: "- max-size: 10 # bytes",
+			want: []token{
+				{kindPlain, "- "},
+				{kindKey, "max-size"},
+				{kindPlain, ": "},
+				{kindNumber, "10"},
+				{kindPlain, " "},
+				{kindComment, "# bytes"},
+			},
+		},
+		{
+			desc: "shell variables and comments",
+			lang: "shell",
+			line: `if [ "$a" ]; then echo ${HOME} $#; fi # x`,
+			want: []token{
+				{kindKeyword, "if"},
+				{kindPlain, " [ "},
+				{kindString, `"$a"`},
+				{kindPlain, " ]; "},
+				{kindKeyword, "then"},
+				{kindPlain, " echo "},
+				{kindKey, "${HOME}"},
+				{kindPlain, " $#; "},
+				{kindKeyword, "fi"},
+				{kindPlain, " "},
+				{kindComment, "# x"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tokenize(languages[tc.lang], tc.line)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("tokenize => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package collapsible implements a widget that displays another widget under
+// a title bar and allows the user to collapse it to only the title bar.
+package collapsible
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// The symbols displayed before the title.
+const (
+	expandedSymbol  = '▼'
+	collapsedSymbol = '▶'
+)
+
+// titleHeight is the height of the title bar in cells.
+const titleHeight = 1
+
+// Collapsible displays a title bar and under it the wrapped widget when
+// expanded. When collapsed, only the title bar is displayed and the
+// Collapsible asks its container for a canvas of only the height of the title
+// bar.
+//
+// Clicking on the title bar or pressing the ToggleKey when focused expands or
+// collapses the Collapsible. Other keyboard events are forwarded to the
+// wrapped widget and mouse events to the widget under the cursor if the
+// widget is expanded and wants them.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Collapsible struct {
+	// title is displayed in the title bar.
+	title string
+	// widget is the wrapped widget.
+	widget widgetapi.Widget
+	// expanded indicates if the widget is displayed.
+	expanded bool
+
+	// lastContent is the area of the widget during the last call to Draw.
+	lastContent image.Rectangle
+
+	// mu protects the Collapsible.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Collapsible with the title that wraps the widget.
+func New(title string, w widgetapi.Widget, opts ...Option) (*Collapsible, error) {
+	if w == nil {
+		return nil, errors.New("the wrapped widget cannot be nil")
+	}
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	return &Collapsible{
+		title:    title,
+		widget:   w,
+		expanded: o.expanded,
+		opts:     o,
+	}, nil
+}
+
+// Expanded returns true if the widget is displayed.
+func (c *Collapsible) Expanded() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.expanded
+}
+
+// SetExpanded expands or collapses the Collapsible.
+// The new state is displayed on the next redraw.
+func (c *Collapsible) SetExpanded(expanded bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.expanded = expanded
+}
+
+// drawTitle draws the title bar into the area.
+// Caller must hold c.mu.
+func (c *Collapsible) drawTitle(cvs *canvas.Canvas, ar image.Rectangle, focused bool) error {
+	cOpts := c.opts.titleCellOpts
+	if focused {
+		cOpts = c.opts.focusedTitleCellOpts
+	}
+	if err := cvs.SetAreaCells(ar, ' ', cOpts...); err != nil {
+		return err
+	}
+
+	symbol := collapsedSymbol
+	if c.expanded {
+		symbol = expandedSymbol
+	}
+	return draw.Text(cvs, fmt.Sprintf("%c %s", symbol, c.title), ar.Min,
+		draw.TextMaxX(ar.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(cOpts...),
+	)
+}
+
+// drawContent draws the widget into its area.
+// Caller must hold c.mu.
+func (c *Collapsible) drawContent(cvs *canvas.Canvas, ar image.Rectangle, meta *widgetapi.Meta) error {
+	wCvs, err := canvas.New(ar)
+	if err != nil {
+		return err
+	}
+
+	needSize := image.Point{1, 1}
+	if min := c.widget.Options().MinimumSize; min.X > 0 && min.Y > 0 {
+		needSize = min
+	}
+	if ar.Dx() < needSize.X || ar.Dy() < needSize.Y {
+		if err := draw.ResizeNeeded(wCvs); err != nil {
+			return err
+		}
+		return wCvs.CopyTo(cvs)
+	}
+
+	if err := c.widget.Draw(wCvs, meta); err != nil {
+		return err
+	}
+	return wCvs.CopyTo(cvs)
+}
+
+// Draw draws the Collapsible widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (c *Collapsible) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ar := cvs.Area()
+	titleAr := image.Rect(ar.Min.X, ar.Min.Y, ar.Max.X, ar.Min.Y+titleHeight)
+	if err := c.drawTitle(cvs, titleAr, meta.Focused); err != nil {
+		return err
+	}
+
+	c.lastContent = image.ZR
+	if !c.expanded || ar.Dy() <= titleHeight {
+		return nil
+	}
+	c.lastContent = image.Rect(ar.Min.X, titleAr.Max.Y, ar.Max.X, ar.Max.Y)
+	if err := c.drawContent(cvs, c.lastContent, meta); err != nil {
+		return fmt.Errorf("failed to draw the wrapped widget: %v", err)
+	}
+	return nil
+}
+
+// Keyboard processes keyboard events.
+// Implements widgetapi.Widget.Keyboard.
+func (c *Collapsible) Keyboard(k *terminalapi.Keyboard) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if k.Key == c.opts.toggleKey {
+		c.expanded = !c.expanded
+		return nil
+	}
+	if c.expanded && c.widget.Options().WantKeyboard != widgetapi.KeyScopeNone {
+		return c.widget.Keyboard(k)
+	}
+	return nil
+}
+
+// Mouse processes mouse events.
+// Implements widgetapi.Widget.Mouse.
+func (c *Collapsible) Mouse(m *terminalapi.Mouse) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case m.Position.Y < titleHeight:
+		if m.Button == mouse.ButtonLeft {
+			c.expanded = !c.expanded
+		}
+		return nil
+
+	case c.expanded && m.Position.In(c.lastContent):
+		if c.widget.Options().WantMouse == widgetapi.MouseScopeNone {
+			return nil
+		}
+		return c.widget.Mouse(&terminalapi.Mouse{
+			Position: m.Position.Sub(c.lastContent.Min),
+			Button:   m.Button,
+		})
+	}
+	return nil
+}
+
+// Options of the widget.
+// Implements widgetapi.Widget.Options.
+// When collapsed, both the minimum and the maximum height are the height of
+// the title bar. When expanded, the minimum size reflects the minimum size of
+// the wrapped widget.
+func (c *Collapsible) Options() widgetapi.Options {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	opts := widgetapi.Options{
+		MinimumSize:  image.Point{1, titleHeight},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if !c.expanded {
+		opts.MaximumSize = image.Point{0, titleHeight}
+		return opts
+	}
+
+	wOpts := c.widget.Options()
+	if wOpts.MinimumSize.X > opts.MinimumSize.X {
+		opts.MinimumSize.X = wOpts.MinimumSize.X
+	}
+	if wOpts.MinimumSize.Y > 0 {
+		opts.MinimumSize.Y += wOpts.MinimumSize.Y
+	} else {
+		opts.MinimumSize.Y++
+	}
+	opts.MaximumSize = wOpts.MaximumSize
+	if opts.MaximumSize.Y > 0 {
+		opts.MaximumSize.Y += titleHeight
+	}
+	return opts
+}
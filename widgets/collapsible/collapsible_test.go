@@ -0,0 +1,357 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collapsible
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mirrorOpts are the options of the wrapped widget.
+var mirrorOpts = widgetapi.Options{
+	MinimumSize:  image.Point{2, 2},
+	WantKeyboard: widgetapi.KeyScopeFocused,
+	WantMouse:    widgetapi.MouseScopeWidget,
+}
+
+// mustDrawTitle draws the title bar with the text on the first row of the
+// canvas.
+func mustDrawTitle(c *canvas.Canvas, text string, cOpts ...cell.Option) {
+	ar := image.Rect(0, 0, c.Area().Dx(), 1)
+	testcanvas.MustSetAreaCells(c, ar, ' ', cOpts...)
+	testdraw.MustText(c, text, ar.Min, draw.TextCellOpts(cOpts...))
+}
+
+// titleOpts and focusedOpts are the default cell options of the title bar.
+var (
+	titleOpts   = newOptions().titleCellOpts
+	focusedOpts = newOptions().focusedTitleCellOpts
+)
+
+func TestCollapsible(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		meta   *widgetapi.Meta
+		canvas image.Rectangle
+		// events are delivered to the Collapsible after the first draw.
+		events []terminalapi.Event
+		want   func(size image.Point) *faketerm.Terminal
+	}{
+		{
+			desc:   "draws only the title bar when collapsed",
+			canvas: image.Rect(0, 0, 10, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, "▶ title", titleOpts...)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "highlights the title bar when focused",
+			meta:   &widgetapi.Meta{Focused: true},
+			canvas: image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, "▶ title", focusedOpts...)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims a long title",
+			canvas: image.Rect(0, 0, 5, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, "▶ ti…", titleOpts...)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws the widget when initially expanded",
+			opts:   []Option{Expanded()},
+			canvas: image.Rect(0, 0, 10, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, "▼ title", titleOpts...)
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 10, 4)), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:   "toggle key expands the widget",
+			canvas: image.Rect(0, 0, 10, 4),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, "▼ title", titleOpts...)
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 10, 4)), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:   "custom toggle key collapses the widget",
+			opts:   []Option{Expanded(), ToggleKey(' ')},
+			canvas: image.Rect(0, 0, 10, 4),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: ' '},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, "▶ title", titleOpts...)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "clicking on the title bar toggles the widget",
+			canvas: image.Rect(0, 0, 10, 4),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonRelease},
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, "▶ title", titleOpts...)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "forwards events to the expanded widget",
+			opts:   []Option{Expanded()},
+			canvas: image.Rect(0, 0, 20, 7),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Mouse{Position: image.Point{1, 2}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, "▼ title", titleOpts...)
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 20, 7)), &widgetapi.Meta{}, mirrorOpts,
+					&terminalapi.Keyboard{Key: 'a'},
+					&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+		},
+		{
+			desc:   "doesn't forward events to the collapsed widget",
+			canvas: image.Rect(0, 0, 20, 7),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Mouse{Position: image.Point{1, 2}, Button: mouse.ButtonLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, "▼ title", titleOpts...)
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 20, 7)), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:   "draws resize needed when the widget doesn't fit",
+			opts:   []Option{Expanded()},
+			canvas: image.Rect(0, 0, 10, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTitle(c, "▼ title", titleOpts...)
+				testcanvas.MustApply(c, ft)
+
+				wc := testcanvas.MustNew(image.Rect(0, 1, 10, 2))
+				testdraw.MustResizeNeeded(wc)
+				testcanvas.MustApply(wc, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			meta := tc.meta
+			if meta == nil {
+				meta = &widgetapi.Meta{}
+			}
+			col, err := New("title", fakewidget.New(mirrorOpts), tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := col.Draw(c, meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					if err := col.Keyboard(e); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+				case *terminalapi.Mouse:
+					if err := col.Mouse(e); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				// The layout changes when the widget is toggled.
+				if err := col.Draw(c, meta); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := col.Draw(c, meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("title", nil); err == nil {
+		t.Errorf("New => got nil err for a nil widget, wanted one")
+	}
+}
+
+func TestSetExpanded(t *testing.T) {
+	col, err := New("title", fakewidget.New(mirrorOpts))
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if col.Expanded() {
+		t.Errorf("Expanded => got true for a new Collapsible, want false")
+	}
+	col.SetExpanded(true)
+	if !col.Expanded() {
+		t.Errorf("Expanded => got false after SetExpanded(true), want true")
+	}
+	col.SetExpanded(false)
+	if col.Expanded() {
+		t.Errorf("Expanded => got true after SetExpanded(false), want false")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc     string
+		wOpts    widgetapi.Options
+		expanded bool
+		want     widgetapi.Options
+	}{
+		{
+			desc:  "collapsed occupies only the title bar",
+			wOpts: mirrorOpts,
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 1},
+				MaximumSize:  image.Point{0, 1},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc:     "expanded reflects the minimum size of the widget",
+			wOpts:    mirrorOpts,
+			expanded: true,
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{2, 3},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc: "expanded reflects the maximum size of the widget",
+			wOpts: widgetapi.Options{
+				MaximumSize: image.Point{5, 4},
+			},
+			expanded: true,
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 2},
+				MaximumSize:  image.Point{5, 5},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			col, err := New("title", fakewidget.New(tc.wOpts))
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			col.SetExpanded(tc.expanded)
+			if diff := pretty.Compare(tc.want, col.Options()); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collapsible
+
+// options.go contains configurable options for Collapsible.
+
+import (
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	expanded             bool
+	toggleKey            keyboard.Key
+	titleCellOpts        []cell.Option
+	focusedTitleCellOpts []cell.Option
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		toggleKey: DefaultToggleKey,
+		titleCellOpts: []cell.Option{
+			cell.BgColor(cell.ColorNumber(DefaultTitleColorNumber)),
+		},
+		focusedTitleCellOpts: []cell.Option{
+			cell.FgColor(cell.ColorBlack),
+			cell.BgColor(cell.ColorNumber(DefaultFocusedTitleColorNumber)),
+		},
+	}
+}
+
+// Expanded makes the Collapsible initially display its widget.
+// By default the Collapsible starts collapsed.
+func Expanded() Option {
+	return option(func(opts *options) {
+		opts.expanded = true
+	})
+}
+
+// DefaultToggleKey is the default value for the ToggleKey option.
+const DefaultToggleKey = keyboard.KeyEnter
+
+// ToggleKey sets the key that expands or collapses the Collapsible when it is
+// focused. All the other keys are forwarded to the widget if it is expanded.
+// Defaults to DefaultToggleKey.
+func ToggleKey(k keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.toggleKey = k
+	})
+}
+
+// DefaultTitleColorNumber is the default color number for the background of
+// the title bar.
+const DefaultTitleColorNumber = 238
+
+// TitleCellOpts sets cell options on the cells of the title bar when the
+// Collapsible isn't focused.
+func TitleCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.titleCellOpts = cOpts
+	})
+}
+
+// DefaultFocusedTitleColorNumber is the default color number for the
+// background of the title bar when the Collapsible is focused.
+const DefaultFocusedTitleColorNumber = 117
+
+// FocusedTitleCellOpts sets cell options on the cells of the title bar when
+// the Collapsible is focused.
+func FocusedTitleCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.focusedTitleCellOpts = cOpts
+	})
+}
@@ -0,0 +1,414 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dialog contains a widget that displays a modal dialog with a message
+// and buttons over another widget.
+package dialog
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/alignfor"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/private/wrap"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// Button is a button displayed at the bottom of the dialog.
+type Button struct {
+	// Label is the text on the button.
+	Label string
+	// IsPrimary indicates the button that performs the main action of the
+	// dialog, it is displayed with a distinct color.
+	IsPrimary bool
+}
+
+// The layout of the dialog box.
+const (
+	// borderWidth is the number of cells the border occupies on each side.
+	borderWidth = 1
+	// padding is the number of empty cells between the border and the
+	// message on the left and the right.
+	padding = 1
+	// buttonPadding is the number of cells on each side of the button label.
+	buttonPadding = 1
+	// buttonGap is the number of cells between two buttons.
+	buttonGap = 2
+)
+
+// Dialog displays a centered box with a border, a message and buttons over
+// the wrapped widget.
+//
+// The dialog is hidden until Show is called. While it is visible, the dialog
+// receives all keyboard events regardless of the focused container and
+// neither keyboard nor mouse events reach the wrapped widget. The Tab and
+// the right arrow keys focus the next button, Shift+Tab and the left arrow
+// keys the previous one. The Enter key or a mouse click activates the button
+// and hides the dialog.
+//
+// Widgets in other containers still receive the keyboard events they
+// registered for with widgetapi.KeyScopeGlobal, wrap the widget that covers
+// the area the dialog should be centered on.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Dialog struct {
+	// widget is the wrapped widget, can be nil.
+	widget widgetapi.Widget
+
+	// result receives the index of the activated button, nil when the dialog
+	// is hidden.
+	result chan int
+	// focused is the index of the focused button.
+	focused int
+	// buttonAreas are the areas of the buttons during the last call to Draw.
+	buttonAreas []image.Rectangle
+
+	// mu protects the Dialog.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new hidden Dialog that is displayed over the wrapped widget.
+// The widget can be nil, the area around the dialog is empty in that case.
+func New(w widgetapi.Widget, opts ...Option) (*Dialog, error) {
+	o := newOptions(opts...)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &Dialog{
+		widget: w,
+		opts:   o,
+	}, nil
+}
+
+// Show displays the dialog with its first button focused. Returns a channel
+// that receives the index of the button the user activates. The channel is
+// closed after the dialog is hidden, without receiving a value if the dialog
+// was hidden by calling Hide or Show again.
+func Show(d *Dialog) chan int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.hide()
+	d.result = make(chan int, 1)
+	d.focused = 0
+	return d.result
+}
+
+// Hide hides the dialog without activating any button.
+func (d *Dialog) Hide() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.hide()
+}
+
+// hide hides the dialog and closes the result channel.
+// The caller must hold d.mu.
+func (d *Dialog) hide() {
+	if d.result == nil {
+		return
+	}
+	close(d.result)
+	d.result = nil
+	d.buttonAreas = nil
+}
+
+// Visible returns true if the dialog is displayed.
+func (d *Dialog) Visible() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.result != nil
+}
+
+// activate sends the index of the focused button and hides the dialog.
+// The caller must hold d.mu.
+func (d *Dialog) activate() {
+	d.result <- d.focused
+	d.hide()
+}
+
+// buttonsWidth returns the number of cells the row of buttons occupies.
+func buttonsWidth(buttons []Button) int {
+	w := (len(buttons) - 1) * buttonGap
+	for _, b := range buttons {
+		w += runewidth.StringWidth(b.Label) + 2*buttonPadding
+	}
+	return w
+}
+
+// messageWidth returns the width of the longest line of the message.
+func messageWidth(msg string) int {
+	var w int
+	for _, l := range strings.Split(msg, "\n") {
+		if lw := runewidth.StringWidth(l); lw > w {
+			w = lw
+		}
+	}
+	return w
+}
+
+// errTooSmall indicates that the dialog doesn't fit the canvas.
+var errTooSmall = errors.New("the dialog doesn't fit the canvas")
+
+// layout returns the area of the dialog box and the wrapped lines of the
+// message. Returns errTooSmall if the dialog doesn't fit the area.
+func layout(ar image.Rectangle, opts *options) (image.Rectangle, [][]*buffer.Cell, error) {
+	frame := 2 * (borderWidth + padding)
+	maxInner := ar.Dx() - frame
+	bw := buttonsWidth(opts.buttons)
+	if maxInner < bw {
+		return image.ZR, nil, errTooSmall
+	}
+
+	inner := bw
+	if mw := messageWidth(opts.message); mw > inner {
+		inner = mw
+	}
+	if inner > maxInner {
+		inner = maxInner
+	}
+
+	var lines [][]*buffer.Cell
+	height := 1 // The buttons.
+	if opts.message != "" {
+		var err error
+		lines, err = wrap.Cells(buffer.NewCells(opts.message), inner, wrap.AtWords)
+		if err != nil {
+			return image.ZR, nil, err
+		}
+		height += len(lines) + 1 // An empty line above the buttons.
+	}
+	height += 2 * borderWidth
+	if height > ar.Dy() {
+		return image.ZR, nil, errTooSmall
+	}
+
+	box, err := alignfor.Rectangle(ar, image.Rect(ar.Min.X, ar.Min.Y, ar.Min.X+inner+frame, ar.Min.Y+height), align.HorizontalCenter, align.VerticalMiddle)
+	if err != nil {
+		return image.ZR, nil, err
+	}
+	return box, lines, nil
+}
+
+// drawContent draws the wrapped widget onto the canvas.
+// Caller must hold d.mu.
+func (d *Dialog) drawContent(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	ar := cvs.Area()
+	wCvs, err := canvas.New(ar)
+	if err != nil {
+		return err
+	}
+	if min := d.widget.Options().MinimumSize; ar.Dx() < min.X || ar.Dy() < min.Y {
+		if err := draw.ResizeNeeded(wCvs); err != nil {
+			return err
+		}
+		return wCvs.CopyTo(cvs)
+	}
+	if err := d.widget.Draw(wCvs, meta); err != nil {
+		return err
+	}
+	return wCvs.CopyTo(cvs)
+}
+
+// clearBox resets the cells of the box, including the second half of any
+// full-width rune drawn by the wrapped widget just left of the box.
+func clearBox(cvs *canvas.Canvas, box image.Rectangle) error {
+	cOpts := []cell.Option{cell.FgColor(cell.ColorDefault), cell.BgColor(cell.ColorDefault)}
+	if box.Min.X > cvs.Area().Min.X {
+		for y := box.Min.Y; y < box.Max.Y; y++ {
+			p := image.Point{box.Min.X - 1, y}
+			c, err := cvs.Cell(p)
+			if err != nil {
+				return err
+			}
+			if runewidth.RuneWidth(c.Rune) > 1 {
+				if _, err := cvs.SetCell(p, ' '); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return cvs.SetAreaCells(box, ' ', cOpts...)
+}
+
+// drawDialog draws the dialog box onto the canvas.
+// Caller must hold d.mu.
+func (d *Dialog) drawDialog(cvs *canvas.Canvas) error {
+	box, lines, err := layout(cvs.Area(), d.opts)
+	if err != nil {
+		return err
+	}
+	if err := clearBox(cvs, box); err != nil {
+		return err
+	}
+
+	bOpts := []cell.Option{cell.FgColor(d.opts.borderColor)}
+	if err := draw.Border(cvs, box,
+		draw.BorderCellOpts(bOpts...),
+		draw.BorderTitle(d.opts.title, draw.OverrunModeThreeDot, bOpts...),
+		draw.BorderTitleAlign(align.HorizontalCenter),
+	); err != nil {
+		return err
+	}
+
+	inner := image.Rect(
+		box.Min.X+borderWidth+padding, box.Min.Y+borderWidth,
+		box.Max.X-borderWidth-padding, box.Max.Y-borderWidth,
+	)
+	for i, l := range lines {
+		x := inner.Min.X
+		for _, c := range l {
+			cells, err := cvs.SetCell(image.Point{x, inner.Min.Y + i}, c.Rune, c.Opts)
+			if err != nil {
+				return err
+			}
+			x += cells
+		}
+	}
+
+	y := inner.Max.Y - 1
+	x := inner.Min.X + (inner.Dx()-buttonsWidth(d.opts.buttons))/2
+	d.buttonAreas = nil
+	for i, b := range d.opts.buttons {
+		w := runewidth.StringWidth(b.Label) + 2*buttonPadding
+		bAr := image.Rect(x, y, x+w, y+1)
+		bg := d.opts.buttonColor
+		if b.IsPrimary {
+			bg = d.opts.primaryButtonColor
+		}
+		cOpts := []cell.Option{cell.BgColor(bg)}
+		if i == d.focused {
+			cOpts = []cell.Option{cell.FgColor(cell.ColorBlack), cell.BgColor(d.opts.focusedButtonColor)}
+		}
+		if err := cvs.SetAreaCells(bAr, ' ', cOpts...); err != nil {
+			return err
+		}
+		if err := draw.Text(cvs, b.Label, image.Point{x + buttonPadding, y}, draw.TextCellOpts(cOpts...)); err != nil {
+			return err
+		}
+		d.buttonAreas = append(d.buttonAreas, bAr)
+		x += w + buttonGap
+	}
+	return nil
+}
+
+// Draw draws the wrapped widget and the dialog over it if it is visible.
+// Implements widgetapi.Widget.Draw.
+func (d *Dialog) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.widget != nil {
+		if err := d.drawContent(cvs, meta); err != nil {
+			return fmt.Errorf("failed to draw the wrapped widget: %v", err)
+		}
+	}
+	if d.result == nil {
+		return nil
+	}
+
+	switch err := d.drawDialog(cvs); {
+	case err == errTooSmall:
+		return draw.ResizeNeeded(cvs)
+	case err != nil:
+		return err
+	}
+	return nil
+}
+
+// Keyboard focuses and activates the buttons while the dialog is visible,
+// otherwise forwards the event to the wrapped widget.
+// Implements widgetapi.Widget.Keyboard.
+func (d *Dialog) Keyboard(k *terminalapi.Keyboard) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.result == nil {
+		if d.widget != nil && d.widget.Options().WantKeyboard != widgetapi.KeyScopeNone {
+			return d.widget.Keyboard(k)
+		}
+		return nil
+	}
+
+	n := len(d.opts.buttons)
+	switch k.Key {
+	case keyboard.KeyTab, keyboard.KeyArrowRight:
+		d.focused = (d.focused + 1) % n
+	case keyboard.KeyBacktab, keyboard.KeyArrowLeft:
+		d.focused = (d.focused + n - 1) % n
+	case keyboard.KeyEnter:
+		d.activate()
+	}
+	return nil
+}
+
+// Mouse activates the clicked button while the dialog is visible, otherwise
+// forwards the event to the wrapped widget.
+// Implements widgetapi.Widget.Mouse.
+func (d *Dialog) Mouse(m *terminalapi.Mouse) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.result == nil {
+		if d.widget != nil && d.widget.Options().WantMouse != widgetapi.MouseScopeNone {
+			return d.widget.Mouse(m)
+		}
+		return nil
+	}
+
+	if m.Button != mouse.ButtonLeft {
+		return nil
+	}
+	for i, ar := range d.buttonAreas {
+		if m.Position.In(ar) {
+			d.focused = i
+			d.activate()
+			return nil
+		}
+	}
+	return nil
+}
+
+// Options of the widget.
+// Implements widgetapi.Widget.Options.
+// Reflects the options of the wrapped widget, while the dialog is visible it
+// also requests all keyboard events and the mouse events on its canvas.
+func (d *Dialog) Options() widgetapi.Options {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var opts widgetapi.Options
+	if d.widget != nil {
+		opts = d.widget.Options()
+	}
+	if d.result != nil {
+		opts.WantKeyboard = widgetapi.KeyScopeGlobal
+		opts.WantMouse = widgetapi.MouseScopeWidget
+	}
+	return opts
+}
@@ -0,0 +1,381 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialog
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mirrorOpts are the options of the wrapped widget.
+var mirrorOpts = widgetapi.Options{
+	MinimumSize:  image.Point{2, 2},
+	WantKeyboard: widgetapi.KeyScopeFocused,
+	WantMouse:    widgetapi.MouseScopeWidget,
+}
+
+// yesNo are buttons used in the tests.
+var yesNo = []Button{{Label: "Yes", IsPrimary: true}, {Label: "No"}}
+
+// The cell options of the buttons.
+var (
+	buttonOpts  = []cell.Option{cell.BgColor(cell.ColorNumber(DefaultButtonColorNumber))}
+	primaryOpts = []cell.Option{cell.BgColor(DefaultPrimaryButtonColor)}
+	focusedOpts = []cell.Option{cell.FgColor(cell.ColorBlack), cell.BgColor(cell.ColorNumber(DefaultFocusedButtonColorNumber))}
+)
+
+// mustButton draws a button with the label starting at the point.
+func mustButton(c *canvas.Canvas, label string, start image.Point, cOpts []cell.Option) {
+	testcanvas.MustSetAreaCells(c, image.Rect(start.X, start.Y, start.X+len(label)+2, start.Y+1), ' ', cOpts...)
+	testdraw.MustText(c, label, image.Point{start.X + 1, start.Y}, draw.TextCellOpts(cOpts...))
+}
+
+// mustYesNo draws a dialog with the "Save?" message and the yesNo buttons on
+// a 20x7 canvas.
+func mustYesNo(c *canvas.Canvas, yesOpts, noOpts []cell.Option) {
+	box := image.Rect(2, 1, 17, 6)
+	testcanvas.MustSetAreaCells(c, box, ' ', cell.FgColor(cell.ColorDefault), cell.BgColor(cell.ColorDefault))
+	testdraw.MustBorder(c, box,
+		draw.BorderCellOpts(cell.FgColor(cell.ColorDefault)),
+		draw.BorderTitle("", draw.OverrunModeThreeDot, cell.FgColor(cell.ColorDefault)),
+		draw.BorderTitleAlign(align.HorizontalCenter),
+	)
+	testdraw.MustText(c, "Save?", image.Point{4, 2})
+	mustButton(c, "Yes", image.Point{4, 4}, yesOpts)
+	mustButton(c, "No", image.Point{11, 4}, noOpts)
+}
+
+func TestDialog(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		widget     widgetapi.Widget
+		canvas     image.Rectangle
+		show       bool
+		events     []terminalapi.Event
+		want       func(size image.Point) *faketerm.Terminal
+		wantResult []int
+		wantNewErr bool
+	}{
+		{
+			desc:       "fails without buttons",
+			opts:       []Option{Buttons()},
+			canvas:     image.Rect(0, 0, 20, 7),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on an empty button label",
+			opts:       []Option{Buttons(Button{})},
+			canvas:     image.Rect(0, 0, 20, 7),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on an invalid message",
+			opts:       []Option{Message("a\tb")},
+			canvas:     image.Rect(0, 0, 20, 7),
+			wantNewErr: true,
+		},
+		{
+			desc:   "draws only the wrapped widget when hidden",
+			widget: fakewidget.New(mirrorOpts),
+			canvas: image.Rect(0, 0, 20, 7),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:   "draws the dialog centered with the first button focused",
+			opts:   []Option{Message("Save?"), Buttons(yesNo...)},
+			canvas: image.Rect(0, 0, 20, 7),
+			show:   true,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustYesNo(c, focusedOpts, buttonOpts)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws the dialog over the wrapped widget and the title",
+			opts:   []Option{Title("Q"), BorderColor(cell.ColorRed)},
+			widget: fakewidget.New(mirrorOpts),
+			canvas: image.Rect(0, 0, 20, 5),
+			show:   true,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				fakewidget.MustDraw(ft, c, &widgetapi.Meta{}, mirrorOpts)
+
+				box := image.Rect(6, 1, 14, 4)
+				testcanvas.MustSetAreaCells(c, box, ' ', cell.FgColor(cell.ColorDefault), cell.BgColor(cell.ColorDefault))
+				testdraw.MustBorder(c, box,
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+					draw.BorderTitle("Q", draw.OverrunModeThreeDot, cell.FgColor(cell.ColorRed)),
+					draw.BorderTitleAlign(align.HorizontalCenter),
+				)
+				mustButton(c, "OK", image.Point{8, 2}, focusedOpts)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "wraps long messages",
+			opts:   []Option{Message("one two")},
+			canvas: image.Rect(0, 0, 10, 6),
+			show:   true,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				box := image.Rect(0, 0, 10, 6)
+				testcanvas.MustSetAreaCells(c, box, ' ', cell.FgColor(cell.ColorDefault), cell.BgColor(cell.ColorDefault))
+				testdraw.MustBorder(c, box,
+					draw.BorderCellOpts(cell.FgColor(cell.ColorDefault)),
+					draw.BorderTitle("", draw.OverrunModeThreeDot, cell.FgColor(cell.ColorDefault)),
+					draw.BorderTitleAlign(align.HorizontalCenter),
+				)
+				testdraw.MustText(c, "one", image.Point{2, 1})
+				testdraw.MustText(c, "two", image.Point{2, 2})
+				mustButton(c, "OK", image.Point{3, 4}, focusedOpts)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws resize needed when the dialog doesn't fit",
+			opts:   []Option{Buttons(yesNo...)},
+			canvas: image.Rect(0, 0, 10, 3),
+			show:   true,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "keys move the focus and enter activates the button",
+			opts:   []Option{Message("Save?"), Buttons(yesNo...)},
+			widget: fakewidget.New(mirrorOpts),
+			canvas: image.Rect(0, 0, 20, 7),
+			show:   true,
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: keyboard.KeyBacktab},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: 'x'},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				// The events didn't reach the wrapped widget.
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+			wantResult: []int{1},
+		},
+		{
+			desc:   "clicking on a button activates it",
+			opts:   []Option{Message("Save?"), Buttons(yesNo...)},
+			canvas: image.Rect(0, 0, 20, 7),
+			show:   true,
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{3, 4}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{12, 4}, Button: mouse.ButtonRight},
+				&terminalapi.Mouse{Position: image.Point{12, 4}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantResult: []int{1},
+		},
+		{
+			desc:   "forwards events to the wrapped widget when hidden",
+			widget: fakewidget.New(mirrorOpts),
+			canvas: image.Rect(0, 0, 20, 7),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				fakewidget.MustDraw(ft, testcanvas.MustNew(ft.Area()), &widgetapi.Meta{}, mirrorOpts,
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+					&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			d, err := New(tc.widget, tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			var result chan int
+			if tc.show {
+				result = Show(d)
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// The first draw positions the buttons for the mouse events.
+			if err := d.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					err = d.Keyboard(e)
+				case *terminalapi.Mouse:
+					err = d.Mouse(e)
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				if err != nil {
+					t.Fatalf("event => unexpected error: %v", err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := d.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			if tc.wantResult != nil {
+				var gotResult []int
+				for r := range result {
+					gotResult = append(gotResult, r)
+				}
+				if diff := pretty.Compare(tc.wantResult, gotResult); diff != "" {
+					t.Errorf("Show => unexpected result diff (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestShowAndHide(t *testing.T) {
+	d, err := New(nil)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if d.Visible() {
+		t.Errorf("Visible => got true before Show, want false")
+	}
+
+	first := Show(d)
+	second := Show(d)
+	if _, ok := <-first; ok {
+		t.Errorf("Show => the first channel received a value, want it closed")
+	}
+	if !d.Visible() {
+		t.Errorf("Visible => got false after Show, want true")
+	}
+
+	d.Hide()
+	if _, ok := <-second; ok {
+		t.Errorf("Hide => the channel received a value, want it closed")
+	}
+	if d.Visible() {
+		t.Errorf("Visible => got true after Hide, want false")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc   string
+		widget widgetapi.Widget
+		show   bool
+		want   widgetapi.Options
+	}{
+		{
+			desc: "hidden without a wrapped widget",
+		},
+		{
+			desc:   "hidden reflects the wrapped widget",
+			widget: fakewidget.New(mirrorOpts),
+			want:   mirrorOpts,
+		},
+		{
+			desc:   "visible requests all keyboard events",
+			widget: fakewidget.New(mirrorOpts),
+			show:   true,
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{2, 2},
+				WantKeyboard: widgetapi.KeyScopeGlobal,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			d, err := New(tc.widget)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.show {
+				Show(d)
+			}
+			if diff := pretty.Compare(tc.want, d.Options()); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
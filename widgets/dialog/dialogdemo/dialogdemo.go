@@ -0,0 +1,83 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary dialogdemo displays the Dialog widget.
+// Exist when the dialog is confirmed.
+package main
+
+import (
+	"context"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/dialog"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	txt, err := text.New(text.WrapAtWords())
+	if err != nil {
+		panic(err)
+	}
+	if err := txt.Write("Press Q to open the dialog that asks for confirmation before quitting."); err != nil {
+		panic(err)
+	}
+
+	d, err := dialog.New(txt,
+		dialog.Title("Quit"),
+		dialog.Message("Do you want to quit the demo?"),
+		dialog.Buttons(
+			dialog.Button{Label: "Yes", IsPrimary: true},
+			dialog.Button{Label: "No"},
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(d),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if (k.Key == 'q' || k.Key == 'Q') && !d.Visible() {
+			result := dialog.Show(d)
+			go func() {
+				if r, ok := <-result; ok && r == 0 {
+					cancel()
+				}
+			}()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialog
+
+// options.go contains configurable options for Dialog.
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/wrap"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	title              string
+	message            string
+	buttons            []Button
+	borderColor        cell.Color
+	buttonColor        cell.Color
+	primaryButtonColor cell.Color
+	focusedButtonColor cell.Color
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		buttons:            DefaultButtons(),
+		borderColor:        cell.ColorDefault,
+		buttonColor:        cell.ColorNumber(DefaultButtonColorNumber),
+		primaryButtonColor: DefaultPrimaryButtonColor,
+		focusedButtonColor: cell.ColorNumber(DefaultFocusedButtonColorNumber),
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.message != "" {
+		if err := wrap.ValidText(o.message); err != nil {
+			return fmt.Errorf("invalid Message: %v", err)
+		}
+	}
+	if len(o.buttons) == 0 {
+		return errors.New("at least one button must be provided")
+	}
+	for i, b := range o.buttons {
+		if b.Label == "" {
+			return fmt.Errorf("the label of button %d cannot be empty", i)
+		}
+		if err := wrap.ValidText(b.Label); err != nil {
+			return fmt.Errorf("invalid label of button %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// Title sets the title displayed in the border of the dialog.
+func Title(title string) Option {
+	return option(func(opts *options) {
+		opts.title = title
+	})
+}
+
+// Message sets the text displayed in the dialog. The text is wrapped at word
+// boundaries to fit the canvas and may contain newline characters.
+func Message(msg string) Option {
+	return option(func(opts *options) {
+		opts.message = msg
+	})
+}
+
+// DefaultButtons returns the buttons displayed unless the Buttons option is
+// provided, a single primary OK button.
+func DefaultButtons() []Button {
+	return []Button{{Label: "OK", IsPrimary: true}}
+}
+
+// Buttons sets the buttons displayed at the bottom of the dialog, at least one
+// button must be provided. Defaults to DefaultButtons.
+func Buttons(buttons ...Button) Option {
+	return option(func(opts *options) {
+		opts.buttons = buttons
+	})
+}
+
+// BorderColor sets the color of the border and the title.
+// Defaults to cell.ColorDefault.
+func BorderColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.borderColor = c
+	})
+}
+
+// The default background colors of the buttons.
+const (
+	// DefaultButtonColorNumber is an Xterm color number, see
+	// cell.ColorNumber.
+	DefaultButtonColorNumber = 238
+	// DefaultPrimaryButtonColor is the color of the primary buttons.
+	DefaultPrimaryButtonColor = cell.ColorBlue
+	// DefaultFocusedButtonColorNumber is an Xterm color number, see
+	// cell.ColorNumber.
+	DefaultFocusedButtonColorNumber = 117
+)
+
+// ButtonColor sets the background color of the buttons.
+// Defaults to DefaultButtonColorNumber.
+func ButtonColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.buttonColor = c
+	})
+}
+
+// PrimaryButtonColor sets the background color of the primary buttons.
+// Defaults to DefaultPrimaryButtonColor.
+func PrimaryButtonColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.primaryButtonColor = c
+	})
+}
+
+// FocusedButtonColor sets the background color of the focused button.
+// Defaults to DefaultFocusedButtonColorNumber.
+func FocusedButtonColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.focusedButtonColor = c
+	})
+}
@@ -0,0 +1,161 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diffview
+
+// diff.go computes the line differences between two texts.
+
+// op is the operation of a single edit.
+type op int
+
+const (
+	// opEqual marks a line present in both texts.
+	opEqual op = iota
+	// opDelete marks a line only present in the left text.
+	opDelete
+	// opInsert marks a line only present in the right text.
+	opInsert
+)
+
+// edit is a single line of the diff.
+type edit struct {
+	op   op
+	line string
+}
+
+// diff returns the edits that transform the left lines into the right lines.
+// Uses the Longest Common Subsequence of the two texts, lines outside of it
+// are deleted from the left or inserted from the right.
+func diff(left, right []string) []edit {
+	// lcs[i][j] is the length of the LCS of left[i:] and right[j:].
+	lcs := make([][]int, len(left)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(right)+1)
+	}
+	for i := len(left) - 1; i >= 0; i-- {
+		for j := len(right) - 1; j >= 0; j-- {
+			switch {
+			case left[i] == right[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var edits []edit
+	i, j := 0, 0
+	for i < len(left) && j < len(right) {
+		switch {
+		case left[i] == right[j]:
+			edits = append(edits, edit{opEqual, left[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, edit{opDelete, left[i]})
+			i++
+		default:
+			edits = append(edits, edit{opInsert, right[j]})
+			j++
+		}
+	}
+	for ; i < len(left); i++ {
+		edits = append(edits, edit{opDelete, left[i]})
+	}
+	for ; j < len(right); j++ {
+		edits = append(edits, edit{opInsert, right[j]})
+	}
+	return edits
+}
+
+// row is a single row of the side-by-side diff.
+type row struct {
+	// left and right are the lines displayed on each side.
+	left, right string
+	// leftOp and rightOp are the operations of the lines on each side.
+	// Rows where a side has no line have opEqual with an empty line there.
+	leftOp, rightOp op
+	// hidden when non-zero indicates that this row replaces the specified
+	// number of identical lines that were collapsed.
+	hidden int
+}
+
+// rows arranges the edits side-by-side. Deleted lines are paired with the
+// inserted lines that follow them. Runs of identical lines further than
+// maxContext lines from any change are collapsed into a single row.
+func rows(edits []edit, maxContext int) []row {
+	var res []row
+	for i := 0; i < len(edits); {
+		start := i
+		if edits[i].op == opEqual {
+			for i < len(edits) && edits[i].op == opEqual {
+				i++
+			}
+			res = append(res, contextRows(edits[start:i], start == 0, i == len(edits), maxContext)...)
+			continue
+		}
+
+		var deleted, inserted []string
+		for ; i < len(edits) && edits[i].op != opEqual; i++ {
+			if edits[i].op == opDelete {
+				deleted = append(deleted, edits[i].line)
+			} else {
+				inserted = append(inserted, edits[i].line)
+			}
+		}
+		for k := 0; k < len(deleted) || k < len(inserted); k++ {
+			var r row
+			if k < len(deleted) {
+				r.left = deleted[k]
+				r.leftOp = opDelete
+			}
+			if k < len(inserted) {
+				r.right = inserted[k]
+				r.rightOp = opInsert
+			}
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// contextRows returns the rows for a run of identical lines. First and last
+// indicate whether the run is at the start or the end of the diff, those
+// only keep context lines on the side facing a change.
+func contextRows(edits []edit, first, last bool, maxContext int) []row {
+	head, tail := maxContext, maxContext
+	if first {
+		head = 0
+	}
+	if last {
+		tail = 0
+	}
+	if head+tail >= len(edits) {
+		head, tail = len(edits), 0
+	}
+
+	var res []row
+	for _, e := range edits[:head] {
+		res = append(res, row{left: e.line, right: e.line})
+	}
+	if hidden := len(edits) - head - tail; hidden > 0 {
+		res = append(res, row{hidden: hidden})
+	}
+	for _, e := range edits[len(edits)-tail:] {
+		res = append(res, row{left: e.line, right: e.line})
+	}
+	return res
+}
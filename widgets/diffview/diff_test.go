@@ -0,0 +1,231 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diffview
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		desc  string
+		left  []string
+		right []string
+		want  []edit
+	}{
+		{
+			desc: "no lines",
+		},
+		{
+			desc:  "identical lines",
+			left:  []string{"a", "b"},
+			right: []string{"a", "b"},
+			want: []edit{
+				{opEqual, "a"},
+				{opEqual, "b"},
+			},
+		},
+		{
+			desc:  "all lines inserted",
+			right: []string{"a", "b"},
+			want: []edit{
+				{opInsert, "a"},
+				{opInsert, "b"},
+			},
+		},
+		{
+			desc: "all lines deleted",
+			left: []string{"a", "b"},
+			want: []edit{
+				{opDelete, "a"},
+				{opDelete, "b"},
+			},
+		},
+		{
+			desc:  "line changed in the middle",
+			left:  []string{"a", "b", "c"},
+			right: []string{"a", "x", "c"},
+			want: []edit{
+				{opEqual, "a"},
+				{opDelete, "b"},
+				{opInsert, "x"},
+				{opEqual, "c"},
+			},
+		},
+		{
+			desc:  "keeps the longest common subsequence",
+			left:  []string{"a", "b", "c", "d"},
+			right: []string{"b", "d", "e"},
+			want: []edit{
+				{opDelete, "a"},
+				{opEqual, "b"},
+				{opDelete, "c"},
+				{opEqual, "d"},
+				{opInsert, "e"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := diff(tc.left, tc.right)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("diff => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRows(t *testing.T) {
+	tests := []struct {
+		desc       string
+		edits      []edit
+		maxContext int
+		want       []row
+	}{
+		{
+			desc:       "no edits",
+			maxContext: 3,
+		},
+		{
+			desc: "pairs deleted lines with inserted lines",
+			edits: []edit{
+				{opDelete, "a"},
+				{opDelete, "b"},
+				{opInsert, "x"},
+			},
+			maxContext: 3,
+			want: []row{
+				{left: "a", leftOp: opDelete, right: "x", rightOp: opInsert},
+				{left: "b", leftOp: opDelete},
+			},
+		},
+		{
+			desc: "more inserted than deleted lines",
+			edits: []edit{
+				{opDelete, "a"},
+				{opInsert, "x"},
+				{opInsert, "y"},
+			},
+			maxContext: 3,
+			want: []row{
+				{left: "a", leftOp: opDelete, right: "x", rightOp: opInsert},
+				{right: "y", rightOp: opInsert},
+			},
+		},
+		{
+			desc: "collapses all identical lines without changes",
+			edits: []edit{
+				{opEqual, "a"},
+				{opEqual, "b"},
+			},
+			maxContext: 3,
+			want: []row{
+				{hidden: 2},
+			},
+		},
+		{
+			desc: "keeps short context around a change",
+			edits: []edit{
+				{opEqual, "a"},
+				{opDelete, "b"},
+				{opEqual, "c"},
+			},
+			maxContext: 1,
+			want: []row{
+				{left: "a", right: "a"},
+				{left: "b", leftOp: opDelete},
+				{left: "c", right: "c"},
+			},
+		},
+		{
+			desc: "collapses leading and trailing identical lines",
+			edits: []edit{
+				{opEqual, "a"},
+				{opEqual, "b"},
+				{opInsert, "x"},
+				{opEqual, "c"},
+				{opEqual, "d"},
+			},
+			maxContext: 1,
+			want: []row{
+				{hidden: 1},
+				{left: "b", right: "b"},
+				{right: "x", rightOp: opInsert},
+				{left: "c", right: "c"},
+				{hidden: 1},
+			},
+		},
+		{
+			desc: "collapses identical lines between changes",
+			edits: []edit{
+				{opInsert, "x"},
+				{opEqual, "a"},
+				{opEqual, "b"},
+				{opEqual, "c"},
+				{opInsert, "y"},
+			},
+			maxContext: 1,
+			want: []row{
+				{right: "x", rightOp: opInsert},
+				{left: "a", right: "a"},
+				{hidden: 1},
+				{left: "c", right: "c"},
+				{right: "y", rightOp: opInsert},
+			},
+		},
+		{
+			desc: "doesn't collapse identical lines between close changes",
+			edits: []edit{
+				{opInsert, "x"},
+				{opEqual, "a"},
+				{opEqual, "b"},
+				{opInsert, "y"},
+			},
+			maxContext: 1,
+			want: []row{
+				{right: "x", rightOp: opInsert},
+				{left: "a", right: "a"},
+				{left: "b", right: "b"},
+				{right: "y", rightOp: opInsert},
+			},
+		},
+		{
+			desc: "zero context lines",
+			edits: []edit{
+				{opEqual, "a"},
+				{opInsert, "x"},
+				{opEqual, "b"},
+			},
+			maxContext: 0,
+			want: []row{
+				{hidden: 1},
+				{right: "x", rightOp: opInsert},
+				{hidden: 1},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := rows(tc.edits, tc.maxContext)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("rows => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package diffview contains a widget that displays the differences between
+// two texts side-by-side.
+package diffview
+
+import (
+	"fmt"
+	"image"
+	"strings"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/wrap"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// DiffView displays the differences between two texts side-by-side.
+//
+// The left text is displayed on the left side and the right text on the right
+// side. Lines that were deleted from the left text and added in the right text
+// are highlighted, long runs of identical lines are collapsed. The content can
+// be scrolled using the keyboard or the mouse.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type DiffView struct {
+	// rows are the rows of the side-by-side diff.
+	rows []row
+	// offset is the index of the first visible row.
+	offset int
+	// height is the height of the canvas during the last Draw.
+	height int
+
+	// mu protects the DiffView.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new DiffView.
+func New(opts ...Option) (*DiffView, error) {
+	o := newOptions(opts...)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &DiffView{
+		opts: o,
+	}, nil
+}
+
+// SetText computes and displays the differences between the left and the
+// right text. Each text is split into lines at newline characters. The texts
+// cannot contain other control or space characters and replace any texts
+// provided previously. Scrolls back to the start of the diff.
+func (dv *DiffView) SetText(left, right string) error {
+	l, err := splitLines(left)
+	if err != nil {
+		return fmt.Errorf("invalid left text: %v", err)
+	}
+	r, err := splitLines(right)
+	if err != nil {
+		return fmt.Errorf("invalid right text: %v", err)
+	}
+
+	dv.mu.Lock()
+	defer dv.mu.Unlock()
+	dv.rows = rows(diff(l, r), dv.opts.maxContextLines)
+	dv.offset = 0
+	return nil
+}
+
+// splitLines validates the text and splits it into lines.
+func splitLines(text string) ([]string, error) {
+	if text == "" {
+		return nil, nil
+	}
+	if err := wrap.ValidText(text); err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n"), nil
+}
+
+// scroll moves the first visible row by the specified number of rows, the
+// offset is kept within the content.
+// The caller must hold dv.mu.
+func (dv *DiffView) scroll(by int) {
+	dv.offset += by
+	if max := len(dv.rows) - dv.height; dv.offset > max {
+		dv.offset = max
+	}
+	if dv.offset < 0 {
+		dv.offset = 0
+	}
+}
+
+// Draw draws the DiffView widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (dv *DiffView) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	dv.mu.Lock()
+	defer dv.mu.Unlock()
+
+	ar := cvs.Area()
+	dv.height = ar.Dy()
+	dv.scroll(0)
+
+	// The left side, a divider column and the right side.
+	leftWidth := (ar.Dx() - 1) / 2
+	divX := ar.Min.X + leftWidth
+	for i, r := range dv.rows[dv.offset:] {
+		y := ar.Min.Y + i
+		if y >= ar.Max.Y {
+			break
+		}
+
+		if r.hidden > 0 {
+			text := fmt.Sprintf("⋯ %d identical lines", r.hidden)
+			if r.hidden == 1 {
+				text = "⋯ 1 identical line"
+			}
+			if err := dv.drawLine(cvs, text, image.Point{ar.Min.X, y}, ar.Max.X, dv.opts.contextCellOpts); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := cvs.SetCell(image.Point{divX, y}, '│', dv.opts.contextCellOpts...); err != nil {
+			return err
+		}
+		if err := dv.drawSide(cvs, r.left, r.leftOp, image.Point{ar.Min.X, y}, divX); err != nil {
+			return err
+		}
+		if err := dv.drawSide(cvs, r.right, r.rightOp, image.Point{divX + 1, y}, ar.Max.X); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawSide draws one side of a row, the line is prefixed with a marker of the
+// operation.
+func (dv *DiffView) drawSide(cvs *canvas.Canvas, line string, o op, start image.Point, maxX int) error {
+	switch o {
+	case opDelete:
+		return dv.drawLine(cvs, "- "+line, start, maxX, dv.opts.deletedCellOpts)
+	case opInsert:
+		return dv.drawLine(cvs, "+ "+line, start, maxX, dv.opts.addedCellOpts)
+	default:
+		if line == "" {
+			// Either an empty line or no line on this side.
+			return nil
+		}
+		return dv.drawLine(cvs, "  "+line, start, maxX, dv.opts.contextCellOpts)
+	}
+}
+
+// drawLine draws the text starting at the point, trimming it at maxX.
+func (dv *DiffView) drawLine(cvs *canvas.Canvas, text string, start image.Point, maxX int, cOpts []cell.Option) error {
+	if start.X >= maxX {
+		return nil
+	}
+	return draw.Text(cvs, text, start,
+		draw.TextCellOpts(cOpts...),
+		draw.TextMaxX(maxX),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	)
+}
+
+// Keyboard scrolls the diff.
+// Implements widgetapi.Widget.Keyboard.
+func (dv *DiffView) Keyboard(k *terminalapi.Keyboard) error {
+	dv.mu.Lock()
+	defer dv.mu.Unlock()
+
+	switch k.Key {
+	case dv.opts.keyUp:
+		dv.scroll(-1)
+	case dv.opts.keyDown:
+		dv.scroll(1)
+	case dv.opts.keyPgUp:
+		dv.scroll(-dv.height)
+	case dv.opts.keyPgDown:
+		dv.scroll(dv.height)
+	}
+	return nil
+}
+
+// Mouse scrolls the diff.
+// Implements widgetapi.Widget.Mouse.
+func (dv *DiffView) Mouse(m *terminalapi.Mouse) error {
+	dv.mu.Lock()
+	defer dv.mu.Unlock()
+
+	switch m.Button {
+	case dv.opts.mouseUpButton:
+		dv.scroll(-1)
+	case dv.opts.mouseDownButton:
+		dv.scroll(1)
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (dv *DiffView) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// One cell for each side and the divider.
+		MinimumSize:  image.Point{3, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
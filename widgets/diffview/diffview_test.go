@@ -0,0 +1,311 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diffview
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mustDrawDivider draws the divider between the two sides on the rows.
+func mustDrawDivider(c *canvas.Canvas, x int, rows ...int) {
+	for _, y := range rows {
+		testcanvas.MustSetCell(c, image.Point{x, y}, '│')
+	}
+}
+
+func TestDiffView(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		left       string
+		right      string
+		canvas     image.Rectangle
+		events     []terminalapi.Event
+		want       func(size image.Point) *faketerm.Terminal
+		wantNewErr bool
+		wantSetErr bool
+	}{
+		{
+			desc:       "fails on negative MaxContextLines",
+			opts:       []Option{MaxContextLines(-1)},
+			canvas:     image.Rect(0, 0, 11, 3),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on duplicate scroll keys",
+			opts:       []Option{ScrollKeys('a', 'a', 'b', 'c')},
+			canvas:     image.Rect(0, 0, 11, 3),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on duplicate scroll mouse buttons",
+			opts:       []Option{ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonLeft)},
+			canvas:     image.Rect(0, 0, 11, 3),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on text with control characters",
+			left:       "a\tb",
+			canvas:     image.Rect(0, 0, 11, 3),
+			wantSetErr: true,
+		},
+		{
+			desc:   "draws nothing without text",
+			canvas: image.Rect(0, 0, 11, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws changed lines side-by-side",
+			left:   "a\nb\nc\n",
+			right:  "a\nx\nc\ny\n",
+			canvas: image.Rect(0, 0, 11, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawDivider(c, 5, 0, 1, 2, 3)
+				testdraw.MustText(c, "  a", image.Point{0, 0})
+				testdraw.MustText(c, "  a", image.Point{6, 0})
+				testdraw.MustText(c, "- b", image.Point{0, 1}, draw.TextCellOpts(cell.FgColor(DefaultDeletedColor)))
+				testdraw.MustText(c, "+ x", image.Point{6, 1}, draw.TextCellOpts(cell.FgColor(DefaultAddedColor)))
+				testdraw.MustText(c, "  c", image.Point{0, 2})
+				testdraw.MustText(c, "  c", image.Point{6, 2})
+				testdraw.MustText(c, "+ y", image.Point{6, 3}, draw.TextCellOpts(cell.FgColor(DefaultAddedColor)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims long lines",
+			left:   "abcdef",
+			right:  "abcdefgh",
+			canvas: image.Rect(0, 0, 11, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawDivider(c, 5, 0)
+				testdraw.MustText(c, "- ab…", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(DefaultDeletedColor)))
+				testdraw.MustText(c, "+ ab…", image.Point{6, 0}, draw.TextCellOpts(cell.FgColor(DefaultAddedColor)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "collapses identical lines",
+			opts:   []Option{MaxContextLines(1)},
+			left:   "a\nb\nc",
+			right:  "a\nb\nx",
+			canvas: image.Rect(0, 0, 25, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "⋯ 1 identical line", image.Point{0, 0})
+				mustDrawDivider(c, 12, 1, 2)
+				testdraw.MustText(c, "  b", image.Point{0, 1})
+				testdraw.MustText(c, "  b", image.Point{13, 1})
+				testdraw.MustText(c, "- c", image.Point{0, 2}, draw.TextCellOpts(cell.FgColor(DefaultDeletedColor)))
+				testdraw.MustText(c, "+ x", image.Point{13, 2}, draw.TextCellOpts(cell.FgColor(DefaultAddedColor)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom cell options",
+			opts: []Option{
+				AddedCellOpts(cell.FgColor(cell.ColorBlue)),
+				DeletedCellOpts(cell.FgColor(cell.ColorYellow)),
+				ContextCellOpts(cell.FgColor(cell.ColorWhite)),
+			},
+			left:   "a\nb",
+			right:  "a\nx",
+			canvas: image.Rect(0, 0, 11, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{5, 0}, '│', cell.FgColor(cell.ColorWhite))
+				testcanvas.MustSetCell(c, image.Point{5, 1}, '│', cell.FgColor(cell.ColorWhite))
+				testdraw.MustText(c, "  a", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorWhite)))
+				testdraw.MustText(c, "  a", image.Point{6, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorWhite)))
+				testdraw.MustText(c, "- b", image.Point{0, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorYellow)))
+				testdraw.MustText(c, "+ x", image.Point{6, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls down with the keyboard",
+			left:   "a\nb\nc",
+			right:  "x\ny\nz",
+			canvas: image.Rect(0, 0, 11, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawDivider(c, 5, 0, 1)
+				testdraw.MustText(c, "- b", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(DefaultDeletedColor)))
+				testdraw.MustText(c, "+ y", image.Point{6, 0}, draw.TextCellOpts(cell.FgColor(DefaultAddedColor)))
+				testdraw.MustText(c, "- c", image.Point{0, 1}, draw.TextCellOpts(cell.FgColor(DefaultDeletedColor)))
+				testdraw.MustText(c, "+ z", image.Point{6, 1}, draw.TextCellOpts(cell.FgColor(DefaultAddedColor)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "doesn't scroll past the end of the diff",
+			left:   "a\nb\nc",
+			right:  "x\ny\nz",
+			canvas: image.Rect(0, 0, 11, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawDivider(c, 5, 0, 1)
+				testdraw.MustText(c, "- a", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(DefaultDeletedColor)))
+				testdraw.MustText(c, "+ x", image.Point{6, 0}, draw.TextCellOpts(cell.FgColor(DefaultAddedColor)))
+				testdraw.MustText(c, "- b", image.Point{0, 1}, draw.TextCellOpts(cell.FgColor(DefaultDeletedColor)))
+				testdraw.MustText(c, "+ y", image.Point{6, 1}, draw.TextCellOpts(cell.FgColor(DefaultAddedColor)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls with the mouse wheel",
+			left:   "a\nb\nc",
+			right:  "x\ny\nz",
+			canvas: image.Rect(0, 0, 11, 1),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustDrawDivider(c, 5, 0)
+				testdraw.MustText(c, "- b", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(DefaultDeletedColor)))
+				testdraw.MustText(c, "+ y", image.Point{6, 0}, draw.TextCellOpts(cell.FgColor(DefaultAddedColor)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			dv, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			err = dv.SetText(tc.left, tc.right)
+			if (err != nil) != tc.wantSetErr {
+				t.Errorf("SetText => unexpected error: %v, wantSetErr: %v", err, tc.wantSetErr)
+			}
+			if err != nil {
+				return
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// The first draw determines the page size used when scrolling.
+			if err := dv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					if err := dv.Keyboard(e); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+				case *terminalapi.Mouse:
+					if err := dv.Mouse(e); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := dv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	dv, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := dv.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{3, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
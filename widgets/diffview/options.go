@@ -0,0 +1,172 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diffview
+
+// options.go contains configurable options for DiffView.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	maxContextLines int
+	addedCellOpts   []cell.Option
+	deletedCellOpts []cell.Option
+	contextCellOpts []cell.Option
+	mouseUpButton   mouse.Button
+	mouseDownButton mouse.Button
+	keyUp           keyboard.Key
+	keyDown         keyboard.Key
+	keyPgUp         keyboard.Key
+	keyPgDown       keyboard.Key
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		maxContextLines: DefaultMaxContextLines,
+		addedCellOpts:   []cell.Option{cell.FgColor(DefaultAddedColor)},
+		deletedCellOpts: []cell.Option{cell.FgColor(DefaultDeletedColor)},
+		mouseUpButton:   DefaultScrollMouseButtonUp,
+		mouseDownButton: DefaultScrollMouseButtonDown,
+		keyUp:           DefaultScrollKeyUp,
+		keyDown:         DefaultScrollKeyDown,
+		keyPgUp:         DefaultScrollKeyPageUp,
+		keyPgDown:       DefaultScrollKeyPageDown,
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.maxContextLines < 0 {
+		return fmt.Errorf("invalid MaxContextLines(%d), must be zero or a positive number", o.maxContextLines)
+	}
+	keys := map[keyboard.Key]bool{
+		o.keyUp:     true,
+		o.keyDown:   true,
+		o.keyPgUp:   true,
+		o.keyPgDown: true,
+	}
+	if len(keys) != 4 {
+		return fmt.Errorf("invalid ScrollKeys(up:%v, down:%v, pageUp:%v, pageDown:%v), the keys must be unique", o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown)
+	}
+	if o.mouseUpButton == o.mouseDownButton {
+		return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the buttons must be unique", o.mouseUpButton, o.mouseDownButton)
+	}
+	return nil
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// DefaultMaxContextLines is the default value for the MaxContextLines option.
+const DefaultMaxContextLines = 3
+
+// MaxContextLines sets the number of identical lines displayed before and
+// after each change. Longer runs of identical lines are collapsed into a
+// single row that states how many lines were hidden. Must be zero or a
+// positive number. Defaults to DefaultMaxContextLines.
+func MaxContextLines(lines int) Option {
+	return option(func(opts *options) {
+		opts.maxContextLines = lines
+	})
+}
+
+// The default colors of the added and deleted lines.
+const (
+	DefaultAddedColor   = cell.ColorGreen
+	DefaultDeletedColor = cell.ColorRed
+)
+
+// AddedCellOpts sets the cell options of lines that were added, i.e. lines
+// only present in the right text. Defaults to a DefaultAddedColor foreground.
+func AddedCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.addedCellOpts = cOpts
+	})
+}
+
+// DeletedCellOpts sets the cell options of lines that were deleted, i.e.
+// lines only present in the left text. Defaults to a DefaultDeletedColor
+// foreground.
+func DeletedCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.deletedCellOpts = cOpts
+	})
+}
+
+// ContextCellOpts sets the cell options of the context lines, i.e. lines
+// present in both texts. Defaults to the default cell colors.
+func ContextCellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.contextCellOpts = cOpts
+	})
+}
+
+// The default mouse buttons for content scrolling.
+const (
+	DefaultScrollMouseButtonUp   = mouse.ButtonWheelUp
+	DefaultScrollMouseButtonDown = mouse.ButtonWheelDown
+)
+
+// ScrollMouseButtons configures the mouse buttons that scroll the diff.
+// The provided buttons must be unique, e.g. the same button cannot be both up
+// and down.
+func ScrollMouseButtons(up, down mouse.Button) Option {
+	return option(func(opts *options) {
+		opts.mouseUpButton = up
+		opts.mouseDownButton = down
+	})
+}
+
+// The default keys for content scrolling.
+const (
+	DefaultScrollKeyUp       = keyboard.KeyArrowUp
+	DefaultScrollKeyDown     = keyboard.KeyArrowDown
+	DefaultScrollKeyPageUp   = keyboard.KeyPgUp
+	DefaultScrollKeyPageDown = keyboard.KeyPgDn
+)
+
+// ScrollKeys configures the keyboard keys that scroll the diff.
+// The provided keys must be unique, e.g. the same key cannot be both up and
+// down.
+func ScrollKeys(up, down, pageUp, pageDown keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keyUp = up
+		opts.keyDown = down
+		opts.keyPgUp = pageUp
+		opts.keyPgDown = pageDown
+	})
+}
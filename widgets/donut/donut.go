@@ -299,6 +299,18 @@ func (d *Donut) Options() widgetapi.Options {
 	}
 }
 
+// Clone returns a new Donut with the same options as this one, but without
+// any progress. The clone doesn't share any mutable state with this Donut.
+// Implements widgetapi.Cloneable.
+func (d *Donut) Clone() (widgetapi.Widget, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return &Donut{
+		opts: d.opts.clone(),
+	}, nil
+}
+
 // donutAndLabel splits the canvas area into an area for the donut and an
 // area under the donut for the text label.
 func donutAndLabel(cvsAr image.Rectangle) (donAr, labelAr image.Rectangle, err error) {
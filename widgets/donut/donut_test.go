@@ -916,3 +916,50 @@ func TestOptions(t *testing.T) {
 	}
 
 }
+
+func TestClone(t *testing.T) {
+	cOpts := []cell.Option{cell.FgColor(cell.ColorRed)}
+	d, err := New(
+		CellOpts(cOpts...),
+		Label("label"),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	w, err := widgetapi.CloneWidget(d)
+	if err != nil {
+		t.Fatalf("CloneWidget => unexpected error: %v", err)
+	}
+	clone := w.(*Donut)
+	if err := clone.Percent(50); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+
+	draw := func() *faketerm.Terminal {
+		c, err := canvas.New(image.Rect(0, 0, 6, 6))
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := clone.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		ft, err := faketerm.New(c.Size())
+		if err != nil {
+			t.Fatalf("faketerm.New => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+	want := draw()
+
+	cOpts[0] = cell.FgColor(cell.ColorBlue)
+	if err := d.Percent(100, Label("other"), HideTextProgress()); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	if diff := faketerm.Diff(want, draw()); diff != "" {
+		t.Errorf("Draw => the clone changed after modifying the original %s", diff)
+	}
+}
@@ -69,6 +69,15 @@ func (o *options) validate() error {
 	return nil
 }
 
+// clone returns a deep copy of the options.
+func (o *options) clone() *options {
+	c := *o
+	c.textCellOpts = append([]cell.Option(nil), o.textCellOpts...)
+	c.cellOpts = append([]cell.Option(nil), o.cellOpts...)
+	c.labelCellOpts = append([]cell.Option(nil), o.labelCellOpts...)
+	return &c
+}
+
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
@@ -21,6 +21,7 @@ import (
 	"image"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mum4k/termdash/cell"
 	"github.com/mum4k/termdash/linestyle"
@@ -70,6 +71,10 @@ type Gauge struct {
 	// For progressTypePercent, this is 100, for progressTypeAbsolute this is
 	// the total provided by the caller.
 	total int
+
+	// now returns the current time, used to animate an indeterminate gauge.
+	now func() time.Time
+
 	// mu protects the Gauge.
 	mu sync.Mutex
 
@@ -88,6 +93,7 @@ func New(opts ...Option) (*Gauge, error) {
 	}
 
 	return &Gauge{
+		now:  time.Now,
 		opts: opt,
 	}, nil
 }
@@ -144,6 +150,25 @@ func (g *Gauge) width(ar image.Rectangle) int {
 	return int(width)
 }
 
+// segment determines the area of the segment animated by an indeterminate
+// gauge within the provided area.
+func (g *Gauge) segment(ar image.Rectangle) image.Rectangle {
+	width := g.opts.segmentWidth
+	travel := ar.Dx() - width
+	if travel <= 0 {
+		return ar
+	}
+
+	// The segment moves one cell per step, travelling right and then back
+	// left, which takes 2*travel steps.
+	step := g.now().UnixNano() / int64(g.opts.animationSpeed)
+	pos := int(step % int64(2*travel))
+	if pos > travel {
+		pos = 2*travel - pos
+	}
+	return image.Rect(ar.Min.X+pos, ar.Min.Y, ar.Min.X+pos+width, ar.Max.Y)
+}
+
 // hasBorder determines of the gauge has a border.
 func (g *Gauge) hasBorder() bool {
 	return g.opts.border != linestyle.None
@@ -159,7 +184,7 @@ func (g *Gauge) usable(cvs *canvas.Canvas) image.Rectangle {
 
 // progressText returns the textual representation of the current progress.
 func (g *Gauge) progressText() string {
-	if g.opts.hideTextProgress {
+	if g.opts.hideTextProgress || g.opts.indeterminate {
 		return ""
 	}
 
@@ -270,12 +295,17 @@ func (g *Gauge) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	}
 
 	usable := g.usable(cvs)
-	progress := image.Rect(
-		usable.Min.X,
-		usable.Min.Y,
-		usable.Min.X+g.width(usable),
-		usable.Max.Y,
-	)
+	var progress image.Rectangle
+	if g.opts.indeterminate {
+		progress = g.segment(usable)
+	} else {
+		progress = image.Rect(
+			usable.Min.X,
+			usable.Min.Y,
+			usable.Min.X+g.width(usable),
+			usable.Max.Y,
+		)
+	}
 	if progress.Dx() > 0 {
 		if err := draw.Rectangle(cvs, progress,
 			draw.RectChar(g.opts.gaugeChar),
@@ -330,3 +360,16 @@ func (g *Gauge) Options() widgetapi.Options {
 		WantMouse:    widgetapi.MouseScopeNone,
 	}
 }
+
+// Clone returns a new Gauge with the same options as this one, but without
+// any progress. The clone doesn't share any mutable state with this Gauge.
+// Implements widgetapi.Cloneable.
+func (g *Gauge) Clone() (widgetapi.Widget, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return &Gauge{
+		now:  g.now,
+		opts: g.opts.clone(),
+	}, nil
+}
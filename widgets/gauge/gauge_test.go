@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"image"
 	"testing"
+	"time"
 
 	"github.com/kylelemons/godebug/pretty"
 	"github.com/mum4k/termdash/align"
@@ -53,6 +54,7 @@ func TestGauge(t *testing.T) {
 		absolute      *absoluteCall // if set the test case calls Gauge.Absolute().
 		canvas        image.Rectangle
 		meta          *widgetapi.Meta
+		now           time.Time // if set, the current time used to animate the gauge.
 		want          func(size image.Point) *faketerm.Terminal
 		wantErr       bool
 		wantUpdateErr bool // whether to expect an error on a call to Gauge.Percent() or Gauge.Absolute().
@@ -141,6 +143,129 @@ func TestGauge(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "fails on zero SegmentWidth",
+			opts: []Option{
+				SegmentWidth(0),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on zero AnimationSpeed",
+			opts: []Option{
+				AnimationSpeed(0),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "indeterminate gauge starts on the left without progress text",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+				SegmentWidth(3),
+			},
+			percent: &percentCall{p: 35},
+			canvas:  image.Rect(0, 0, 10, 1),
+			now:     time.Unix(0, 0),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "indeterminate gauge segment bounces back from the right",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+				SegmentWidth(3),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			// Nine steps, seven to the right edge and two back.
+			now: time.Unix(0, int64(9*DefaultAnimationSpeed)),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(5, 0, 8, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "indeterminate gauge with custom animation speed",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+				SegmentWidth(3),
+				AnimationSpeed(time.Second),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			now:    time.Unix(2, 0),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(2, 0, 5, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "indeterminate gauge segment wider than the gauge fills it",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+				SegmentWidth(20),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			now:    time.Unix(3, 0),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 10, 1),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "determinate option turns off the animation",
+			opts: []Option{
+				Char('o'),
+				Indeterminate(),
+			},
+			percent: &percentCall{p: 35, opts: []Option{Determinate()}},
+			canvas:  image.Rect(0, 0, 10, 3),
+			now:     time.Unix(3, 0),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 3),
+					draw.RectChar('o'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorGreen)),
+				)
+				testdraw.MustText(c, "35%", image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc: "fails when Percent is less than zero",
 			opts: []Option{
@@ -852,6 +977,9 @@ func TestGauge(t *testing.T) {
 			if err != nil {
 				return
 			}
+			if !tc.now.IsZero() {
+				g.now = func() time.Time { return tc.now }
+			}
 
 			c, err := canvas.New(tc.canvas)
 			if err != nil {
@@ -1000,3 +1128,50 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestClone(t *testing.T) {
+	cOpts := []cell.Option{cell.FgColor(cell.ColorRed)}
+	g, err := New(
+		Border(linestyle.Light, cOpts...),
+		TextLabel("label"),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	w, err := widgetapi.CloneWidget(g)
+	if err != nil {
+		t.Fatalf("CloneWidget => unexpected error: %v", err)
+	}
+	clone := w.(*Gauge)
+	if err := clone.Percent(50); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+
+	draw := func() *faketerm.Terminal {
+		c, err := canvas.New(image.Rect(0, 0, 10, 3))
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := clone.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		ft, err := faketerm.New(c.Size())
+		if err != nil {
+			t.Fatalf("faketerm.New => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+	want := draw()
+
+	cOpts[0] = cell.FgColor(cell.ColorBlue)
+	if err := g.Percent(100, TextLabel("other"), HideTextProgress()); err != nil {
+		t.Fatalf("Percent => unexpected error: %v", err)
+	}
+	if diff := faketerm.Diff(want, draw()); diff != "" {
+		t.Errorf("Draw => the clone changed after modifying the original %s", diff)
+	}
+}
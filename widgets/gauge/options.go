@@ -18,6 +18,7 @@ package gauge
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/cell"
@@ -47,6 +48,10 @@ type options struct {
 	borderCellOpts    []cell.Option
 	borderTitle       string
 	borderTitleHAlign align.Horizontal
+	// If set, animates a segment instead of displaying the progress.
+	indeterminate  bool
+	segmentWidth   int
+	animationSpeed time.Duration
 }
 
 // newOptions returns options with the default values set.
@@ -58,6 +63,8 @@ func newOptions() *options {
 		color:           DefaultColor,
 		filledTextColor: DefaultFilledTextColor,
 		emptyTextColor:  DefaultEmptyTextColor,
+		segmentWidth:    DefaultSegmentWidth,
+		animationSpeed:  DefaultAnimationSpeed,
 	}
 }
 
@@ -66,9 +73,22 @@ func (o *options) validate() error {
 	if got, min := o.height, 0; got < min {
 		return fmt.Errorf("invalid Height %d, must be %d <= Height", got, min)
 	}
+	if got, min := o.segmentWidth, 1; got < min {
+		return fmt.Errorf("invalid SegmentWidth %d, must be %d <= SegmentWidth", got, min)
+	}
+	if got, min := o.animationSpeed, time.Duration(1); got < min {
+		return fmt.Errorf("invalid AnimationSpeed %v, must be %v <= AnimationSpeed", got, min)
+	}
 	return nil
 }
 
+// clone returns a deep copy of the options.
+func (o *options) clone() *options {
+	c := *o
+	c.borderCellOpts = append([]cell.Option(nil), o.borderCellOpts...)
+	return &c
+}
+
 // option implements Option.
 type option func(*options)
 
@@ -201,3 +221,46 @@ func BorderTitleAlign(h align.Horizontal) Option {
 		opts.borderTitleHAlign = h
 	})
 }
+
+// Indeterminate configures the Gauge to display an operation whose progress
+// is unknown. Instead of the progress, the Gauge animates a segment that
+// bounces between its left and right edges. The position of the segment is
+// derived from the current time, so the animation advances on each redraw.
+// The text enumerating the progress isn't displayed, the text label is.
+func Indeterminate() Option {
+	return option(func(opts *options) {
+		opts.indeterminate = true
+	})
+}
+
+// Determinate configures the Gauge to display the progress set by a call to
+// Percent() or Absolute(). This is the default behavior.
+func Determinate() Option {
+	return option(func(opts *options) {
+		opts.indeterminate = false
+	})
+}
+
+// DefaultSegmentWidth is the default value for the SegmentWidth option.
+const DefaultSegmentWidth = 5
+
+// SegmentWidth sets the width in cells of the segment animated by an
+// Indeterminate Gauge. Must be a positive number.
+// Defaults to DefaultSegmentWidth.
+func SegmentWidth(cells int) Option {
+	return option(func(opts *options) {
+		opts.segmentWidth = cells
+	})
+}
+
+// DefaultAnimationSpeed is the default value for the AnimationSpeed option.
+const DefaultAnimationSpeed = 100 * time.Millisecond
+
+// AnimationSpeed sets how long it takes the segment of an Indeterminate Gauge
+// to move by one cell. Must be a positive duration.
+// Defaults to DefaultAnimationSpeed.
+func AnimationSpeed(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.animationSpeed = d
+	})
+}
@@ -0,0 +1,292 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hexdump implements a widget that displays binary data in the format
+// of a hex editor.
+package hexdump
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// BytesPerRow is the number of bytes displayed on each row.
+const BytesPerRow = 16
+
+// highlight is a range of bytes displayed with a background color.
+type highlight struct {
+	// start is the index of the first highlighted byte.
+	start int
+	// end is the index of the byte after the last highlighted byte.
+	end   int
+	color cell.Color
+}
+
+// HexDump displays binary data as rows of sixteen bytes. Each row starts with
+// the offset of its first byte, followed by the hexadecimal values of the
+// bytes and their printable ASCII representation. Bytes that don't represent a
+// printable ASCII character are displayed as a dot.
+//
+// Rows that don't fit the width of the canvas are trimmed. The content can be
+// scrolled by rows with the keyboard and the mouse, see the options for the
+// default keys and buttons.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type HexDump struct {
+	// data is the displayed data.
+	data []byte
+	// highlights are the highlighted ranges in the order they were added.
+	highlights []*highlight
+
+	// firstRow is the index of the row displayed at the top of the canvas.
+	firstRow int
+	// lastHeight is the height of the canvas during the last call to Draw.
+	lastHeight int
+
+	// mu protects the HexDump.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new HexDump.
+func New(opts ...Option) (*HexDump, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &HexDump{
+		opts: o,
+	}, nil
+}
+
+// SetData sets the displayed data. The HexDump keeps a copy of the data.
+// The scroll position is kept if the new data still has enough rows and all
+// the highlighted ranges are removed.
+func (hd *HexDump) SetData(data []byte) {
+	hd.mu.Lock()
+	defer hd.mu.Unlock()
+
+	hd.data = append([]byte(nil), data...)
+	hd.highlights = nil
+	hd.clampFirstRow()
+}
+
+// HighlightRange displays the bytes from index start up to but not including
+// index end with the specified background color. Ranges can overlap, the
+// range highlighted last takes precedence. The ranges are removed when new
+// data is set.
+func (hd *HexDump) HighlightRange(start, end int, color cell.Color) error {
+	hd.mu.Lock()
+	defer hd.mu.Unlock()
+
+	if start < 0 {
+		return fmt.Errorf("invalid start %d, must be 0 <= start", start)
+	}
+	if end <= start {
+		return fmt.Errorf("invalid end %d, must be start(%d) < end", end, start)
+	}
+	hd.highlights = append(hd.highlights, &highlight{
+		start: start,
+		end:   end,
+		color: color,
+	})
+	return nil
+}
+
+// ClearHighlights removes all the highlighted ranges.
+func (hd *HexDump) ClearHighlights() {
+	hd.mu.Lock()
+	defer hd.mu.Unlock()
+	hd.highlights = nil
+}
+
+// rows returns the number of rows needed to display the data.
+// Caller must hold hd.mu.
+func (hd *HexDump) rows() int {
+	return (len(hd.data) + BytesPerRow - 1) / BytesPerRow
+}
+
+// clampFirstRow ensures that the first row is within the valid range.
+// Caller must hold hd.mu.
+func (hd *HexDump) clampFirstRow() {
+	if max := hd.rows() - hd.lastHeight; hd.firstRow > max {
+		hd.firstRow = max
+	}
+	if hd.firstRow < 0 {
+		hd.firstRow = 0
+	}
+}
+
+// scroll scrolls the content down by the specified number of rows, negative
+// values scroll up.
+// Caller must hold hd.mu.
+func (hd *HexDump) scroll(rows int) {
+	hd.firstRow += rows
+	hd.clampFirstRow()
+}
+
+// highlightColor returns the background color of the byte at the index and
+// true if the byte is highlighted.
+// Caller must hold hd.mu.
+func (hd *HexDump) highlightColor(idx int) (cell.Color, bool) {
+	for i := len(hd.highlights) - 1; i >= 0; i-- {
+		h := hd.highlights[i]
+		if idx >= h.start && idx < h.end {
+			return h.color, true
+		}
+	}
+	return cell.ColorDefault, false
+}
+
+// rowCell is a single cell of a displayed row.
+type rowCell struct {
+	r rune
+	// byteIdx is the index of the byte the cell represents or -1 if the cell
+	// doesn't represent a byte.
+	byteIdx int
+}
+
+// appendText appends cells that don't represent any byte.
+func appendText(cells []rowCell, text string) []rowCell {
+	for _, r := range text {
+		cells = append(cells, rowCell{r: r, byteIdx: -1})
+	}
+	return cells
+}
+
+// row returns the cells of the row at the index.
+// Caller must hold hd.mu.
+func (hd *HexDump) row(idx int) []rowCell {
+	offsetFmt, byteFmt := "%08x", "%02x"
+	if hd.opts.uppercase {
+		offsetFmt, byteFmt = "%08X", "%02X"
+	}
+
+	offset := idx * BytesPerRow
+	cells := appendText(nil, fmt.Sprintf(offsetFmt, offset))
+	cells = appendText(cells, " ")
+	for i := 0; i < BytesPerRow; i++ {
+		if i%(BytesPerRow/2) == 0 {
+			cells = appendText(cells, " ")
+		}
+		byteIdx := offset + i
+		if byteIdx >= len(hd.data) {
+			cells = appendText(cells, "   ")
+			continue
+		}
+		for _, r := range fmt.Sprintf(byteFmt, hd.data[byteIdx]) {
+			cells = append(cells, rowCell{r: r, byteIdx: byteIdx})
+		}
+		cells = appendText(cells, " ")
+	}
+
+	cells = appendText(cells, " |")
+	for byteIdx := offset; byteIdx < offset+BytesPerRow && byteIdx < len(hd.data); byteIdx++ {
+		r := rune(hd.data[byteIdx])
+		if r < 0x20 || r > 0x7e {
+			r = '.'
+		}
+		cells = append(cells, rowCell{r: r, byteIdx: byteIdx})
+	}
+	return appendText(cells, "|")
+}
+
+// Draw draws the HexDump widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (hd *HexDump) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	hd.mu.Lock()
+	defer hd.mu.Unlock()
+
+	ar := cvs.Area()
+	hd.lastHeight = ar.Dy()
+	hd.clampFirstRow()
+
+	for y := ar.Min.Y; y < ar.Max.Y; y++ {
+		rowIdx := hd.firstRow + y - ar.Min.Y
+		if rowIdx >= hd.rows() {
+			break
+		}
+
+		for i, rc := range hd.row(rowIdx) {
+			x := ar.Min.X + i
+			if x >= ar.Max.X {
+				break
+			}
+			var cOpts []cell.Option
+			if rc.byteIdx >= 0 {
+				if color, ok := hd.highlightColor(rc.byteIdx); ok {
+					cOpts = append(cOpts, cell.BgColor(color))
+				}
+			}
+			if _, err := cvs.SetCell(image.Point{x, y}, rc.r, cOpts...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+func (hd *HexDump) Keyboard(k *terminalapi.Keyboard) error {
+	hd.mu.Lock()
+	defer hd.mu.Unlock()
+
+	switch k.Key {
+	case hd.opts.keyUp:
+		hd.scroll(-1)
+	case hd.opts.keyDown:
+		hd.scroll(1)
+	case hd.opts.keyPgUp:
+		hd.scroll(-hd.lastHeight)
+	case hd.opts.keyPgDown:
+		hd.scroll(hd.lastHeight)
+	}
+	return nil
+}
+
+// Mouse implements widgetapi.Widget.Mouse.
+func (hd *HexDump) Mouse(m *terminalapi.Mouse) error {
+	hd.mu.Lock()
+	defer hd.mu.Unlock()
+
+	switch m.Button {
+	case hd.opts.mouseUpButton:
+		hd.scroll(-1)
+	case hd.opts.mouseDownButton:
+		hd.scroll(1)
+	}
+	return nil
+}
+
+// Options of the widget.
+// Implements widgetapi.Widget.Options.
+func (hd *HexDump) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// At least the offset of the first row.
+		MinimumSize:  image.Point{8, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
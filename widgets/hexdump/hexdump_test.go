@@ -0,0 +1,274 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hexdump
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// testData is 20 bytes of data displayed on two rows.
+var testData = []byte("Hello world\n\x00\x01\xfe\xffABCD")
+
+func TestHexDump(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		// update gets called before the first draw.
+		update func(*HexDump) error
+		// events are delivered to the HexDump after the first draw.
+		events     []terminalapi.Event
+		want       func(size image.Point) *faketerm.Terminal
+		wantNewErr bool
+		wantErr    bool
+	}{
+		{
+			desc: "fails on duplicate scroll keys",
+			opts: []Option{
+				ScrollKeys(keyboard.KeyArrowUp, keyboard.KeyArrowUp, keyboard.KeyPgUp, keyboard.KeyPgDn),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on duplicate scroll mouse buttons",
+			opts: []Option{
+				ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonLeft),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc:   "fails on negative highlight start",
+			canvas: image.Rect(0, 0, 80, 2),
+			update: func(hd *HexDump) error {
+				return hd.HighlightRange(-1, 2, cell.ColorRed)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "fails on highlight end before start",
+			canvas: image.Rect(0, 0, 80, 2),
+			update: func(hd *HexDump) error {
+				return hd.HighlightRange(2, 2, cell.ColorRed)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws nothing without data",
+			canvas: image.Rect(0, 0, 80, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws the rows in lowercase",
+			canvas: image.Rect(0, 0, 80, 3),
+			update: func(hd *HexDump) error {
+				hd.SetData(testData)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "00000000  48 65 6c 6c 6f 20 77 6f  72 6c 64 0a 00 01 fe ff  |Hello world.....|", image.Point{0, 0})
+				testdraw.MustText(c, "00000010  41 42 43 44                                       |ABCD|", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws the rows in uppercase",
+			opts:   []Option{Uppercase()},
+			canvas: image.Rect(0, 0, 80, 3),
+			update: func(hd *HexDump) error {
+				hd.SetData(testData)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "00000000  48 65 6C 6C 6F 20 77 6F  72 6C 64 0A 00 01 FE FF  |Hello world.....|", image.Point{0, 0})
+				testdraw.MustText(c, "00000010  41 42 43 44                                       |ABCD|", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims rows that don't fit",
+			canvas: image.Rect(0, 0, 14, 1),
+			update: func(hd *HexDump) error {
+				hd.SetData(testData)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "00000000  48 6", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "highlights a range of bytes",
+			canvas: image.Rect(0, 0, 80, 1),
+			update: func(hd *HexDump) error {
+				hd.SetData(testData[:2])
+				return hd.HighlightRange(1, 5, cell.ColorRed)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "00000000  48 65                                             |He|", image.Point{0, 0})
+				testcanvas.MustSetCell(c, image.Point{13, 0}, '6', cell.BgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{14, 0}, '5', cell.BgColor(cell.ColorRed))
+				testcanvas.MustSetCell(c, image.Point{62, 0}, 'e', cell.BgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls down by rows",
+			canvas: image.Rect(0, 0, 80, 1),
+			update: func(hd *HexDump) error {
+				hd.SetData(testData)
+				return nil
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "00000010  41 42 43 44                                       |ABCD|", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls back up",
+			canvas: image.Rect(0, 0, 80, 1),
+			update: func(hd *HexDump) error {
+				hd.SetData(testData)
+				return nil
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "00000000  48 65 6c 6c 6f 20 77 6f  72 6c 64 0a 00 01 fe ff  |Hello world.....|", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			hd, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				err := tc.update(hd)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("update => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := hd.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			if len(tc.events) > 0 {
+				for _, ev := range tc.events {
+					switch e := ev.(type) {
+					case *terminalapi.Keyboard:
+						if err := hd.Keyboard(e); err != nil {
+							t.Fatalf("Keyboard => unexpected error: %v", err)
+						}
+					case *terminalapi.Mouse:
+						if err := hd.Mouse(e); err != nil {
+							t.Fatalf("Mouse => unexpected error: %v", err)
+						}
+					default:
+						t.Fatalf("unsupported event type: %T", ev)
+					}
+				}
+
+				c, err = canvas.New(tc.canvas)
+				if err != nil {
+					t.Fatalf("canvas.New => unexpected error: %v", err)
+				}
+				if err := hd.Draw(c, &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	hd, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := hd.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{8, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hexdump
+
+// options.go contains configurable options for HexDump.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	uppercase       bool
+	keyUp           keyboard.Key
+	keyDown         keyboard.Key
+	keyPgUp         keyboard.Key
+	keyPgDown       keyboard.Key
+	mouseUpButton   mouse.Button
+	mouseDownButton mouse.Button
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		keyUp:           DefaultScrollKeyUp,
+		keyDown:         DefaultScrollKeyDown,
+		keyPgUp:         DefaultScrollKeyPageUp,
+		keyPgDown:       DefaultScrollKeyPageDown,
+		mouseUpButton:   DefaultScrollMouseButtonUp,
+		mouseDownButton: DefaultScrollMouseButtonDown,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	keys := map[keyboard.Key]bool{
+		o.keyUp:     true,
+		o.keyDown:   true,
+		o.keyPgUp:   true,
+		o.keyPgDown: true,
+	}
+	if len(keys) != 4 {
+		return fmt.Errorf("invalid ScrollKeys(up:%v, down:%v, pageUp:%v, pageDown:%v), the keys must be unique", o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown)
+	}
+	if o.mouseUpButton == o.mouseDownButton {
+		return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the buttons must be unique", o.mouseUpButton, o.mouseDownButton)
+	}
+	return nil
+}
+
+// Uppercase displays the offsets and the hexadecimal values of the bytes in
+// uppercase.
+// Defaults to lowercase.
+func Uppercase() Option {
+	return option(func(opts *options) {
+		opts.uppercase = true
+	})
+}
+
+// Lowercase displays the offsets and the hexadecimal values of the bytes in
+// lowercase. This is the default.
+func Lowercase() Option {
+	return option(func(opts *options) {
+		opts.uppercase = false
+	})
+}
+
+// The default mouse buttons for content scrolling.
+const (
+	DefaultScrollMouseButtonUp   = mouse.ButtonWheelUp
+	DefaultScrollMouseButtonDown = mouse.ButtonWheelDown
+)
+
+// ScrollMouseButtons configures the mouse buttons that scroll the content by
+// one row.
+// The provided buttons must be unique, e.g. the same button cannot be both up
+// and down.
+func ScrollMouseButtons(up, down mouse.Button) Option {
+	return option(func(opts *options) {
+		opts.mouseUpButton = up
+		opts.mouseDownButton = down
+	})
+}
+
+// The default keys for content scrolling.
+const (
+	DefaultScrollKeyUp       = keyboard.KeyArrowUp
+	DefaultScrollKeyDown     = keyboard.KeyArrowDown
+	DefaultScrollKeyPageUp   = keyboard.KeyPgUp
+	DefaultScrollKeyPageDown = keyboard.KeyPgDn
+)
+
+// ScrollKeys configures the keyboard keys that scroll the content.
+// The up and down keys scroll by one row, the page keys by the height of the
+// canvas.
+// The provided keys must be unique, e.g. the same key cannot be both up and
+// down.
+func ScrollKeys(up, down, pageUp, pageDown keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keyUp = up
+		opts.keyDown = down
+		opts.keyPgUp = pageUp
+		opts.keyPgDown = pageDown
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package image is a widget that displays a bitmap image.
+package image
+
+import (
+	"errors"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// Image displays a bitmap image.
+//
+// The image is downsampled to the size of the widget and drawn using half
+// block characters. Each cell displays two pixels above each other, the upper
+// one in the foreground color and the lower one in the background color.
+// Fully or mostly transparent pixels aren't drawn.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Image struct {
+	// img is the displayed image.
+	img image.Image
+
+	// mu protects the Image.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Image that displays the provided image.
+// The image can be nil, in which case the widget stays empty until an image
+// is provided by calling SetImage.
+func New(img image.Image, opts ...Option) (*Image, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Image{
+		img:  img,
+		opts: opt,
+	}, nil
+}
+
+// SetImage replaces the displayed image.
+// Providing a nil image clears the widget.
+func (i *Image) SetImage(img image.Image) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.img = img
+}
+
+// Draw draws the Image widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (i *Image) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.img == nil || i.img.Bounds().Empty() {
+		return nil
+	}
+
+	ar := cvs.Area()
+	// Every cell displays two pixels above each other.
+	pxSize := image.Point{ar.Dx(), ar.Dy() * 2}
+	dst := scaledArea(i.img.Bounds().Size(), pxSize, i.opts.scaleMode)
+	for row := 0; row < ar.Dy(); row++ {
+		for col := 0; col < ar.Dx(); col++ {
+			upper := pixelColor(i.img, dst, image.Point{col, row * 2})
+			lower := pixelColor(i.img, dst, image.Point{col, row*2 + 1})
+
+			var (
+				r    rune
+				opts []cell.Option
+			)
+			switch {
+			case upper == cell.ColorDefault && lower == cell.ColorDefault:
+				continue
+			case lower == cell.ColorDefault:
+				r = '▀'
+				opts = []cell.Option{cell.FgColor(upper)}
+			case upper == cell.ColorDefault:
+				r = '▄'
+				opts = []cell.Option{cell.FgColor(lower)}
+			default:
+				r = '▀'
+				opts = []cell.Option{cell.FgColor(upper), cell.BgColor(lower)}
+			}
+			if _, err := cvs.SetCell(image.Point{col, row}, r, opts...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Keyboard input isn't supported on the Image widget.
+func (*Image) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the Image widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the Image widget.
+func (*Image) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the Image widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (*Image) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
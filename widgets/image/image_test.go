@@ -0,0 +1,339 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+var (
+	red         = color.RGBA{255, 0, 0, 255}
+	blue        = color.RGBA{0, 0, 255, 255}
+	transparent = color.RGBA{}
+)
+
+// mustImage returns an image with the provided rows of pixels.
+func mustImage(rows ...[]color.Color) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, len(rows[0]), len(rows)))
+	for y, row := range rows {
+		if len(row) != len(rows[0]) {
+			panic("all the rows must have the same number of pixels")
+		}
+		for x, c := range row {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestImage(t *testing.T) {
+	tests := []struct {
+		desc        string
+		img         image.Image
+		opts        []Option
+		update      func(*Image) // update gets called before drawing of the widget.
+		canvas      image.Rectangle
+		want        func(size image.Point) *faketerm.Terminal
+		wantErr     bool
+		wantDrawErr bool
+	}{
+		{
+			desc: "fails on unsupported scale mode",
+			opts: []Option{
+				ScaleMode(ImageScaleMode(-1)),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws empty without an image",
+			canvas: image.Rect(0, 0, 3, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "draws two pixels per cell",
+			img: mustImage(
+				[]color.Color{red},
+				[]color.Color{blue},
+			),
+			opts: []Option{
+				ScaleMode(ImageScaleModeStretch),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '▀',
+					cell.FgColor(cell.ColorRGB24(255, 0, 0)),
+					cell.BgColor(cell.ColorRGB24(0, 0, 255)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "doesn't draw transparent pixels",
+			img: mustImage(
+				[]color.Color{red, transparent, transparent},
+				[]color.Color{transparent, blue, transparent},
+			),
+			opts: []Option{
+				ScaleMode(ImageScaleModeStretch),
+			},
+			canvas: image.Rect(0, 0, 3, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '▀',
+					cell.FgColor(cell.ColorRGB24(255, 0, 0)),
+				)
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '▄',
+					cell.FgColor(cell.ColorRGB24(0, 0, 255)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "downsamples by averaging the pixels",
+			img: mustImage(
+				[]color.Color{red, blue},
+				[]color.Color{blue, blue},
+			),
+			opts: []Option{
+				ScaleMode(ImageScaleModeStretch),
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '▀',
+					cell.FgColor(cell.ColorRGB24(127, 0, 127)),
+					cell.BgColor(cell.ColorRGB24(0, 0, 255)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fit preserves the aspect ratio and centers the image",
+			img: mustImage(
+				[]color.Color{red, blue},
+				[]color.Color{red, blue},
+			),
+			canvas: image.Rect(0, 0, 4, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '▀',
+					cell.FgColor(cell.ColorRGB24(255, 0, 0)),
+					cell.BgColor(cell.ColorRGB24(255, 0, 0)),
+				)
+				testcanvas.MustSetCell(c, image.Point{2, 0}, '▀',
+					cell.FgColor(cell.ColorRGB24(0, 0, 255)),
+					cell.BgColor(cell.ColorRGB24(0, 0, 255)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "fill preserves the aspect ratio and crops the image",
+			img: mustImage(
+				[]color.Color{red, blue, red, blue},
+				[]color.Color{blue, red, blue, red},
+			),
+			opts: []Option{
+				ScaleMode(ImageScaleModeFill),
+			},
+			canvas: image.Rect(0, 0, 2, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '▀',
+					cell.FgColor(cell.ColorRGB24(0, 0, 255)),
+					cell.BgColor(cell.ColorRGB24(255, 0, 0)),
+				)
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '▀',
+					cell.FgColor(cell.ColorRGB24(255, 0, 0)),
+					cell.BgColor(cell.ColorRGB24(0, 0, 255)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "none doesn't scale the image",
+			img: mustImage(
+				[]color.Color{red},
+			),
+			opts: []Option{
+				ScaleMode(ImageScaleModeNone),
+			},
+			canvas: image.Rect(0, 0, 3, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{1, 0}, '▀',
+					cell.FgColor(cell.ColorRGB24(255, 0, 0)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "set image replaces the image",
+			img: mustImage(
+				[]color.Color{red},
+				[]color.Color{red},
+			),
+			opts: []Option{
+				ScaleMode(ImageScaleModeStretch),
+			},
+			update: func(i *Image) {
+				i.SetImage(mustImage(
+					[]color.Color{blue},
+					[]color.Color{transparent},
+				))
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '▀',
+					cell.FgColor(cell.ColorRGB24(0, 0, 255)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "set image with nil image clears the widget",
+			img: mustImage(
+				[]color.Color{red},
+			),
+			update: func(i *Image) {
+				i.SetImage(nil)
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			i, err := New(tc.img, tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				tc.update(i)
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+
+			err = i.Draw(c, &widgetapi.Meta{})
+			if (err != nil) != tc.wantDrawErr {
+				t.Errorf("Draw => unexpected error: %v, wantDrawErr: %v", err, tc.wantDrawErr)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	i, err := New(nil)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := i.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnter}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	i, err := New(nil)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := i.Mouse(&terminalapi.Mouse{Button: mouse.ButtonLeft}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	i, err := New(nil)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := i.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary imagedemo displays a couple of Image widgets.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	stdimage "image"
+	"image/color"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/image"
+)
+
+// gradient returns an image of the provided size with a color gradient.
+func gradient(width, height int) stdimage.Image {
+	img := stdimage.NewRGBA(stdimage.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{
+				R: uint8(255 * x / width),
+				G: uint8(255 * y / height),
+				B: 128,
+				A: 255,
+			})
+		}
+	}
+	return img
+}
+
+func main() {
+	t, err := termbox.New(termbox.ColorMode(terminalapi.ColorMode256))
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	img := gradient(64, 32)
+	fit, err := image.New(img)
+	if err != nil {
+		panic(err)
+	}
+	stretch, err := image.New(img, image.ScaleMode(image.ImageScaleModeStretch))
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.SplitVertical(
+			container.Left(
+				container.Border(linestyle.Light),
+				container.BorderTitle("ImageScaleModeFit"),
+				container.PlaceWidget(fit),
+			),
+			container.Right(
+				container.Border(linestyle.Light),
+				container.BorderTitle("ImageScaleModeStretch"),
+				container.PlaceWidget(stretch),
+			),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
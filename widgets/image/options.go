@@ -0,0 +1,102 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+// options.go contains configurable options for Image.
+
+import "fmt"
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	scaleMode ImageScaleMode
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		scaleMode: DefaultScaleMode,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if _, ok := imageScaleModeNames[o.scaleMode]; !ok {
+		return fmt.Errorf("unsupported ScaleMode %v", o.scaleMode)
+	}
+	return nil
+}
+
+// ImageScaleMode determines how the image is sized to the area of the widget.
+type ImageScaleMode int
+
+// String implements fmt.Stringer()
+func (ism ImageScaleMode) String() string {
+	if n, ok := imageScaleModeNames[ism]; ok {
+		return n
+	}
+	return "ImageScaleModeUnknown"
+}
+
+// imageScaleModeNames maps ImageScaleMode values to human readable names.
+var imageScaleModeNames = map[ImageScaleMode]string{
+	ImageScaleModeFit:     "ImageScaleModeFit",
+	ImageScaleModeFill:    "ImageScaleModeFill",
+	ImageScaleModeStretch: "ImageScaleModeStretch",
+	ImageScaleModeNone:    "ImageScaleModeNone",
+}
+
+const (
+	// ImageScaleModeFit scales the image so that all of it fits into the
+	// widget while preserving its aspect ratio. The image is centered.
+	ImageScaleModeFit ImageScaleMode = iota
+
+	// ImageScaleModeFill scales the image so that it fills the whole widget
+	// while preserving its aspect ratio. The image is centered and the parts
+	// that don't fit are cropped.
+	ImageScaleModeFill
+
+	// ImageScaleModeStretch scales the image to the size of the widget
+	// without preserving its aspect ratio.
+	ImageScaleModeStretch
+
+	// ImageScaleModeNone displays one pixel of the image per half of a cell.
+	// The image is centered and the parts that don't fit are cropped.
+	ImageScaleModeNone
+)
+
+// DefaultScaleMode is the default value for the ScaleMode option.
+const DefaultScaleMode = ImageScaleModeFit
+
+// ScaleMode sets how the image is sized to the area of the widget.
+// Defaults to DefaultScaleMode.
+func ScaleMode(ism ImageScaleMode) Option {
+	return option(func(opts *options) {
+		opts.scaleMode = ism
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package image
+
+// scale.go contains code that scales and samples the image.
+
+import (
+	"image"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// scaledArea returns the area in pixels that the image of the provided size
+// occupies in an area of pixels of size available when scaled according to
+// the scale mode. The returned area can extend beyond the available area, in
+// which case the image gets cropped.
+func scaledArea(img, available image.Point, ism ImageScaleMode) image.Rectangle {
+	var size image.Point
+	// Comparing the aspect ratios, true if the image is relatively wider than
+	// the available area.
+	wider := img.X*available.Y > img.Y*available.X
+	switch ism {
+	case ImageScaleModeFit:
+		if wider {
+			size = image.Point{available.X, img.Y * available.X / img.X}
+		} else {
+			size = image.Point{img.X * available.Y / img.Y, available.Y}
+		}
+	case ImageScaleModeFill:
+		if wider {
+			size = image.Point{img.X * available.Y / img.Y, available.Y}
+		} else {
+			size = image.Point{available.X, img.Y * available.X / img.X}
+		}
+	case ImageScaleModeStretch:
+		size = available
+	default: // ImageScaleModeNone.
+		size = img
+	}
+	if size.X < 1 {
+		size.X = 1
+	}
+	if size.Y < 1 {
+		size.Y = 1
+	}
+
+	start := image.Point{
+		(available.X - size.X) / 2,
+		(available.Y - size.Y) / 2,
+	}
+	return image.Rectangle{Min: start, Max: start.Add(size)}
+}
+
+// pixelColor returns the color of the pixel at the provided point when the
+// image is scaled onto the dst area. The color is the average of all the image
+// pixels that map onto the point. Returns cell.ColorDefault if the point falls
+// outside of the image or the pixel is mostly transparent.
+func pixelColor(img image.Image, dst image.Rectangle, p image.Point) cell.Color {
+	if !p.In(dst) {
+		return cell.ColorDefault
+	}
+
+	b := img.Bounds()
+	d := p.Sub(dst.Min)
+	x0 := b.Min.X + d.X*b.Dx()/dst.Dx()
+	x1 := b.Min.X + (d.X+1)*b.Dx()/dst.Dx()
+	y0 := b.Min.Y + d.Y*b.Dy()/dst.Dy()
+	y1 := b.Min.Y + (d.Y+1)*b.Dy()/dst.Dy()
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+
+	var rs, gs, bs, as, n uint64
+	for y := y0; y < y1; y++ {
+		for x := x0; x < x1; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			rs += uint64(r)
+			gs += uint64(g)
+			bs += uint64(b)
+			as += uint64(a)
+			n++
+		}
+	}
+	if as/n < 0x8000 {
+		return cell.ColorDefault
+	}
+
+	// The color channels are premultiplied by alpha, the averages of both
+	// share the same divisor.
+	return cell.ColorRGB24(
+		int(rs*0xff/as),
+		int(gs*0xff/as),
+		int(bs*0xff/as),
+	)
+}
@@ -0,0 +1,432 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jsonview contains a widget that displays a JSON document as a tree
+// with foldable objects and arrays.
+package jsonview
+
+import (
+	"image"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/private/wrap"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// line is a single displayed line of the document.
+type line struct {
+	// level is the indentation level of the line.
+	level int
+	// c is the object member or the array element displayed on the line.
+	c *child
+}
+
+// indentWidth is the number of cells each indentation level occupies.
+const indentWidth = 2
+
+// foldable determines if the line displays a non-empty object or array.
+func (l line) foldable() bool {
+	return l.c.node.collection() && len(l.c.node.children) > 0
+}
+
+// rootLabel is the first element of the path to the selected value.
+const rootLabel = "$"
+
+// pathSeparator separates the elements of the path to the selected value.
+const pathSeparator = " > "
+
+// copyKey is the key that copies the selected value.
+const copyKey = 'c'
+
+// JSONView displays a JSON document as a tree.
+//
+// The first line displays the path to the selected value. The members of
+// objects and the elements of arrays are displayed below it, one per line.
+// Objects and arrays deeper than the FoldDepth option are initially folded
+// and displayed as {...} or [...]. The arrow and the page keys move the
+// selected line, the Enter key or a mouse click folds or expands the selected
+// object or array. The right arrow expands and the left arrow folds the
+// selected line or moves to its parent. The 'c' key copies the selected value
+// to the clipboard of the terminal, see terminalapi.ClipboardTerminal.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type JSONView struct {
+	// root is the root of the parsed document.
+	root *node
+	// lines are the currently displayed lines.
+	lines []line
+
+	// selected is the index of the selected line.
+	selected int
+	// offset is the index of the first visible line.
+	offset int
+	// height is the number of lines available for the document during the
+	// last Draw.
+	height int
+	// toCopy is the value to copy to the clipboard on the next Draw, nil if
+	// no copy was requested.
+	toCopy *string
+
+	// mu protects the JSONView.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new JSONView.
+func New(opts ...Option) (*JSONView, error) {
+	o := newOptions(opts...)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &JSONView{
+		opts: o,
+	}, nil
+}
+
+// SetJSON parses and displays the JSON document, replacing any previous
+// document. Returns an error if the document isn't valid JSON, the previous
+// document remains displayed in that case.
+func (jv *JSONView) SetJSON(doc []byte) error {
+	root, err := parse(doc)
+	if err != nil {
+		return err
+	}
+
+	jv.mu.Lock()
+	defer jv.mu.Unlock()
+	fold(root, 0, jv.opts.foldDepth)
+	jv.root = root
+	jv.selected = 0
+	jv.offset = 0
+	jv.flatten()
+	return nil
+}
+
+// fold folds the objects and arrays at or below the depth.
+func fold(n *node, depth, foldDepth int) {
+	n.folded = n.collection() && depth >= foldDepth
+	for _, c := range n.children {
+		fold(c.node, depth+1, foldDepth)
+	}
+}
+
+// flatten updates the displayed lines from the document.
+// The caller must hold jv.mu.
+func (jv *JSONView) flatten() {
+	jv.lines = nil
+	switch {
+	case jv.root == nil:
+	case !jv.root.collection():
+		jv.lines = append(jv.lines, line{c: &child{node: jv.root}})
+	default:
+		jv.addLines(jv.root, 0)
+	}
+}
+
+// addLines adds lines for the children of the node at the level.
+// The caller must hold jv.mu.
+func (jv *JSONView) addLines(n *node, level int) {
+	for _, c := range n.children {
+		jv.lines = append(jv.lines, line{level: level, c: c})
+		if c.node.collection() && !c.node.folded {
+			jv.addLines(c.node, level+1)
+		}
+	}
+}
+
+// setFolded folds or expands the selected line.
+// The caller must hold jv.mu.
+func (jv *JSONView) setFolded(folded bool) {
+	if jv.selected >= len(jv.lines) || !jv.lines[jv.selected].foldable() {
+		return
+	}
+	jv.lines[jv.selected].c.node.folded = folded
+	jv.flatten()
+}
+
+// toggle folds or expands the selected line.
+// The caller must hold jv.mu.
+func (jv *JSONView) toggle() {
+	if jv.selected >= len(jv.lines) {
+		return
+	}
+	jv.setFolded(!jv.lines[jv.selected].c.node.folded)
+}
+
+// foldOrParent folds the selected line if it is expanded, otherwise selects
+// the line of its parent.
+// The caller must hold jv.mu.
+func (jv *JSONView) foldOrParent() {
+	if jv.selected >= len(jv.lines) {
+		return
+	}
+	l := jv.lines[jv.selected]
+	if l.foldable() && !l.c.node.folded {
+		jv.setFolded(true)
+		return
+	}
+	for i := jv.selected - 1; i >= 0; i-- {
+		if jv.lines[i].level < l.level {
+			jv.selected = i
+			return
+		}
+	}
+}
+
+// moveSelection moves the selected line by the number of lines.
+// The caller must hold jv.mu.
+func (jv *JSONView) moveSelection(by int) {
+	jv.selected += by
+	if jv.selected >= len(jv.lines) {
+		jv.selected = len(jv.lines) - 1
+	}
+	if jv.selected < 0 {
+		jv.selected = 0
+	}
+}
+
+// path returns the path from the root of the document to the selected value.
+// The caller must hold jv.mu.
+func (jv *JSONView) path() []string {
+	if jv.selected >= len(jv.lines) {
+		return nil
+	}
+	var res []string
+	for c := jv.lines[jv.selected].c; c != nil && c.owner != nil; c = c.owner.parent {
+		res = append([]string{label(c)}, res...)
+	}
+	return append([]string{rootLabel}, res...)
+}
+
+// label returns the text identifying the child, the key of an object member
+// or the index of an array element in brackets.
+func label(c *child) string {
+	if c.index {
+		return "[" + c.key + "]"
+	}
+	return displayable(c.key)
+}
+
+// copySelected requests a copy of the selected value to the clipboard and
+// passes it to the OnCopy function.
+// The caller must hold jv.mu.
+func (jv *JSONView) copySelected() error {
+	if jv.selected >= len(jv.lines) {
+		return nil
+	}
+	v := encode(jv.lines[jv.selected].c.node)
+	jv.toCopy = &v
+	if jv.opts.onCopy == nil {
+		return nil
+	}
+	return jv.opts.onCopy(v)
+}
+
+// segment is a part of a line drawn with the same cell options.
+type segment struct {
+	text  string
+	cOpts []cell.Option
+}
+
+// segments returns the segments of the line without the indentation.
+// The caller must hold jv.mu.
+func (jv *JSONView) segments(l line) []segment {
+	var res []segment
+	switch {
+	case l.c.owner == nil:
+	case l.c.index:
+		res = append(res, segment{text: label(l.c) + ": "})
+	default:
+		res = append(res,
+			segment{text: label(l.c), cOpts: []cell.Option{cell.FgColor(jv.opts.keyColor)}},
+			segment{text: ": "},
+		)
+	}
+
+	n := l.c.node
+	switch n.kind {
+	case nodeString:
+		res = append(res, segment{text: quote(n.value), cOpts: []cell.Option{cell.FgColor(jv.opts.stringColor)}})
+	case nodeNumber:
+		res = append(res, segment{text: n.value, cOpts: []cell.Option{cell.FgColor(jv.opts.numberColor)}})
+	case nodeBool, nodeNull:
+		res = append(res, segment{text: n.value})
+	case nodeObject:
+		res = appendCollection(res, n, "{", "}")
+	case nodeArray:
+		res = appendCollection(res, n, "[", "]")
+	}
+	return res
+}
+
+// appendCollection appends the segment displayed after the key of an empty or
+// a folded object or array. Expanded objects and arrays display their members
+// on the following lines instead.
+func appendCollection(segs []segment, n *node, open, close string) []segment {
+	switch {
+	case len(n.children) == 0:
+		return append(segs, segment{text: open + close})
+	case n.folded:
+		return append(segs, segment{text: open + "..." + close})
+	default:
+		return segs
+	}
+}
+
+// displayable returns the text if it can be drawn or its quoted form that
+// escapes newlines and other control characters.
+func displayable(text string) string {
+	if err := wrap.ValidText(text); err != nil {
+		return strconv.Quote(text)
+	}
+	return text
+}
+
+// Draw draws the JSONView widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (jv *JSONView) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	jv.mu.Lock()
+	defer jv.mu.Unlock()
+
+	if jv.toCopy != nil {
+		cvs.CopyToClipboard(*jv.toCopy)
+		jv.toCopy = nil
+	}
+
+	ar := cvs.Area()
+	if p := jv.path(); len(p) > 0 {
+		if err := draw.Text(cvs, strings.Join(p, pathSeparator), ar.Min,
+			draw.TextCellOpts(cell.FgColor(jv.opts.pathColor)),
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+	}
+
+	docAr := image.Rect(ar.Min.X, ar.Min.Y+1, ar.Max.X, ar.Max.Y)
+	jv.height = docAr.Dy()
+	// Keep the selected line visible.
+	if jv.selected < jv.offset {
+		jv.offset = jv.selected
+	}
+	if jv.selected >= jv.offset+jv.height {
+		jv.offset = jv.selected - jv.height + 1
+	}
+	if max := len(jv.lines) - jv.height; jv.offset > max && max >= 0 {
+		jv.offset = max
+	}
+
+	for i := jv.offset; i < len(jv.lines) && i-jv.offset < docAr.Dy(); i++ {
+		y := docAr.Min.Y + i - jv.offset
+		l := jv.lines[i]
+		var selOpts []cell.Option
+		if i == jv.selected {
+			selOpts = []cell.Option{cell.BgColor(jv.opts.selectedColor)}
+			if err := cvs.SetAreaCells(image.Rect(docAr.Min.X, y, docAr.Max.X, y+1), ' ', selOpts...); err != nil {
+				return err
+			}
+		}
+
+		x := docAr.Min.X + l.level*indentWidth
+		for _, s := range jv.segments(l) {
+			if x >= docAr.Max.X {
+				break
+			}
+			cOpts := append(append([]cell.Option(nil), s.cOpts...), selOpts...)
+			if err := draw.Text(cvs, s.text, image.Point{x, y},
+				draw.TextCellOpts(cOpts...),
+				draw.TextMaxX(docAr.Max.X),
+				draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			); err != nil {
+				return err
+			}
+			x += runewidth.StringWidth(s.text)
+		}
+	}
+	return nil
+}
+
+// Keyboard moves the selected line, folds or expands it and copies its value.
+// Implements widgetapi.Widget.Keyboard.
+func (jv *JSONView) Keyboard(k *terminalapi.Keyboard) error {
+	jv.mu.Lock()
+	defer jv.mu.Unlock()
+
+	switch k.Key {
+	case keyboard.KeyArrowUp:
+		jv.moveSelection(-1)
+	case keyboard.KeyArrowDown:
+		jv.moveSelection(1)
+	case keyboard.KeyPgUp:
+		jv.moveSelection(-jv.height)
+	case keyboard.KeyPgDn:
+		jv.moveSelection(jv.height)
+	case keyboard.KeyArrowRight:
+		jv.setFolded(false)
+	case keyboard.KeyArrowLeft:
+		jv.foldOrParent()
+	case keyboard.KeyEnter:
+		jv.toggle()
+	case copyKey:
+		return jv.copySelected()
+	}
+	return nil
+}
+
+// Mouse selects the clicked line and folds or expands it, the mouse wheel
+// moves the selected line.
+// Implements widgetapi.Widget.Mouse.
+func (jv *JSONView) Mouse(m *terminalapi.Mouse) error {
+	jv.mu.Lock()
+	defer jv.mu.Unlock()
+
+	switch m.Button {
+	case mouse.ButtonLeft:
+		// The first line displays the path.
+		if m.Position.Y < 1 {
+			return nil
+		}
+		if i := jv.offset + m.Position.Y - 1; i < len(jv.lines) {
+			jv.selected = i
+			jv.toggle()
+		}
+	case mouse.ButtonWheelUp:
+		jv.moveSelection(-1)
+	case mouse.ButtonWheelDown:
+		jv.moveSelection(1)
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (jv *JSONView) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// The path and at least one line of the document.
+		MinimumSize:  image.Point{1, 2},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
@@ -0,0 +1,461 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonview
+
+import (
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// testDoc is a JSON document used in the tests.
+const testDoc = `{
+  "name": "web",
+  "server": {"port": 80, "tls": {"cert": "x"}},
+  "hosts": ["a", null]
+}`
+
+// selected are the cell options of the background of the selected line.
+var selected = cell.BgColor(cell.ColorNumber(DefaultSelectedColorNumber))
+
+// colored returns text options with the foreground color and the extra cell
+// options.
+func colored(c cell.Color, cOpts ...cell.Option) draw.TextOption {
+	return draw.TextCellOpts(append([]cell.Option{cell.FgColor(c)}, cOpts...)...)
+}
+
+// mustSelect highlights the line as selected.
+func mustSelect(c *canvas.Canvas, y int) {
+	testcanvas.MustSetAreaCells(c, image.Rect(0, y, c.Area().Dx(), y+1), ' ', selected)
+}
+
+// mustPath draws the path on the first line.
+func mustPath(c *canvas.Canvas, path string) {
+	testdraw.MustText(c, path, image.Point{0, 0}, colored(DefaultPathColor))
+}
+
+func TestJSONView(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		doc        string
+		canvas     image.Rectangle
+		events     []terminalapi.Event
+		want       func(size image.Point) *faketerm.Terminal
+		wantCopied []string
+		wantNewErr bool
+		wantSetErr bool
+		wantKbErr  bool
+	}{
+		{
+			desc:       "fails on invalid FoldDepth",
+			opts:       []Option{FoldDepth(0)},
+			canvas:     image.Rect(0, 0, 20, 5),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on invalid document",
+			doc:        `{"a": }`,
+			canvas:     image.Rect(0, 0, 20, 5),
+			wantSetErr: true,
+		},
+		{
+			desc:   "draws nothing without a document",
+			canvas: image.Rect(0, 0, 20, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws a top level scalar",
+			doc:    `"a\tb"`,
+			canvas: image.Rect(0, 0, 20, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustPath(c, "$")
+				mustSelect(c, 1)
+				testdraw.MustText(c, `"a\tb"`, image.Point{0, 1}, colored(DefaultStringColor, selected))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "folds deep objects by default",
+			doc:    testDoc,
+			canvas: image.Rect(0, 0, 20, 8),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustPath(c, "$ > name")
+				mustSelect(c, 1)
+				testdraw.MustText(c, "name", image.Point{0, 1}, colored(DefaultKeyColor, selected))
+				testdraw.MustText(c, ": ", image.Point{4, 1}, draw.TextCellOpts(selected))
+				testdraw.MustText(c, `"web"`, image.Point{6, 1}, colored(DefaultStringColor, selected))
+				testdraw.MustText(c, "server", image.Point{0, 2}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": ", image.Point{6, 2})
+				testdraw.MustText(c, "port", image.Point{2, 3}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": ", image.Point{6, 3})
+				testdraw.MustText(c, "80", image.Point{8, 3}, colored(DefaultNumberColor))
+				testdraw.MustText(c, "tls", image.Point{2, 4}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": {...}", image.Point{5, 4})
+				testdraw.MustText(c, "hosts", image.Point{0, 5}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": ", image.Point{5, 5})
+				testdraw.MustText(c, "[0]: ", image.Point{2, 6})
+				testdraw.MustText(c, `"a"`, image.Point{7, 6}, colored(DefaultStringColor))
+				testdraw.MustText(c, "[1]: null", image.Point{2, 7})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "custom fold depth and colors",
+			opts:   []Option{FoldDepth(1), KeyColor(cell.ColorRed), PathColor(cell.ColorWhite), SelectedColor(cell.ColorWhite)},
+			doc:    `{"a": {"b": 1}, "c": [], "d": [1]}`,
+			canvas: image.Rect(0, 0, 20, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				sel := cell.BgColor(cell.ColorWhite)
+				testdraw.MustText(c, "$ > a", image.Point{0, 0}, colored(cell.ColorWhite))
+				testcanvas.MustSetAreaCells(c, image.Rect(0, 1, 20, 2), ' ', sel)
+				testdraw.MustText(c, "a", image.Point{0, 1}, colored(cell.ColorRed, sel))
+				testdraw.MustText(c, ": ", image.Point{1, 1}, draw.TextCellOpts(sel))
+				testdraw.MustText(c, "{...}", image.Point{3, 1}, draw.TextCellOpts(sel))
+				testdraw.MustText(c, "c", image.Point{0, 2}, colored(cell.ColorRed))
+				testdraw.MustText(c, ": []", image.Point{1, 2})
+				testdraw.MustText(c, "d", image.Point{0, 3}, colored(cell.ColorRed))
+				testdraw.MustText(c, ": [...]", image.Point{1, 3})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "enter expands the selected line and the path follows the selection",
+			opts:   []Option{FoldDepth(1)},
+			doc:    `{"a": [true]}`,
+			canvas: image.Rect(0, 0, 20, 3),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustPath(c, "$ > a > [0]")
+				testdraw.MustText(c, "a", image.Point{0, 1}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": ", image.Point{1, 1})
+				mustSelect(c, 2)
+				testdraw.MustText(c, "[0]: ", image.Point{2, 2}, draw.TextCellOpts(selected))
+				testdraw.MustText(c, "true", image.Point{7, 2}, draw.TextCellOpts(selected))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "left arrow moves to the parent and folds it",
+			doc:    `{"a": {"b": 1}}`,
+			canvas: image.Rect(0, 0, 20, 3),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustPath(c, "$ > a")
+				mustSelect(c, 1)
+				testdraw.MustText(c, "a", image.Point{0, 1}, colored(DefaultKeyColor, selected))
+				testdraw.MustText(c, ": ", image.Point{1, 1}, draw.TextCellOpts(selected))
+				testdraw.MustText(c, "{...}", image.Point{3, 1}, draw.TextCellOpts(selected))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "right arrow expands the selected line",
+			opts:   []Option{FoldDepth(1)},
+			doc:    `{"a": {"b": 1}}`,
+			canvas: image.Rect(0, 0, 20, 3),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustPath(c, "$ > a")
+				mustSelect(c, 1)
+				testdraw.MustText(c, "a", image.Point{0, 1}, colored(DefaultKeyColor, selected))
+				testdraw.MustText(c, ": ", image.Point{1, 1}, draw.TextCellOpts(selected))
+				testdraw.MustText(c, "b", image.Point{2, 2}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": ", image.Point{3, 2})
+				testdraw.MustText(c, "1", image.Point{5, 2}, colored(DefaultNumberColor))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "clicking on a line selects and folds it",
+			doc:    `{"x": true, "a": {"b": 1}}`,
+			canvas: image.Rect(0, 0, 20, 3),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{0, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{0, 2}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustPath(c, "$ > a")
+				testdraw.MustText(c, "x", image.Point{0, 1}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": true", image.Point{1, 1})
+				mustSelect(c, 2)
+				testdraw.MustText(c, "a", image.Point{0, 2}, colored(DefaultKeyColor, selected))
+				testdraw.MustText(c, ": ", image.Point{1, 2}, draw.TextCellOpts(selected))
+				testdraw.MustText(c, "{...}", image.Point{3, 2}, draw.TextCellOpts(selected))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls to keep the selected line visible",
+			doc:    `[1, 2, 3, 4]`,
+			canvas: image.Rect(0, 0, 10, 3),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustPath(c, "$ > [3]")
+				testdraw.MustText(c, "[2]: ", image.Point{0, 1})
+				testdraw.MustText(c, "3", image.Point{5, 1}, colored(DefaultNumberColor))
+				mustSelect(c, 2)
+				testdraw.MustText(c, "[3]: ", image.Point{0, 2}, draw.TextCellOpts(selected))
+				testdraw.MustText(c, "4", image.Point{5, 2}, colored(DefaultNumberColor, selected))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "copies the selected value",
+			doc:    `{"a": {"b": [1, "x"]}, "c": null}`,
+			canvas: image.Rect(0, 0, 20, 3),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'c'},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: 'c'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustPath(c, "$ > c")
+				testdraw.MustText(c, "b", image.Point{2, 1}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": [...]", image.Point{3, 1})
+				mustSelect(c, 2)
+				testdraw.MustText(c, "c", image.Point{0, 2}, colored(DefaultKeyColor, selected))
+				testdraw.MustText(c, ": ", image.Point{1, 2}, draw.TextCellOpts(selected))
+				testdraw.MustText(c, "null", image.Point{3, 2}, draw.TextCellOpts(selected))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantCopied: []string{`{"b":[1,"x"]}`, "null"},
+		},
+		{
+			desc:   "returns the error from OnCopy",
+			opts:   []Option{OnCopy(func(string) error { return errors.New("copy failed") })},
+			doc:    `[1]`,
+			canvas: image.Rect(0, 0, 20, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'c'},
+			},
+			wantKbErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var copied []string
+			opts := append([]Option{OnCopy(func(v string) error {
+				copied = append(copied, v)
+				return nil
+			})}, tc.opts...)
+			jv, err := New(opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.doc != "" {
+				err = jv.SetJSON([]byte(tc.doc))
+				if (err != nil) != tc.wantSetErr {
+					t.Errorf("SetJSON => unexpected error: %v, wantSetErr: %v", err, tc.wantSetErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// The first draw determines the page size used when scrolling.
+			if err := jv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					err := jv.Keyboard(e)
+					if (err != nil) != tc.wantKbErr {
+						t.Errorf("Keyboard => unexpected error: %v, wantKbErr: %v", err, tc.wantKbErr)
+					}
+					if err != nil {
+						return
+					}
+				case *terminalapi.Mouse:
+					if err := jv.Mouse(e); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				if err := jv.Draw(c, &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := jv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+			if diff := pretty.Compare(tc.wantCopied, copied); diff != "" {
+				t.Errorf("OnCopy => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// clipboardTerm is a fake terminal that records the clipboard requests.
+type clipboardTerm struct {
+	*faketerm.Terminal
+
+	// copied is the text of each clipboard request.
+	copied []string
+}
+
+// SetClipboard implements terminalapi.ClipboardTerminal.SetClipboard.
+func (ct *clipboardTerm) SetClipboard(text string) error {
+	ct.copied = append(ct.copied, text)
+	return nil
+}
+
+func TestCopyToClipboard(t *testing.T) {
+	jv, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := jv.SetJSON([]byte(`{"a": [1, 2]}`)); err != nil {
+		t.Fatalf("SetJSON => unexpected error: %v", err)
+	}
+	if err := jv.Keyboard(&terminalapi.Keyboard{Key: 'c'}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+
+	ft, err := faketerm.New(image.Point{10, 3})
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	ct := &clipboardTerm{Terminal: ft}
+	// The value is only copied on the first Draw after the key press.
+	for i := 0; i < 2; i++ {
+		c, err := canvas.New(ft.Area())
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := jv.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		if err := c.Apply(ct); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+	}
+
+	if diff := pretty.Compare([]string{"[1,2]"}, ct.copied); diff != "" {
+		t.Errorf("SetClipboard => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	jv, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := jv.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 2},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary jsonviewdemo displays the JSONView widget.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/jsonview"
+)
+
+const release = `{
+  "name": "termdash",
+  "version": "0.13.0",
+  "stable": true,
+  "license": null,
+  "authors": [
+    {"name": "mum4k", "roles": ["owner", "maintainer"]}
+  ],
+  "widgets": {
+    "charts": ["barchart", "linechart", "sparkline"],
+    "gauges": {"count": 3, "names": ["gauge", "donut", "arcgauge"]}
+  }
+}`
+
+func main() {
+	t, err := termbox.New(termbox.WithClipboardSupport(true))
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	jv, err := jsonview.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := jv.SetJSON([]byte(release)); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(jv),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
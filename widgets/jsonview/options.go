@@ -0,0 +1,149 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonview
+
+// options.go contains configurable options for JSONView.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	foldDepth     int
+	keyColor      cell.Color
+	stringColor   cell.Color
+	numberColor   cell.Color
+	pathColor     cell.Color
+	selectedColor cell.Color
+	onCopy        CopyFn
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		foldDepth:     DefaultFoldDepth,
+		keyColor:      DefaultKeyColor,
+		stringColor:   DefaultStringColor,
+		numberColor:   DefaultNumberColor,
+		pathColor:     DefaultPathColor,
+		selectedColor: cell.ColorNumber(DefaultSelectedColorNumber),
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.foldDepth < 1 {
+		return fmt.Errorf("invalid FoldDepth(%d), must be a positive number", o.foldDepth)
+	}
+	return nil
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// DefaultFoldDepth is the default value for the FoldDepth option.
+const DefaultFoldDepth = 2
+
+// FoldDepth sets the depth from which objects and arrays are initially
+// displayed folded. The members of the top level object or array are at
+// depth one. Must be a positive number. Defaults to DefaultFoldDepth.
+func FoldDepth(depth int) Option {
+	return option(func(opts *options) {
+		opts.foldDepth = depth
+	})
+}
+
+// The default colors of the keys, the values and the path.
+const (
+	DefaultKeyColor    = cell.ColorBlue
+	DefaultStringColor = cell.ColorGreen
+	DefaultNumberColor = cell.ColorYellow
+	DefaultPathColor   = cell.ColorCyan
+)
+
+// KeyColor sets the color of the object keys.
+// Defaults to DefaultKeyColor.
+func KeyColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.keyColor = c
+	})
+}
+
+// StringColor sets the color of the string values.
+// Defaults to DefaultStringColor.
+func StringColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.stringColor = c
+	})
+}
+
+// NumberColor sets the color of the numeric values.
+// Defaults to DefaultNumberColor.
+func NumberColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.numberColor = c
+	})
+}
+
+// PathColor sets the color of the path to the selected value displayed on
+// the first line. Defaults to DefaultPathColor.
+func PathColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.pathColor = c
+	})
+}
+
+// DefaultSelectedColorNumber is the default background color of the selected
+// line, this is an Xterm color number, see cell.ColorNumber.
+const DefaultSelectedColorNumber = 238
+
+// SelectedColor sets the background color of the selected line.
+// Defaults to DefaultSelectedColorNumber.
+func SelectedColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.selectedColor = c
+	})
+}
+
+// CopyFn is a function called with the compact JSON encoding of the value
+// on the selected line when the user requests a copy. An error returned from
+// the function is returned from the Keyboard method of the widget.
+type CopyFn func(value string) error
+
+// OnCopy sets the function called when the 'c' key copies the selected value
+// to the clipboard.
+func OnCopy(fn CopyFn) Option {
+	return option(func(opts *options) {
+		opts.onCopy = fn
+	})
+}
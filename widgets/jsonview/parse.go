@@ -0,0 +1,176 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonview
+
+// parse.go parses JSON documents into ordered trees.
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// nodeKind is the kind of a JSON value.
+type nodeKind int
+
+const (
+	nodeObject nodeKind = iota
+	nodeArray
+	nodeString
+	nodeNumber
+	nodeBool
+	nodeNull
+)
+
+// node is a value in the parsed document.
+type node struct {
+	kind nodeKind
+	// value is the text of a number or a boolean or the value of a string.
+	value string
+	// children are the members of an object or the elements of an array, in
+	// the order they appear in the document.
+	children []*child
+	// parent is the child that contains this node, nil for the root.
+	parent *child
+	// folded indicates that the children of an object or an array are hidden.
+	folded bool
+}
+
+// child is a member of an object or an element of an array.
+type child struct {
+	// key is the key of an object member or the index of an array element.
+	key string
+	// index indicates that the key is an array index.
+	index bool
+	node  *node
+	// owner is the object or the array containing this child.
+	owner *node
+}
+
+// collection determines if the node is an object or an array.
+func (n *node) collection() bool {
+	return n.kind == nodeObject || n.kind == nodeArray
+}
+
+// parse parses the JSON document. The members of objects keep their order.
+func parse(doc []byte) (*node, error) {
+	d := json.NewDecoder(bytes.NewReader(doc))
+	d.UseNumber()
+	n, err := parseValue(d)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, errors.New("invalid JSON, unexpected data after the top level value")
+	}
+	return n, nil
+}
+
+// parseValue parses the next value from the decoder.
+func parseValue(d *json.Decoder) (*node, error) {
+	t, err := d.Token()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	switch v := t.(type) {
+	case json.Delim:
+		n := &node{kind: nodeObject}
+		if v == '[' {
+			n.kind = nodeArray
+		}
+		for i := 0; d.More(); i++ {
+			c := &child{owner: n}
+			if n.kind == nodeObject {
+				kt, err := d.Token()
+				if err != nil {
+					return nil, fmt.Errorf("invalid JSON: %v", err)
+				}
+				c.key = kt.(string)
+			} else {
+				c.key = fmt.Sprint(i)
+				c.index = true
+			}
+			if c.node, err = parseValue(d); err != nil {
+				return nil, err
+			}
+			c.node.parent = c
+			n.children = append(n.children, c)
+		}
+		// The closing delimiter.
+		if _, err := d.Token(); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %v", err)
+		}
+		return n, nil
+
+	case string:
+		return &node{kind: nodeString, value: v}, nil
+	case json.Number:
+		return &node{kind: nodeNumber, value: v.String()}, nil
+	case bool:
+		return &node{kind: nodeBool, value: fmt.Sprint(v)}, nil
+	default:
+		return &node{kind: nodeNull, value: "null"}, nil
+	}
+}
+
+// encode returns the compact JSON encoding of the node.
+func encode(n *node) string {
+	var b strings.Builder
+	writeNode(&b, n)
+	return b.String()
+}
+
+// writeNode writes the compact JSON encoding of the node.
+func writeNode(b *strings.Builder, n *node) {
+	switch n.kind {
+	case nodeObject, nodeArray:
+		open, close := "{", "}"
+		if n.kind == nodeArray {
+			open, close = "[", "]"
+		}
+		b.WriteString(open)
+		for i, c := range n.children {
+			if i > 0 {
+				b.WriteString(",")
+			}
+			if n.kind == nodeObject {
+				b.WriteString(quote(c.key))
+				b.WriteString(":")
+			}
+			writeNode(b, c.node)
+		}
+		b.WriteString(close)
+	case nodeString:
+		b.WriteString(quote(n.value))
+	default:
+		b.WriteString(n.value)
+	}
+}
+
+// quote returns the JSON encoding of the string.
+func quote(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		// Encoding a string cannot fail.
+		panic(err)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
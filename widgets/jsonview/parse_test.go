@@ -0,0 +1,97 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsonview
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		desc string
+		doc  string
+		// want is the compact encoding of the parsed document.
+		want    string
+		wantErr bool
+	}{
+		{
+			desc: "top level scalar",
+			doc:  ` "a\tb" `,
+			want: `"a\tb"`,
+		},
+		{
+			desc: "keeps the order of object members",
+			doc:  `{"z": 1, "a": [true, null, 1.5e3], "m": {}}`,
+			want: `{"z":1,"a":[true,null,1.5e3],"m":{}}`,
+		},
+		{
+			desc: "keeps HTML characters unescaped",
+			doc:  `["<a&b>"]`,
+			want: `["<a&b>"]`,
+		},
+		{
+			desc:    "fails on an empty document",
+			doc:     "",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on an unterminated object",
+			doc:     `{"a": 1`,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on data after the top level value",
+			doc:     `{} []`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parse([]byte(tc.doc))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("parse => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if enc := encode(got); enc != tc.want {
+				t.Errorf("parse => got %q, want %q", enc, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseStructure(t *testing.T) {
+	n, err := parse([]byte(`{"a": [1, {"b": "c"}]}`))
+	if err != nil {
+		t.Fatalf("parse => unexpected error: %v", err)
+	}
+
+	if n.kind != nodeObject || len(n.children) != 1 {
+		t.Fatalf("parse => got root kind %v with %d children, want an object with one member", n.kind, len(n.children))
+	}
+	a := n.children[0]
+	if a.key != "a" || a.index || a.owner != n || a.node.parent != a {
+		t.Errorf("parse => unexpected member %+v", a)
+	}
+	if len(a.node.children) != 2 {
+		t.Fatalf("parse => got %d array elements, want 2", len(a.node.children))
+	}
+	e := a.node.children[1]
+	if e.key != "1" || !e.index || e.owner != a.node || e.node.kind != nodeObject {
+		t.Errorf("parse => unexpected array element %+v", e)
+	}
+}
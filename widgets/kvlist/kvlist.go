@@ -0,0 +1,247 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kvlist contains a widget that displays a list of key-value pairs.
+package kvlist
+
+import (
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/private/wrap"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// KVPair is a single key and its value.
+type KVPair struct {
+	Key   string
+	Value string
+}
+
+// validate validates the pair.
+func (p KVPair) validate() error {
+	for _, s := range []string{p.Key, p.Value} {
+		if s == "" {
+			continue
+		}
+		if err := wrap.ValidText(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// KVList displays a list of key-value pairs in two columns.
+//
+// The keys are right-aligned in a column as wide as the longest key, the
+// values are left-aligned next to them. Keys and values that don't fit are
+// trimmed. The pairs can be scrolled using the keyboard or the mouse.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type KVList struct {
+	// pairs are the displayed pairs.
+	pairs []KVPair
+
+	// offset is the index of the first visible pair.
+	offset int
+	// height is the height of the canvas during the last Draw.
+	height int
+
+	// mu protects the KVList.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new KVList.
+func New(opts ...Option) (*KVList, error) {
+	o := newOptions(opts...)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &KVList{
+		opts: o,
+	}, nil
+}
+
+// SetPairs replaces all the displayed pairs. The keys and values cannot
+// contain control characters.
+func (kv *KVList) SetPairs(pairs []KVPair) error {
+	for i, p := range pairs {
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("invalid pair at index %d: %v", i, err)
+		}
+	}
+
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	kv.pairs = append([]KVPair(nil), pairs...)
+	kv.scroll(0)
+	return nil
+}
+
+// SetValue updates the value of the first pair with the key. A new pair is
+// added at the end of the list if there is no pair with the key.
+func (kv *KVList) SetValue(key, value string) error {
+	p := KVPair{Key: key, Value: value}
+	if err := p.validate(); err != nil {
+		return err
+	}
+
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	for i := range kv.pairs {
+		if kv.pairs[i].Key == key {
+			kv.pairs[i].Value = value
+			return nil
+		}
+	}
+	kv.pairs = append(kv.pairs, p)
+	return nil
+}
+
+// scroll moves the first visible pair by the specified number of pairs, the
+// offset is kept within the content.
+// The caller must hold kv.mu.
+func (kv *KVList) scroll(by int) {
+	kv.offset += by
+	if max := len(kv.pairs) - kv.height; kv.offset > max {
+		kv.offset = max
+	}
+	if kv.offset < 0 {
+		kv.offset = 0
+	}
+}
+
+// keyWidth returns the width of the key column, the longest key is trimmed
+// so that at least one cell of each value remains visible.
+// The caller must hold kv.mu.
+func (kv *KVList) keyWidth(width int) int {
+	kw := 0
+	for _, p := range kv.pairs {
+		if w := runewidth.StringWidth(p.Key); w > kw {
+			kw = w
+		}
+	}
+	if max := width - runewidth.StringWidth(kv.opts.separator) - 1; kw > max {
+		kw = max
+	}
+	if kw < 0 {
+		return 0
+	}
+	return kw
+}
+
+// Draw draws the KVList widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (kv *KVList) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	ar := cvs.Area()
+	kv.height = ar.Dy()
+	kv.scroll(0)
+
+	kw := kv.keyWidth(ar.Dx())
+	sepX := ar.Min.X + kw
+	valX := sepX + runewidth.StringWidth(kv.opts.separator)
+	keyOpts := []cell.Option{cell.FgColor(kv.opts.keyColor)}
+	for i, p := range kv.pairs[kv.offset:] {
+		y := ar.Min.Y + i
+		if y >= ar.Max.Y {
+			break
+		}
+
+		if p.Key != "" && kw > 0 {
+			x := sepX - runewidth.StringWidth(p.Key)
+			if x < ar.Min.X {
+				x = ar.Min.X
+			}
+			if err := drawText(cvs, p.Key, image.Point{x, y}, sepX, keyOpts); err != nil {
+				return err
+			}
+		}
+		if kv.opts.separator != "" {
+			if err := drawText(cvs, kv.opts.separator, image.Point{sepX, y}, valX, keyOpts); err != nil {
+				return err
+			}
+		}
+		if p.Value != "" {
+			if err := drawText(cvs, p.Value, image.Point{valX, y}, ar.Max.X, []cell.Option{cell.FgColor(kv.opts.valueColor)}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// drawText draws the text starting at the point, trimming it at maxX.
+func drawText(cvs *canvas.Canvas, text string, start image.Point, maxX int, cOpts []cell.Option) error {
+	return draw.Text(cvs, text, start,
+		draw.TextCellOpts(cOpts...),
+		draw.TextMaxX(maxX),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	)
+}
+
+// Keyboard scrolls the pairs.
+// Implements widgetapi.Widget.Keyboard.
+func (kv *KVList) Keyboard(k *terminalapi.Keyboard) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	switch k.Key {
+	case kv.opts.keyUp:
+		kv.scroll(-1)
+	case kv.opts.keyDown:
+		kv.scroll(1)
+	case kv.opts.keyPgUp:
+		kv.scroll(-kv.height)
+	case kv.opts.keyPgDown:
+		kv.scroll(kv.height)
+	}
+	return nil
+}
+
+// Mouse scrolls the pairs.
+// Implements widgetapi.Widget.Mouse.
+func (kv *KVList) Mouse(m *terminalapi.Mouse) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+
+	switch m.Button {
+	case kv.opts.mouseUpButton:
+		kv.scroll(-1)
+	case kv.opts.mouseDownButton:
+		kv.scroll(1)
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (kv *KVList) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// One cell for the key, the separator and one cell for the value.
+		MinimumSize:  image.Point{runewidth.StringWidth(kv.opts.separator) + 2, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvlist
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// keyOpts are the default cell options of keys and separators.
+var keyOpts = draw.TextCellOpts(cell.FgColor(DefaultKeyColor))
+
+func TestKVList(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		pairs      []KVPair
+		update     func(*KVList) error
+		canvas     image.Rectangle
+		events     []terminalapi.Event
+		want       func(size image.Point) *faketerm.Terminal
+		wantNewErr bool
+		wantSetErr bool
+		wantUpdErr bool
+	}{
+		{
+			desc:       "fails on separator with control characters",
+			opts:       []Option{Separator("\t")},
+			canvas:     image.Rect(0, 0, 10, 3),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on duplicate scroll keys",
+			opts:       []Option{ScrollKeys('a', 'b', 'c', 'c')},
+			canvas:     image.Rect(0, 0, 10, 3),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on duplicate scroll mouse buttons",
+			opts:       []Option{ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonLeft)},
+			canvas:     image.Rect(0, 0, 10, 3),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on pair with control characters",
+			pairs:      []KVPair{{Key: "a", Value: "b\x01"}},
+			canvas:     image.Rect(0, 0, 10, 3),
+			wantSetErr: true,
+		},
+		{
+			desc:       "SetValue fails on value with control characters",
+			pairs:      []KVPair{{Key: "a", Value: "b"}},
+			update:     func(kv *KVList) error { return kv.SetValue("a", "\x01") },
+			canvas:     image.Rect(0, 0, 10, 3),
+			wantUpdErr: true,
+		},
+		{
+			desc:   "draws nothing without pairs",
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "right aligns keys and left aligns values",
+			pairs: []KVPair{
+				{Key: "host", Value: "localhost"},
+				{Key: "pid", Value: "42"},
+				{Key: "", Value: "x"},
+			},
+			canvas: image.Rect(0, 0, 20, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "host: ", image.Point{0, 0}, keyOpts)
+				testdraw.MustText(c, "localhost", image.Point{6, 0})
+				testdraw.MustText(c, "pid: ", image.Point{1, 1}, keyOpts)
+				testdraw.MustText(c, "42", image.Point{6, 1})
+				testdraw.MustText(c, ": ", image.Point{4, 2}, keyOpts)
+				testdraw.MustText(c, "x", image.Point{6, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom colors and separator",
+			opts: []Option{
+				KeyColor(cell.ColorRed),
+				ValueColor(cell.ColorBlue),
+				Separator(" = "),
+			},
+			pairs:  []KVPair{{Key: "a", Value: "b"}},
+			canvas: image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "a = ", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "b", image.Point{4, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "trims long keys and values",
+			pairs: []KVPair{
+				{Key: "abcdefgh", Value: "12345"},
+			},
+			canvas: image.Rect(0, 0, 8, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "abcd…: ", image.Point{0, 0}, keyOpts)
+				testdraw.MustText(c, "…", image.Point{7, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:  "SetValue updates an existing pair",
+			pairs: []KVPair{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+			update: func(kv *KVList) error {
+				return kv.SetValue("a", "3")
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "a: ", image.Point{0, 0}, keyOpts)
+				testdraw.MustText(c, "3", image.Point{3, 0})
+				testdraw.MustText(c, "b: ", image.Point{0, 1}, keyOpts)
+				testdraw.MustText(c, "2", image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:  "SetValue adds a new pair",
+			pairs: []KVPair{{Key: "a", Value: "1"}},
+			update: func(kv *KVList) error {
+				return kv.SetValue("bb", "2")
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "a: ", image.Point{1, 0}, keyOpts)
+				testdraw.MustText(c, "1", image.Point{4, 0})
+				testdraw.MustText(c, "bb: ", image.Point{0, 1}, keyOpts)
+				testdraw.MustText(c, "2", image.Point{4, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "scrolls with the keyboard and the mouse",
+			pairs: []KVPair{
+				{Key: "a", Value: "1"},
+				{Key: "b", Value: "2"},
+				{Key: "c", Value: "3"},
+				{Key: "d", Value: "4"},
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "b: ", image.Point{0, 0}, keyOpts)
+				testdraw.MustText(c, "2", image.Point{3, 0})
+				testdraw.MustText(c, "c: ", image.Point{0, 1}, keyOpts)
+				testdraw.MustText(c, "3", image.Point{3, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			kv, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			err = kv.SetPairs(tc.pairs)
+			if (err != nil) != tc.wantSetErr {
+				t.Errorf("SetPairs => unexpected error: %v, wantSetErr: %v", err, tc.wantSetErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				err := tc.update(kv)
+				if (err != nil) != tc.wantUpdErr {
+					t.Errorf("update => unexpected error: %v, wantUpdErr: %v", err, tc.wantUpdErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// The first draw determines the page size used when scrolling.
+			if err := kv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					if err := kv.Keyboard(e); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+				case *terminalapi.Mouse:
+					if err := kv.Mouse(e); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := kv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want widgetapi.Options
+	}{
+		{
+			desc: "default separator",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{4, 1},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc: "empty separator",
+			opts: []Option{Separator("")},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{2, 1},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			kv, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, kv.Options()); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary kvlistdemo displays the KVList widget.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/kvlist"
+)
+
+// updateStats periodically updates the displayed runtime statistics.
+func updateStats(ctx context.Context, kv *kvlist.KVList, delay time.Duration) {
+	start := time.Now()
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			var ms runtime.MemStats
+			runtime.ReadMemStats(&ms)
+			for _, p := range []kvlist.KVPair{
+				{Key: "uptime", Value: time.Since(start).Round(time.Second).String()},
+				{Key: "goroutines", Value: fmt.Sprint(runtime.NumGoroutine())},
+				{Key: "heap", Value: fmt.Sprintf("%d KiB", ms.HeapAlloc/1024)},
+				{Key: "gc cycles", Value: fmt.Sprint(ms.NumGC)},
+			} {
+				if err := kv.SetValue(p.Key, p.Value); err != nil {
+					panic(err)
+				}
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	kv, err := kvlist.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := kv.SetPairs([]kvlist.KVPair{
+		{Key: "go version", Value: runtime.Version()},
+		{Key: "os", Value: runtime.GOOS},
+		{Key: "arch", Value: runtime.GOARCH},
+		{Key: "cpus", Value: fmt.Sprint(runtime.NumCPU())},
+	}); err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go updateStats(ctx, kv, time.Second)
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(kv),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kvlist
+
+// options.go contains configurable options for KVList.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/wrap"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	keyColor        cell.Color
+	valueColor      cell.Color
+	separator       string
+	mouseUpButton   mouse.Button
+	mouseDownButton mouse.Button
+	keyUp           keyboard.Key
+	keyDown         keyboard.Key
+	keyPgUp         keyboard.Key
+	keyPgDown       keyboard.Key
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		keyColor:        DefaultKeyColor,
+		valueColor:      DefaultValueColor,
+		separator:       DefaultSeparator,
+		mouseUpButton:   DefaultScrollMouseButtonUp,
+		mouseDownButton: DefaultScrollMouseButtonDown,
+		keyUp:           DefaultScrollKeyUp,
+		keyDown:         DefaultScrollKeyDown,
+		keyPgUp:         DefaultScrollKeyPageUp,
+		keyPgDown:       DefaultScrollKeyPageDown,
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.separator != "" {
+		if err := wrap.ValidText(o.separator); err != nil {
+			return fmt.Errorf("invalid Separator: %v", err)
+		}
+	}
+	keys := map[keyboard.Key]bool{
+		o.keyUp:     true,
+		o.keyDown:   true,
+		o.keyPgUp:   true,
+		o.keyPgDown: true,
+	}
+	if len(keys) != 4 {
+		return fmt.Errorf("invalid ScrollKeys(up:%v, down:%v, pageUp:%v, pageDown:%v), the keys must be unique", o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown)
+	}
+	if o.mouseUpButton == o.mouseDownButton {
+		return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the buttons must be unique", o.mouseUpButton, o.mouseDownButton)
+	}
+	return nil
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// The default colors of the keys and the values.
+const (
+	DefaultKeyColor   = cell.ColorCyan
+	DefaultValueColor = cell.ColorDefault
+)
+
+// KeyColor sets the color of the keys and the separators.
+// Defaults to DefaultKeyColor.
+func KeyColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.keyColor = c
+	})
+}
+
+// ValueColor sets the color of the values.
+// Defaults to DefaultValueColor.
+func ValueColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.valueColor = c
+	})
+}
+
+// DefaultSeparator is the default value for the Separator option.
+const DefaultSeparator = ": "
+
+// Separator sets the text displayed between each key and its value. Can be
+// empty, but cannot contain control characters. Defaults to DefaultSeparator.
+func Separator(s string) Option {
+	return option(func(opts *options) {
+		opts.separator = s
+	})
+}
+
+// The default mouse buttons for content scrolling.
+const (
+	DefaultScrollMouseButtonUp   = mouse.ButtonWheelUp
+	DefaultScrollMouseButtonDown = mouse.ButtonWheelDown
+)
+
+// ScrollMouseButtons configures the mouse buttons that scroll the pairs.
+// The provided buttons must be unique, e.g. the same button cannot be both up
+// and down.
+func ScrollMouseButtons(up, down mouse.Button) Option {
+	return option(func(opts *options) {
+		opts.mouseUpButton = up
+		opts.mouseDownButton = down
+	})
+}
+
+// The default keys for content scrolling.
+const (
+	DefaultScrollKeyUp       = keyboard.KeyArrowUp
+	DefaultScrollKeyDown     = keyboard.KeyArrowDown
+	DefaultScrollKeyPageUp   = keyboard.KeyPgUp
+	DefaultScrollKeyPageDown = keyboard.KeyPgDn
+)
+
+// ScrollKeys configures the keyboard keys that scroll the pairs.
+// The provided keys must be unique, e.g. the same key cannot be both up and
+// down.
+func ScrollKeys(up, down, pageUp, pageDown keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keyUp = up
+		opts.keyDown = down
+		opts.keyPgUp = pageUp
+		opts.keyPgDown = pageDown
+	})
+}
@@ -0,0 +1,38 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package linechart
+
+// datasource.go contains code that feeds the line chart from data sources.
+
+import "github.com/mum4k/termdash/datasource"
+
+// SetDataSource sets the values of the series with the provided label from
+// the data source and keeps them updated whenever the source emits new data.
+// The new values are displayed on the next redraw.
+// The provided options are applied on every update, see Series.
+func (lc *LineChart) SetDataSource(label string, a datasource.Adapter[[]float64], opts ...SeriesOption) error {
+	if err := lc.Series(label, a.Data(), opts...); err != nil {
+		return err
+	}
+	a.Subscribe(func(values []float64) {
+		// Series only fails on invalid label or options, both of which were
+		// validated above.
+		lc.Series(label, values, opts...)
+	})
+	return nil
+}
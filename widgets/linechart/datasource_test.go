@@ -0,0 +1,52 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package linechart
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/datasource"
+)
+
+func TestSetDataSource(t *testing.T) {
+	lc, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	if err := lc.SetDataSource("", datasource.NewSeries(nil)); err == nil {
+		t.Errorf("SetDataSource => expected an error for an empty label, got nil")
+	}
+
+	src := datasource.NewSeries([]float64{1, 2})
+	if err := lc.SetDataSource("series", src); err != nil {
+		t.Fatalf("SetDataSource => unexpected error: %v", err)
+	}
+	if diff := pretty.Compare([]float64{1, 2}, lc.series["series"].values); diff != "" {
+		t.Errorf("SetDataSource => unexpected values, diff (-want, +got):\n%s", diff)
+	}
+
+	src.Set([]float64{3, 4, 5})
+	if diff := pretty.Compare([]float64{3, 4, 5}, lc.series["series"].values); diff != "" {
+		t.Errorf("Set => unexpected values, diff (-want, +got):\n%s", diff)
+	}
+	if got, want := lc.yMax, 5.0; got != want {
+		t.Errorf("Set => yMax %v, want %v", got, want)
+	}
+}
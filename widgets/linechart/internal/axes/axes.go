@@ -16,17 +16,20 @@
 package axes
 
 import (
+	"errors"
 	"fmt"
 	"image"
+	"sort"
 
 	"github.com/mum4k/termdash/private/runewidth"
 )
 
 const (
-	// nonZeroDecimals determines the overall precision of values displayed on the
-	// graph, it indicates the number of non-zero decimal places the values will be
-	// rounded up to.
-	nonZeroDecimals = 2
+	// DefaultDecimals determines the overall precision of values displayed on
+	// the axes, it indicates the number of non-zero decimal places the values
+	// will be rounded up to. Use it with NewValue to format values
+	// consistently with the axis labels.
+	DefaultDecimals = 2
 
 	// axisWidth is width of an axis.
 	axisWidth = 1
@@ -45,23 +48,65 @@ type YDetails struct {
 	End image.Point
 
 	// Scale is the scale of the Y axis.
+	// Always set when NewYDetails returns without an error.
 	Scale *YScale
 
+	// EffectiveMin is the minimum value displayed on the Y axis. This differs
+	// from the Min in the properties if the scale was adjusted, e.g. anchored
+	// at zero.
+	EffectiveMin float64
+	// EffectiveMax is the maximum value displayed on the Y axis. This differs
+	// from the Max in the properties if the scale was adjusted.
+	EffectiveMax float64
+
 	// Labels are the labels for values on the Y axis in an increasing order.
 	Labels []*Label
+
+	// dataAr is the area right of the axis where the data are drawn.
+	dataAr image.Rectangle
+}
+
+// YAxisLayout describes the placement of the Y axis on the canvas.
+type YAxisLayout struct {
+	// Width in character cells of the Y axis and its character labels.
+	Width int
+
+	// Start is the point where the Y axis starts.
+	Start image.Point
+	// End is the point where the Y axis ends.
+	End image.Point
+
+	// DataStart is the top left corner of the area where the data are drawn.
+	DataStart image.Point
+	// DataEnd is the bottom right corner of the area where the data are
+	// drawn. Like the Max point of image.Rectangle, it falls just outside of
+	// the area.
+	DataEnd image.Point
+}
+
+// Layout returns the placement of the Y axis and of the data next to it.
+func (yd *YDetails) Layout() YAxisLayout {
+	return YAxisLayout{
+		Width:     yd.Width,
+		Start:     yd.Start,
+		End:       yd.End,
+		DataStart: yd.dataAr.Min,
+		DataEnd:   yd.dataAr.Max,
+	}
 }
 
 // RequiredWidth calculates the minimum width required in order to draw the Y
 // axis and its labels when displaying values that have this minimum and
 // maximum among all the series.
+// This is equivalent to calling YProperties.RequiredWidth with the provided
+// values and all the other properties at their defaults. Use the method
+// directly when the labels use a LabelFormatter.
 func RequiredWidth(minVal, maxVal float64) int {
-	// This is an estimation only, it is possible that more labels in the
-	// middle will be generated and might be wider than this. Such cases are
-	// handled on the call to Details when the size of canvas is known.
-	return longestLabel([]*Label{
-		{Value: NewValue(minVal, nonZeroDecimals)},
-		{Value: NewValue(maxVal, nonZeroDecimals)},
-	}) + axisWidth
+	yp := &YProperties{
+		Min: minVal,
+		Max: maxVal,
+	}
+	return yp.RequiredWidth()
 }
 
 // YProperties are the properties of the Y axis.
@@ -74,29 +119,107 @@ type YProperties struct {
 	ReqXHeight int
 	// ScaleMode determines how the Y axis scales.
 	ScaleMode YScaleMode
-	// ValueFormatter is the formatter used to format numeric values to string representation.
-	ValueFormatter func(float64) string
+	// YScaleAnchor is the value the Y axis is anchored to when the ScaleMode
+	// is YScaleModeAnchored. Defaults to zero.
+	YScaleAnchor float64
+	// LabelFormatter is the formatter used to format numeric values of the
+	// labels to string representation. Use SimpleLabelFormatter for
+	// formatters that don't need the context of the label.
+	LabelFormatter LabelFormatter
+	// TextDirection is the direction in which the text of the labels flows.
+	TextDirection TextDirection
+	// Inset is an optional area of the canvas explicitly allocated for the
+	// data, i.e. the graph inside of the axes. When not empty, the Y axis is
+	// placed in the column immediately left of the inset and spans its
+	// height, the labels occupy the space between the left edge of the
+	// canvas and the axis. ReqXHeight is ignored when the inset is provided.
+	Inset image.Rectangle
+	// PadChar when not zero is used to pad the text of the labels so that
+	// each label fills the entire width of the label area, e.g. '0' displays
+	// "007" instead of "  7". The rune must have a width of one cell.
+	// Labels are aligned by their position without any padding when zero.
+	PadChar rune
+	// HideLabelsWhenTooNarrow when set hides the labels instead of failing
+	// when the canvas is too narrow for them or when the labels would take
+	// more than half of its width. The Y axis then only occupies a single
+	// column for the axis line. Ignored when the inset is provided.
+	HideLabelsWhenTooNarrow bool
+}
+
+// RequiredWidth calculates the minimum width required in order to draw the Y
+// axis and its labels with these properties.
+// The minimum and maximum values are formatted the same way as the labels
+// will be, so the width also accounts for values that are displayed in the
+// scientific notation or using the LabelFormatter. The LabelFormatter is
+// called as if these values were the only labels on the axis.
+func (yp *YProperties) RequiredWidth() int {
+	// This is an estimation only, it is possible that more labels in the
+	// middle will be generated and might be wider than this. Such cases are
+	// handled on the call to Details when the size of canvas is known.
+	values := []float64{yp.Min, yp.Max}
+	if yp.ScaleMode == YScaleModeAnchored {
+		// The scale extends to the anchor, which might have a wider label.
+		values = append(values, yp.YScaleAnchor)
+	}
+	sort.Float64s(values)
+
+	var labels []*Label
+	for i, v := range values {
+		vf := yp.LabelFormatter.valueFormatter(LabelContext{
+			Index: i,
+			Total: len(values),
+			Axis:  AxisY,
+		})
+		labels = append(labels, &Label{
+			Value: yScaleNewValue(v, DefaultDecimals, vf),
+		})
+	}
+	return longestLabel(labels) + axisWidth
 }
 
 // NewYDetails retrieves details about the Y axis required to draw it on a
 // canvas of the provided area.
+// Unless an inset is provided in the properties, the canvas is split between
+// the width of the Y axis with its labels and the width of the data.
 func NewYDetails(cvsAr image.Rectangle, yp *YProperties) (*YDetails, error) {
+	if cvsAr.Dx() <= 0 {
+		return nil, errors.New("canvas width must be positive")
+	}
+	if cvsAr.Dy() <= 0 {
+		return nil, errors.New("canvas height must be positive")
+	}
+	if r := yp.PadChar; r != 0 && runewidth.RuneWidth(r) != 1 {
+		return nil, fmt.Errorf("invalid PadChar %q, must have a width of one cell", r)
+	}
+	if !yp.Inset.Empty() {
+		return insetYDetails(cvsAr, yp)
+	}
+
 	cvsWidth := cvsAr.Dx()
 	cvsHeight := cvsAr.Dy()
 	maxWidth := cvsWidth - 1 // Reserve one column for the line chart itself.
-	if req := RequiredWidth(yp.Min, yp.Max); maxWidth < req {
+	req := yp.RequiredWidth()
+	hideLabels := yp.HideLabelsWhenTooNarrow && (maxWidth < req || 2*(req-axisWidth) > cvsWidth)
+	if hideLabels && maxWidth < axisWidth {
+		return nil, fmt.Errorf("the available maxWidth %d is smaller than the width of the axis %d", maxWidth, axisWidth)
+	}
+	if !hideLabels && maxWidth < req {
 		return nil, fmt.Errorf("the available maxWidth %d is smaller than the reported required width %d", maxWidth, req)
 	}
 
 	graphHeight := cvsHeight - yp.ReqXHeight
-	scale, err := NewYScale(yp.Min, yp.Max, graphHeight, nonZeroDecimals, yp.ScaleMode, yp.ValueFormatter)
+	scale, err := NewYScale(yp.Min, yp.Max, graphHeight, DefaultDecimals, yp.ScaleMode, yp.LabelFormatter.valueFormatter(LabelContext{Axis: AxisY}), YScaleAnchor(yp.YScaleAnchor))
 	if err != nil {
 		return nil, err
 	}
 
+	if hideLabels {
+		return unlabeledYDetails(cvsWidth, graphHeight, scale), nil
+	}
+
 	// See how the labels would look like on the entire maxWidth.
 	maxLabelWidth := maxWidth - axisWidth
-	labels, err := yLabels(scale, maxLabelWidth)
+	labels, err := yLabels(scale, maxLabelWidth, yp.TextDirection, yp.LabelFormatter)
 	if err != nil {
 		return nil, err
 	}
@@ -105,9 +228,13 @@ func NewYDetails(cvsAr image.Rectangle, yp *YProperties) (*YDetails, error) {
 	// Determine the largest label, which might be less than maxWidth.
 	// Such case would allow us to save more space for the line chart itself.
 	widest := longestLabel(labels)
+	if yp.HideLabelsWhenTooNarrow && 2*widest > cvsWidth {
+		// The labels in the middle of the scale can be wider than estimated.
+		return unlabeledYDetails(cvsWidth, graphHeight, scale), nil
+	}
 	if widest < maxLabelWidth {
 		// Save the space and recalculate the labels, since they need to be realigned.
-		l, err := yLabels(scale, widest)
+		l, err := yLabels(scale, widest, yp.TextDirection, yp.LabelFormatter)
 		if err != nil {
 			return nil, err
 		}
@@ -116,27 +243,119 @@ func NewYDetails(cvsAr image.Rectangle, yp *YProperties) (*YDetails, error) {
 	} else {
 		width = maxWidth
 	}
+	if yp.PadChar != 0 {
+		padLabels(labels, width-axisWidth, yp.PadChar, yp.TextDirection)
+	}
+	// Labels are positioned relative to the top left corner of the canvas.
+	if err := validateYLabels(labels, image.Rect(0, 0, cvsWidth, cvsHeight)); err != nil {
+		return nil, err
+	}
+
+	return &YDetails{
+		Width:        width,
+		Start:        image.Point{width - 1, 0},
+		End:          image.Point{width - 1, graphHeight},
+		Scale:        scale,
+		EffectiveMin: scale.Min.Value,
+		EffectiveMax: scale.Max.Value,
+		Labels:       labels,
+		dataAr:       image.Rect(width, 0, cvsWidth, graphHeight),
+	}, nil
+}
+
+// unlabeledYDetails returns details of a Y axis without any labels that only
+// occupies a single column of the canvas.
+func unlabeledYDetails(cvsWidth, graphHeight int, scale *YScale) *YDetails {
+	return &YDetails{
+		Width:        axisWidth,
+		Start:        image.Point{axisWidth - 1, 0},
+		End:          image.Point{axisWidth - 1, graphHeight},
+		Scale:        scale,
+		EffectiveMin: scale.Min.Value,
+		EffectiveMax: scale.Max.Value,
+		dataAr:       image.Rect(axisWidth, 0, cvsWidth, graphHeight),
+	}
+}
+
+// insetYDetails retrieves details about the Y axis drawn left of the inset
+// explicitly allocated for the data in the provided canvas area.
+func insetYDetails(cvsAr image.Rectangle, yp *YProperties) (*YDetails, error) {
+	if !yp.Inset.In(cvsAr) {
+		return nil, fmt.Errorf("the inset %v must fall within the canvas area %v", yp.Inset, cvsAr)
+	}
+	width := yp.Inset.Min.X - cvsAr.Min.X
+	if req := yp.RequiredWidth(); width < req {
+		return nil, fmt.Errorf("the width %d left of the inset %v is smaller than the reported required width %d", width, yp.Inset, req)
+	}
+
+	scale, err := NewYScale(yp.Min, yp.Max, yp.Inset.Dy(), DefaultDecimals, yp.ScaleMode, yp.LabelFormatter.valueFormatter(LabelContext{Axis: AxisY}), YScaleAnchor(yp.YScaleAnchor))
+	if err != nil {
+		return nil, err
+	}
+	labels, err := yLabels(scale, width-axisWidth, yp.TextDirection, yp.LabelFormatter)
+	if err != nil {
+		return nil, err
+	}
+	if yp.PadChar != 0 {
+		padLabels(labels, width-axisWidth, yp.PadChar, yp.TextDirection)
+	}
+	// The labels are positioned relative to the top left corner of the
+	// label area.
+	offset := image.Point{cvsAr.Min.X, yp.Inset.Min.Y}
+	for _, l := range labels {
+		l.Pos = l.Pos.Add(offset)
+	}
+	if err := validateYLabels(labels, cvsAr); err != nil {
+		return nil, err
+	}
 
+	axisX := yp.Inset.Min.X - axisWidth
 	return &YDetails{
-		Width:  width,
-		Start:  image.Point{width - 1, 0},
-		End:    image.Point{width - 1, graphHeight},
-		Scale:  scale,
-		Labels: labels,
+		Width:        width,
+		Start:        image.Point{axisX, yp.Inset.Min.Y},
+		End:          image.Point{axisX, yp.Inset.Max.Y},
+		Scale:        scale,
+		EffectiveMin: scale.Min.Value,
+		EffectiveMax: scale.Max.Value,
+		Labels:       labels,
+		dataAr:       yp.Inset,
 	}, nil
 }
 
+// validateYLabels validates that all the labels are positioned on rows within
+// the provided area.
+func validateYLabels(labels []*Label, ar image.Rectangle) error {
+	for _, l := range labels {
+		if y := l.Pos.Y; y < ar.Min.Y || y >= ar.Max.Y {
+			return fmt.Errorf("label %q at %v falls outside of the rows %d <= y < %d", l.Value.Text(), l.Pos, ar.Min.Y, ar.Max.Y)
+		}
+	}
+	return nil
+}
+
 // longestLabel returns the width of the widest label.
 func longestLabel(labels []*Label) int {
 	var widest int
 	for _, label := range labels {
-		if l := runewidth.StringWidth(label.Value.Text()); l > widest {
+		if l := LabelWidth(label.Value.Text()); l > widest {
 			widest = l
 		}
 	}
 	return widest
 }
 
+// tallestLabel returns the number of rows of the tallest label when the labels
+// are drawn one cluster per row, see LabelClusters.
+func tallestLabel(labels []*Label) int {
+	var tallest int
+	for _, label := range labels {
+		if l := labelRows(label.Value.Text()); l > tallest {
+			tallest = l
+		}
+	}
+	return tallest
+}
+
 // XDetails contain information about the X axis that will be drawn onto the
 // canvas.
 type XDetails struct {
@@ -147,11 +366,18 @@ type XDetails struct {
 	End image.Point
 
 	// Scale is the scale of the X axis.
+	// Always set when NewXDetails returns without an error.
 	Scale *XScale
 
 	// Labels are the labels for values on the X axis in an increasing order.
+	// Contains a label for each column that represents at least one value,
+	// only the labels that fit without overlapping are visible.
 	Labels []*Label
 
+	// LO is the orientation the labels were placed in. This differs from the
+	// orientation in Properties if the labels were automatically rotated.
+	LO LabelOrientation
+
 	// Properties are the properties that were used on the call to NewXDetails.
 	Properties *XProperties
 }
@@ -176,6 +402,21 @@ type XProperties struct {
 	CustomLabels map[int]string
 	// LO is the desired orientation of labels under the X axis.
 	LO LabelOrientation
+	// TruncateVerticalLabels when true allows vertical labels to be drawn on
+	// a canvas that is too short to fit them. Such labels are truncated to the
+	// available rows and end with a '…' rune.
+	// When false, NewXDetails returns an error if the labels don't fit.
+	TruncateVerticalLabels bool
+	// AutoRotate when true allows NewXDetails to place horizontal labels
+	// vertically instead if that displays more of them, i.e. when adjacent
+	// horizontal labels would overlap.
+	// The choice only depends on the properties and the canvas size, so the
+	// orientation stays the same across frames unless these change.
+	AutoRotate bool
+	// MaxLabels when positive limits the number of labels placed under the X
+	// axis. The displayed labels are spread evenly across the axis and custom
+	// labels are preferred over the others.
+	MaxLabels int
 }
 
 // NewXDetails retrieves details about the X axis required to draw it on a canvas
@@ -185,16 +426,63 @@ type XProperties struct {
 // customLabels are the desired labels for the X axis, these are preferred if
 // provided.
 func NewXDetails(cvsAr image.Rectangle, xp *XProperties) (*XDetails, error) {
+	if cvsAr.Dx() <= 0 {
+		return nil, errors.New("canvas width must be positive")
+	}
+	if cvsAr.Dy() <= 0 {
+		return nil, errors.New("canvas height must be positive")
+	}
+	xd, err := newXDetails(cvsAr, xp, xp.LO)
+	if err != nil {
+		return nil, err
+	}
+	if !xp.AutoRotate || xp.LO != LabelOrientationHorizontal {
+		return xd, nil
+	}
+
+	rotated, err := newXDetails(cvsAr, xp, LabelOrientationVertical)
+	if err != nil {
+		// The vertical labels don't fit the height of the canvas.
+		return xd, nil
+	}
+	if visibleLabels(rotated.Labels) > visibleLabels(xd.Labels) {
+		return rotated, nil
+	}
+	return xd, nil
+}
+
+// visibleLabels returns the number of visible labels.
+func visibleLabels(labels []*Label) int {
+	var n int
+	for _, l := range labels {
+		if l.Visible {
+			n++
+		}
+	}
+	return n
+}
+
+// newXDetails is like NewXDetails, but places the labels in the provided
+// orientation.
+func newXDetails(cvsAr image.Rectangle, xp *XProperties, lo LabelOrientation) (*XDetails, error) {
 	cvsHeight := cvsAr.Dy()
 	maxHeight := cvsHeight - 1 // Reserve one row for the line chart itself.
-	reqHeight := RequiredHeight(xp.Max, xp.CustomLabels, xp.LO)
+	reqHeight, err := RequiredHeight(xp.Max, xp.CustomLabels, lo)
+	if err != nil {
+		return nil, err
+	}
+	truncate := false
 	if maxHeight < reqHeight {
-		return nil, fmt.Errorf("the available maxHeight %d is smaller than the reported required height %d", maxHeight, reqHeight)
+		if !xp.TruncateVerticalLabels || lo != LabelOrientationVertical || maxHeight < axisWidth+1 {
+			return nil, fmt.Errorf("the available maxHeight %d is smaller than the reported required height %d", maxHeight, reqHeight)
+		}
+		reqHeight = maxHeight
+		truncate = true
 	}
 
 	// The space between the start of the axis and the end of the canvas.
 	graphWidth := cvsAr.Dx() - xp.ReqYWidth - 1
-	scale, err := NewXScale(xp.Min, xp.Max, graphWidth, nonZeroDecimals)
+	scale, err := NewXScale(xp.Min, xp.Max, graphWidth, DefaultDecimals)
 	if err != nil {
 		return nil, err
 	}
@@ -205,36 +493,67 @@ func NewXDetails(cvsAr image.Rectangle, xp *XProperties) (*XDetails, error) {
 		xp.ReqYWidth + 1,
 		cvsAr.Dy() - reqHeight - 1,
 	}
-	labels, err := xLabels(scale, graphZero, xp.CustomLabels, xp.LO)
+	labels, err := xLabels(scale, graphZero, xp.CustomLabels, lo)
+	if err != nil {
+		return nil, err
+	}
+	labels = limitLabels(labels, xp.MaxLabels)
+	if truncate {
+		for _, l := range labels {
+			l.Value = truncateLabel(l.Value, reqHeight-axisWidth)
+		}
+	}
+	labels, err = xTicks(scale, graphZero, xp.CustomLabels, labels)
 	if err != nil {
 		return nil, err
 	}
+	if xp.Min == xp.Max {
+		// The scale was widened to avoid zero extent, but only the value
+		// that exists on the axis gets a label.
+		var inRange []*Label
+		for _, l := range labels {
+			if !(l.Value.Value > float64(xp.Max)) {
+				inRange = append(inRange, l)
+			}
+		}
+		labels = inRange
+	}
 
 	return &XDetails{
 		Start:      image.Point{xp.ReqYWidth, cvsAr.Dy() - reqHeight}, // Space for the labels.
 		End:        image.Point{xp.ReqYWidth + graphWidth, cvsAr.Dy() - reqHeight},
 		Scale:      scale,
 		Labels:     labels,
+		LO:         lo,
 		Properties: xp,
 	}, nil
 }
 
 // RequiredHeight calculates the minimum height required in order to draw the X
 // axis and its labels.
-func RequiredHeight(max int, customLabels map[int]string, lo LabelOrientation) int {
-	if lo == LabelOrientationHorizontal {
+// Both vertical and diagonal labels place one rune and its combining
+// characters per row, so they require as many rows as there are such clusters
+// in the longest label, regardless of how many cells each cluster occupies.
+// Returns an error for an unsupported label orientation.
+func RequiredHeight(max int, customLabels map[int]string, lo LabelOrientation) (int, error) {
+	switch lo {
+	case LabelOrientationHorizontal:
 		// One row for the X axis and one row for its labels flowing
 		// horizontally.
-		return axisWidth + 1
-	}
+		return axisWidth + 1, nil
 
-	labels := []*Label{
-		{Value: NewValue(float64(max), nonZeroDecimals)},
-	}
-	for _, cl := range customLabels {
-		labels = append(labels, &Label{
-			Value: NewTextValue(cl),
-		})
+	case LabelOrientationVertical, LabelOrientationDiagonal:
+		labels := []*Label{
+			{Value: NewValue(float64(max), DefaultDecimals)},
+		}
+		for _, cl := range customLabels {
+			labels = append(labels, &Label{
+				Value: NewTextValue(cl),
+			})
+		}
+		return tallestLabel(labels) + axisWidth, nil
+
+	default:
+		return 0, fmt.Errorf("unsupported label orientation %v(%d)", lo, lo)
 	}
-	return longestLabel(labels) + axisWidth
 }
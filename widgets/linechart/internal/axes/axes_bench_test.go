@@ -0,0 +1,127 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package axes
+
+import (
+	"image"
+	"testing"
+)
+
+// benchCvsAr is the area of a typical dashboard sized canvas.
+var benchCvsAr = image.Rect(0, 0, 200, 50)
+
+func BenchmarkNewYDetails(b *testing.B) {
+	benchmarks := []struct {
+		desc string
+		yp   *YProperties
+	}{
+		{
+			desc: "anchored scale",
+			yp: &YProperties{
+				Min:        12.5,
+				Max:        1234.5,
+				ReqXHeight: 2,
+				ScaleMode:  YScaleModeAnchored,
+			},
+		},
+		{
+			desc: "adaptive scale",
+			yp: &YProperties{
+				Min:        12.5,
+				Max:        1234.5,
+				ReqXHeight: 2,
+				ScaleMode:  YScaleModeAdaptive,
+			},
+		},
+		{
+			desc: "adaptive scale with negative values",
+			yp: &YProperties{
+				Min:        -1234.5,
+				Max:        1234.5,
+				ReqXHeight: 2,
+				ScaleMode:  YScaleModeAdaptive,
+			},
+		},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.desc, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := NewYDetails(benchCvsAr, bm.yp); err != nil {
+					b.Fatalf("NewYDetails => unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkNewXDetails(b *testing.B) {
+	benchmarks := []struct {
+		desc string
+		xp   *XProperties
+	}{
+		{
+			desc: "horizontal labels",
+			xp: &XProperties{
+				Min:       0,
+				Max:       999,
+				ReqYWidth: 8,
+			},
+		},
+		{
+			desc: "vertical labels",
+			xp: &XProperties{
+				Min:       0,
+				Max:       999,
+				ReqYWidth: 8,
+				LO:        LabelOrientationVertical,
+			},
+		},
+		{
+			desc: "auto rotated labels",
+			xp: &XProperties{
+				Min:        0,
+				Max:        999,
+				ReqYWidth:  8,
+				AutoRotate: true,
+			},
+		},
+		{
+			desc: "custom labels",
+			xp: &XProperties{
+				Min:       0,
+				Max:       99,
+				ReqYWidth: 8,
+				CustomLabels: map[int]string{
+					0:  "start",
+					50: "middle",
+					99: "end",
+				},
+			},
+		},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.desc, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := NewXDetails(benchCvsAr, bm.xp); err != nil {
+					b.Fatalf("NewXDetails => unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
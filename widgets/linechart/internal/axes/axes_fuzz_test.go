@@ -0,0 +1,93 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package axes
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+// seedFloats are float64 values that are likely to trigger edge cases in the
+// axis calculations.
+var seedFloats = []float64{
+	0,
+	-0.0,
+	1,
+	-1,
+	math.MaxFloat64,
+	-math.MaxFloat64,
+	math.SmallestNonzeroFloat64,
+	-math.SmallestNonzeroFloat64,
+	math.Inf(1),
+	math.Inf(-1),
+	math.NaN(),
+}
+
+func FuzzRequiredWidth(f *testing.F) {
+	for _, min := range seedFloats {
+		for _, max := range seedFloats {
+			f.Add(min, max)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, min, max float64) {
+		if got := RequiredWidth(min, max); got < axisWidth {
+			t.Errorf("RequiredWidth(%v, %v) => %d, want at least %d", min, max, got, axisWidth)
+		}
+	})
+}
+
+func FuzzNewYDetails(f *testing.F) {
+	for _, min := range seedFloats {
+		for _, max := range seedFloats {
+			f.Add(min, max, 20, 10, 2, int(YScaleModeAnchored))
+			f.Add(min, max, 20, 10, 2, int(YScaleModeAdaptive))
+		}
+	}
+	// Zero ranges and tiny canvases.
+	f.Add(5.0, 5.0, 0, 0, 0, int(YScaleModeAnchored))
+	f.Add(5.0, 5.0, 1, 1, 1, int(YScaleModeAdaptive))
+
+	f.Fuzz(func(t *testing.T, min, max float64, width, height, reqXHeight, scaleMode int) {
+		// Keep the canvas reasonably sized, the calculations scale with it.
+		// The X axis is never larger than the canvas.
+		if width < 0 || width > 500 || height < 0 || height > 500 || reqXHeight < 0 || reqXHeight > height {
+			return
+		}
+		yp := &YProperties{
+			Min:        min,
+			Max:        max,
+			ReqXHeight: reqXHeight,
+			ScaleMode:  YScaleMode(scaleMode),
+		}
+		cvsAr := image.Rect(0, 0, width, height)
+		got, err := NewYDetails(cvsAr, yp)
+		if err != nil {
+			return
+		}
+		if got.Width < axisWidth || got.Width > width {
+			t.Errorf("NewYDetails(%v, %+v) => Width %d, want in range %d <= Width <= %d", cvsAr, yp, got.Width, axisWidth, width)
+		}
+		for _, l := range got.Labels {
+			if !l.Pos.In(cvsAr) {
+				t.Errorf("NewYDetails(%v, %+v) => label %v at %v, which falls outside of the canvas", cvsAr, yp, l.Value, l.Pos)
+			}
+		}
+	})
+}
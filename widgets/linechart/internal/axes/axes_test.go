@@ -26,6 +26,13 @@ var (
 	testValueFormatter = func(float64) string { return "test" }
 )
 
+// paddedValue returns a copy of the value displayed as the padded text.
+func paddedValue(v *Value, text string) *Value {
+	c := v.clone()
+	c.text = text
+	return c
+}
+
 type updateY struct {
 	minVal float64
 	maxVal float64
@@ -51,6 +58,28 @@ func TestY(t *testing.T) {
 			wantWidth: 2,
 			wantErr:   true,
 		},
+		{
+			desc: "fails on zero canvas height",
+			yp: &YProperties{
+				Min:        0,
+				Max:        3,
+				ReqXHeight: 2,
+			},
+			cvsAr:     image.Rect(0, 0, 4, 0),
+			wantWidth: 2,
+			wantErr:   true,
+		},
+		{
+			desc: "fails on zero canvas height with an inset",
+			yp: &YProperties{
+				Min:   0,
+				Max:   3,
+				Inset: image.Rect(3, 0, 4, 1),
+			},
+			cvsAr:     image.Rect(0, 0, 4, 0),
+			wantWidth: 2,
+			wantErr:   true,
+		},
 		{
 			desc: "fails on cvsWidth less than required width",
 			yp: &YProperties{
@@ -62,6 +91,58 @@ func TestY(t *testing.T) {
 			wantWidth: 2,
 			wantErr:   true,
 		},
+		{
+			desc: "hides labels on cvsWidth less than required width",
+			yp: &YProperties{
+				Min:                     0,
+				Max:                     3,
+				ReqXHeight:              2,
+				HideLabelsWhenTooNarrow: true,
+			},
+			cvsAr:     image.Rect(0, 0, 2, 4),
+			wantWidth: 2,
+			want: &YDetails{
+				Width:        1,
+				Start:        image.Point{0, 0},
+				End:          image.Point{0, 2},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
+				dataAr:       image.Rect(1, 0, 2, 2),
+			},
+		},
+		{
+			desc: "hides labels wider than half of the canvas",
+			yp: &YProperties{
+				Min:                     0,
+				Max:                     1000,
+				ReqXHeight:              2,
+				HideLabelsWhenTooNarrow: true,
+			},
+			cvsAr:     image.Rect(0, 0, 7, 4),
+			wantWidth: 5,
+			want: &YDetails{
+				Width:        1,
+				Start:        image.Point{0, 0},
+				End:          image.Point{0, 2},
+				Scale:        mustNewYScale(0, 1000, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 1000,
+				dataAr:       image.Rect(1, 0, 7, 2),
+			},
+		},
+		{
+			desc: "fails to hide labels when there is no space for the axis",
+			yp: &YProperties{
+				Min:                     0,
+				Max:                     3,
+				ReqXHeight:              2,
+				HideLabelsWhenTooNarrow: true,
+			},
+			cvsAr:     image.Rect(0, 0, 1, 4),
+			wantWidth: 2,
+			wantErr:   true,
+		},
 		{
 			desc: "fails when max is less than min",
 			yp: &YProperties{
@@ -73,6 +154,18 @@ func TestY(t *testing.T) {
 			wantWidth: 3,
 			wantErr:   true,
 		},
+		{
+			desc: "fails when the label of the anchor doesn't fit",
+			yp: &YProperties{
+				Min:          0,
+				Max:          3,
+				ReqXHeight:   2,
+				YScaleAnchor: 1000,
+			},
+			cvsAr:     image.Rect(0, 0, 5, 4),
+			wantWidth: 5,
+			wantErr:   true,
+		},
 		{
 			desc: "cvsWidth equals required width",
 			yp: &YProperties{
@@ -83,14 +176,41 @@ func TestY(t *testing.T) {
 			cvsAr:     image.Rect(0, 0, 3, 4),
 			wantWidth: 2,
 			want: &YDetails{
-				Width: 2,
-				Start: image.Point{1, 0},
-				End:   image.Point{1, 2},
-				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored, nil),
+				Width:        2,
+				Start:        image.Point{1, 0},
+				End:          image.Point{1, 2},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
+				Labels: []*Label{
+					{NewValue(0, DefaultDecimals), image.Point{0, 1}, true},
+					{NewValue(1.72, DefaultDecimals), image.Point{0, 0}, true},
+				},
+				dataAr: image.Rect(2, 0, 3, 2),
+			},
+		},
+		{
+			desc: "right to left labels start at the right edge of the label area",
+			yp: &YProperties{
+				Min:           0,
+				Max:           3,
+				ReqXHeight:    2,
+				TextDirection: TextDirectionRTL,
+			},
+			cvsAr:     image.Rect(0, 0, 10, 4),
+			wantWidth: 2,
+			want: &YDetails{
+				Width:        5,
+				Start:        image.Point{4, 0},
+				End:          image.Point{4, 2},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
 				Labels: []*Label{
-					{NewValue(0, nonZeroDecimals), image.Point{0, 1}},
-					{NewValue(1.72, nonZeroDecimals), image.Point{0, 0}},
+					{NewValue(0, DefaultDecimals), image.Point{3, 1}, true},
+					{NewValue(1.72, DefaultDecimals), image.Point{3, 0}, true},
 				},
+				dataAr: image.Rect(5, 0, 10, 2),
 			},
 		},
 		{
@@ -104,14 +224,17 @@ func TestY(t *testing.T) {
 			cvsAr:     image.Rect(0, 0, 3, 4),
 			wantWidth: 2,
 			want: &YDetails{
-				Width: 2,
-				Start: image.Point{1, 0},
-				End:   image.Point{1, 2},
-				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored, nil),
+				Width:        2,
+				Start:        image.Point{1, 0},
+				End:          image.Point{1, 2},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
 				Labels: []*Label{
-					{NewValue(0, nonZeroDecimals), image.Point{0, 1}},
-					{NewValue(1.72, nonZeroDecimals), image.Point{0, 0}},
+					{NewValue(0, DefaultDecimals), image.Point{0, 1}, true},
+					{NewValue(1.72, DefaultDecimals), image.Point{0, 0}, true},
 				},
+				dataAr: image.Rect(2, 0, 3, 2),
 			},
 		},
 		{
@@ -125,14 +248,17 @@ func TestY(t *testing.T) {
 			cvsAr:     image.Rect(0, 0, 3, 6),
 			wantWidth: 2,
 			want: &YDetails{
-				Width: 2,
-				Start: image.Point{1, 0},
-				End:   image.Point{1, 2},
-				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored, nil),
+				Width:        2,
+				Start:        image.Point{1, 0},
+				End:          image.Point{1, 2},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
 				Labels: []*Label{
-					{NewValue(0, nonZeroDecimals), image.Point{0, 1}},
-					{NewValue(1.72, nonZeroDecimals), image.Point{0, 0}},
+					{NewValue(0, DefaultDecimals), image.Point{0, 1}, true},
+					{NewValue(1.72, DefaultDecimals), image.Point{0, 0}, true},
 				},
+				dataAr: image.Rect(2, 0, 3, 2),
 			},
 		},
 		{
@@ -146,14 +272,17 @@ func TestY(t *testing.T) {
 			cvsAr:     image.Rect(0, 0, 3, 4),
 			wantWidth: 2,
 			want: &YDetails{
-				Width: 2,
-				Start: image.Point{1, 0},
-				End:   image.Point{1, 2},
-				Scale: mustNewYScale(1, 6, 2, nonZeroDecimals, YScaleModeAdaptive, nil),
+				Width:        2,
+				Start:        image.Point{1, 0},
+				End:          image.Point{1, 2},
+				Scale:        mustNewYScale(1, 6, 2, DefaultDecimals, YScaleModeAdaptive, nil),
+				EffectiveMin: 1,
+				EffectiveMax: 6,
 				Labels: []*Label{
-					{NewValue(1, nonZeroDecimals), image.Point{0, 1}},
-					{NewValue(3.88, nonZeroDecimals), image.Point{0, 0}},
+					{NewValue(1, DefaultDecimals), image.Point{0, 1}, true},
+					{NewValue(3.88, DefaultDecimals), image.Point{0, 0}, true},
 				},
+				dataAr: image.Rect(2, 0, 3, 2),
 			},
 		},
 		{
@@ -166,14 +295,17 @@ func TestY(t *testing.T) {
 			cvsAr:     image.Rect(0, 0, 6, 4),
 			wantWidth: 2,
 			want: &YDetails{
-				Width: 5,
-				Start: image.Point{4, 0},
-				End:   image.Point{4, 2},
-				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored, nil),
+				Width:        5,
+				Start:        image.Point{4, 0},
+				End:          image.Point{4, 2},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
 				Labels: []*Label{
-					{NewValue(0, nonZeroDecimals), image.Point{3, 1}},
-					{NewValue(1.72, nonZeroDecimals), image.Point{0, 0}},
+					{NewValue(0, DefaultDecimals), image.Point{3, 1}, true},
+					{NewValue(1.72, DefaultDecimals), image.Point{0, 0}, true},
 				},
+				dataAr: image.Rect(5, 0, 6, 2),
 			},
 		},
 		{
@@ -186,14 +318,17 @@ func TestY(t *testing.T) {
 			cvsAr:     image.Rect(0, 0, 7, 4),
 			wantWidth: 2,
 			want: &YDetails{
-				Width: 5,
-				Start: image.Point{4, 0},
-				End:   image.Point{4, 2},
-				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored, nil),
+				Width:        5,
+				Start:        image.Point{4, 0},
+				End:          image.Point{4, 2},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
 				Labels: []*Label{
-					{NewValue(0, nonZeroDecimals), image.Point{3, 1}},
-					{NewValue(1.72, nonZeroDecimals), image.Point{0, 0}},
+					{NewValue(0, DefaultDecimals), image.Point{3, 1}, true},
+					{NewValue(1.72, DefaultDecimals), image.Point{0, 0}, true},
 				},
+				dataAr: image.Rect(5, 0, 7, 2),
 			},
 		},
 		{
@@ -203,26 +338,159 @@ func TestY(t *testing.T) {
 				Max:            3,
 				ReqXHeight:     2,
 				ScaleMode:      YScaleModeAnchored,
-				ValueFormatter: testValueFormatter,
+				LabelFormatter: SimpleLabelFormatter(testValueFormatter),
 			},
-			cvsAr:     image.Rect(0, 0, 3, 4),
+			cvsAr:     image.Rect(0, 0, 6, 4),
+			wantWidth: 5,
+			want: &YDetails{
+				Width:        5,
+				Start:        image.Point{4, 0},
+				End:          image.Point{4, 2},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, testValueFormatter),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
+				Labels: []*Label{
+					{NewValue(0, DefaultDecimals, ValueFormatter(testValueFormatter)), image.Point{0, 1}, true},
+					{NewValue(1.72, DefaultDecimals, ValueFormatter(testValueFormatter)), image.Point{0, 0}, true},
+				},
+				dataAr: image.Rect(5, 0, 6, 2),
+			},
+		},
+		{
+			desc: "places the axis left of the inset",
+			yp: &YProperties{
+				Min:   0,
+				Max:   3,
+				Inset: image.Rect(3, 1, 6, 3),
+			},
+			cvsAr:     image.Rect(0, 0, 6, 6),
+			wantWidth: 2,
+			want: &YDetails{
+				Width:        3,
+				Start:        image.Point{2, 1},
+				End:          image.Point{2, 3},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
+				Labels: []*Label{
+					{NewValue(0, DefaultDecimals), image.Point{1, 2}, true},
+					{NewValue(1.72, DefaultDecimals), image.Point{0, 1}, true},
+				},
+				dataAr: image.Rect(3, 1, 6, 3),
+			},
+		},
+		{
+			desc: "fails when the inset falls outside of the canvas",
+			yp: &YProperties{
+				Min:   0,
+				Max:   3,
+				Inset: image.Rect(3, 1, 7, 3),
+			},
+			cvsAr:     image.Rect(0, 0, 6, 6),
+			wantWidth: 2,
+			wantErr:   true,
+		},
+		{
+			desc: "fails when the inset leaves too little space for the axis",
+			yp: &YProperties{
+				Min:   0,
+				Max:   3,
+				Inset: image.Rect(1, 0, 6, 3),
+			},
+			cvsAr:     image.Rect(0, 0, 6, 6),
+			wantWidth: 2,
+			wantErr:   true,
+		},
+		{
+			desc: "pads the labels with the pad character",
+			yp: &YProperties{
+				Min:        0,
+				Max:        3,
+				ReqXHeight: 2,
+				PadChar:    '0',
+			},
+			cvsAr:     image.Rect(0, 0, 10, 4),
+			wantWidth: 2,
+			want: &YDetails{
+				Width:        5,
+				Start:        image.Point{4, 0},
+				End:          image.Point{4, 2},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
+				Labels: []*Label{
+					{paddedValue(NewValue(0, DefaultDecimals), "0000"), image.Point{0, 1}, true},
+					{NewValue(1.72, DefaultDecimals), image.Point{0, 0}, true},
+				},
+				dataAr: image.Rect(5, 0, 10, 2),
+			},
+		},
+		{
+			desc: "pads the end of right to left labels",
+			yp: &YProperties{
+				Min:           0,
+				Max:           3,
+				ReqXHeight:    2,
+				TextDirection: TextDirectionRTL,
+				PadChar:       '─',
+			},
+			cvsAr:     image.Rect(0, 0, 10, 4),
 			wantWidth: 2,
 			want: &YDetails{
-				Width: 2,
-				Start: image.Point{1, 0},
-				End:   image.Point{1, 2},
-				Scale: mustNewYScale(0, 3, 2, nonZeroDecimals, YScaleModeAnchored, testValueFormatter),
+				Width:        5,
+				Start:        image.Point{4, 0},
+				End:          image.Point{4, 2},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
 				Labels: []*Label{
-					{NewValue(0, nonZeroDecimals, ValueFormatter(testValueFormatter)), image.Point{0, 1}},
-					{NewValue(1.72, nonZeroDecimals, ValueFormatter(testValueFormatter)), image.Point{0, 0}},
+					{paddedValue(NewValue(0, DefaultDecimals), "0───"), image.Point{3, 1}, true},
+					{NewValue(1.72, DefaultDecimals), image.Point{3, 0}, true},
 				},
+				dataAr: image.Rect(5, 0, 10, 2),
+			},
+		},
+		{
+			desc: "pads the labels left of the inset",
+			yp: &YProperties{
+				Min:     0,
+				Max:     3,
+				Inset:   image.Rect(4, 1, 6, 3),
+				PadChar: '0',
+			},
+			cvsAr:     image.Rect(0, 0, 6, 6),
+			wantWidth: 2,
+			want: &YDetails{
+				Width:        4,
+				Start:        image.Point{3, 1},
+				End:          image.Point{3, 3},
+				Scale:        mustNewYScale(0, 3, 2, DefaultDecimals, YScaleModeAnchored, nil),
+				EffectiveMin: 0,
+				EffectiveMax: 3,
+				Labels: []*Label{
+					{paddedValue(NewValue(0, DefaultDecimals), "000"), image.Point{0, 2}, true},
+					{NewValue(1.72, DefaultDecimals), image.Point{0, 1}, true},
+				},
+				dataAr: image.Rect(4, 1, 6, 3),
+			},
+		},
+		{
+			desc: "fails on a pad character wider than one cell",
+			yp: &YProperties{
+				Min:        0,
+				Max:        3,
+				ReqXHeight: 2,
+				PadChar:    '世',
 			},
+			cvsAr:     image.Rect(0, 0, 10, 4),
+			wantWidth: 2,
+			wantErr:   true,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			gotWidth := RequiredWidth(tc.yp.Min, tc.yp.Max)
+			gotWidth := tc.yp.RequiredWidth()
 			if gotWidth != tc.wantWidth {
 				t.Errorf("RequiredWidth => got %v, want %v", gotWidth, tc.wantWidth)
 			}
@@ -241,6 +509,190 @@ func TestY(t *testing.T) {
 	}
 }
 
+func TestYDetailsLayout(t *testing.T) {
+	tests := []struct {
+		desc  string
+		yp    *YProperties
+		cvsAr image.Rectangle
+		want  YAxisLayout
+	}{
+		{
+			desc: "data are right of the axis and above the X axis",
+			yp: &YProperties{
+				Min:        0,
+				Max:        3,
+				ReqXHeight: 2,
+			},
+			cvsAr: image.Rect(0, 0, 3, 4),
+			want: YAxisLayout{
+				Width:     2,
+				Start:     image.Point{1, 0},
+				End:       image.Point{1, 2},
+				DataStart: image.Point{2, 0},
+				DataEnd:   image.Point{3, 2},
+			},
+		},
+		{
+			desc: "data are in the inset",
+			yp: &YProperties{
+				Min:   0,
+				Max:   3,
+				Inset: image.Rect(3, 1, 6, 3),
+			},
+			cvsAr: image.Rect(0, 0, 6, 6),
+			want: YAxisLayout{
+				Width:     3,
+				Start:     image.Point{2, 1},
+				End:       image.Point{2, 3},
+				DataStart: image.Point{3, 1},
+				DataEnd:   image.Point{6, 3},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			yd, err := NewYDetails(tc.cvsAr, tc.yp)
+			if err != nil {
+				t.Fatalf("NewYDetails => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, yd.Layout()); diff != "" {
+				t.Errorf("Layout => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequiredWidth(t *testing.T) {
+	tests := []struct {
+		desc           string
+		min            float64
+		max            float64
+		labelFormatter LabelFormatter
+		want           int
+	}{
+		{
+			desc: "small integer values",
+			min:  0,
+			max:  3,
+			want: 2,
+		},
+		{
+			desc: "negative value is wider",
+			min:  -100,
+			max:  3,
+			want: 5,
+		},
+		{
+			desc: "tiny value displayed in the scientific notation",
+			min:  1e-15,
+			max:  1,
+			want: 9,
+		},
+		{
+			desc: "large integer value",
+			min:  0,
+			max:  1e12,
+			want: 14,
+		},
+		{
+			desc:           "uses the value formatter",
+			min:            1e-15,
+			max:            1e12,
+			labelFormatter: SimpleLabelFormatter(testValueFormatter),
+			want:           5,
+		},
+		{
+			desc: "value formatter producing wide labels",
+			min:  0,
+			max:  1,
+			labelFormatter: SimpleLabelFormatter(func(v float64) string {
+				return fmt.Sprintf("%.6e units", v)
+			}),
+			want: 19,
+		},
+		{
+			desc: "label formatter knows the position of the label",
+			min:  0,
+			max:  1,
+			labelFormatter: func(v float64, ctx LabelContext) string {
+				if ctx.Last() {
+					return fmt.Sprintf("%v units", v)
+				}
+				return fmt.Sprint(v)
+			},
+			want: 8,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.labelFormatter == nil {
+				got := RequiredWidth(tc.min, tc.max)
+				if got != tc.want {
+					t.Errorf("RequiredWidth => %d, want %d", got, tc.want)
+				}
+			}
+
+			yp := &YProperties{
+				Min:            tc.min,
+				Max:            tc.max,
+				LabelFormatter: tc.labelFormatter,
+			}
+			if got := yp.RequiredWidth(); got != tc.want {
+				t.Errorf("YProperties.RequiredWidth => %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateYLabels(t *testing.T) {
+	tests := []struct {
+		desc    string
+		labels  []*Label
+		ar      image.Rectangle
+		wantErr bool
+	}{
+		{
+			desc: "no labels",
+			ar:   image.Rect(0, 0, 3, 3),
+		},
+		{
+			desc: "labels within the area",
+			labels: []*Label{
+				{Value: NewValue(0, DefaultDecimals), Pos: image.Point{0, 2}},
+				{Value: NewValue(1, DefaultDecimals), Pos: image.Point{0, 0}},
+			},
+			ar: image.Rect(0, 0, 3, 3),
+		},
+		{
+			desc: "label above the area",
+			labels: []*Label{
+				{Value: NewValue(1, DefaultDecimals), Pos: image.Point{0, 0}},
+			},
+			ar:      image.Rect(0, 1, 3, 3),
+			wantErr: true,
+		},
+		{
+			desc: "label below the area",
+			labels: []*Label{
+				{Value: NewValue(0, DefaultDecimals), Pos: image.Point{0, 3}},
+			},
+			ar:      image.Rect(0, 0, 3, 3),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := validateYLabels(tc.labels, tc.ar)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("validateYLabels => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
 func TestNewXDetails(t *testing.T) {
 	tests := []struct {
 		desc    string
@@ -259,6 +711,26 @@ func TestNewXDetails(t *testing.T) {
 			cvsAr:   image.Rect(0, 0, 2, 3),
 			wantErr: true,
 		},
+		{
+			desc: "fails on zero canvas width",
+			xp: &XProperties{
+				Min:       0,
+				Max:       0,
+				ReqYWidth: 0,
+			},
+			cvsAr:   image.Rect(0, 0, 0, 3),
+			wantErr: true,
+		},
+		{
+			desc: "fails on zero canvas height",
+			xp: &XProperties{
+				Min:       0,
+				Max:       0,
+				ReqYWidth: 0,
+			},
+			cvsAr:   image.Rect(0, 0, 3, 0),
+			wantErr: true,
+		},
 		{
 			desc: "fails when cvsAr isn't wide enough",
 			xp: &XProperties{
@@ -290,11 +762,12 @@ func TestNewXDetails(t *testing.T) {
 			want: &XDetails{
 				Start: image.Point{0, 1},
 				End:   image.Point{1, 1},
-				Scale: mustNewXScale(0, 0, 1, nonZeroDecimals),
+				Scale: mustNewXScale(0, 0, 1, DefaultDecimals),
 				Labels: []*Label{
 					{
-						Value: NewValue(0, nonZeroDecimals),
-						Pos:   image.Point{1, 2},
+						Value:   NewValue(0, DefaultDecimals),
+						Pos:     image.Point{1, 2},
+						Visible: true,
 					},
 				},
 				Properties: &XProperties{
@@ -316,13 +789,15 @@ func TestNewXDetails(t *testing.T) {
 			want: &XDetails{
 				Start: image.Point{0, 1},
 				End:   image.Point{1, 1},
-				Scale: mustNewXScale(0, 0, 1, nonZeroDecimals),
+				Scale: mustNewXScale(0, 0, 1, DefaultDecimals),
 				Labels: []*Label{
 					{
-						Value: NewValue(0, nonZeroDecimals),
-						Pos:   image.Point{1, 2},
+						Value:   NewValue(0, DefaultDecimals),
+						Pos:     image.Point{1, 2},
+						Visible: true,
 					},
 				},
+				LO: LabelOrientationVertical,
 				Properties: &XProperties{
 					Min:       0,
 					Max:       0,
@@ -331,6 +806,32 @@ func TestNewXDetails(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "single data point at a non-zero position",
+			xp: &XProperties{
+				Min:       5,
+				Max:       5,
+				ReqYWidth: 0,
+			},
+			cvsAr: image.Rect(0, 0, 3, 3),
+			want: &XDetails{
+				Start: image.Point{0, 1},
+				End:   image.Point{2, 1},
+				Scale: mustNewXScale(5, 5, 2, DefaultDecimals),
+				Labels: []*Label{
+					{
+						Value:   NewValue(5, DefaultDecimals),
+						Pos:     image.Point{1, 2},
+						Visible: true,
+					},
+				},
+				Properties: &XProperties{
+					Min:       5,
+					Max:       5,
+					ReqYWidth: 0,
+				},
+			},
+		},
 		{
 			desc: "accounts for non-zero yStart",
 			xp: &XProperties{
@@ -342,11 +843,12 @@ func TestNewXDetails(t *testing.T) {
 			want: &XDetails{
 				Start: image.Point{2, 3},
 				End:   image.Point{3, 3},
-				Scale: mustNewXScale(0, 0, 1, nonZeroDecimals),
+				Scale: mustNewXScale(0, 0, 1, DefaultDecimals),
 				Labels: []*Label{
 					{
-						Value: NewValue(0, nonZeroDecimals),
-						Pos:   image.Point{3, 4},
+						Value:   NewValue(0, DefaultDecimals),
+						Pos:     image.Point{3, 4},
+						Visible: true,
 					},
 				},
 				Properties: &XProperties{
@@ -368,22 +870,111 @@ func TestNewXDetails(t *testing.T) {
 			want: &XDetails{
 				Start: image.Point{2, 5},
 				End:   image.Point{9, 5},
-				Scale: mustNewXScale(0, 1000, 7, nonZeroDecimals),
+				Scale: mustNewXScale(0, 1000, 7, DefaultDecimals),
+				Labels: []*Label{
+					{
+						Value:   NewValue(0, DefaultDecimals),
+						Pos:     image.Point{3, 6},
+						Visible: true,
+					},
+					{
+						Value:   NewValue(154, DefaultDecimals),
+						Pos:     image.Point{4, 6},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(308, DefaultDecimals),
+						Pos:     image.Point{5, 6},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(462, DefaultDecimals),
+						Pos:     image.Point{6, 6},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(615, DefaultDecimals),
+						Pos:     image.Point{7, 6},
+						Visible: true,
+					},
+					{
+						Value:   NewValue(769, DefaultDecimals),
+						Pos:     image.Point{8, 6},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(923, DefaultDecimals),
+						Pos:     image.Point{9, 6},
+						Visible: false,
+					},
+				},
+				LO: LabelOrientationVertical,
+				Properties: &XProperties{
+					Min:       0,
+					Max:       1000,
+					ReqYWidth: 2,
+					LO:        LabelOrientationVertical,
+				},
+			},
+		},
+		{
+			desc: "limits the number of labels",
+			xp: &XProperties{
+				Min:       0,
+				Max:       1000,
+				ReqYWidth: 2,
+				LO:        LabelOrientationVertical,
+				MaxLabels: 1,
+			},
+			cvsAr: image.Rect(0, 0, 10, 10),
+			want: &XDetails{
+				Start: image.Point{2, 5},
+				End:   image.Point{9, 5},
+				Scale: mustNewXScale(0, 1000, 7, DefaultDecimals),
 				Labels: []*Label{
 					{
-						Value: NewValue(0, nonZeroDecimals),
-						Pos:   image.Point{3, 6},
+						Value:   NewValue(0, DefaultDecimals),
+						Pos:     image.Point{3, 6},
+						Visible: true,
+					},
+					{
+						Value:   NewValue(154, DefaultDecimals),
+						Pos:     image.Point{4, 6},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(308, DefaultDecimals),
+						Pos:     image.Point{5, 6},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(462, DefaultDecimals),
+						Pos:     image.Point{6, 6},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(615, DefaultDecimals),
+						Pos:     image.Point{7, 6},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(769, DefaultDecimals),
+						Pos:     image.Point{8, 6},
+						Visible: false,
 					},
 					{
-						Value: NewValue(615, nonZeroDecimals),
-						Pos:   image.Point{7, 6},
+						Value:   NewValue(923, DefaultDecimals),
+						Pos:     image.Point{9, 6},
+						Visible: false,
 					},
 				},
+				LO: LabelOrientationVertical,
 				Properties: &XProperties{
 					Min:       0,
 					Max:       1000,
 					ReqYWidth: 2,
 					LO:        LabelOrientationVertical,
+					MaxLabels: 1,
 				},
 			},
 		},
@@ -399,17 +990,45 @@ func TestNewXDetails(t *testing.T) {
 			want: &XDetails{
 				Start: image.Point{2, 6},
 				End:   image.Point{9, 6},
-				Scale: mustNewXScale(0, 999, 7, nonZeroDecimals),
+				Scale: mustNewXScale(0, 999, 7, DefaultDecimals),
 				Labels: []*Label{
 					{
-						Value: NewValue(0, nonZeroDecimals),
-						Pos:   image.Point{3, 7},
+						Value:   NewValue(0, DefaultDecimals),
+						Pos:     image.Point{3, 7},
+						Visible: true,
+					},
+					{
+						Value:   NewValue(154, DefaultDecimals),
+						Pos:     image.Point{4, 7},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(307, DefaultDecimals),
+						Pos:     image.Point{5, 7},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(461, DefaultDecimals),
+						Pos:     image.Point{6, 7},
+						Visible: false,
+					},
+					{
+						Value:   NewValue(615, DefaultDecimals),
+						Pos:     image.Point{7, 7},
+						Visible: true,
+					},
+					{
+						Value:   NewValue(769, DefaultDecimals),
+						Pos:     image.Point{8, 7},
+						Visible: false,
 					},
 					{
-						Value: NewValue(615, nonZeroDecimals),
-						Pos:   image.Point{7, 7},
+						Value:   NewValue(922, DefaultDecimals),
+						Pos:     image.Point{9, 7},
+						Visible: false,
 					},
 				},
+				LO: LabelOrientationVertical,
 				Properties: &XProperties{
 					Min:       0,
 					Max:       999,
@@ -434,17 +1053,20 @@ func TestNewXDetails(t *testing.T) {
 			want: &XDetails{
 				Start: image.Point{5, 4},
 				End:   image.Point{19, 4},
-				Scale: mustNewXScale(0, 1, 14, nonZeroDecimals),
+				Scale: mustNewXScale(0, 1, 14, DefaultDecimals),
 				Labels: []*Label{
 					{
-						Value: NewTextValue("start"),
-						Pos:   image.Point{6, 5},
+						Value:   NewTextValue("start"),
+						Pos:     image.Point{6, 5},
+						Visible: true,
 					},
 					{
-						Value: NewTextValue("end"),
-						Pos:   image.Point{19, 5},
+						Value:   NewTextValue("end"),
+						Pos:     image.Point{19, 5},
+						Visible: true,
 					},
 				},
+				LO: LabelOrientationVertical,
 				Properties: &XProperties{
 					Min:       0,
 					Max:       1,
@@ -457,6 +1079,243 @@ func TestNewXDetails(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "fails when vertical labels don't fit and truncation is disabled",
+			xp: &XProperties{
+				Min:       0,
+				Max:       1,
+				ReqYWidth: 5,
+				CustomLabels: map[int]string{
+					0: "start",
+					1: "end",
+				},
+				LO: LabelOrientationVertical,
+			},
+			cvsAr:   image.Rect(0, 0, 20, 5),
+			wantErr: true,
+		},
+		{
+			desc: "truncates vertical labels that don't fit",
+			xp: &XProperties{
+				Min:       0,
+				Max:       1,
+				ReqYWidth: 5,
+				CustomLabels: map[int]string{
+					0: "start",
+					1: "end",
+				},
+				LO:                     LabelOrientationVertical,
+				TruncateVerticalLabels: true,
+			},
+			cvsAr: image.Rect(0, 0, 20, 5),
+			want: &XDetails{
+				Start: image.Point{5, 1},
+				End:   image.Point{19, 1},
+				Scale: mustNewXScale(0, 1, 14, DefaultDecimals),
+				Labels: []*Label{
+					{
+						Value:   NewTextValue("st…"),
+						Pos:     image.Point{6, 2},
+						Visible: true,
+					},
+					{
+						Value:   NewTextValue("end"),
+						Pos:     image.Point{19, 2},
+						Visible: true,
+					},
+				},
+				LO: LabelOrientationVertical,
+				Properties: &XProperties{
+					Min:       0,
+					Max:       1,
+					ReqYWidth: 5,
+					CustomLabels: map[int]string{
+						0: "start",
+						1: "end",
+					},
+					LO:                     LabelOrientationVertical,
+					TruncateVerticalLabels: true,
+				},
+			},
+		},
+		{
+			desc: "truncation needs at least one row for the labels",
+			xp: &XProperties{
+				Min:                    0,
+				Max:                    1,
+				ReqYWidth:              5,
+				LO:                     LabelOrientationVertical,
+				TruncateVerticalLabels: true,
+			},
+			cvsAr:   image.Rect(0, 0, 20, 2),
+			wantErr: true,
+		},
+		{
+			desc: "auto rotates overlapping horizontal labels",
+			xp: &XProperties{
+				Min:       0,
+				Max:       1,
+				ReqYWidth: 5,
+				CustomLabels: map[int]string{
+					0: "start",
+					1: "end",
+				},
+				AutoRotate: true,
+			},
+			cvsAr: image.Rect(0, 0, 20, 10),
+			want: &XDetails{
+				Start: image.Point{5, 4},
+				End:   image.Point{19, 4},
+				Scale: mustNewXScale(0, 1, 14, DefaultDecimals),
+				Labels: []*Label{
+					{
+						Value:   NewTextValue("start"),
+						Pos:     image.Point{6, 5},
+						Visible: true,
+					},
+					{
+						Value:   NewTextValue("end"),
+						Pos:     image.Point{19, 5},
+						Visible: true,
+					},
+				},
+				LO: LabelOrientationVertical,
+				Properties: &XProperties{
+					Min:       0,
+					Max:       1,
+					ReqYWidth: 5,
+					CustomLabels: map[int]string{
+						0: "start",
+						1: "end",
+					},
+					AutoRotate: true,
+				},
+			},
+		},
+		{
+			desc: "doesn't auto rotate when vertical labels don't fit the height",
+			xp: &XProperties{
+				Min:       0,
+				Max:       1,
+				ReqYWidth: 5,
+				CustomLabels: map[int]string{
+					0: "start",
+					1: "end",
+				},
+				AutoRotate: true,
+			},
+			cvsAr: image.Rect(0, 0, 20, 5),
+			want: &XDetails{
+				Start: image.Point{5, 3},
+				End:   image.Point{19, 3},
+				Scale: mustNewXScale(0, 1, 14, DefaultDecimals),
+				Labels: []*Label{
+					{
+						Value:   NewTextValue("start"),
+						Pos:     image.Point{6, 4},
+						Visible: true,
+					},
+					{
+						Value:   NewTextValue("end"),
+						Pos:     image.Point{19, 4},
+						Visible: false,
+					},
+				},
+				Properties: &XProperties{
+					Min:       0,
+					Max:       1,
+					ReqYWidth: 5,
+					CustomLabels: map[int]string{
+						0: "start",
+						1: "end",
+					},
+					AutoRotate: true,
+				},
+			},
+		},
+		{
+			desc: "doesn't auto rotate when horizontal labels don't overlap",
+			xp: &XProperties{
+				Min:       0,
+				Max:       1,
+				ReqYWidth: 5,
+				CustomLabels: map[int]string{
+					0: "a",
+					1: "b",
+				},
+				AutoRotate: true,
+			},
+			cvsAr: image.Rect(0, 0, 20, 10),
+			want: &XDetails{
+				Start: image.Point{5, 8},
+				End:   image.Point{19, 8},
+				Scale: mustNewXScale(0, 1, 14, DefaultDecimals),
+				Labels: []*Label{
+					{
+						Value:   NewTextValue("a"),
+						Pos:     image.Point{6, 9},
+						Visible: true,
+					},
+					{
+						Value:   NewTextValue("b"),
+						Pos:     image.Point{19, 9},
+						Visible: true,
+					},
+				},
+				Properties: &XProperties{
+					Min:       0,
+					Max:       1,
+					ReqYWidth: 5,
+					CustomLabels: map[int]string{
+						0: "a",
+						1: "b",
+					},
+					AutoRotate: true,
+				},
+			},
+		},
+		{
+			desc: "diagonal labels that don't fit to the right aren't visible",
+			xp: &XProperties{
+				Min:       0,
+				Max:       1,
+				ReqYWidth: 5,
+				CustomLabels: map[int]string{
+					0: "start",
+					1: "end",
+				},
+				LO: LabelOrientationDiagonal,
+			},
+			cvsAr: image.Rect(0, 0, 20, 10),
+			want: &XDetails{
+				Start: image.Point{5, 4},
+				End:   image.Point{19, 4},
+				Scale: mustNewXScale(0, 1, 14, DefaultDecimals),
+				Labels: []*Label{
+					{
+						Value:   NewTextValue("start"),
+						Pos:     image.Point{6, 5},
+						Visible: true,
+					},
+					{
+						Value:   NewTextValue("end"),
+						Pos:     image.Point{19, 5},
+						Visible: false,
+					},
+				},
+				LO: LabelOrientationDiagonal,
+				Properties: &XProperties{
+					Min:       0,
+					Max:       1,
+					ReqYWidth: 5,
+					CustomLabels: map[int]string{
+						0: "start",
+						1: "end",
+					},
+					LO: LabelOrientationDiagonal,
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -477,6 +1336,39 @@ func TestNewXDetails(t *testing.T) {
 	}
 }
 
+func TestNewXDetailsSinglePoint(t *testing.T) {
+	for w := 0; w <= 10; w++ {
+		for h := 0; h <= 10; h++ {
+			for _, lo := range []LabelOrientation{LabelOrientationHorizontal, LabelOrientationVertical, LabelOrientationDiagonal} {
+				xp := &XProperties{
+					Min: 0,
+					Max: 0,
+					LO:  lo,
+				}
+				xd, err := NewXDetails(image.Rect(0, 0, w, h), xp)
+				if err != nil {
+					continue
+				}
+				if got := len(xd.Labels); got != 1 {
+					t.Errorf("NewXDetails(%dx%d, %v) => got %d labels, want 1", w, h, lo, got)
+				}
+				if got, want := xd.Scale.Min.Value, 0.0; got != want {
+					t.Errorf("NewXDetails(%dx%d, %v) => got scale min %v, want %v", w, h, lo, got, want)
+				}
+				if got, want := xd.Scale.Max.Value, 1.0; got != want {
+					t.Errorf("NewXDetails(%dx%d, %v) => got scale max %v, want %v", w, h, lo, got, want)
+				}
+				if got := xd.Scale.Step.Value; got <= 0 {
+					t.Errorf("NewXDetails(%dx%d, %v) => got step %v, want a positive step", w, h, lo, got)
+				}
+				if _, err := xd.Scale.ValueToPixel(0); err != nil {
+					t.Errorf("NewXDetails(%dx%d, %v) => ValueToPixel(0) unexpected error: %v", w, h, lo, err)
+				}
+			}
+		}
+	}
+}
+
 func TestRequiredHeight(t *testing.T) {
 	tests := []struct {
 		desc             string
@@ -484,6 +1376,7 @@ func TestRequiredHeight(t *testing.T) {
 		customLabels     map[int]string
 		labelOrientation LabelOrientation
 		want             int
+		wantErr          bool
 	}{
 		{
 			desc: "horizontal orientation",
@@ -515,11 +1408,51 @@ func TestRequiredHeight(t *testing.T) {
 			labelOrientation: LabelOrientationVertical,
 			want:             6,
 		},
+		{
+			desc:             "vertical orientation, full-width custom labels take one row per rune",
+			max:              99,
+			customLabels:     map[int]string{1: "一二三"},
+			labelOrientation: LabelOrientationVertical,
+			want:             4,
+		},
+		{
+			desc:             "vertical orientation, combining characters share the row of their rune",
+			max:              9,
+			customLabels:     map[int]string{1: "e\u0301e\u0301"},
+			labelOrientation: LabelOrientationVertical,
+			want:             3,
+		},
+		{
+			desc:             "vertical orientation, emoji sequences take one row per rune",
+			max:              9,
+			customLabels:     map[int]string{1: "👍🏽🇺🇸"},
+			labelOrientation: LabelOrientationVertical,
+			want:             5,
+		},
+		{
+			desc:             "diagonal orientation, needs a row per character of the longest label",
+			max:              99,
+			customLabels:     map[int]string{1: "a", 2: "bbbbb"},
+			labelOrientation: LabelOrientationDiagonal,
+			want:             6,
+		},
+		{
+			desc:             "fails on an unsupported orientation",
+			max:              99,
+			labelOrientation: LabelOrientation(-1),
+			wantErr:          true,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.desc, func(t *testing.T) {
-			got := RequiredHeight(tc.max, tc.customLabels, tc.labelOrientation)
+			got, err := RequiredHeight(tc.max, tc.customLabels, tc.labelOrientation)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("RequiredHeight => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
 			if got != tc.want {
 				t.Errorf("RequiredHeight => %d, want %d", got, tc.want)
 			}
@@ -0,0 +1,35 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+// +build go1.23
+
+package axes
+
+// iter.go contains iterators over the axes details.
+
+import "iter"
+
+// LabelIter returns an iterator over the labels on the Y axis, yielding the
+// index of each label and the label in an increasing order of values.
+// Requires Go 1.23 or newer.
+func (yd *YDetails) LabelIter() iter.Seq2[int, *Label] {
+	return func(yield func(int, *Label) bool) {
+		for i, l := range yd.Labels {
+			if !yield(i, l) {
+				return
+			}
+		}
+	}
+}
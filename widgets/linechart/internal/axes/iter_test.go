@@ -0,0 +1,59 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+// +build go1.23
+
+package axes
+
+import (
+	"image"
+	"testing"
+)
+
+func TestLabelIter(t *testing.T) {
+	yd, err := NewYDetails(image.Rect(0, 0, 20, 10), &YProperties{
+		Min:        0,
+		Max:        100,
+		ReqXHeight: 2,
+		ScaleMode:  YScaleModeAnchored,
+	})
+	if err != nil {
+		t.Fatalf("NewYDetails => unexpected error: %v", err)
+	}
+
+	var got int
+	for i, l := range yd.LabelIter() {
+		if i != got {
+			t.Errorf("LabelIter => yielded index %d, want %d", i, got)
+		}
+		if l != yd.Labels[i] {
+			t.Errorf("LabelIter => yielded label %v at index %d, want %v", l, i, yd.Labels[i])
+		}
+		got++
+	}
+	if want := len(yd.Labels); got != want {
+		t.Errorf("LabelIter => yielded %d labels, want %d", got, want)
+	}
+
+	// Stops early when the loop breaks.
+	var visited int
+	for range yd.LabelIter() {
+		visited++
+		break
+	}
+	if visited != 1 {
+		t.Errorf("LabelIter => visited %d labels after break, want 1", visited)
+	}
+}
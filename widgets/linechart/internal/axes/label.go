@@ -19,9 +19,13 @@ package axes
 import (
 	"fmt"
 	"image"
+	"sort"
+	"strings"
 
 	"github.com/mum4k/termdash/align"
 	"github.com/mum4k/termdash/private/alignfor"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/runewidth"
 )
 
 // LabelOrientation represents the orientation of text labels.
@@ -39,6 +43,7 @@ func (lo LabelOrientation) String() string {
 var labelOrientationNames = map[LabelOrientation]string{
 	LabelOrientationHorizontal: "LabelOrientationHorizontal",
 	LabelOrientationVertical:   "LabelOrientationVertical",
+	LabelOrientationDiagonal:   "LabelOrientationDiagonal",
 }
 
 const (
@@ -48,6 +53,112 @@ const (
 
 	// LabelOrientationVertical is an orientation where text flows vertically.
 	LabelOrientationVertical
+
+	// LabelOrientationDiagonal is an orientation where text flows downwards
+	// at approximately 45 degrees, one character per row with each row
+	// shifted one column to the right.
+	LabelOrientationDiagonal
+)
+
+// AxisType identifies one of the axes.
+type AxisType int
+
+// String implements fmt.Stringer()
+func (at AxisType) String() string {
+	if n, ok := axisTypeNames[at]; ok {
+		return n
+	}
+	return "AxisTypeUnknown"
+}
+
+// axisTypeNames maps AxisType values to human readable names.
+var axisTypeNames = map[AxisType]string{
+	AxisX: "AxisX",
+	AxisY: "AxisY",
+}
+
+const (
+	// AxisX is the horizontal axis.
+	AxisX AxisType = iota
+
+	// AxisY is the vertical axis.
+	AxisY
+)
+
+// LabelContext describes the label a value is formatted for.
+type LabelContext struct {
+	// Index is the position of the label among the labels on the axis, the
+	// label with the lowest value has index zero.
+	Index int
+	// Total is the number of labels on the axis.
+	// Zero when the value doesn't belong to a label, e.g. for the bounds of
+	// the scale.
+	Total int
+	// Axis is the axis the label is placed on.
+	Axis AxisType
+}
+
+// First asserts whether this is the first label on the axis.
+func (lc LabelContext) First() bool {
+	return lc.Total > 0 && lc.Index == 0
+}
+
+// Last asserts whether this is the last label on the axis.
+func (lc LabelContext) Last() bool {
+	return lc.Total > 0 && lc.Index == lc.Total-1
+}
+
+// LabelFormatter formats the value of a label knowing its position among the
+// other labels, e.g. to only include units in the first and the last label.
+type LabelFormatter func(v float64, ctx LabelContext) string
+
+// SimpleLabelFormatter adapts a formatter that only needs the value to a
+// LabelFormatter. Returns nil if the provided formatter is nil.
+func SimpleLabelFormatter(fn func(float64) string) LabelFormatter {
+	if fn == nil {
+		return nil
+	}
+	return func(v float64, _ LabelContext) string {
+		return fn(v)
+	}
+}
+
+// valueFormatter returns a formatter of values for the label in the context.
+// Returns nil if the label formatter is nil.
+func (lf LabelFormatter) valueFormatter(ctx LabelContext) func(float64) string {
+	if lf == nil {
+		return nil
+	}
+	return func(v float64) string {
+		return lf(v, ctx)
+	}
+}
+
+// TextDirection represents the direction in which the text of labels flows.
+type TextDirection int
+
+// String implements fmt.Stringer()
+func (td TextDirection) String() string {
+	if n, ok := textDirectionNames[td]; ok {
+		return n
+	}
+	return "TextDirectionUnknown"
+}
+
+// textDirectionNames maps TextDirection values to human readable names.
+var textDirectionNames = map[TextDirection]string{
+	TextDirectionLTR: "TextDirectionLTR",
+	TextDirectionRTL: "TextDirectionRTL",
+}
+
+const (
+	// TextDirectionLTR is the default text direction where text flows from
+	// left to right.
+	TextDirectionLTR TextDirection = iota
+
+	// TextDirectionRTL is a text direction where text flows from right to
+	// left, e.g. Arabic or Hebrew.
+	TextDirectionRTL
 )
 
 // Label is one value label on an axis.
@@ -56,7 +167,38 @@ type Label struct {
 	Value *Value
 
 	// Position of the label within the canvas.
+	// For labels placed with TextDirectionRTL, this is the position of the
+	// first rune of the label, i.e. its right-most cell. The text flows to the
+	// left.
 	Pos image.Point
+
+	// Visible indicates if the label text should be displayed.
+	// Labels on the X axis that would overlap with their neighbors aren't
+	// visible, their positions can still be used to draw tick marks or grid
+	// lines. Labels on the Y axis are always visible.
+	Visible bool
+}
+
+// VisualText returns the text in the order in which its clusters (see
+// LabelClusters) should be drawn left to right in order to display it in the
+// specified direction.
+func VisualText(text string, td TextDirection) string {
+	if td != TextDirectionRTL {
+		return text
+	}
+	runes := []rune(text)
+	var clusters [][]rune
+	for len(runes) > 0 {
+		_, n := clusterWidth(runes)
+		clusters = append(clusters, runes[:n])
+		runes = runes[n:]
+	}
+
+	var res []rune
+	for i := len(clusters) - 1; i >= 0; i-- {
+		res = append(res, clusters[i]...)
+	}
+	return string(res)
 }
 
 // yLabels returns labels that should be placed next to the Y axis.
@@ -67,7 +209,10 @@ type Label struct {
 // Label value is not trimmed to the provided labelWidth, the label width is
 // only used to align the labels. Alignment is done with the assumption that
 // longer labels will be trimmed.
-func yLabels(scale *YScale, labelWidth int) ([]*Label, error) {
+// Labels with TextDirectionRTL start at the right edge of the label area.
+// If not nil, the label formatter formats the values knowing the position of
+// each label among the returned labels.
+func yLabels(scale *YScale, labelWidth int, td TextDirection, lf LabelFormatter) ([]*Label, error) {
 	if min := 2; scale.GraphHeight < min {
 		return nil, fmt.Errorf("cannot place labels on a canvas with height %d, minimum is %d", scale.GraphHeight, min)
 	}
@@ -75,25 +220,45 @@ func yLabels(scale *YScale, labelWidth int) ([]*Label, error) {
 		return nil, fmt.Errorf("cannot place labels in label area width %d, minimum is %d", labelWidth, min)
 	}
 
-	var labels []*Label
+	var rows []int
+	var values []*Value
 	const labelSpacing = 4
 	seen := map[string]bool{}
 	for y := scale.GraphHeight - 1; y >= 0; y -= labelSpacing {
-		label, err := rowLabel(scale, y, labelWidth)
+		v, err := scale.CellLabel(y)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("unable to determine label value for row %d: %v", y, err)
 		}
-		if !seen[label.Value.Text()] {
-			labels = append(labels, label)
-			seen[label.Value.Text()] = true
+		if !seen[v.Text()] {
+			rows = append(rows, y)
+			values = append(values, v)
+			seen[v.Text()] = true
 		}
 	}
 
 	// If we have data, place at least two labels, first and last.
 	haveData := scale.Min.Rounded != 0 || scale.Max.Rounded != 0
-	if len(labels) < 2 && haveData {
+	if len(rows) < 2 && haveData {
 		const maxRow = 0
-		label, err := rowLabel(scale, maxRow, labelWidth)
+		v, err := scale.CellLabel(maxRow)
+		if err != nil {
+			return nil, fmt.Errorf("unable to determine label value for row %d: %v", maxRow, err)
+		}
+		rows = append(rows, maxRow)
+		values = append(values, v)
+	}
+
+	var labels []*Label
+	for i, y := range rows {
+		v := values[i]
+		if lf != nil {
+			v.formatter = lf.valueFormatter(LabelContext{
+				Index: i,
+				Total: len(rows),
+				Axis:  AxisY,
+			})
+		}
+		label, err := rowLabel(v, y, labelWidth, td)
 		if err != nil {
 			return nil, err
 		}
@@ -108,24 +273,57 @@ func rowLabelArea(row int, labelWidth int) image.Rectangle {
 	return image.Rect(0, row, labelWidth, row+1)
 }
 
-// rowLabel returns label for the specified row.
-func rowLabel(scale *YScale, y int, labelWidth int) (*Label, error) {
-	v, err := scale.CellLabel(y)
-	if err != nil {
-		return nil, fmt.Errorf("unable to determine label value for row %d: %v", y, err)
+// rowLabel returns a label with the value for the specified row.
+func rowLabel(v *Value, y int, labelWidth int, td TextDirection) (*Label, error) {
+	ar := rowLabelArea(y, labelWidth)
+	if td == TextDirectionRTL {
+		x := ar.Max.X - 1
+		if x < ar.Min.X {
+			x = ar.Min.X
+		}
+		return &Label{
+			Value:   v,
+			Pos:     image.Point{x, y},
+			Visible: true,
+		}, nil
 	}
 
-	ar := rowLabelArea(y, labelWidth)
 	pos, err := alignfor.Text(ar, v.Text(), align.HorizontalRight, align.VerticalMiddle)
 	if err != nil {
 		return nil, fmt.Errorf("unable to align the label value: %v", err)
 	}
 	return &Label{
-		Value: v,
-		Pos:   pos,
+		Value:   v,
+		Pos:     pos,
+		Visible: true,
 	}, nil
 }
 
+// padLabels pads the text of the labels with the rune so that each label
+// fills the label area of the provided width. Left to right labels are padded
+// at the start of their text and moved to the left edge of the area, right to
+// left labels keep their position and are padded at the end of their text.
+// Labels that are already wider than the area are left unchanged.
+func padLabels(labels []*Label, labelWidth int, pad rune, td TextDirection) {
+	for _, l := range labels {
+		text := l.Value.Text()
+		n := labelWidth - LabelWidth(text)
+		if n <= 0 {
+			continue
+		}
+
+		padding := strings.Repeat(string(pad), n)
+		v := l.Value.clone()
+		if td == TextDirectionRTL {
+			v.text = text + padding
+		} else {
+			v.text = padding + text
+			l.Pos.X -= n
+		}
+		l.Value = v
+	}
+}
+
 // xSpace represents an available space among the X axis.
 type xSpace struct {
 	// min is the current relative coordinate.
@@ -229,6 +427,101 @@ func xLabels(scale *XScale, graphZero image.Point, customLabels map[int]string,
 	return res, nil
 }
 
+// limitLabels returns at most max of the provided labels, ordered by their
+// position. Custom labels, i.e. labels with text, are selected first and the
+// remaining labels fill the rest. Labels of each kind are picked evenly
+// spaced among the labels of that kind.
+// Returns the labels unchanged if max isn't positive or there are fewer
+// labels.
+func limitLabels(labels []*Label, max int) []*Label {
+	if max <= 0 || len(labels) <= max {
+		return labels
+	}
+
+	var custom, other []int
+	for i, l := range labels {
+		if l.Value.text != "" {
+			custom = append(custom, i)
+		} else {
+			other = append(other, i)
+		}
+	}
+
+	selected := evenlySpaced(custom, max)
+	if remaining := max - len(selected); remaining > 0 {
+		selected = append(selected, evenlySpaced(other, remaining)...)
+	}
+	sort.Ints(selected)
+
+	var res []*Label
+	for _, i := range selected {
+		res = append(res, labels[i])
+	}
+	return res
+}
+
+// evenlySpaced returns at most count of the provided indexes spread evenly
+// from the first to the last one.
+func evenlySpaced(indexes []int, count int) []int {
+	if len(indexes) <= count {
+		return indexes
+	}
+	if count == 1 {
+		return indexes[:1]
+	}
+
+	var res []int
+	for i := 0; i < count; i++ {
+		res = append(res, indexes[i*(len(indexes)-1)/(count-1)])
+	}
+	return res
+}
+
+// xTicks returns labels for all the tick positions on the X axis, i.e. a
+// label for each column that represents at least one value. The provided
+// visible labels are kept at their positions, labels in all the other columns
+// aren't visible.
+// Labels are returned in an increasing position order.
+func xTicks(scale *XScale, graphZero image.Point, customLabels map[int]string, visible []*Label) ([]*Label, error) {
+	byX := map[int]*Label{}
+	for _, l := range visible {
+		byX[l.Pos.X] = l
+	}
+
+	for v := int(scale.Min.Value); v <= int(scale.Max.Value); v++ {
+		cell, err := scale.ValueToCell(v)
+		if err != nil {
+			return nil, err
+		}
+		x := graphZero.X + cell
+		if _, ok := byX[x]; ok {
+			continue
+		}
+
+		// Use the same value a visible label in this column would have.
+		value, err := scale.CellLabel(cell)
+		if err != nil {
+			return nil, err
+		}
+		if custom, ok := customLabels[int(value.Value)]; ok {
+			value = NewTextValue(custom)
+		}
+		byX[x] = &Label{
+			Value: value,
+			Pos:   image.Point{x, graphZero.Y + 2}, // First down is the axis, second the label.
+		}
+	}
+
+	var res []*Label
+	for _, l := range byX {
+		res = append(res, l)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Pos.X < res[j].Pos.X
+	})
+	return res, nil
+}
+
 // colLabel returns a label placed at the beginning of the space.
 // The space is adjusted according to how much space was taken by the label.
 // Returns nil, nil if the label doesn't fit in the space.
@@ -246,9 +539,17 @@ func colLabel(scale *XScale, space *xSpace, customLabels map[int]string, lo Labe
 	var labelLen int
 	switch lo {
 	case LabelOrientationHorizontal:
-		labelLen = len(label.Text())
+		labelLen = LabelWidth(label.Text())
 	case LabelOrientationVertical:
 		labelLen = 1
+	case LabelOrientationDiagonal:
+		// Diagonal labels only take one cell directly under the axis, but
+		// each following character is shifted one column to the right, so
+		// the whole label must fit into the remaining space.
+		if LabelWidth(label.Text()) > space.Remaining() {
+			return nil, nil
+		}
+		labelLen = 1
 	}
 	if labelLen > space.Remaining() {
 		return nil, nil
@@ -260,7 +561,96 @@ func colLabel(scale *XScale, space *xSpace, customLabels map[int]string, lo Labe
 	}
 
 	return &Label{
-		Value: label,
-		Pos:   abs,
+		Value:   label,
+		Pos:     abs,
+		Visible: true,
 	}, nil
 }
+
+// LabelWidth returns the number of cells needed to display the label text.
+//
+// The width is measured the same way the draw package lays the text out, so
+// that the space reserved for a label matches what gets drawn. Each rune
+// occupies its own cells, full-width runes (e.g. CJK ideographs) occupy two
+// cells. Combining characters (e.g. diacritics) are attached to the preceding
+// rune and don't occupy cells of their own. Sequences that terminals may
+// display as a single glyph (e.g. emoji joined with the zero width joiner or
+// flags made of regional indicators) are measured rune by rune, because that
+// is how they are drawn.
+func LabelWidth(s string) int {
+	var width int
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		w, n := clusterWidth(runes[i:])
+		width += w
+		i += n
+	}
+	return width
+}
+
+// LabelClusters splits the label text into clusters, each cluster is a rune
+// followed by its combining characters. Vertical and diagonal labels are
+// drawn one cluster per row.
+func LabelClusters(s string) []string {
+	var clusters []string
+	runes := []rune(s)
+	for len(runes) > 0 {
+		_, n := clusterWidth(runes)
+		clusters = append(clusters, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return clusters
+}
+
+// labelRows returns the number of rows needed to display the label text with
+// one cluster per row.
+func labelRows(s string) int {
+	return len(LabelClusters(s))
+}
+
+// truncateLabel returns the value with its text truncated so that it fits
+// into the provided number of cells. Truncated text ends with a '…' rune.
+// Returns the value unchanged if its text already fits.
+func truncateLabel(v *Value, cells int) *Value {
+	text := v.Text()
+	if LabelWidth(text) <= cells {
+		return v
+	}
+
+	const ellipsis = '…'
+	avail := cells - runewidth.RuneWidth(ellipsis)
+	runes := []rune(text)
+	var width, end int
+	for end < len(runes) {
+		w, n := clusterWidth(runes[end:])
+		if width+w > avail {
+			break
+		}
+		width += w
+		end += n
+	}
+
+	res := v.clone()
+	res.text = string(runes[:end]) + string(ellipsis)
+	return res
+}
+
+// clusterWidth returns the number of cells and the number of runes of the
+// cluster that starts at the beginning of the provided runes. A cluster is a
+// rune and the combining characters that follow it, the unit the draw package
+// places into a cell. A combining character at the start has no rune to
+// attach to, it isn't drawn and occupies no cells.
+func clusterWidth(runes []rune) (width, count int) {
+	count = 1
+	if !buffer.IsCombining(runes[0]) {
+		width = runewidth.RuneWidth(runes[0])
+		if width == 0 {
+			// Even invisible runes occupy a cell when drawn.
+			width = 1
+		}
+	}
+	for count < len(runes) && buffer.IsCombining(runes[count]) {
+		count++
+	}
+	return width, count
+}
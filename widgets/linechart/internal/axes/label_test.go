@@ -15,10 +15,14 @@
 package axes
 
 import (
+	"fmt"
 	"image"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
 )
 
 func TestYLabels(t *testing.T) {
@@ -29,7 +33,10 @@ func TestYLabels(t *testing.T) {
 		max         float64
 		graphHeight int
 		labelWidth  int
+		td          TextDirection
+		lf          LabelFormatter
 		want        []*Label
+		wantTexts   []string
 		wantErr     bool
 	}{
 		{
@@ -55,7 +62,7 @@ func TestYLabels(t *testing.T) {
 			graphHeight: 2,
 			labelWidth:  1,
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 1}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 1}, true},
 			},
 		},
 		{
@@ -65,7 +72,7 @@ func TestYLabels(t *testing.T) {
 			graphHeight: 25,
 			labelWidth:  1,
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 24}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 24}, true},
 			},
 		},
 		{
@@ -75,8 +82,8 @@ func TestYLabels(t *testing.T) {
 			graphHeight: 2,
 			labelWidth:  1,
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 1}},
-				{NewValue(2.88, nonZeroDecimals), image.Point{0, 0}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 1}, true},
+				{NewValue(2.88, nonZeroDecimals), image.Point{0, 0}, true},
 			},
 		},
 		{
@@ -86,8 +93,8 @@ func TestYLabels(t *testing.T) {
 			graphHeight: 2,
 			labelWidth:  1,
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 1}},
-				{NewValue(2.88, nonZeroDecimals), image.Point{0, 0}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 1}, true},
+				{NewValue(2.88, nonZeroDecimals), image.Point{0, 0}, true},
 			},
 		},
 		{
@@ -97,8 +104,32 @@ func TestYLabels(t *testing.T) {
 			graphHeight: 2,
 			labelWidth:  5,
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{4, 1}},
-				{NewValue(2.88, nonZeroDecimals), image.Point{1, 0}},
+				{NewValue(0, nonZeroDecimals), image.Point{4, 1}, true},
+				{NewValue(2.88, nonZeroDecimals), image.Point{1, 0}, true},
+			},
+		},
+		{
+			desc:        "right to left labels start on the right edge",
+			min:         0,
+			max:         5,
+			graphHeight: 2,
+			labelWidth:  5,
+			td:          TextDirectionRTL,
+			want: []*Label{
+				{NewValue(0, nonZeroDecimals), image.Point{4, 1}, true},
+				{NewValue(2.88, nonZeroDecimals), image.Point{4, 0}, true},
+			},
+		},
+		{
+			desc:        "right to left labels in a zero width label area",
+			min:         0,
+			max:         5,
+			graphHeight: 2,
+			labelWidth:  0,
+			td:          TextDirectionRTL,
+			want: []*Label{
+				{NewValue(0, nonZeroDecimals), image.Point{0, 1}, true},
+				{NewValue(2.88, nonZeroDecimals), image.Point{0, 0}, true},
 			},
 		},
 		{
@@ -108,9 +139,9 @@ func TestYLabels(t *testing.T) {
 			graphHeight: 9,
 			labelWidth:  1,
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 8}},
-				{NewValue(2.4, nonZeroDecimals), image.Point{0, 4}},
-				{NewValue(4.8, nonZeroDecimals), image.Point{0, 0}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 8}, true},
+				{NewValue(2.4, nonZeroDecimals), image.Point{0, 4}, true},
+				{NewValue(4.8, nonZeroDecimals), image.Point{0, 0}, true},
 			},
 		},
 		{
@@ -120,10 +151,29 @@ func TestYLabels(t *testing.T) {
 			graphHeight: 10,
 			labelWidth:  1,
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 9}},
-				{NewValue(2.08, nonZeroDecimals), image.Point{0, 5}},
-				{NewValue(4.16, nonZeroDecimals), image.Point{0, 1}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 9}, true},
+				{NewValue(2.08, nonZeroDecimals), image.Point{0, 5}, true},
+				{NewValue(4.16, nonZeroDecimals), image.Point{0, 1}, true},
+			},
+		},
+		{
+			desc:        "formats the labels knowing their position",
+			min:         0,
+			max:         5,
+			graphHeight: 9,
+			labelWidth:  4,
+			lf: func(v float64, ctx LabelContext) string {
+				if ctx.First() || ctx.Last() {
+					return fmt.Sprintf("%vV", v)
+				}
+				return fmt.Sprint(v)
+			},
+			want: []*Label{
+				{NewValue(0, nonZeroDecimals), image.Point{2, 8}, true},
+				{NewValue(2.4, nonZeroDecimals), image.Point{1, 4}, true},
+				{NewValue(4.8, nonZeroDecimals), image.Point{0, 0}, true},
 			},
+			wantTexts: []string{"0V", "2.4", "4.8V"},
 		},
 	}
 
@@ -134,7 +184,7 @@ func TestYLabels(t *testing.T) {
 				t.Fatalf("NewYScale => unexpected error: %v", err)
 			}
 			t.Logf("scale step: %v", scale.Step.Rounded)
-			got, err := yLabels(scale, tc.labelWidth)
+			got, err := yLabels(scale, tc.labelWidth, tc.td, tc.lf)
 			if (err != nil) != tc.wantErr {
 				t.Errorf("yLabels => unexpected error: %v, wantErr: %v", err, tc.wantErr)
 			}
@@ -144,6 +194,15 @@ func TestYLabels(t *testing.T) {
 			if diff := pretty.Compare(tc.want, got); diff != "" {
 				t.Errorf("yLabels => unexpected diff (-want, +got):\n%s", diff)
 			}
+			if tc.wantTexts != nil {
+				var gotTexts []string
+				for _, l := range got {
+					gotTexts = append(gotTexts, l.Value.Text())
+				}
+				if diff := pretty.Compare(tc.wantTexts, gotTexts); diff != "" {
+					t.Errorf("yLabels => unexpected label texts, diff (-want, +got):\n%s", diff)
+				}
+			}
 		})
 	}
 }
@@ -168,7 +227,7 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 1,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
 			},
 		},
 		{
@@ -179,7 +238,7 @@ func TestXLabels(t *testing.T) {
 			graphZero:        image.Point{0, 1},
 			labelOrientation: LabelOrientationVertical,
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
 			},
 		},
 		{
@@ -189,7 +248,7 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 1,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
 			},
 		},
 		{
@@ -199,8 +258,8 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 5,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
-				{NewValue(1, nonZeroDecimals), image.Point{4, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
+				{NewValue(1, nonZeroDecimals), image.Point{4, 3}, true},
 			},
 		},
 		{
@@ -211,8 +270,8 @@ func TestXLabels(t *testing.T) {
 			graphZero:        image.Point{0, 1},
 			labelOrientation: LabelOrientationVertical,
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
-				{NewValue(1, nonZeroDecimals), image.Point{4, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
+				{NewValue(1, nonZeroDecimals), image.Point{4, 3}, true},
 			},
 		},
 		{
@@ -222,8 +281,8 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 5,
 			graphZero:  image.Point{3, 5},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{3, 7}},
-				{NewValue(1, nonZeroDecimals), image.Point{7, 7}},
+				{NewValue(0, nonZeroDecimals), image.Point{3, 7}, true},
+				{NewValue(1, nonZeroDecimals), image.Point{7, 7}, true},
 			},
 		},
 		{
@@ -233,7 +292,7 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 4,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
 			},
 		},
 		{
@@ -243,7 +302,7 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 5,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
 			},
 		},
 		{
@@ -253,8 +312,8 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 6,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
-				{NewValue(1, nonZeroDecimals), image.Point{5, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
+				{NewValue(1, nonZeroDecimals), image.Point{5, 3}, true},
 			},
 		},
 		{
@@ -264,8 +323,8 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 100,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
-				{NewValue(1, nonZeroDecimals), image.Point{98, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
+				{NewValue(1, nonZeroDecimals), image.Point{98, 3}, true},
 			},
 		},
 		{
@@ -275,10 +334,10 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 100,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
-				{NewValue(1, nonZeroDecimals), image.Point{31, 3}},
-				{NewValue(2, nonZeroDecimals), image.Point{62, 3}},
-				{NewValue(3, nonZeroDecimals), image.Point{94, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
+				{NewValue(1, nonZeroDecimals), image.Point{31, 3}, true},
+				{NewValue(2, nonZeroDecimals), image.Point{62, 3}, true},
+				{NewValue(3, nonZeroDecimals), image.Point{94, 3}, true},
 			},
 		},
 		{
@@ -288,10 +347,10 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 100,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(1, nonZeroDecimals), image.Point{0, 3}},
-				{NewValue(2, nonZeroDecimals), image.Point{31, 3}},
-				{NewValue(3, nonZeroDecimals), image.Point{62, 3}},
-				{NewValue(4, nonZeroDecimals), image.Point{94, 3}},
+				{NewValue(1, nonZeroDecimals), image.Point{0, 3}, true},
+				{NewValue(2, nonZeroDecimals), image.Point{31, 3}, true},
+				{NewValue(3, nonZeroDecimals), image.Point{62, 3}, true},
+				{NewValue(4, nonZeroDecimals), image.Point{94, 3}, true},
 			},
 		},
 		{
@@ -307,10 +366,10 @@ func TestXLabels(t *testing.T) {
 				3: "d",
 			},
 			want: []*Label{
-				{NewTextValue("a"), image.Point{0, 3}},
-				{NewTextValue("b"), image.Point{31, 3}},
-				{NewTextValue("c"), image.Point{62, 3}},
-				{NewTextValue("d"), image.Point{94, 3}},
+				{NewTextValue("a"), image.Point{0, 3}, true},
+				{NewTextValue("b"), image.Point{31, 3}, true},
+				{NewTextValue("c"), image.Point{62, 3}, true},
+				{NewTextValue("d"), image.Point{94, 3}, true},
 			},
 		},
 		{
@@ -326,10 +385,10 @@ func TestXLabels(t *testing.T) {
 				4: "d",
 			},
 			want: []*Label{
-				{NewTextValue("a"), image.Point{0, 3}},
-				{NewTextValue("b"), image.Point{31, 3}},
-				{NewTextValue("c"), image.Point{62, 3}},
-				{NewTextValue("d"), image.Point{94, 3}},
+				{NewTextValue("a"), image.Point{0, 3}, true},
+				{NewTextValue("b"), image.Point{31, 3}, true},
+				{NewTextValue("c"), image.Point{62, 3}, true},
+				{NewTextValue("d"), image.Point{94, 3}, true},
 			},
 		},
 		{
@@ -349,8 +408,8 @@ func TestXLabels(t *testing.T) {
 				7: "h",
 			},
 			want: []*Label{
-				{NewTextValue("a"), image.Point{0, 3}},
-				{NewTextValue("g"), image.Point{4, 3}},
+				{NewTextValue("a"), image.Point{0, 3}, true},
+				{NewTextValue("g"), image.Point{4, 3}, true},
 			},
 		},
 		{
@@ -364,10 +423,10 @@ func TestXLabels(t *testing.T) {
 				3: "d",
 			},
 			want: []*Label{
-				{NewTextValue("a"), image.Point{0, 3}},
-				{NewValue(1, nonZeroDecimals), image.Point{31, 3}},
-				{NewValue(2, nonZeroDecimals), image.Point{62, 3}},
-				{NewTextValue("d"), image.Point{94, 3}},
+				{NewTextValue("a"), image.Point{0, 3}, true},
+				{NewValue(1, nonZeroDecimals), image.Point{31, 3}, true},
+				{NewValue(2, nonZeroDecimals), image.Point{62, 3}, true},
+				{NewTextValue("d"), image.Point{94, 3}, true},
 			},
 		},
 		{
@@ -388,8 +447,8 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 6,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
-				{NewValue(72, nonZeroDecimals), image.Point{4, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
+				{NewValue(72, nonZeroDecimals), image.Point{4, 3}, true},
 			},
 		},
 		{
@@ -399,8 +458,8 @@ func TestXLabels(t *testing.T) {
 			graphWidth: 10,
 			graphZero:  image.Point{0, 1},
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
-				{NewValue(421, nonZeroDecimals), image.Point{4, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
+				{NewValue(421, nonZeroDecimals), image.Point{4, 3}, true},
 			},
 		},
 		{
@@ -411,9 +470,9 @@ func TestXLabels(t *testing.T) {
 			graphZero:        image.Point{0, 1},
 			labelOrientation: LabelOrientationVertical,
 			want: []*Label{
-				{NewValue(0, nonZeroDecimals), image.Point{0, 3}},
-				{NewValue(421, nonZeroDecimals), image.Point{4, 3}},
-				{NewValue(842, nonZeroDecimals), image.Point{8, 3}},
+				{NewValue(0, nonZeroDecimals), image.Point{0, 3}, true},
+				{NewValue(421, nonZeroDecimals), image.Point{4, 3}, true},
+				{NewValue(842, nonZeroDecimals), image.Point{8, 3}, true},
 			},
 		},
 		{
@@ -428,7 +487,22 @@ func TestXLabels(t *testing.T) {
 				841: "this label just keeps on going",
 			},
 			want: []*Label{
-				{NewTextValue("zero label"), image.Point{0, 3}},
+				{NewTextValue("zero label"), image.Point{0, 3}, true},
+			},
+		},
+		{
+			desc:       "full-width custom labels take two cells per rune",
+			min:        0,
+			max:        10,
+			graphWidth: 10,
+			graphZero:  image.Point{0, 1},
+			customLabels: map[int]string{
+				0: "零",
+				5: "一二",
+			},
+			want: []*Label{
+				{NewTextValue("零"), image.Point{0, 3}, true},
+				{NewTextValue("一二"), image.Point{5, 3}, true},
 			},
 		},
 		{
@@ -444,9 +518,26 @@ func TestXLabels(t *testing.T) {
 			},
 			labelOrientation: LabelOrientationVertical,
 			want: []*Label{
-				{NewTextValue("zero label"), image.Point{0, 3}},
-				{NewTextValue("this one is even longer"), image.Point{4, 3}},
-				{NewTextValue("this label just keeps on going"), image.Point{8, 3}},
+				{NewTextValue("zero label"), image.Point{0, 3}, true},
+				{NewTextValue("this one is even longer"), image.Point{4, 3}, true},
+				{NewTextValue("this label just keeps on going"), image.Point{8, 3}, true},
+			},
+		},
+		{
+			desc:       "longer labels, diagonal labels must fit to the right",
+			min:        0,
+			max:        1000,
+			graphWidth: 10,
+			graphZero:  image.Point{0, 1},
+			customLabels: map[int]string{
+				0:   "zero",
+				421: "four",
+				842: "eight",
+			},
+			labelOrientation: LabelOrientationDiagonal,
+			want: []*Label{
+				{NewTextValue("zero"), image.Point{0, 3}, true},
+				{NewTextValue("four"), image.Point{4, 3}, true},
 			},
 		},
 	}
@@ -472,6 +563,135 @@ func TestXLabels(t *testing.T) {
 	}
 }
 
+func TestXTicks(t *testing.T) {
+	tests := []struct {
+		desc         string
+		min          int
+		max          int
+		graphWidth   int
+		customLabels map[int]string
+		visible      []*Label
+		want         []*Label
+	}{
+		{
+			desc:       "a tick for each value when there are fewer values than columns",
+			min:        0,
+			max:        2,
+			graphWidth: 5,
+			visible: []*Label{
+				{NewValue(0, DefaultDecimals), image.Point{0, 3}, true},
+			},
+			want: []*Label{
+				{NewValue(0, DefaultDecimals), image.Point{0, 3}, true},
+				{NewValue(1, DefaultDecimals), image.Point{2, 3}, false},
+				{NewValue(2, DefaultDecimals), image.Point{4, 3}, false},
+			},
+		},
+		{
+			desc:       "a tick for each column when there are more values than columns",
+			min:        0,
+			max:        100,
+			graphWidth: 3,
+			visible: []*Label{
+				{NewValue(0, DefaultDecimals), image.Point{0, 3}, true},
+			},
+			want: []*Label{
+				{NewValue(0, DefaultDecimals), image.Point{0, 3}, true},
+				{NewValue(40, DefaultDecimals), image.Point{1, 3}, false},
+				{NewValue(80, DefaultDecimals), image.Point{2, 3}, false},
+			},
+		},
+		{
+			desc:         "uses custom labels",
+			min:          0,
+			max:          1,
+			graphWidth:   3,
+			customLabels: map[int]string{1: "one"},
+			want: []*Label{
+				{NewValue(0, DefaultDecimals), image.Point{0, 3}, false},
+				{NewTextValue("one"), image.Point{2, 3}, false},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			scale, err := NewXScale(tc.min, tc.max, tc.graphWidth, DefaultDecimals)
+			if err != nil {
+				t.Fatalf("NewXScale => unexpected error: %v", err)
+			}
+			got, err := xTicks(scale, image.Point{0, 1}, tc.customLabels, tc.visible)
+			if err != nil {
+				t.Fatalf("xTicks => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("xTicks => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLimitLabels(t *testing.T) {
+	value := func(v float64) *Label {
+		return &Label{Value: NewValue(v, DefaultDecimals), Pos: image.Point{int(v), 0}}
+	}
+	text := func(v int, t string) *Label {
+		return &Label{Value: NewTextValue(t), Pos: image.Point{v, 0}}
+	}
+
+	tests := []struct {
+		desc   string
+		labels []*Label
+		max    int
+		want   []*Label
+	}{
+		{
+			desc:   "zero max doesn't limit labels",
+			labels: []*Label{value(0), value(1), value(2)},
+			want:   []*Label{value(0), value(1), value(2)},
+		},
+		{
+			desc:   "fewer labels than max",
+			labels: []*Label{value(0), value(1)},
+			max:    3,
+			want:   []*Label{value(0), value(1)},
+		},
+		{
+			desc:   "single label",
+			labels: []*Label{value(0), value(1), value(2)},
+			max:    1,
+			want:   []*Label{value(0)},
+		},
+		{
+			desc:   "labels are spread evenly",
+			labels: []*Label{value(0), value(1), value(2), value(3), value(4), value(5), value(6)},
+			max:    3,
+			want:   []*Label{value(0), value(3), value(6)},
+		},
+		{
+			desc:   "prefers custom labels",
+			labels: []*Label{value(0), text(1, "a"), value(2), value(3), text(4, "b"), value(5)},
+			max:    2,
+			want:   []*Label{text(1, "a"), text(4, "b")},
+		},
+		{
+			desc:   "fills the rest with other labels",
+			labels: []*Label{value(0), text(1, "a"), value(2), value(3), value(4)},
+			max:    3,
+			want:   []*Label{value(0), text(1, "a"), value(4)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := limitLabels(tc.labels, tc.max)
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("limitLabels => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestXSpace(t *testing.T) {
 	tests := []struct {
 		desc          string
@@ -530,3 +750,218 @@ func TestXSpace(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelWidth(t *testing.T) {
+	tests := []struct {
+		desc string
+		text string
+		want int
+	}{
+		{
+			desc: "empty label",
+			text: "",
+			want: 0,
+		},
+		{
+			desc: "half-width runes",
+			text: "abc",
+			want: 3,
+		},
+		{
+			desc: "full-width runes",
+			text: "一二",
+			want: 4,
+		},
+		{
+			desc: "emoji",
+			text: "😀",
+			want: 2,
+		},
+		{
+			desc: "combining marks don't occupy cells",
+			text: "e\u0301e\u0323\u0300",
+			want: 2,
+		},
+		{
+			desc: "leading combining mark isn't drawn",
+			text: "\u0301a",
+			want: 1,
+		},
+		{
+			desc: "flag is measured per regional indicator",
+			text: "🇺🇸",
+			want: 2,
+		},
+		{
+			desc: "skin tone modifier is measured as a rune of its own",
+			text: "a👍🏽b",
+			want: 6,
+		},
+		{
+			desc: "variation selector is a combining mark",
+			text: "❤\ufe0f",
+			want: 1,
+		},
+		{
+			desc: "zero width joiner occupies a cell",
+			text: "👩\u200d💻",
+			want: 5,
+		},
+		{
+			desc: "precomposed Hangul syllable",
+			text: "각",
+			want: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := LabelWidth(tc.text)
+			if got != tc.want {
+				t.Errorf("LabelWidth(%q) => %d, want %d", tc.text, got, tc.want)
+			}
+
+			// The measured width must match the width of the drawn label.
+			cvs, err := canvas.New(image.Rect(0, 0, 20, 1))
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := draw.Text(cvs, tc.text, image.Point{0, 0}); err != nil {
+				t.Fatalf("draw.Text => unexpected error: %v", err)
+			}
+			var drawn int
+			for x := 0; x < cvs.Area().Dx(); x++ {
+				c, err := cvs.Cell(image.Point{x, 0})
+				if err != nil {
+					t.Fatalf("Cell => unexpected error: %v", err)
+				}
+				if c.Rune == 0 {
+					continue
+				}
+				rw := runewidth.RuneWidth(c.Rune)
+				if rw == 0 {
+					rw = 1
+				}
+				drawn = x + rw
+			}
+			if got != drawn {
+				t.Errorf("LabelWidth(%q) => %d, but draw.Text occupied %d cells", tc.text, got, drawn)
+			}
+		})
+	}
+}
+
+func TestTruncateLabel(t *testing.T) {
+	tests := []struct {
+		desc  string
+		text  string
+		cells int
+		want  string
+	}{
+		{
+			desc:  "fits exactly",
+			text:  "abc",
+			cells: 3,
+			want:  "abc",
+		},
+		{
+			desc:  "truncated with the ellipsis",
+			text:  "abcdef",
+			cells: 3,
+			want:  "ab…",
+		},
+		{
+			desc:  "only the ellipsis fits",
+			text:  "abcdef",
+			cells: 1,
+			want:  "…",
+		},
+		{
+			desc:  "full-width runes aren't split",
+			text:  "一二三",
+			cells: 4,
+			want:  "一…",
+		},
+		{
+			desc:  "combining characters aren't split from their rune",
+			text:  "e\u0301e\u0301e\u0301",
+			cells: 2,
+			want:  "e\u0301…",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := truncateLabel(NewTextValue(tc.text), tc.cells).Text()
+			if got != tc.want {
+				t.Errorf("truncateLabel(%q, %d) => %q, want %q", tc.text, tc.cells, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestVisualText(t *testing.T) {
+	tests := []struct {
+		desc string
+		text string
+		td   TextDirection
+		want string
+	}{
+		{
+			desc: "left to right text is unchanged",
+			text: "abc",
+			td:   TextDirectionLTR,
+			want: "abc",
+		},
+		{
+			desc: "right to left empty text",
+			text: "",
+			td:   TextDirectionRTL,
+			want: "",
+		},
+		{
+			desc: "right to left Hebrew text is reversed",
+			text: "שלום",
+			td:   TextDirectionRTL,
+			want: "םולש",
+		},
+		{
+			desc: "right to left Arabic text is reversed",
+			text: "مرحبا",
+			td:   TextDirectionRTL,
+			want: "ابحرم",
+		},
+		{
+			desc: "right to left keeps combining marks with their base",
+			text: "שָׁלוֹם",
+			td:   TextDirectionRTL,
+			want: "םוֹלשָׁ",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := VisualText(tc.text, tc.td)
+			if got != tc.want {
+				t.Errorf("VisualText(%q, %v) => %q, want %q", tc.text, tc.td, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSimpleLabelFormatter(t *testing.T) {
+	if got := SimpleLabelFormatter(nil); got != nil {
+		t.Errorf("SimpleLabelFormatter(nil) => non-nil formatter, want nil")
+	}
+
+	lf := SimpleLabelFormatter(func(v float64) string {
+		return fmt.Sprintf("%vs", v)
+	})
+	ctx := LabelContext{Index: 1, Total: 2, Axis: AxisY}
+	if got, want := lf(1.5, ctx), "1.5s"; got != want {
+		t.Errorf("SimpleLabelFormatter => %q, want %q", got, want)
+	}
+	if !ctx.Last() || ctx.First() {
+		t.Errorf("LabelContext %+v => First:%v Last:%v, want First:false Last:true", ctx, ctx.First(), ctx.Last())
+	}
+}
@@ -14,7 +14,7 @@
 
 package axes
 
-// scale.go calculates the scale of the Y axis.
+// scale.go calculates the scales of the X and Y axes.
 
 import (
 	"fmt"
@@ -23,6 +23,20 @@ import (
 	"github.com/mum4k/termdash/private/canvas/braille"
 )
 
+// Scale maps values on an axis to the coordinates of pixels on the braille
+// canvas and back. Allows drawing code to work with either axis without
+// knowing its direction.
+type Scale interface {
+	// PixelFor returns the coordinate of the pixel along the axis that most
+	// closely represents the value. The value must be within the bounds of
+	// the scale.
+	PixelFor(v float64) (int, error)
+
+	// ValueAt returns the value represented by the pixel at the coordinate
+	// along the axis. The coordinate must be within the bounds of the scale.
+	ValueAt(px int) (float64, error)
+}
+
 // YScaleMode determines whether the Y scale is anchored to the zero value.
 type YScaleMode int
 
@@ -41,8 +55,10 @@ var yScaleModeNames = map[YScaleMode]string{
 }
 
 const (
-	// YScaleModeAnchored is a mode in which the Y scale always starts at value
-	// zero regardless of the min and max on the series.
+	// YScaleModeAnchored is a mode in which the Y scale always includes the
+	// anchor value regardless of the min and max on the series. The anchor is
+	// zero unless set with YScaleAnchor, so by default the scale starts at zero
+	// for all-positive series and ends at zero for all-negative series.
 	YScaleModeAnchored YScaleMode = iota
 
 	// YScaleModeAdaptive is a mode where the Y scale adapts its base value
@@ -77,12 +93,46 @@ func (ys *YScale) String() string {
 	return fmt.Sprintf("YScale{Min:%v, Max:%v, Step:%v, GraphHeight:%v}", ys.Min, ys.Max, ys.Step, ys.GraphHeight)
 }
 
+// YScaleOption is used to provide options to the NewYScale function.
+type YScaleOption interface {
+	// set sets the provided option.
+	set(*yScaleOptions)
+}
+
+type yScaleOptions struct {
+	anchor float64
+}
+
+// yScaleOption implements YScaleOption.
+type yScaleOption func(opts *yScaleOptions)
+
+// set implements YScaleOption.set.
+func (yo yScaleOption) set(opts *yScaleOptions) {
+	yo(opts)
+}
+
+// YScaleAnchor sets the value the scale is anchored to in the
+// YScaleModeAnchored mode. The effective minimum of the scale is the lesser of
+// the min and the anchor and the effective maximum is the greater of the max
+// and the anchor.
+// Defaults to zero.
+func YScaleAnchor(anchor float64) YScaleOption {
+	return yScaleOption(func(opts *yScaleOptions) {
+		opts.anchor = anchor
+	})
+}
+
 // NewYScale calculates the scale of the Y axis, given the boundary values and
 // the height of the graph. The nonZeroDecimals dictates rounding of the
 // calculated scale, see NewValue for details.
 // Max must be greater or equal to min. The graphHeight must be a positive
 // number.
-func NewYScale(min, max float64, graphHeight, nonZeroDecimals int, mode YScaleMode, valueFormatter func(float64) string) (*YScale, error) {
+func NewYScale(min, max float64, graphHeight, nonZeroDecimals int, mode YScaleMode, valueFormatter func(float64) string, opts ...YScaleOption) (*YScale, error) {
+	opt := &yScaleOptions{}
+	for _, o := range opts {
+		o.set(opt)
+	}
+
 	if max < min {
 		return nil, fmt.Errorf("max(%v) cannot be less than min(%v)", max, min)
 	}
@@ -95,12 +145,12 @@ func NewYScale(min, max float64, graphHeight, nonZeroDecimals int, mode YScaleMo
 
 	switch mode {
 	case YScaleModeAnchored:
-		// Anchor the axis at the zero value.
-		if min > 0 {
-			min = 0
+		// Anchor the axis at the anchor value.
+		if min > opt.anchor {
+			min = opt.anchor
 		}
-		if max < 0 {
-			max = 0
+		if max < opt.anchor {
+			max = opt.anchor
 		}
 
 	case YScaleModeAdaptive:
@@ -175,6 +225,18 @@ func (ys *YScale) ValueToPixel(v float64) (int, error) {
 	return positionToY(pos, ys.brailleHeight)
 }
 
+// PixelFor implements Scale.PixelFor, it is equivalent to ValueToPixel.
+// Y coordinates grow down.
+func (ys *YScale) PixelFor(v float64) (int, error) {
+	return ys.ValueToPixel(v)
+}
+
+// ValueAt implements Scale.ValueAt, it is equivalent to PixelToValue.
+// Y coordinates grow down.
+func (ys *YScale) ValueAt(px int) (float64, error) {
+	return ys.PixelToValue(px)
+}
+
 // CellLabel given a Y coordinate of a cell on the canvas, determines value of
 // the label that should be next to it. The Y coordinate must be within the
 // graphHeight provided to NewYScale. Y coordinates grow down.
@@ -196,6 +258,17 @@ func (ys *YScale) CellLabel(y int) (*Value, error) {
 	return yScaleNewValue(v, ys.Min.NonZeroDecimals, ys.valueFormatter), nil
 }
 
+// Clone returns a deep copy of the scale that doesn't share any pointers with
+// this scale. This allows drawing with the copy while a new scale is computed
+// concurrently.
+func (ys *YScale) Clone() *YScale {
+	c := *ys
+	c.Min = ys.Min.clone()
+	c.Max = ys.Max.clone()
+	c.Step = ys.Step.clone()
+	return &c
+}
+
 // yScaleNewValue is a helper method to get new values for the y scale.
 func yScaleNewValue(value float64, nonZeroDecimals int, valueFormatter func(float64) string) *Value {
 	opts := []ValueOption{}
@@ -227,6 +300,9 @@ type XScale struct {
 // The nonZeroDecimals dictates rounding of the calculated scale, see
 // NewValue for details.
 // The boundary values must be positive or zero and must be min <= max.
+// When min equals max, e.g. with only a single data point, the scale would
+// have zero extent. The domain is then widened to [min, min+1], i.e. [0, 1]
+// for a single point at zero.
 // The graphWidth must be a positive number.
 func NewXScale(min, max int, graphWidth, nonZeroDecimals int) (*XScale, error) {
 	if min < 0 || max < 0 {
@@ -244,6 +320,10 @@ func NewXScale(min, max int, graphWidth, nonZeroDecimals int) (*XScale, error) {
 
 	minVal := float64(min)
 	maxVal := float64(max)
+	if maxVal == minVal {
+		// Avoid a zero extent, which would result in a zero step.
+		maxVal = minVal + 1
+	}
 	diff := maxVal - minVal
 	step := NewValue(diff/float64(usablePixels), nonZeroDecimals)
 	return &XScale{
@@ -287,17 +367,29 @@ func (xs *XScale) PixelToValue(x int) (float64, error) {
 // The value must be within the bounds provided to NewXScale. X coordinates
 // grow right.
 func (xs *XScale) ValueToPixel(v int) (int, error) {
-	fv := float64(v)
-	if min, max := xs.Min.Value, xs.Max.Rounded; fv < min || fv > max {
+	return xs.PixelFor(float64(v))
+}
+
+// PixelFor implements Scale.PixelFor, it is like ValueToPixel, but also
+// accepts values between the positions in the series.
+// X coordinates grow right.
+func (xs *XScale) PixelFor(v float64) (int, error) {
+	if min, max := xs.Min.Value, xs.Max.Rounded; v < min || v > max {
 		return 0, fmt.Errorf("invalid value %v, must be in range %v <= v <= %v", v, min, max)
 	}
 	if xs.Step.Rounded == 0 {
 		return 0, nil
 	}
 	if xs.Min.Value > 0 {
-		fv -= xs.Min.Value
+		v -= xs.Min.Value
 	}
-	return int(math.Round(fv / xs.Step.Rounded)), nil
+	return int(math.Round(v / xs.Step.Rounded)), nil
+}
+
+// ValueAt implements Scale.ValueAt, it is equivalent to PixelToValue.
+// X coordinates grow right.
+func (xs *XScale) ValueAt(px int) (float64, error) {
+	return xs.PixelToValue(px)
 }
 
 // ValueToCell given a value, determines the X coordinate of the cell that
@@ -75,6 +75,7 @@ func TestYScale(t *testing.T) {
 		graphHeight       int
 		nonZeroDecimals   int
 		mode              YScaleMode
+		anchor            float64
 		pixelToValueTests []pixelToValueTest
 		valueToPixelTests []valueToPixelTest
 		cellLabelTests    []cellLabelTest
@@ -193,6 +194,53 @@ func TestYScale(t *testing.T) {
 				{0, NewValue(0, 2), false},
 			},
 		},
+		{
+			desc:            "scale is anchored to a custom anchor below min",
+			min:             6,
+			max:             6,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeAnchored,
+			anchor:          3,
+			pixelToValueTests: []pixelToValueTest{
+				{3, 3, false},
+				{0, 6, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{3, 3, false},
+				{6, 0, false},
+			},
+		},
+		{
+			desc:            "scale is anchored to a custom anchor above max",
+			min:             1,
+			max:             3,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeAnchored,
+			anchor:          4,
+			pixelToValueTests: []pixelToValueTest{
+				{3, 1, false},
+				{0, 4, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{1, 3, false},
+				{4, 0, false},
+			},
+		},
+		{
+			desc:            "custom anchor is ignored when the scale is adaptive",
+			min:             1,
+			max:             3,
+			graphHeight:     1,
+			nonZeroDecimals: 2,
+			mode:            YScaleModeAdaptive,
+			anchor:          4,
+			pixelToValueTests: []pixelToValueTest{
+				{3, 1, false},
+				{0, 3, false},
+			},
+		},
 		{
 			desc:            "min and max are non-zero positive and equal, scale is adaptive",
 			min:             6,
@@ -766,7 +814,7 @@ func TestYScale(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		scale, err := NewYScale(test.min, test.max, test.graphHeight, test.nonZeroDecimals, test.mode, nil)
+		scale, err := NewYScale(test.min, test.max, test.graphHeight, test.nonZeroDecimals, test.mode, nil, YScaleAnchor(test.anchor))
 		if (err != nil) != test.wantErr {
 			t.Errorf("NewYScale => unexpected error: %v, wantErr: %v", err, test.wantErr)
 		}
@@ -822,6 +870,31 @@ func TestYScale(t *testing.T) {
 	}
 }
 
+func TestYScaleClone(t *testing.T) {
+	ys, err := NewYScale(0, 10, 4, DefaultDecimals, YScaleModeAnchored, func(v float64) string {
+		return fmt.Sprintf("%.1fV", v)
+	})
+	if err != nil {
+		t.Fatalf("NewYScale => unexpected error: %v", err)
+	}
+
+	got := ys.Clone()
+	if diff := pretty.Compare(ys, got); diff != "" {
+		t.Errorf("Clone => unexpected diff (-want, +got):\n%s", diff)
+	}
+	if got.Min == ys.Min || got.Max == ys.Max || got.Step == ys.Step {
+		t.Errorf("Clone => the clone shares values with the original scale")
+	}
+
+	ys.Max.Value = 20
+	if want := 10.0; got.Max.Value != want {
+		t.Errorf("Clone => modifying the original changed the clone, got Max %v, want %v", got.Max.Value, want)
+	}
+	if v, err := got.PixelToValue(0); err != nil || v != 10 {
+		t.Errorf("PixelToValue on the clone => %v, %v, want 10, <nil>", v, err)
+	}
+}
+
 func TestXScale(t *testing.T) {
 	tests := []struct {
 		desc              string
@@ -861,6 +934,21 @@ func TestXScale(t *testing.T) {
 			graphWidth: 0,
 			wantErr:    true,
 		},
+		{
+			desc:            "single value widens the domain to [0, 1]",
+			min:             0,
+			max:             0,
+			graphWidth:      1,
+			nonZeroDecimals: 2,
+			pixelToValueTests: []pixelToValueTest{
+				{0, 0, false},
+				{1, 1, false},
+			},
+			valueToPixelTests: []valueToPixelTest{
+				{0, 0, false},
+				{1, 1, false},
+			},
+		},
 		{
 			desc:            "fails on negative pixel",
 			min:             0,
@@ -904,10 +992,10 @@ func TestXScale(t *testing.T) {
 			graphWidth:      1,
 			nonZeroDecimals: 2,
 			valueToPixelTests: []valueToPixelTest{
-				{1, 0, true},
+				{2, 0, true},
 			},
 			valueToCellTests: []valueToCellTest{
-				{1, 0, true},
+				{2, 0, true},
 			},
 			cellLabelTests: []cellLabelTest{
 				{2, nil, true},
@@ -1206,3 +1294,90 @@ func TestXScale(t *testing.T) {
 		})
 	}
 }
+
+func TestScale(t *testing.T) {
+	tests := []struct {
+		desc      string
+		scale     func() (Scale, error)
+		value     float64
+		wantPixel int
+		pixel     int
+		wantValue float64
+		wantErr   bool
+	}{
+		{
+			desc: "Y scale, coordinates grow down",
+			scale: func() (Scale, error) {
+				return NewYScale(0, 7, 2, DefaultDecimals, YScaleModeAnchored, nil)
+			},
+			value:     7,
+			wantPixel: 0,
+			pixel:     7,
+			wantValue: 0,
+		},
+		{
+			desc: "X scale, coordinates grow right",
+			scale: func() (Scale, error) {
+				return NewXScale(0, 3, 2, DefaultDecimals)
+			},
+			value:     3,
+			wantPixel: 3,
+			pixel:     0,
+			wantValue: 0,
+		},
+		{
+			desc: "X scale accepts values between the positions",
+			scale: func() (Scale, error) {
+				return NewXScale(0, 3, 2, DefaultDecimals)
+			},
+			value:     1.4,
+			wantPixel: 1,
+			pixel:     2,
+			wantValue: 2,
+		},
+		{
+			desc: "X scale fails on value out of bounds",
+			scale: func() (Scale, error) {
+				return NewXScale(0, 3, 2, DefaultDecimals)
+			},
+			value:   3.5,
+			wantErr: true,
+		},
+		{
+			desc: "Y scale fails on pixel out of bounds",
+			scale: func() (Scale, error) {
+				return NewYScale(0, 7, 2, DefaultDecimals, YScaleModeAnchored, nil)
+			},
+			value:   7,
+			pixel:   8,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := tc.scale()
+			if err != nil {
+				t.Fatalf("scale => unexpected error: %v", err)
+			}
+
+			gotPixel, err := s.PixelFor(tc.value)
+			if err == nil {
+				var gotValue float64
+				gotValue, err = s.ValueAt(tc.pixel)
+				if err == nil && gotValue != tc.wantValue {
+					t.Errorf("ValueAt(%d) => %v, want %v", tc.pixel, gotValue, tc.wantValue)
+				}
+			}
+			if (err != nil) != tc.wantErr {
+				t.Errorf("PixelFor or ValueAt => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if gotPixel != tc.wantPixel {
+				t.Errorf("PixelFor(%v) => %d, want %d", tc.value, gotPixel, tc.wantPixel)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package axes
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/mum4k/termdash/private/numbers"
 )
@@ -74,14 +75,24 @@ func (v *Value) String() string {
 	return fmt.Sprintf("Value{Round(%v) => %v}", v.Value, v.Rounded)
 }
 
+// smallestNormal is the smallest positive normal float64 value. Values
+// closer to zero than this are subnormal.
+const smallestNormal = 0x1p-1022
+
 // NewValue returns a new instance representing the provided value, rounding
 // the value up to the specified number of non-zero decimal places.
+// Negative zero and subnormal values are normalized to zero, so they are
+// displayed as "0".
 func NewValue(v float64, nonZeroDecimals int, opts ...ValueOption) *Value {
 	opt := &valueOptions{}
 	for _, o := range opts {
 		o.set(opt)
 	}
 
+	if math.Abs(v) < smallestNormal {
+		v = 0
+	}
+
 	r, zd := numbers.RoundToNonZeroPlaces(v, nonZeroDecimals)
 	return &Value{
 		Value:           v,
@@ -93,12 +104,22 @@ func NewValue(v float64, nonZeroDecimals int, opts ...ValueOption) *Value {
 }
 
 // NewTextValue constructs a value out of the provided text.
+// Leading and trailing white space is removed from the text.
 func NewTextValue(text string) *Value {
 	return &Value{
 		Value:   math.NaN(),
 		Rounded: math.NaN(),
-		text:    text,
+		text:    strings.TrimSpace(text),
+	}
+}
+
+// clone returns a copy of the value or nil if the value is nil.
+func (v *Value) clone() *Value {
+	if v == nil {
+		return nil
 	}
+	c := *v
+	return &c
 }
 
 // Text returns textual representation of the value.
@@ -16,6 +16,7 @@ package axes
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/kylelemons/godebug/pretty"
@@ -42,6 +43,28 @@ func TestValue(t *testing.T) {
 				NonZeroDecimals: 0,
 			},
 		},
+		{
+			desc:            "normalizes negative zero",
+			float:           math.Copysign(0, -1),
+			nonZeroDecimals: 2,
+			want: &Value{
+				Value:           0,
+				Rounded:         0,
+				ZeroDecimals:    0,
+				NonZeroDecimals: 2,
+			},
+		},
+		{
+			desc:            "normalizes subnormal values to zero",
+			float:           math.SmallestNonzeroFloat64,
+			nonZeroDecimals: 2,
+			want: &Value{
+				Value:           0,
+				Rounded:         0,
+				ZeroDecimals:    0,
+				NonZeroDecimals: 2,
+			},
+		},
 		{
 			desc:            "rounds to requested precision",
 			float:           1.01234,
@@ -97,6 +120,9 @@ func TestText(t *testing.T) {
 		wantText        string
 	}{
 		{0, 2, 0, "0"},
+		{math.Copysign(0, -1), 2, 0, "0"},
+		{math.SmallestNonzeroFloat64, 2, 0, "0"},
+		{-math.SmallestNonzeroFloat64, 2, 0, "0"},
 		{10, 2, 10, "10"},
 		{-10, 2, -10, "-10"},
 		{0.5, 2, 0.5, "0.50"},
@@ -133,10 +159,23 @@ func TestText(t *testing.T) {
 }
 
 func TestNewTextValue(t *testing.T) {
-	const want = "foo"
-	v := NewTextValue(want)
-	got := v.Text()
-	if got != want {
-		t.Errorf("v.Text => got %q, want %q", got, want)
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"foo", "foo"},
+		{"  foo  ", "foo"},
+		{"foo\n", "foo"},
+		{"\tfoo bar ", "foo bar"},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%q", tc.text), func(t *testing.T) {
+			v := NewTextValue(tc.text)
+			got := v.Text()
+			if got != tc.want {
+				t.Errorf("v.Text => got %q, want %q", got, tc.want)
+			}
+		})
 	}
 }
@@ -1558,7 +1558,7 @@ func TestFindCellPair(t *testing.T) {
 			wantMax: axes.NewValue(2, 2),
 		},
 		{
-			desc:  "cells point at the same value, only distinct are first and last",
+			desc:  "single value, the scale is widened to the next value",
 			cvsAr: image.Rect(0, 0, 4, 4),
 			baseP: &axes.XProperties{
 				Min: 0,
@@ -1567,7 +1567,7 @@ func TestFindCellPair(t *testing.T) {
 			minCell: 1,
 			maxCell: 2,
 			wantMin: axes.NewValue(0, 2),
-			wantMax: axes.NewValue(0, 2),
+			wantMax: axes.NewValue(1, 2),
 		},
 	}
 
@@ -0,0 +1,36 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+// +build go1.23
+
+package linechart
+
+// iter.go contains code that reads series from iterators.
+
+import "iter"
+
+// AddSeriesIter is like Series, but reads the values from the iterator
+// instead of a slice. This avoids materializing a copy of the values in the
+// caller when they are produced lazily. A nil iterator sets an empty series.
+// Requires Go 1.23 or newer.
+func (lc *LineChart) AddSeriesIter(label string, it iter.Seq[float64], opts ...SeriesOption) error {
+	var values []float64
+	if it != nil {
+		for v := range it {
+			values = append(values, v)
+		}
+	}
+	return lc.Series(label, values, opts...)
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.23
+// +build go1.23
+
+package linechart
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestAddSeriesIter(t *testing.T) {
+	tests := []struct {
+		desc    string
+		label   string
+		values  []float64
+		wantErr bool
+	}{
+		{
+			desc:    "fails on empty label",
+			values:  []float64{1},
+			wantErr: true,
+		},
+		{
+			desc:  "empty iterator",
+			label: "series",
+		},
+		{
+			desc:   "reads all the values",
+			label:  "series",
+			values: []float64{1, 2, 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lc, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			err = lc.AddSeriesIter(tc.label, slices.Values(tc.values))
+			if (err != nil) != tc.wantErr {
+				t.Errorf("AddSeriesIter => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			want := tc.values
+			if want == nil {
+				want = []float64{}
+			}
+			if diff := pretty.Compare(want, lc.series[tc.label].values); diff != "" {
+				t.Errorf("AddSeriesIter => unexpected values, diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -104,8 +104,45 @@ type LineChart struct {
 
 	// zoom tracks the zooming of the X axis.
 	zoom *zoom.Tracker
+
+	// viewport when not nil restricts the values displayed on the X axis.
+	// Set by calling SetXViewport.
+	viewport *xViewport
+
+	// lastXD and lastYD are the axes details used by the last successful
+	// call to Draw. Nil before the first Draw.
+	lastXD *axes.XDetails
+	lastYD *axes.YDetails
+}
+
+// xViewport is a range of indices of the values displayed on the X axis.
+type xViewport struct {
+	// start is the index of the first displayed value.
+	start int
+	// end is the index right after the last displayed value.
+	end int
 }
 
+// values returns the values of the series that fall into the viewport.
+func (xv *xViewport) values(values []float64) []float64 {
+	start, end := xv.start, xv.end
+	if end > len(values) {
+		end = len(values)
+	}
+	if start >= end {
+		return nil
+	}
+	return values[start:end]
+}
+
+// YDetails contain information about the Y axis as it was drawn by the
+// LineChart.
+type YDetails = axes.YDetails
+
+// XDetails contain information about the X axis as it was drawn by the
+// LineChart.
+type XDetails = axes.XDetails
+
 // New returns a new line chart widget.
 func New(opts ...Option) (*LineChart, error) {
 	opt := newOptions(opts...)
@@ -161,12 +198,28 @@ func SeriesXLabels(labels map[int]string) SeriesOption {
 }
 
 // yMinMax determines the min and max values for the Y axis.
-func (lc *LineChart) yMinMax() (float64, float64) {
+// When the viewport isn't nil, only the values in the viewport are considered.
+func (lc *LineChart) yMinMax(viewport *xViewport) (float64, float64) {
 	var (
 		minimums []float64
 		maximums []float64
 	)
 	for _, sv := range lc.series {
+		if viewport != nil {
+			// Series without any values in the viewport must not affect
+			// the Y axis.
+			vals := viewport.values(sv.values)
+			if len(vals) == 0 {
+				continue
+			}
+			min, max := numbers.MinMax(vals)
+			if math.IsNaN(min) {
+				continue
+			}
+			minimums = append(minimums, min)
+			maximums = append(maximums, max)
+			continue
+		}
 		minimums = append(minimums, sv.min)
 		maximums = append(maximums, sv.max)
 	}
@@ -226,12 +279,75 @@ func (lc *LineChart) Series(label string, values []float64, opts ...SeriesOption
 	}
 
 	lc.series[label] = series
-	yMin, yMax := lc.yMinMax()
+	yMin, yMax := lc.yMinMax(nil)
 	lc.yMin = yMin
 	lc.yMax = yMax
 	return nil
 }
 
+// SetXViewport restricts the values displayed on the X axis to the indices
+// in range start <= index < end. The viewport can extend past the end of the
+// series. When the YAxisAdaptive option is provided, the Y axis adapts to the
+// values within the viewport.
+// Unlike the mouse based zoom, the viewport is set programmatically, e.g. to
+// pan multiple line charts in sync. The mouse based zoom then operates within
+// the viewport.
+func (lc *LineChart) SetXViewport(start, end int) error {
+	if start < 0 {
+		return fmt.Errorf("invalid viewport start %d, must be a zero or positive integer", start)
+	}
+	if min := start + 2; end < min {
+		return fmt.Errorf("invalid viewport end %d, the viewport must contain at least two values, i.e. end >= %d", end, min)
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.viewport = &xViewport{start: start, end: end}
+	return nil
+}
+
+// ClearXViewport removes the viewport set by SetXViewport, all the values
+// are displayed on the X axis again.
+func (lc *LineChart) ClearXViewport() {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	lc.viewport = nil
+}
+
+// yRange returns the min and max values for the Y axis accounting for the
+// viewport if the Y axis is adaptive.
+func (lc *LineChart) yRange() (float64, float64) {
+	if lc.viewport == nil || lc.opts.yAxisMode != axes.YScaleModeAdaptive {
+		return lc.yMin, lc.yMax
+	}
+	return lc.yMinMax(lc.viewport)
+}
+
+// xRange returns the min and max values for the X axis accounting for the
+// viewport.
+func (lc *LineChart) xRange() (int, int) {
+	if lc.viewport == nil {
+		return 0, lc.maxXValue()
+	}
+	return lc.viewport.start, lc.viewport.end - 1
+}
+
+// drawRTLYLabel draws a right-to-left label on the Y axis. The label starts at
+// its position and flows to the left, it is trimmed if it doesn't fit.
+func (lc *LineChart) drawRTLYLabel(cvs *canvas.Canvas, l *axes.Label) error {
+	text, err := draw.TrimText(l.Value.Text(), l.Pos.X+1, draw.OverrunModeThreeDot)
+	if err != nil {
+		return fmt.Errorf("failed to trim the Y label: %v", err)
+	}
+	start := image.Point{l.Pos.X - axes.LabelWidth(text) + 1, l.Pos.Y}
+	if err := draw.Text(cvs, axes.VisualText(text, axes.TextDirectionRTL), start,
+		draw.TextCellOpts(lc.opts.yLabelCellOpts...),
+	); err != nil {
+		return fmt.Errorf("failed to draw the Y labels: %v", err)
+	}
+	return nil
+}
+
 // xDetails returns the details for the X axis given the specified minimum and
 // maximum value to display.
 func (lc *LineChart) xDetails(cvs *canvas.Canvas, reqYWidth, min, max int) (*axes.XDetails, error) {
@@ -273,21 +389,28 @@ func (lc *LineChart) xDetailsForCap(cvs *canvas.Canvas, bc *braille.Canvas, xd *
 
 // axesDetails determines the details about the X and Y axes.
 func (lc *LineChart) axesDetails(cvs *canvas.Canvas) (*axes.XDetails, *axes.YDetails, error) {
-	reqXHeight := axes.RequiredHeight(lc.maxXValue(), lc.xLabels, lc.opts.xLabelOrientation)
+	xMin, xMax := lc.xRange()
+	reqXHeight, err := axes.RequiredHeight(xMax, lc.xLabels, lc.opts.xLabelOrientation)
+	if err != nil {
+		return nil, nil, err
+	}
+	yMin, yMax := lc.yRange()
 	yp := &axes.YProperties{
-		Min:            lc.yMin,
-		Max:            lc.yMax,
+		Min:            yMin,
+		Max:            yMax,
 		ReqXHeight:     reqXHeight,
 		ScaleMode:      lc.opts.yAxisMode,
-		ValueFormatter: lc.opts.yAxisValueFormatter,
+		LabelFormatter: axes.SimpleLabelFormatter(lc.opts.yAxisValueFormatter),
+		TextDirection:  lc.opts.yLabelDirection,
+		PadChar:        lc.opts.yLabelPadChar,
+
+		HideLabelsWhenTooNarrow: lc.opts.yLabelsHide,
 	}
 	yd, err := axes.NewYDetails(cvs.Area(), yp)
 	if err != nil {
 		return nil, nil, fmt.Errorf("NewYDetails => %v", err)
 	}
 
-	const xMin = 0
-	xMax := lc.maxXValue()
 	xd, err := lc.xDetails(cvs, yd.Start.X, xMin, xMax)
 	if err != nil {
 		return nil, nil, err
@@ -301,7 +424,11 @@ func (lc *LineChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	lc.mu.Lock()
 	defer lc.mu.Unlock()
 
-	needAr, err := area.FromSize(lc.minSize())
+	minSize, err := lc.minSize()
+	if err != nil {
+		return err
+	}
+	needAr, err := area.FromSize(minSize)
 	if err != nil {
 		return err
 	}
@@ -318,7 +445,33 @@ func (lc *LineChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 	if err != nil {
 		return err
 	}
-	return lc.drawAxes(cvs, adjXD, yd)
+	if err := lc.drawAxes(cvs, adjXD, yd); err != nil {
+		return err
+	}
+	lc.lastXD = adjXD
+	lc.lastYD = yd
+	return nil
+}
+
+// LastYDetails returns the details of the Y axis that were used by the last
+// successful call to Draw. Useful when drawing custom overlays on top of the
+// chart, e.g. threshold lines or a crosshair.
+// Returns nil before the first call to Draw. The caller must not modify the
+// returned value.
+func (lc *LineChart) LastYDetails() *YDetails {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	return lc.lastYD
+}
+
+// LastXDetails returns the details of the X axis that were used by the last
+// successful call to Draw. This accounts for any zoom applied to the X axis.
+// Returns nil before the first call to Draw. The caller must not modify the
+// returned value.
+func (lc *LineChart) LastXDetails() *XDetails {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	return lc.lastXD
 }
 
 // drawAxes draws the X,Y axes and their labels.
@@ -332,6 +485,12 @@ func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YD
 	}
 
 	for _, l := range yd.Labels {
+		if lc.opts.yLabelDirection == axes.TextDirectionRTL {
+			if err := lc.drawRTLYLabel(cvs, l); err != nil {
+				return err
+			}
+			continue
+		}
 		if err := draw.Text(cvs, l.Value.Text(), l.Pos,
 			draw.TextMaxX(yd.Start.X),
 			draw.TextOverrunMode(draw.OverrunModeThreeDot),
@@ -342,19 +501,43 @@ func (lc *LineChart) drawAxes(cvs *canvas.Canvas, xd *axes.XDetails, yd *axes.YD
 	}
 
 	for _, l := range xd.Labels {
-		switch lc.opts.xLabelOrientation {
+		if !l.Visible {
+			continue
+		}
+		switch xd.LO {
 		case axes.LabelOrientationHorizontal:
 			if err := draw.Text(cvs, l.Value.Text(), l.Pos, draw.TextCellOpts(lc.opts.xLabelCellOpts...)); err != nil {
 				return fmt.Errorf("failed to draw the X horizontal labels: %v", err)
 			}
 
 		case axes.LabelOrientationVertical:
-			if err := draw.VerticalText(cvs, l.Value.Text(), l.Pos,
-				draw.VerticalTextCellOpts(lc.opts.xLabelCellOpts...),
-				draw.VerticalTextOverrunMode(draw.OverrunModeThreeDot),
-			); err != nil {
+			if err := drawTextRows(cvs, l.Value.Text(), l.Pos, image.Point{0, 1}, lc.opts.xLabelCellOpts...); err != nil {
 				return fmt.Errorf("failed to draw the vertical X labels: %v", err)
 			}
+
+		case axes.LabelOrientationDiagonal:
+			if err := drawTextRows(cvs, l.Value.Text(), l.Pos, image.Point{1, 1}, lc.opts.xLabelCellOpts...); err != nil {
+				return fmt.Errorf("failed to draw the diagonal X labels: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// drawTextRows draws the text one cluster per row starting at the
+// provided point, each following cluster is moved by the step. This matches
+// the number of rows axes.RequiredHeight reserves for vertical and diagonal
+// labels.
+// Clusters that would fall outside of the canvas are not drawn.
+func drawTextRows(cvs *canvas.Canvas, text string, start image.Point, step image.Point, opts ...cell.Option) error {
+	ar := cvs.Area()
+	for i, cl := range axes.LabelClusters(text) {
+		p := start.Add(step.Mul(i))
+		if !p.In(ar) {
+			break
+		}
+		if err := draw.Text(cvs, cl, p, draw.TextCellOpts(opts...), draw.TextOverrunMode(draw.OverrunModeTrim)); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -494,17 +677,31 @@ func (lc *LineChart) Mouse(m *terminalapi.Mouse) error {
 }
 
 // minSize determines the minimum required size to draw the line chart.
-func (lc *LineChart) minSize() image.Point {
+func (lc *LineChart) minSize() (image.Point, error) {
 	// At the very least we need:
 	// - n cells width for the Y axis and its labels as reported by it.
 	// - at least 1 cell width for the graph.
-	reqWidth := axes.RequiredWidth(lc.yMin, lc.yMax) + 1
+	yMin, yMax := lc.yRange()
+	yp := &axes.YProperties{
+		Min:            yMin,
+		Max:            yMax,
+		LabelFormatter: axes.SimpleLabelFormatter(lc.opts.yAxisValueFormatter),
+	}
+	reqWidth := yp.RequiredWidth() + 1
+	if lc.opts.yLabelsHide {
+		// The labels are hidden when they don't fit, only the axis remains.
+		reqWidth = 2
+	}
 
 	// And for the height:
 	// - n cells width for the X axis and its labels as reported by it.
 	// - at least 2 cell height for the graph.
-	reqHeight := axes.RequiredHeight(lc.maxXValue(), lc.xLabels, lc.opts.xLabelOrientation) + 2
-	return image.Point{reqWidth, reqHeight}
+	_, xMax := lc.xRange()
+	reqXHeight, err := axes.RequiredHeight(xMax, lc.xLabels, lc.opts.xLabelOrientation)
+	if err != nil {
+		return image.ZP, err
+	}
+	return image.Point{reqWidth, reqXHeight + 2}, nil
 }
 
 // Options implements widgetapi.Widget.Options.
@@ -512,12 +709,28 @@ func (lc *LineChart) Options() widgetapi.Options {
 	lc.mu.RLock()
 	defer lc.mu.RUnlock()
 
+	// Only fails on an unsupported label orientation, which the options don't
+	// allow. Draw reports the error regardless.
+	minSize, _ := lc.minSize()
 	return widgetapi.Options{
-		MinimumSize: lc.minSize(),
+		MinimumSize: minSize,
 		WantMouse:   widgetapi.MouseScopeGlobal,
 	}
 }
 
+// Clone returns a new LineChart with the same options as this one, but without
+// any series. The clone doesn't share any mutable state with this LineChart.
+// Implements widgetapi.Cloneable.
+func (lc *LineChart) Clone() (widgetapi.Widget, error) {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+
+	return &LineChart{
+		series: map[string]*seriesValues{},
+		opts:   lc.opts.clone(),
+	}, nil
+}
+
 // maxXValue returns the maximum value on the X axis among all the series.
 // lc.mu must be held when calling this method.
 func (lc *LineChart) maxXValue() int {
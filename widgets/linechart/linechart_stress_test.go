@@ -0,0 +1,129 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package linechart
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// stressValues returns n values of a series that change with the offset.
+func stressValues(n, offset int) []float64 {
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = float64((i + offset) % 100)
+	}
+	return values
+}
+
+// renderLoop draws the line chart continuously until the stop channel is
+// closed. Reports the first error encountered on errCh.
+func renderLoop(lc *LineChart, stop <-chan struct{}, errCh chan<- error) {
+	cvs, err := canvas.New(image.Rect(0, 0, 80, 24))
+	if err != nil {
+		errCh <- err
+		return
+	}
+	for {
+		select {
+		case <-stop:
+			errCh <- nil
+			return
+		default:
+		}
+
+		if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+			errCh <- fmt.Errorf("Draw => unexpected error: %v", err)
+			return
+		}
+		lc.Options()
+	}
+}
+
+func BenchmarkHighFrequencyUpdate(b *testing.B) {
+	lc, err := New()
+	if err != nil {
+		b.Fatalf("New => unexpected error: %v", err)
+	}
+
+	stop := make(chan struct{})
+	errCh := make(chan error, 1)
+	go renderLoop(lc, stop, errCh)
+
+	var goroutines int32
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		name := fmt.Sprintf("series%d", atomic.AddInt32(&goroutines, 1))
+		var i int
+		for pb.Next() {
+			if err := lc.Series(name, stressValues(100, i)); err != nil {
+				b.Errorf("Series => unexpected error: %v", err)
+				return
+			}
+			i++
+		}
+	})
+	b.StopTimer()
+
+	close(stop)
+	if err := <-errCh; err != nil {
+		b.Fatal(err)
+	}
+}
+
+func TestRaceHighFrequencyUpdate(t *testing.T) {
+	lc, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	stop := make(chan struct{})
+	errCh := make(chan error, 1)
+	go renderLoop(lc, stop, errCh)
+
+	const (
+		writers = 8
+		updates = 200
+	)
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			// Half of the writers share a series name, so that updates of the
+			// same series race as well.
+			name := fmt.Sprintf("series%d", w%(writers/2))
+			for i := 0; i < updates; i++ {
+				if err := lc.Series(name, stressValues(50, i)); err != nil {
+					t.Errorf("Series => unexpected error: %v", err)
+					return
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	close(stop)
+	if err := <-errCh; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -62,6 +62,14 @@ func TestLineChartDraws(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			desc:   "fails with a Y label pad character wider than one cell",
+			canvas: image.Rect(0, 0, 3, 4),
+			opts: []Option{
+				YLabelPadChar('世'),
+			},
+			wantErr: true,
+		},
 		{
 			desc:   "fails with custom scale where min is NaN",
 			canvas: image.Rect(0, 0, 3, 4),
@@ -532,6 +540,153 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "fails on viewport with negative start",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.SetXViewport(-1, 2)
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc:   "fails on viewport with less than two values",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.SetXViewport(1, 2)
+			},
+			wantWriteErr: true,
+		},
+		{
+			desc: "displays only the values in the viewport, Y axis adapts",
+			opts: []Option{
+				YAxisAdaptive(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{1, 2, 3, 4, 10, 20}); err != nil {
+					return err
+				}
+				return lc.SetXViewport(1, 4)
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "2", image.Point{4, 7})
+				testdraw.MustText(c, "3.040", image.Point{0, 3})
+				testdraw.MustText(c, "1", image.Point{6, 9})
+				testdraw.MustText(c, "2", image.Point{12, 9})
+				testdraw.MustText(c, "3", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{13, 16})
+				testdraw.MustBrailleLine(bc, image.Point{13, 16}, image.Point{27, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "series without values in the viewport don't affect the Y axis",
+			opts: []Option{
+				YAxisAdaptive(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{1, 2, 3, 4, 10, 20}); err != nil {
+					return err
+				}
+				if err := lc.Series("outside", []float64{100}); err != nil {
+					return err
+				}
+				if err := lc.Series("nan", []float64{-100, math.NaN(), math.NaN(), math.NaN()}); err != nil {
+					return err
+				}
+				return lc.SetXViewport(1, 4)
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "2", image.Point{4, 7})
+				testdraw.MustText(c, "3.040", image.Point{0, 3})
+				testdraw.MustText(c, "1", image.Point{6, 9})
+				testdraw.MustText(c, "2", image.Point{12, 9})
+				testdraw.MustText(c, "3", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{13, 16})
+				testdraw.MustBrailleLine(bc, image.Point{13, 16}, image.Point{27, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "displays all the values after the viewport is cleared",
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				if err := lc.Series("first", []float64{0, 100}); err != nil {
+					return err
+				}
+				if err := lc.SetXViewport(1, 3); err != nil {
+					return err
+				}
+				lc.ClearXViewport()
+				return nil
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "custom X labels, horizontal by default",
 			canvas: image.Rect(0, 0, 20, 10),
@@ -647,6 +802,90 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "custom X labels, vertical, full-width runes take one row each",
+			opts: []Option{
+				XLabelsVertical(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100}, SeriesXLabels(map[int]string{
+					0: "一二三四五",
+					1: "end",
+				}))
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 4}},
+					{Start: image.Point{6, 4}, End: image.Point{19, 4}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 3})
+				testdraw.MustText(c, "80.040", image.Point{0, 0})
+				for i, r := range []rune("一二三四五") {
+					testcanvas.MustSetCell(c, image.Point{7, 5 + i}, r)
+				}
+				testdraw.MustVerticalText(c, "end", image.Point{19, 5})
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 4)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 15}, image.Point{25, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "custom X labels, diagonal",
+			opts: []Option{
+				XLabelsDiagonal(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100}, SeriesXLabels(map[int]string{
+					0: "start",
+					1: "end",
+				}))
+			},
+			wantCapacity: 26,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{6, 0}, End: image.Point{6, 4}},
+					{Start: image.Point{6, 4}, End: image.Point{19, 4}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{5, 3})
+				testdraw.MustText(c, "80.040", image.Point{0, 0})
+				// The "end" label doesn't fit diagonally to the right.
+				for i, r := range "start" {
+					testdraw.MustText(c, string(r), image.Point{7 + i, 5 + i})
+				}
+
+				// Braille line.
+				graphAr := image.Rect(7, 0, 20, 4)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 15}, image.Point{25, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "sets series cell options",
 			canvas: image.Rect(0, 0, 20, 10),
@@ -1641,6 +1880,121 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "pads the Y-axis labels",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				YLabelPadChar('0'),
+				YAxisFormattedValues(func(v float64) string {
+					if v == 0 || math.IsNaN(v) {
+						return "∅"
+					}
+					return fmt.Sprintf("%.1fs", v+10)
+				}),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0000∅", image.Point{0, 7})
+				testdraw.MustText(c, "61.7s", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "hides the Y-axis labels on a narrow canvas",
+			canvas: image.Rect(0, 0, 8, 10),
+			opts: []Option{
+				YLabelsHideWhenTooNarrow(),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			wantCapacity: 14,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{0, 0}, End: image.Point{0, 8}},
+					{Start: image.Point{0, 8}, End: image.Point{7, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{1, 9})
+				testdraw.MustText(c, "1", image.Point{7, 9})
+
+				// Braille line.
+				graphAr := image.Rect(1, 0, 8, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{13, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws the Y-axis labels that fit when hiding is enabled",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				YLabelsHideWhenTooNarrow(),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			wantCapacity: 28,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{5, 0}, End: image.Point{5, 8}},
+					{Start: image.Point{5, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "0", image.Point{4, 7})
+				testdraw.MustText(c, "51.68", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{6, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(6, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{26, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "custom Y-axis labels using a value formatter",
 			canvas: image.Rect(0, 0, 20, 10),
@@ -1683,6 +2037,49 @@ func TestLineChartDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "right to left Y-axis labels start next to the Y axis",
+			canvas: image.Rect(0, 0, 20, 10),
+			opts: []Option{
+				YLabelsRightToLeft(),
+				YAxisFormattedValues(func(v float64) string {
+					if v == 0 || math.IsNaN(v) {
+						return "∅"
+					}
+					return "מאה"
+				}),
+			},
+			writes: func(lc *LineChart) error {
+				return lc.Series("first", []float64{0, 100})
+			},
+			wantCapacity: 32,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				// Y and X axis.
+				lines := []draw.HVLine{
+					{Start: image.Point{3, 0}, End: image.Point{3, 8}},
+					{Start: image.Point{3, 8}, End: image.Point{19, 8}},
+				}
+				testdraw.MustHVLines(c, lines)
+
+				// Value labels.
+				testdraw.MustText(c, "∅", image.Point{2, 7})
+				testdraw.MustText(c, "האמ", image.Point{0, 3})
+				testdraw.MustText(c, "0", image.Point{4, 9})
+				testdraw.MustText(c, "1", image.Point{19, 9})
+
+				// Braille line.
+				graphAr := image.Rect(4, 0, 20, 8)
+				bc := testbraille.MustNew(graphAr)
+				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{30, 0})
+				testbraille.MustCopyTo(bc, c)
+
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "custom Y-axis labels using a value formatter that returns empty labels",
 			canvas: image.Rect(0, 0, 20, 10),
@@ -1732,29 +2129,12 @@ func TestLineChartDraws(t *testing.T) {
 			writes: func(lc *LineChart) error {
 				return lc.Series("first", []float64{0, 100})
 			},
-			wantCapacity: 2,
 			want: func(size image.Point) *faketerm.Terminal {
 				ft := faketerm.MustNew(size)
 				c := testcanvas.MustNew(ft.Area())
 
-				// Y and X axis.
-				lines := []draw.HVLine{
-					{Start: image.Point{18, 0}, End: image.Point{18, 8}},
-					{Start: image.Point{18, 8}, End: image.Point{19, 8}},
-				}
-				testdraw.MustHVLines(c, lines)
-
-				// Value labels.
-				testdraw.MustText(c, "0", image.Point{17, 7})
-				testdraw.MustText(c, "51.67999999999999…", image.Point{0, 3})
-				testdraw.MustText(c, "0", image.Point{19, 9})
-
-				// Braille line.
-				graphAr := image.Rect(19, 0, 20, 8)
-				bc := testbraille.MustNew(graphAr)
-				testdraw.MustBrailleLine(bc, image.Point{0, 31}, image.Point{1, 0})
-				testbraille.MustCopyTo(bc, c)
-
+				// The labels formatted by the value formatter don't fit.
+				testdraw.MustResizeNeeded(c)
 				testcanvas.MustApply(c, ft)
 				return ft
 			},
@@ -1916,6 +2296,65 @@ func TestMouseDoesNothingWithoutZoomTracker(t *testing.T) {
 	}
 }
 
+func TestLastDetails(t *testing.T) {
+	lc, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if got := lc.LastYDetails(); got != nil {
+		t.Errorf("LastYDetails => got %v before Draw, want nil", got)
+	}
+	if got := lc.LastXDetails(); got != nil {
+		t.Errorf("LastXDetails => got %v before Draw, want nil", got)
+	}
+
+	if err := lc.Series("first", []float64{0, 100}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+
+	// Too small canvas doesn't update the details.
+	small, err := canvas.New(image.Rect(0, 0, 2, 2))
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	if err := lc.Draw(small, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if got := lc.LastYDetails(); got != nil {
+		t.Errorf("LastYDetails => got %v after a resize, want nil", got)
+	}
+
+	cvs, err := canvas.New(image.Rect(0, 0, 20, 10))
+	if err != nil {
+		t.Fatalf("canvas.New => unexpected error: %v", err)
+	}
+	if err := lc.Draw(cvs, &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+
+	yd := lc.LastYDetails()
+	if yd == nil {
+		t.Fatalf("LastYDetails => got nil after Draw, want details")
+	}
+	if want := (image.Point{5, 0}); yd.Start != want {
+		t.Errorf("LastYDetails.Start => got %v, want %v", yd.Start, want)
+	}
+	if want := (image.Point{5, 8}); yd.End != want {
+		t.Errorf("LastYDetails.End => got %v, want %v", yd.End, want)
+	}
+
+	xd := lc.LastXDetails()
+	if xd == nil {
+		t.Fatalf("LastXDetails => got nil after Draw, want details")
+	}
+	if want := (image.Point{5, 8}); xd.Start != want {
+		t.Errorf("LastXDetails.Start => got %v, want %v", xd.Start, want)
+	}
+	if want := (image.Point{19, 8}); xd.End != want {
+		t.Errorf("LastXDetails.End => got %v, want %v", xd.End, want)
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -1998,3 +2437,51 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestClone(t *testing.T) {
+	cOpts := []cell.Option{cell.FgColor(cell.ColorRed)}
+	lc, err := New(
+		AxesCellOpts(cOpts...),
+		YAxisCustomScale(-10, 10),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	w, err := widgetapi.CloneWidget(lc)
+	if err != nil {
+		t.Fatalf("CloneWidget => unexpected error: %v", err)
+	}
+	clone := w.(*LineChart)
+	if err := clone.Series("series", []float64{1, 2}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+
+	draw := func() *faketerm.Terminal {
+		c, err := canvas.New(image.Rect(0, 0, 20, 10))
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := clone.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		ft, err := faketerm.New(c.Size())
+		if err != nil {
+			t.Fatalf("faketerm.New => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+	want := draw()
+
+	cOpts[0] = cell.FgColor(cell.ColorBlue)
+	lc.opts.yAxisCustomScale.max = 20
+	if err := lc.Series("series", []float64{5, 0, 5}); err != nil {
+		t.Fatalf("Series => unexpected error: %v", err)
+	}
+	if diff := faketerm.Diff(want, draw()); diff != "" {
+		t.Errorf("Draw => the clone changed after modifying the original %s", diff)
+	}
+}
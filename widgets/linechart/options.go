@@ -19,6 +19,7 @@ import (
 	"math"
 
 	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/runewidth"
 	"github.com/mum4k/termdash/widgets/linechart/internal/axes"
 	"github.com/mum4k/termdash/widgets/linechart/internal/zoom"
 )
@@ -37,6 +38,9 @@ type options struct {
 	xLabelCellOpts      []cell.Option
 	xLabelOrientation   axes.LabelOrientation
 	yLabelCellOpts      []cell.Option
+	yLabelDirection     axes.TextDirection
+	yLabelPadChar       rune
+	yLabelsHide         bool
 	xAxisUnscaled       bool
 	yAxisMode           axes.YScaleMode
 	yAxisCustomScale    *customScale
@@ -55,12 +59,28 @@ func (o *options) validate() error {
 			return fmt.Errorf("the min(%v) must be less than the max(%v) provided as custom Y scale", o.yAxisCustomScale.min, o.yAxisCustomScale.max)
 		}
 	}
+	if r := o.yLabelPadChar; r != 0 && runewidth.RuneWidth(r) != 1 {
+		return fmt.Errorf("invalid YLabelPadChar %q, must have a width of one cell", r)
+	}
 	if got, min, max := o.zoomStepPercent, 1, 100; got < min || got > max {
 		return fmt.Errorf("invalid ZoomStepPercent %d, must be in range %d <= value <= %d", got, min, max)
 	}
 	return nil
 }
 
+// clone returns a deep copy of the options.
+func (o *options) clone() *options {
+	c := *o
+	c.axesCellOpts = append([]cell.Option(nil), o.axesCellOpts...)
+	c.xLabelCellOpts = append([]cell.Option(nil), o.xLabelCellOpts...)
+	c.yLabelCellOpts = append([]cell.Option(nil), o.yLabelCellOpts...)
+	if o.yAxisCustomScale != nil {
+		cs := *o.yAxisCustomScale
+		c.yAxisCustomScale = &cs
+	}
+	return &c
+}
+
 // newOptions returns a new options instance.
 func newOptions(opts ...Option) *options {
 	opt := &options{
@@ -103,6 +123,15 @@ func XLabelsVertical() Option {
 	})
 }
 
+// XLabelsDiagonal makes the labels under the X axis flow diagonally at
+// approximately 45 degrees. Useful when there are many closely spaced labels.
+// Defaults to labels that flow horizontally.
+func XLabelsDiagonal() Option {
+	return option(func(opts *options) {
+		opts.xLabelOrientation = axes.LabelOrientationDiagonal
+	})
+}
+
 // XLabelsHorizontal makes the labels under the X axis flow horizontally.
 // This is the default option.
 func XLabelsHorizontal() Option {
@@ -118,6 +147,46 @@ func YLabelCellOpts(co ...cell.Option) Option {
 	})
 }
 
+// YLabelsRightToLeft makes the text of the labels on the Y axis flow from right
+// to left. Useful when the labels are formatted in a right-to-left script like
+// Arabic or Hebrew. The labels then start next to the Y axis.
+// Defaults to labels that flow left to right.
+func YLabelsRightToLeft() Option {
+	return option(func(opts *options) {
+		opts.yLabelDirection = axes.TextDirectionRTL
+	})
+}
+
+// YLabelsLeftToRight makes the text of the labels on the Y axis flow from left
+// to right. This is the default option.
+func YLabelsLeftToRight() Option {
+	return option(func(opts *options) {
+		opts.yLabelDirection = axes.TextDirectionLTR
+	})
+}
+
+// YLabelPadChar pads the labels on the Y axis with the rune so that all of
+// them fill the space between the left edge of the canvas and the Y axis.
+// E.g. '0' displays zero padded values or '─' draws a line from the labels
+// to the axis. The rune must have a width of one cell.
+// Defaults to no padding, the labels are aligned to the Y axis.
+func YLabelPadChar(r rune) Option {
+	return option(func(opts *options) {
+		opts.yLabelPadChar = r
+	})
+}
+
+// YLabelsHideWhenTooNarrow hides the labels on the Y axis when the canvas is
+// too narrow for them or when they would take more than half of its width.
+// Only the Y axis itself is drawn in such case, leaving more space for the
+// graph. Defaults to drawing the labels, the chart requests a resize when the
+// canvas is too narrow for them.
+func YLabelsHideWhenTooNarrow() Option {
+	return option(func(opts *options) {
+		opts.yLabelsHide = true
+	})
+}
+
 // YAxisAdaptive makes the Y axis adapt its base value depending on the
 // provided series.
 // Without this option, the Y axis always starts at the zero value regardless of
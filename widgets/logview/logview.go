@@ -0,0 +1,292 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logview implements a widget that displays the tail of a log.
+package logview
+
+import (
+	"bytes"
+	"image"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// LogView displays lines of a log and follows its tail.
+//
+// The LogView implements io.Writer, so it can be used as the output of a
+// log.Logger. Every written line is appended to a buffer that holds at most
+// the configured number of lines, the oldest lines are dropped when the buffer
+// is full. Lines longer than the width of the canvas are trimmed.
+//
+// The LogView automatically scrolls down as new lines are written. When the
+// user scrolls up, the view stays on the selected lines until the user
+// scrolls back to the bottom.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type LogView struct {
+	// lines are the complete lines written so far, oldest first.
+	lines []string
+	// partial is the last written line that wasn't terminated with a newline
+	// yet.
+	partial bytes.Buffer
+
+	// offset is the number of lines the view is scrolled up from the bottom.
+	// Zero means the view follows the tail of the log.
+	offset int
+	// lastHeight is the height of the canvas during the last call to Draw.
+	lastHeight int
+	// discard indicates that all written data is discarded.
+	discard bool
+
+	// mu protects the LogView.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new LogView.
+func New(opts ...Option) (*LogView, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &LogView{
+		opts: o,
+	}, nil
+}
+
+// Write implements io.Writer.
+// Appends the written data to the log. Data after the last newline is held
+// until the line is terminated. Carriage returns are removed and other
+// non-printable characters are replaced with spaces.
+// Always consumes all of the provided data and never returns an error.
+func (lv *LogView) Write(p []byte) (int, error) {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	if lv.discard {
+		return len(p), nil
+	}
+
+	for _, b := range p {
+		if b != '\n' {
+			lv.partial.WriteByte(b)
+			continue
+		}
+		lv.appendLine(lv.partial.String())
+		lv.partial.Reset()
+	}
+	return len(p), nil
+}
+
+// appendLine appends a complete line and drops the oldest lines if the
+// buffer is full.
+// Caller must hold lv.mu.
+func (lv *LogView) appendLine(line string) {
+	lv.lines = append(lv.lines, sanitize(line))
+	if lv.offset > 0 {
+		// The user scrolled up, keep the same lines in view.
+		lv.offset++
+	}
+	if over := len(lv.lines) - lv.opts.maxLines; over > 0 {
+		lv.lines = append([]string(nil), lv.lines[over:]...)
+	}
+	lv.clampOffset()
+}
+
+// sanitize removes carriage returns from the line and replaces other
+// non-printable characters with spaces.
+func sanitize(line string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\r':
+			return -1
+		case !unicode.IsPrint(r):
+			return ' '
+		default:
+			return r
+		}
+	}, line)
+}
+
+// SetDiscard when enabled, all data written to the LogView is discarded,
+// i.e. the LogView behaves like ioutil.Discard until it is disabled again.
+// The lines already in the buffer remain displayed. Any unterminated line
+// written before is dropped.
+func (lv *LogView) SetDiscard(enabled bool) {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	lv.discard = enabled
+	if enabled {
+		lv.partial.Reset()
+	}
+}
+
+// Discarding returns true if the written data is currently discarded.
+func (lv *LogView) Discarding() bool {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+	return lv.discard
+}
+
+// Reset removes all the lines from the LogView and resumes following the
+// tail of the log.
+func (lv *LogView) Reset() {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	lv.lines = nil
+	lv.partial.Reset()
+	lv.offset = 0
+}
+
+// Following returns true if the LogView automatically scrolls as new lines
+// are written, i.e. the user didn't scroll up.
+func (lv *LogView) Following() bool {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+	return lv.offset == 0
+}
+
+// maxOffset returns the largest offset that still fills the canvas.
+// Caller must hold lv.mu.
+func (lv *LogView) maxOffset() int {
+	max := len(lv.lines) - lv.lastHeight
+	if max < 0 {
+		return 0
+	}
+	return max
+}
+
+// clampOffset ensures the offset is within the valid range.
+// Caller must hold lv.mu.
+func (lv *LogView) clampOffset() {
+	if max := lv.maxOffset(); lv.offset > max {
+		lv.offset = max
+	}
+	if lv.offset < 0 {
+		lv.offset = 0
+	}
+}
+
+// scroll scrolls the view up by the specified number of lines, negative
+// values scroll down.
+// Caller must hold lv.mu.
+func (lv *LogView) scroll(lines int) {
+	lv.offset += lines
+	lv.clampOffset()
+}
+
+// lineColor returns the color of the line according to the keyword colors
+// and true if the line contains any of the keywords.
+func (lv *LogView) lineColor(line string) (cell.Color, bool) {
+	for i := len(lv.opts.keywordColors) - 1; i >= 0; i-- {
+		kc := lv.opts.keywordColors[i]
+		if strings.Contains(line, kc.keyword) {
+			return kc.color, true
+		}
+	}
+	return cell.ColorDefault, false
+}
+
+// Draw draws the LogView widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (lv *LogView) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	ar := cvs.Area()
+	lv.lastHeight = ar.Dy()
+	lv.clampOffset()
+
+	end := len(lv.lines) - lv.offset
+	start := end - ar.Dy()
+	if start < 0 {
+		start = 0
+	}
+
+	for i, line := range lv.lines[start:end] {
+		if line == "" {
+			continue
+		}
+		tOpts := []draw.TextOption{
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		}
+		if color, ok := lv.lineColor(line); ok {
+			tOpts = append(tOpts, draw.TextCellOpts(cell.FgColor(color)))
+		}
+		if err := draw.Text(cvs, line, image.Point{ar.Min.X, ar.Min.Y + i}, tOpts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keyboard implements widgetapi.Widget.Keyboard.
+func (lv *LogView) Keyboard(k *terminalapi.Keyboard) error {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	switch k.Key {
+	case lv.opts.keyUp:
+		lv.scroll(1)
+	case lv.opts.keyDown:
+		lv.scroll(-1)
+	case lv.opts.keyPgUp:
+		lv.scroll(lv.lastHeight)
+	case lv.opts.keyPgDown:
+		lv.scroll(-lv.lastHeight)
+	case lv.opts.keyEnd:
+		lv.offset = 0
+	}
+	return nil
+}
+
+// Mouse implements widgetapi.Widget.Mouse.
+func (lv *LogView) Mouse(m *terminalapi.Mouse) error {
+	lv.mu.Lock()
+	defer lv.mu.Unlock()
+
+	switch m.Button {
+	case lv.opts.mouseUpButton:
+		lv.scroll(1)
+	case lv.opts.mouseDownButton:
+		lv.scroll(-1)
+	}
+	return nil
+}
+
+// Options of the widget.
+// Implements widgetapi.Widget.Options.
+func (lv *LogView) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// At least one line with one character.
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
@@ -0,0 +1,404 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logview
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// compile time check that LogView implements io.Writer.
+var _ io.Writer = &LogView{}
+
+func TestLogView(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		// writes are written to the LogView before the first draw.
+		writes []string
+		// events are delivered to the LogView after the first draw.
+		events []terminalapi.Event
+		// writesAfter are written to the LogView after the events.
+		writesAfter []string
+		want        func(size image.Point) *faketerm.Terminal
+		wantNewErr  bool
+	}{
+		{
+			desc:       "fails on invalid MaxLines",
+			opts:       []Option{MaxLines(0)},
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on empty keyword",
+			opts:       []Option{KeywordColor("", cell.ColorBlue)},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on duplicate scroll keys",
+			opts: []Option{
+				ScrollKeys(keyboard.KeyArrowUp, keyboard.KeyArrowUp, keyboard.KeyPgUp, keyboard.KeyPgDn, keyboard.KeyEnd),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on duplicate scroll mouse buttons",
+			opts: []Option{
+				ScrollMouseButtons(mouse.ButtonLeft, mouse.ButtonLeft),
+			},
+			wantNewErr: true,
+		},
+		{
+			desc:   "draws nothing when empty",
+			canvas: image.Rect(0, 0, 10, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws lines that fit",
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: []string{"first\nsec", "ond\n"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "first", image.Point{0, 0})
+				testdraw.MustText(c, "second", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "holds an unterminated line",
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: []string{"first\npartial"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "first", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "removes carriage returns and replaces control characters",
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: []string{"a\tb\r\n"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "a b", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims long lines",
+			canvas: image.Rect(0, 0, 5, 1),
+			writes: []string{"hello world\n"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "hell…", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "follows the tail",
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: []string{"1\n2\n3\n"},
+			writesAfter: []string{
+				"4\n",
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "3", image.Point{0, 0})
+				testdraw.MustText(c, "4", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "drops the oldest lines over MaxLines",
+			opts:   []Option{MaxLines(2)},
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: []string{"1\n2\n3\n"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "2", image.Point{0, 0})
+				testdraw.MustText(c, "3", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "colors lines with the default keywords",
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: []string{"ERROR a\nWARN b\nINFO c\n"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "ERROR a", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "WARN b", image.Point{0, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorYellow)))
+				testdraw.MustText(c, "INFO c", image.Point{0, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "the keyword provided last takes precedence",
+			opts: []Option{
+				NoKeywordColors(),
+				KeywordColor("a", cell.ColorBlue),
+				KeywordColor("b", cell.ColorGreen),
+			},
+			canvas: image.Rect(0, 0, 10, 3),
+			writes: []string{"a b\nERROR\n"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "a b", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorGreen)))
+				testdraw.MustText(c, "ERROR", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolls up with keyboard and keeps the view when new lines arrive",
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: []string{"1\n2\n3\n4\n"},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+			},
+			writesAfter: []string{"5\n"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "2", image.Point{0, 0})
+				testdraw.MustText(c, "3", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolling up stops at the first line",
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: []string{"1\n2\n3\n"},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgUp},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "1", image.Point{0, 0})
+				testdraw.MustText(c, "2", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "scrolling back down resumes following the tail",
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: []string{"1\n2\n3\n"},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Button: mouse.ButtonWheelUp},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+			},
+			writesAfter: []string{"4\n"},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "3", image.Point{0, 0})
+				testdraw.MustText(c, "4", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "the tail key resumes following the tail",
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: []string{"1\n2\n3\n4\n"},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnd},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "3", image.Point{0, 0})
+				testdraw.MustText(c, "4", image.Point{0, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			lv, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			for _, w := range tc.writes {
+				if _, err := fmt.Fprint(lv, w); err != nil {
+					t.Fatalf("Write => unexpected error: %v", err)
+				}
+			}
+			if err := lv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					if err := lv.Keyboard(e); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+				case *terminalapi.Mouse:
+					if err := lv.Mouse(e); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+			}
+			for _, w := range tc.writesAfter {
+				if _, err := fmt.Fprint(lv, w); err != nil {
+					t.Fatalf("Write => unexpected error: %v", err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := lv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	lv, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	lv.SetDiscard(true)
+	if !lv.Discarding() {
+		t.Errorf("Discarding => got false after SetDiscard(true), want true")
+	}
+	const data = "dropped\n"
+	n, err := fmt.Fprint(lv, data)
+	if err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	if n != len(data) {
+		t.Errorf("Write => got %d bytes written, want %d", n, len(data))
+	}
+	if got := len(lv.lines); got != 0 {
+		t.Errorf("Write => got %d lines while discarding, want 0", got)
+	}
+
+	lv.SetDiscard(false)
+	if _, err := fmt.Fprint(lv, "kept\n"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	want := []string{"kept"}
+	if diff := pretty.Compare(want, lv.lines); diff != "" {
+		t.Errorf("Write => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestFollowing(t *testing.T) {
+	lv, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if _, err := fmt.Fprint(lv, "1\n2\n3\n"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	if err := lv.Draw(testcanvas.MustNew(image.Rect(0, 0, 3, 2)), &widgetapi.Meta{}); err != nil {
+		t.Fatalf("Draw => unexpected error: %v", err)
+	}
+	if !lv.Following() {
+		t.Errorf("Following => got false before scrolling, want true")
+	}
+	if err := lv.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyArrowUp}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if lv.Following() {
+		t.Errorf("Following => got true after scrolling up, want false")
+	}
+	lv.Reset()
+	if !lv.Following() {
+		t.Errorf("Following => got false after Reset, want true")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	lv, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := lv.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
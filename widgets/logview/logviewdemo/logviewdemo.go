@@ -0,0 +1,87 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary logviewdemo displays the LogView widget.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/logview"
+)
+
+// writeLogs periodically writes log messages to the logger.
+func writeLogs(ctx context.Context, logger *log.Logger) {
+	levels := []string{"INFO", "INFO", "INFO", "WARN", "ERROR"}
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for i := 0; ; i++ {
+		select {
+		case <-ticker.C:
+			logger.Printf("%-5s message number %d", levels[rand.Intn(len(levels))], i)
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	lv, err := logview.New(logview.MaxLines(500))
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go writeLogs(ctx, log.New(lv, "", log.Ltime))
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT, P TO PAUSE, END TO FOLLOW"),
+		container.PlaceWidget(lv),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	keyHandler := func(k *terminalapi.Keyboard) {
+		switch k.Key {
+		case 'q', 'Q':
+			cancel()
+		case 'p', 'P':
+			lv.SetDiscard(!lv.Discarding())
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(keyHandler)); err != nil {
+		panic(err)
+	}
+}
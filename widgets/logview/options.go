@@ -0,0 +1,182 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logview
+
+// options.go contains configurable options for LogView.
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// keywordColor is the color of lines containing a keyword.
+type keywordColor struct {
+	keyword string
+	color   cell.Color
+}
+
+// options holds the provided options.
+type options struct {
+	maxLines        int
+	keywordColors   []*keywordColor
+	keyUp           keyboard.Key
+	keyDown         keyboard.Key
+	keyPgUp         keyboard.Key
+	keyPgDown       keyboard.Key
+	keyEnd          keyboard.Key
+	mouseUpButton   mouse.Button
+	mouseDownButton mouse.Button
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		maxLines: DefaultMaxLines,
+		keywordColors: []*keywordColor{
+			{keyword: "ERROR", color: DefaultErrorColor},
+			{keyword: "WARN", color: DefaultWarnColor},
+		},
+		keyUp:           DefaultScrollKeyUp,
+		keyDown:         DefaultScrollKeyDown,
+		keyPgUp:         DefaultScrollKeyPageUp,
+		keyPgDown:       DefaultScrollKeyPageDown,
+		keyEnd:          DefaultTailKey,
+		mouseUpButton:   DefaultScrollMouseButtonUp,
+		mouseDownButton: DefaultScrollMouseButtonDown,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if got, min := o.maxLines, 1; got < min {
+		return fmt.Errorf("invalid MaxLines %d, must be %d <= MaxLines", got, min)
+	}
+	for _, kc := range o.keywordColors {
+		if kc.keyword == "" {
+			return errors.New("the keyword provided to KeywordColor cannot be empty")
+		}
+	}
+	keys := map[keyboard.Key]bool{
+		o.keyUp:     true,
+		o.keyDown:   true,
+		o.keyPgUp:   true,
+		o.keyPgDown: true,
+		o.keyEnd:    true,
+	}
+	if len(keys) != 5 {
+		return fmt.Errorf("invalid ScrollKeys(up:%v, down:%v, pageUp:%v, pageDown:%v, tail:%v), the keys must be unique", o.keyUp, o.keyDown, o.keyPgUp, o.keyPgDown, o.keyEnd)
+	}
+	if o.mouseUpButton == o.mouseDownButton {
+		return fmt.Errorf("invalid ScrollMouseButtons(up:%v, down:%v), the buttons must be unique", o.mouseUpButton, o.mouseDownButton)
+	}
+	return nil
+}
+
+// DefaultMaxLines is the default value for the MaxLines option.
+const DefaultMaxLines = 1000
+
+// MaxLines sets the maximum number of lines the LogView keeps. When more
+// lines are written, the oldest lines are discarded.
+// Defaults to DefaultMaxLines. Must be a positive integer.
+func MaxLines(n int) Option {
+	return option(func(opts *options) {
+		opts.maxLines = n
+	})
+}
+
+// The default colors of lines containing the default keywords.
+const (
+	DefaultErrorColor = cell.ColorRed
+	DefaultWarnColor  = cell.ColorYellow
+)
+
+// KeywordColor displays lines that contain the keyword in the specified
+// color. Can be specified multiple times, if a line contains multiple
+// keywords, the keyword provided last determines the color.
+// By default lines containing "ERROR" are displayed in DefaultErrorColor and
+// lines containing "WARN" in DefaultWarnColor.
+func KeywordColor(keyword string, color cell.Color) Option {
+	return option(func(opts *options) {
+		opts.keywordColors = append(opts.keywordColors, &keywordColor{
+			keyword: keyword,
+			color:   color,
+		})
+	})
+}
+
+// NoKeywordColors removes all the keyword colors provided so far including
+// the default ones.
+func NoKeywordColors() Option {
+	return option(func(opts *options) {
+		opts.keywordColors = nil
+	})
+}
+
+// The default mouse buttons for content scrolling.
+const (
+	DefaultScrollMouseButtonUp   = mouse.ButtonWheelUp
+	DefaultScrollMouseButtonDown = mouse.ButtonWheelDown
+)
+
+// ScrollMouseButtons configures the mouse buttons that scroll the content.
+// The provided buttons must be unique, e.g. the same button cannot be both up
+// and down.
+func ScrollMouseButtons(up, down mouse.Button) Option {
+	return option(func(opts *options) {
+		opts.mouseUpButton = up
+		opts.mouseDownButton = down
+	})
+}
+
+// The default keys for content scrolling.
+const (
+	DefaultScrollKeyUp       = keyboard.KeyArrowUp
+	DefaultScrollKeyDown     = keyboard.KeyArrowDown
+	DefaultScrollKeyPageUp   = keyboard.KeyPgUp
+	DefaultScrollKeyPageDown = keyboard.KeyPgDn
+	DefaultTailKey           = keyboard.KeyEnd
+)
+
+// ScrollKeys configures the keyboard keys that scroll the content. The tail
+// key scrolls to the last line and resumes the automatic scrolling.
+// The provided keys must be unique, e.g. the same key cannot be both up and
+// down.
+func ScrollKeys(up, down, pageUp, pageDown, tail keyboard.Key) Option {
+	return option(func(opts *options) {
+		opts.keyUp = up
+		opts.keyDown = down
+		opts.keyPgUp = pageUp
+		opts.keyPgDown = pageDown
+		opts.keyEnd = tail
+	})
+}
@@ -0,0 +1,74 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netgraph
+
+// layout.go positions the nodes on the canvas.
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/mum4k/termdash/private/runewidth"
+)
+
+// nodeHeight is the height of the box of a node in cells, the label is on
+// the middle row.
+const nodeHeight = 3
+
+// minNodeWidth is the width of the box of a node in cells required to display
+// at least one cell of the label.
+const minNodeWidth = 3
+
+// gridLayout places the nodes on a grid in the order they were added.
+// The grid is close to a square with at least as many columns as rows. Each
+// node is centered in its cell of the grid and its box is as wide as its label
+// or the cell of the grid allows.
+// Returns the areas of the boxes of the nodes in the same order as the nodes.
+func gridLayout(ar image.Rectangle, nodes []*node) ([]image.Rectangle, error) {
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	cols := int(math.Ceil(math.Sqrt(float64(len(nodes)))))
+	rows := (len(nodes) + cols - 1) / cols
+	cellW := ar.Dx() / cols
+	cellH := ar.Dy() / rows
+	if cellW < minNodeWidth || cellH < nodeHeight {
+		return nil, fmt.Errorf("the canvas %v is too small to display %d nodes in a grid of %dx%d, each node needs at least %dx%d cells", ar, len(nodes), cols, rows, minNodeWidth, nodeHeight)
+	}
+
+	boxes := make([]image.Rectangle, len(nodes))
+	for i, n := range nodes {
+		w := runewidth.StringWidth(n.label) + 2
+		if w < minNodeWidth {
+			w = minNodeWidth
+		}
+		if w > cellW {
+			w = cellW
+		}
+
+		grid := image.Rect(
+			ar.Min.X+i%cols*cellW, ar.Min.Y+i/cols*cellH,
+			ar.Min.X+(i%cols+1)*cellW, ar.Min.Y+(i/cols+1)*cellH,
+		)
+		start := image.Point{
+			grid.Min.X + (grid.Dx()-w)/2,
+			grid.Min.Y + (grid.Dy()-nodeHeight)/2,
+		}
+		boxes[i] = image.Rect(start.X, start.Y, start.X+w, start.Y+nodeHeight)
+	}
+	return boxes, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netgraph
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+func TestGridLayout(t *testing.T) {
+	tests := []struct {
+		desc    string
+		ar      image.Rectangle
+		labels  []string
+		want    []image.Rectangle
+		wantErr bool
+	}{
+		{
+			desc: "no nodes",
+			ar:   image.Rect(0, 0, 10, 10),
+		},
+		{
+			desc:    "fails when the nodes don't fit",
+			ar:      image.Rect(0, 0, 5, 3),
+			labels:  []string{"a", "b"},
+			wantErr: true,
+		},
+		{
+			desc:   "single node is centered",
+			ar:     image.Rect(0, 0, 10, 5),
+			labels: []string{"ab"},
+			want: []image.Rectangle{
+				image.Rect(3, 1, 7, 4),
+			},
+		},
+		{
+			desc:   "short labels use the minimal width",
+			ar:     image.Rect(0, 0, 3, 3),
+			labels: []string{""},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 3, 3),
+			},
+		},
+		{
+			desc:   "long labels are limited to the grid cell",
+			ar:     image.Rect(0, 0, 5, 3),
+			labels: []string{"long label"},
+			want: []image.Rectangle{
+				image.Rect(0, 0, 5, 3),
+			},
+		},
+		{
+			desc:   "nodes on a grid",
+			ar:     image.Rect(0, 0, 10, 6),
+			labels: []string{"a", "b", "c"},
+			want: []image.Rectangle{
+				image.Rect(1, 0, 4, 3),
+				image.Rect(6, 0, 9, 3),
+				image.Rect(1, 3, 4, 6),
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var nodes []*node
+			for _, l := range tc.labels {
+				nodes = append(nodes, &node{label: l})
+			}
+			got, err := gridLayout(tc.ar, nodes)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("gridLayout => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("gridLayout => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
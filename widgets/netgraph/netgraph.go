@@ -0,0 +1,281 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netgraph implements a widget that displays a graph of nodes
+// connected with edges.
+package netgraph
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/braille"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// node is a node of the graph.
+type node struct {
+	id    string
+	label string
+	opts  *nodeOptions
+}
+
+// edge connects two nodes of the graph.
+type edge struct {
+	from string
+	to   string
+	opts *edgeOptions
+}
+
+// NetworkGraph displays nodes as labeled boxes connected with lines that
+// represent the edges between them.
+//
+// The nodes are placed on a grid in the order they were added.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type NetworkGraph struct {
+	// nodes are the nodes in the order they were added.
+	nodes []*node
+	// nodeIdx maps IDs of nodes to their index in nodes.
+	nodeIdx map[string]int
+	// edges are the edges in the order they were added.
+	edges []*edge
+
+	// mu protects the NetworkGraph.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new NetworkGraph.
+func New(opts ...Option) (*NetworkGraph, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &NetworkGraph{
+		nodeIdx: map[string]int{},
+		opts:    o,
+	}, nil
+}
+
+// AddNode adds a node with the provided ID and label. The ID must be unique
+// and non-empty, it is used to refer to the node when adding edges.
+func (ng *NetworkGraph) AddNode(id, label string, opts ...NodeOption) error {
+	ng.mu.Lock()
+	defer ng.mu.Unlock()
+
+	if id == "" {
+		return errors.New("the node ID cannot be empty")
+	}
+	if _, ok := ng.nodeIdx[id]; ok {
+		return fmt.Errorf("node with ID %q already exists", id)
+	}
+
+	o := newNodeOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	ng.nodeIdx[id] = len(ng.nodes)
+	ng.nodes = append(ng.nodes, &node{
+		id:    id,
+		label: label,
+		opts:  o,
+	})
+	return nil
+}
+
+// AddEdge connects the nodes with the provided IDs. Both nodes must already
+// exist and must be different.
+func (ng *NetworkGraph) AddEdge(from, to string, opts ...EdgeOption) error {
+	ng.mu.Lock()
+	defer ng.mu.Unlock()
+
+	for _, id := range []string{from, to} {
+		if _, ok := ng.nodeIdx[id]; !ok {
+			return fmt.Errorf("node with ID %q doesn't exist", id)
+		}
+	}
+	if from == to {
+		return fmt.Errorf("an edge cannot connect node %q to itself", from)
+	}
+
+	o := newEdgeOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	ng.edges = append(ng.edges, &edge{
+		from: from,
+		to:   to,
+		opts: o,
+	})
+	return nil
+}
+
+// Reset removes all the nodes and edges.
+func (ng *NetworkGraph) Reset() {
+	ng.mu.Lock()
+	defer ng.mu.Unlock()
+
+	ng.nodes = nil
+	ng.nodeIdx = map[string]int{}
+	ng.edges = nil
+}
+
+// pixelMid returns the pixel in the middle of the area of cells on a braille
+// canvas.
+func pixelMid(ar image.Rectangle) image.Point {
+	mid := image.Point{
+		ar.Min.X + ar.Dx()/2,
+		ar.Min.Y + ar.Dy()/2,
+	}
+	return image.Point{
+		mid.X*braille.ColMult + braille.ColMult/2,
+		mid.Y*braille.RowMult + braille.RowMult/2,
+	}
+}
+
+// drawEdges draws the lines and labels of the edges between the boxes of the
+// nodes.
+// Caller must hold ng.mu.
+func (ng *NetworkGraph) drawEdges(cvs *canvas.Canvas, boxes []image.Rectangle) error {
+	bc, err := braille.New(cvs.Area())
+	if err != nil {
+		return fmt.Errorf("braille.New => %v", err)
+	}
+	for _, e := range ng.edges {
+		from := pixelMid(boxes[ng.nodeIdx[e.from]])
+		to := pixelMid(boxes[ng.nodeIdx[e.to]])
+		if err := draw.BrailleLine(bc, from, to, draw.BrailleLineCellOpts(cell.FgColor(e.opts.color))); err != nil {
+			return fmt.Errorf("failed to draw the edge from %q to %q: %v", e.from, e.to, err)
+		}
+	}
+	if err := bc.CopyTo(cvs); err != nil {
+		return err
+	}
+
+	ar := cvs.Area()
+	for _, e := range ng.edges {
+		if e.opts.label == "" {
+			continue
+		}
+		from := boxes[ng.nodeIdx[e.from]]
+		to := boxes[ng.nodeIdx[e.to]]
+		mid := image.Point{
+			(from.Min.X + from.Max.X + to.Min.X + to.Max.X) / 4,
+			(from.Min.Y + from.Max.Y + to.Min.Y + to.Max.Y) / 4,
+		}
+		start := image.Point{mid.X - runewidth.StringWidth(e.opts.label)/2, mid.Y}
+		if start.X < ar.Min.X {
+			start.X = ar.Min.X
+		}
+		if err := draw.Text(cvs, e.opts.label, start,
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeTrim),
+			draw.TextCellOpts(cell.FgColor(e.opts.color)),
+		); err != nil {
+			return fmt.Errorf("failed to draw the label of the edge from %q to %q: %v", e.from, e.to, err)
+		}
+	}
+	return nil
+}
+
+// drawNode draws the box and the label of the node.
+// Caller must hold ng.mu.
+func (ng *NetworkGraph) drawNode(cvs *canvas.Canvas, n *node, box image.Rectangle) error {
+	// Clear any edges that pass under the node.
+	if err := draw.Rectangle(cvs, box); err != nil {
+		return err
+	}
+	if err := draw.Border(cvs, box,
+		draw.BorderLineStyle(ng.opts.nodeBorder),
+		draw.BorderCellOpts(cell.FgColor(n.opts.color)),
+	); err != nil {
+		return err
+	}
+	if n.label == "" {
+		return nil
+	}
+
+	labelAr := image.Rect(box.Min.X+1, box.Min.Y+1, box.Max.X-1, box.Max.Y-1)
+	start := image.Point{
+		labelAr.Min.X + (labelAr.Dx()-runewidth.StringWidth(n.label))/2,
+		labelAr.Min.Y,
+	}
+	if start.X < labelAr.Min.X {
+		start.X = labelAr.Min.X
+	}
+	return draw.Text(cvs, n.label, start,
+		draw.TextMaxX(labelAr.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(cell.FgColor(n.opts.color)),
+	)
+}
+
+// Draw draws the NetworkGraph widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (ng *NetworkGraph) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	ng.mu.Lock()
+	defer ng.mu.Unlock()
+
+	boxes, err := gridLayout(cvs.Area(), ng.nodes)
+	if err != nil {
+		return draw.ResizeNeeded(cvs)
+	}
+	if len(boxes) == 0 {
+		return nil
+	}
+
+	if err := ng.drawEdges(cvs, boxes); err != nil {
+		return err
+	}
+	for i, n := range ng.nodes {
+		if err := ng.drawNode(cvs, n, boxes[i]); err != nil {
+			return fmt.Errorf("failed to draw node %q: %v", n.id, err)
+		}
+	}
+	return nil
+}
+
+// Keyboard input isn't supported on the NetworkGraph widget.
+func (*NetworkGraph) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the NetworkGraph widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the NetworkGraph widget.
+func (*NetworkGraph) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the NetworkGraph widget doesn't support mouse events")
+}
+
+// Options of the widget.
+// Implements widgetapi.Widget.Options.
+func (ng *NetworkGraph) Options() widgetapi.Options {
+	return widgetapi.Options{
+		// At least one node.
+		MinimumSize:  image.Point{minNodeWidth, nodeHeight},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netgraph
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/braille/testbraille"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestNetworkGraph(t *testing.T) {
+	tests := []struct {
+		desc          string
+		opts          []Option
+		update        func(*NetworkGraph) error // update gets called before drawing of the widget.
+		canvas        image.Rectangle
+		want          func(size image.Point) *faketerm.Terminal
+		wantNewErr    bool
+		wantUpdateErr bool
+	}{
+		{
+			desc:       "fails on nodes without border",
+			opts:       []Option{NodeBorder(linestyle.None)},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on empty node ID",
+			update: func(ng *NetworkGraph) error {
+				return ng.AddNode("", "a")
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails on duplicate node ID",
+			update: func(ng *NetworkGraph) error {
+				if err := ng.AddNode("a", "a"); err != nil {
+					return err
+				}
+				return ng.AddNode("a", "b")
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails on edge to a node that doesn't exist",
+			update: func(ng *NetworkGraph) error {
+				if err := ng.AddNode("a", "a"); err != nil {
+					return err
+				}
+				return ng.AddEdge("a", "b")
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "fails on edge from a node to itself",
+			update: func(ng *NetworkGraph) error {
+				if err := ng.AddNode("a", "a"); err != nil {
+					return err
+				}
+				return ng.AddEdge("a", "a")
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "draws nothing without nodes",
+			canvas: image.Rect(0, 0, 5, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws resize needed character when the nodes don't fit",
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(ng *NetworkGraph) error {
+				if err := ng.AddNode("a", "a"); err != nil {
+					return err
+				}
+				return ng.AddNode("b", "b")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws a node with custom color and border",
+			opts:   []Option{NodeBorder(linestyle.Double)},
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(ng *NetworkGraph) error {
+				return ng.AddNode("a", "a", NodeColor(cell.ColorRed))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(c, image.Rect(1, 0, 4, 3),
+					draw.BorderLineStyle(linestyle.Double),
+					draw.BorderCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustText(c, "a", image.Point{2, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "trims labels that don't fit",
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(ng *NetworkGraph) error {
+				return ng.AddNode("a", "abcd")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustBorder(c, image.Rect(0, 0, 5, 3))
+				testdraw.MustText(c, "ab…", image.Point{1, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws an edge with a label between two nodes",
+			canvas: image.Rect(0, 0, 20, 3),
+			update: func(ng *NetworkGraph) error {
+				if err := ng.AddNode("a", "a"); err != nil {
+					return err
+				}
+				if err := ng.AddNode("b", "b"); err != nil {
+					return err
+				}
+				return ng.AddEdge("a", "b", EdgeColor(cell.ColorBlue), EdgeLabel("ab"))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(ft.Area())
+				testdraw.MustBrailleLine(bc, image.Point{9, 6}, image.Point{29, 6},
+					draw.BrailleLineCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testbraille.MustCopyTo(bc, c)
+				testdraw.MustText(c, "ab", image.Point{8, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+
+				nodeOpts := cell.FgColor(DefaultNodeColor)
+				for _, box := range []image.Rectangle{image.Rect(3, 0, 6, 3), image.Rect(13, 0, 16, 3)} {
+					testdraw.MustRectangle(c, box)
+					testdraw.MustBorder(c, box, draw.BorderCellOpts(nodeOpts))
+				}
+				testdraw.MustText(c, "a", image.Point{4, 1}, draw.TextCellOpts(nodeOpts))
+				testdraw.MustText(c, "b", image.Point{14, 1}, draw.TextCellOpts(nodeOpts))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "reset removes the nodes",
+			canvas: image.Rect(0, 0, 5, 3),
+			update: func(ng *NetworkGraph) error {
+				if err := ng.AddNode("a", "a"); err != nil {
+					return err
+				}
+				ng.Reset()
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ng, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				err := tc.update(ng)
+				if (err != nil) != tc.wantUpdateErr {
+					t.Errorf("update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := ng.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	ng, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := ng.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	ng, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := ng.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	ng, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := ng.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{3, 3},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary netgraphdemo displays the NetworkGraph widget.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/netgraph"
+)
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ng, err := netgraph.New()
+	if err != nil {
+		panic(err)
+	}
+	nodes := []struct {
+		id    string
+		label string
+		color cell.Color
+	}{
+		{"lb", "load balancer", cell.ColorCyan},
+		{"fe1", "frontend-1", cell.ColorGreen},
+		{"fe2", "frontend-2", cell.ColorGreen},
+		{"db", "database", cell.ColorYellow},
+		{"cache", "cache", cell.ColorRed},
+	}
+	for _, n := range nodes {
+		if err := ng.AddNode(n.id, n.label, netgraph.NodeColor(n.color)); err != nil {
+			panic(err)
+		}
+	}
+	edges := []struct {
+		from  string
+		to    string
+		label string
+	}{
+		{"lb", "fe1", "http"},
+		{"lb", "fe2", "http"},
+		{"fe1", "db", "sql"},
+		{"fe2", "db", "sql"},
+		{"fe2", "cache", ""},
+	}
+	for _, e := range edges {
+		if err := ng.AddEdge(e.from, e.to, netgraph.EdgeLabel(e.label)); err != nil {
+			panic(err)
+		}
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(ng),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netgraph
+
+// options.go contains configurable options for NetworkGraph, its nodes and
+// edges.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/linestyle"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	nodeBorder linestyle.LineStyle
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		nodeBorder: DefaultNodeBorder,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.nodeBorder == linestyle.None {
+		return fmt.Errorf("invalid NodeBorder %v, the nodes must have a border", o.nodeBorder)
+	}
+	return nil
+}
+
+// DefaultNodeBorder is the default value for the NodeBorder option.
+const DefaultNodeBorder = linestyle.Light
+
+// NodeBorder sets the line style of the boxes that represent the nodes.
+// Defaults to DefaultNodeBorder.
+func NodeBorder(ls linestyle.LineStyle) Option {
+	return option(func(opts *options) {
+		opts.nodeBorder = ls
+	})
+}
+
+// NodeOption is used to provide options to AddNode.
+type NodeOption interface {
+	// set sets the provided option.
+	set(*nodeOptions)
+}
+
+// nodeOption implements NodeOption.
+type nodeOption func(*nodeOptions)
+
+// set implements NodeOption.set.
+func (no nodeOption) set(opts *nodeOptions) {
+	no(opts)
+}
+
+// nodeOptions holds the options provided for a node.
+type nodeOptions struct {
+	color cell.Color
+}
+
+// newNodeOptions returns node options with the default values set.
+func newNodeOptions() *nodeOptions {
+	return &nodeOptions{
+		color: DefaultNodeColor,
+	}
+}
+
+// DefaultNodeColor is the default value for the NodeColor option.
+const DefaultNodeColor = cell.ColorDefault
+
+// NodeColor sets the color of the box and the label of the node.
+// Defaults to DefaultNodeColor.
+func NodeColor(c cell.Color) NodeOption {
+	return nodeOption(func(opts *nodeOptions) {
+		opts.color = c
+	})
+}
+
+// EdgeOption is used to provide options to AddEdge.
+type EdgeOption interface {
+	// set sets the provided option.
+	set(*edgeOptions)
+}
+
+// edgeOption implements EdgeOption.
+type edgeOption func(*edgeOptions)
+
+// set implements EdgeOption.set.
+func (eo edgeOption) set(opts *edgeOptions) {
+	eo(opts)
+}
+
+// edgeOptions holds the options provided for an edge.
+type edgeOptions struct {
+	color cell.Color
+	label string
+}
+
+// newEdgeOptions returns edge options with the default values set.
+func newEdgeOptions() *edgeOptions {
+	return &edgeOptions{
+		color: DefaultEdgeColor,
+	}
+}
+
+// DefaultEdgeColor is the default value for the EdgeColor option.
+const DefaultEdgeColor = cell.ColorDefault
+
+// EdgeColor sets the color of the line and the label of the edge.
+// Defaults to DefaultEdgeColor.
+func EdgeColor(c cell.Color) EdgeOption {
+	return edgeOption(func(opts *edgeOptions) {
+		opts.color = c
+	})
+}
+
+// EdgeLabel sets a label displayed in the middle of the edge. The label is
+// hidden where it overlaps with the nodes.
+// Edges don't have labels by default.
+func EdgeLabel(label string) EdgeOption {
+	return edgeOption(func(opts *edgeOptions) {
+		opts.label = label
+	})
+}
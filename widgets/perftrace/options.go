@@ -0,0 +1,131 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perftrace
+
+// options.go contains configurable options for PerfTrace.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	capacity      int
+	widgetName    string
+	durationColor cell.Color
+	p50Color      cell.Color
+	p95Color      cell.Color
+	p99Color      cell.Color
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		capacity:      DefaultCapacity,
+		durationColor: DefaultDurationColor,
+		p50Color:      DefaultP50Color,
+		p95Color:      DefaultP95Color,
+		p99Color:      DefaultP99Color,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if got, min := o.capacity, 1; got < min {
+		return fmt.Errorf("invalid Capacity %d, must be %d <= Capacity", got, min)
+	}
+	return nil
+}
+
+// DefaultCapacity is the default value for the Capacity option.
+const DefaultCapacity = 120
+
+// Capacity sets the number of most recent render durations the PerfTrace
+// keeps and displays. Older durations are discarded.
+// Defaults to DefaultCapacity. Must be a positive integer.
+func Capacity(n int) Option {
+	return option(func(opts *options) {
+		opts.capacity = n
+	})
+}
+
+// TraceWidget instructs the PerfTrace to record the render durations of the
+// widget with the specified name instead of the durations of complete frames.
+// The name must match the widgetName reported to termdash.RenderHook, i.e.
+// the ID of the container holding the widget.
+func TraceWidget(name string) Option {
+	return option(func(opts *options) {
+		opts.widgetName = name
+	})
+}
+
+// The default colors of the lines drawn by PerfTrace.
+const (
+	DefaultDurationColor = cell.ColorBlue
+	DefaultP50Color      = cell.ColorGreen
+	DefaultP95Color      = cell.ColorYellow
+	DefaultP99Color      = cell.ColorRed
+)
+
+// DurationColor sets the color of the line showing the recorded durations.
+// Defaults to DefaultDurationColor.
+func DurationColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.durationColor = c
+	})
+}
+
+// P50Color sets the color of the threshold line and label of the 50th
+// percentile.
+// Defaults to DefaultP50Color.
+func P50Color(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.p50Color = c
+	})
+}
+
+// P95Color sets the color of the threshold line and label of the 95th
+// percentile.
+// Defaults to DefaultP95Color.
+func P95Color(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.p95Color = c
+	})
+}
+
+// P99Color sets the color of the threshold line and label of the 99th
+// percentile.
+// Defaults to DefaultP99Color.
+func P99Color(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.p99Color = c
+	})
+}
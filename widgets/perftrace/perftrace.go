@@ -0,0 +1,233 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package perftrace is a widget that displays the render durations of the
+// dashboard or of one of its widgets along with their percentiles.
+package perftrace
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/linechart"
+)
+
+// PerfTrace displays the most recent render durations as a line chart. The
+// 50th, 95th and 99th percentiles of the durations are drawn as horizontal
+// threshold lines and their values are displayed above the chart.
+//
+// Register the Record method with the dashboard to feed the PerfTrace:
+//
+//	termdash.Run(ctx, t, c, termdash.AddRenderHook(pt.Record))
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type PerfTrace struct {
+	// lc is the line chart that draws the durations.
+	lc *linechart.LineChart
+
+	// ring is a ring buffer of the recorded durations.
+	ring []time.Duration
+	// next is the index in ring where the next duration will be stored.
+	next int
+	// full indicates that ring is full and older durations are being
+	// overwritten.
+	full bool
+
+	// mu protects the PerfTrace.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new PerfTrace.
+func New(opts ...Option) (*PerfTrace, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+
+	lc, err := linechart.New(
+		linechart.YAxisFormattedValues(linechart.ValueFormatterSuffix(1, "ms")),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &PerfTrace{
+		lc:   lc,
+		ring: make([]time.Duration, opt.capacity),
+		opts: opt,
+	}, nil
+}
+
+// Record records the render duration of the named widget.
+// Durations reported for widgets other than the one selected with the
+// TraceWidget option are ignored. Has the signature of termdash.RenderHook.
+func (pt *PerfTrace) Record(widgetName string, duration time.Duration) {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	if widgetName != pt.opts.widgetName {
+		return
+	}
+	pt.ring[pt.next] = duration
+	pt.next++
+	if pt.next == len(pt.ring) {
+		pt.next = 0
+		pt.full = true
+	}
+}
+
+// durations returns the recorded durations, ordered from oldest to newest.
+// pt.mu must be held when calling this method.
+func (pt *PerfTrace) durations() []time.Duration {
+	if !pt.full {
+		return append([]time.Duration(nil), pt.ring[:pt.next]...)
+	}
+	res := append([]time.Duration(nil), pt.ring[pt.next:]...)
+	return append(res, pt.ring[:pt.next]...)
+}
+
+// percentile returns the p-th percentile of the sorted durations, using the
+// nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// threshold is one of the percentiles drawn by the PerfTrace.
+type threshold struct {
+	label    string
+	duration time.Duration
+	color    cell.Color
+}
+
+// thresholds calculates the thresholds of the provided durations.
+// pt.mu must be held when calling this method.
+func (pt *PerfTrace) thresholds(durations []time.Duration) []*threshold {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+	return []*threshold{
+		{label: "P50", duration: percentile(sorted, 50), color: pt.opts.p50Color},
+		{label: "P95", duration: percentile(sorted, 95), color: pt.opts.p95Color},
+		{label: "P99", duration: percentile(sorted, 99), color: pt.opts.p99Color},
+	}
+}
+
+// toMillis converts the duration to fractional milliseconds.
+func toMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// Draw draws the PerfTrace widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (pt *PerfTrace) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	pt.mu.Lock()
+	defer pt.mu.Unlock()
+
+	durations := pt.durations()
+	if len(durations) == 0 {
+		return nil
+	}
+	ths := pt.thresholds(durations)
+
+	if err := drawHeader(cvs, ths); err != nil {
+		return err
+	}
+
+	values := make([]float64, len(durations))
+	for i, d := range durations {
+		values[i] = toMillis(d)
+	}
+	if err := pt.lc.Series("duration", values, linechart.SeriesCellOpts(cell.FgColor(pt.opts.durationColor))); err != nil {
+		return err
+	}
+	for _, th := range ths {
+		line := make([]float64, len(durations))
+		for i := range line {
+			line[i] = toMillis(th.duration)
+		}
+		if err := pt.lc.Series(th.label, line, linechart.SeriesCellOpts(cell.FgColor(th.color))); err != nil {
+			return err
+		}
+	}
+
+	ar := cvs.Area()
+	chartCvs, err := canvas.New(image.Rect(ar.Min.X, ar.Min.Y+1, ar.Max.X, ar.Max.Y))
+	if err != nil {
+		return err
+	}
+	if err := pt.lc.Draw(chartCvs, meta); err != nil {
+		return err
+	}
+	return chartCvs.CopyTo(cvs)
+}
+
+// drawHeader draws the values of the thresholds on the first line of the
+// canvas.
+func drawHeader(cvs *canvas.Canvas, ths []*threshold) error {
+	ar := cvs.Area()
+	x := ar.Min.X
+	for _, th := range ths {
+		if x >= ar.Max.X {
+			break
+		}
+		text := fmt.Sprintf("%s %.1fms ", th.label, toMillis(th.duration))
+		if err := draw.Text(cvs, text, image.Point{x, ar.Min.Y},
+			draw.TextCellOpts(cell.FgColor(th.color)),
+			draw.TextOverrunMode(draw.OverrunModeTrim),
+		); err != nil {
+			return err
+		}
+		x += len(text)
+	}
+	return nil
+}
+
+// Keyboard input isn't supported on the PerfTrace widget.
+func (*PerfTrace) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the PerfTrace widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the PerfTrace widget.
+func (*PerfTrace) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the PerfTrace widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (pt *PerfTrace) Options() widgetapi.Options {
+	// One additional line for the threshold values above the line chart.
+	min := pt.lc.Options().MinimumSize
+	return widgetapi.Options{
+		MinimumSize: image.Point{min.X, min.Y + 1},
+	}
+}
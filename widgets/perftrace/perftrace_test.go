@@ -0,0 +1,310 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perftrace
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/linechart"
+)
+
+// mustDrawChart draws the expected line chart with the durations and the
+// percentile thresholds under the first line of the canvas.
+func mustDrawChart(cvs *canvas.Canvas, durations []float64, p50, p95, p99 float64) {
+	lc, err := linechart.New(
+		linechart.YAxisFormattedValues(linechart.ValueFormatterSuffix(1, "ms")),
+	)
+	if err != nil {
+		panic(err)
+	}
+	series := []struct {
+		label string
+		value float64
+		color cell.Color
+	}{
+		{"P50", p50, DefaultP50Color},
+		{"P95", p95, DefaultP95Color},
+		{"P99", p99, DefaultP99Color},
+	}
+	if err := lc.Series("duration", durations, linechart.SeriesCellOpts(cell.FgColor(DefaultDurationColor))); err != nil {
+		panic(err)
+	}
+	for _, s := range series {
+		line := make([]float64, len(durations))
+		for i := range line {
+			line[i] = s.value
+		}
+		if err := lc.Series(s.label, line, linechart.SeriesCellOpts(cell.FgColor(s.color))); err != nil {
+			panic(err)
+		}
+	}
+
+	ar := cvs.Area()
+	chartCvs := testcanvas.MustNew(image.Rect(ar.Min.X, ar.Min.Y+1, ar.Max.X, ar.Max.Y))
+	if err := lc.Draw(chartCvs, &widgetapi.Meta{}); err != nil {
+		panic(err)
+	}
+	testcanvas.MustCopyTo(chartCvs, cvs)
+}
+
+func TestPerfTrace(t *testing.T) {
+	tests := []struct {
+		desc    string
+		opts    []Option
+		record  func(*PerfTrace) // record gets called before drawing of the widget.
+		canvas  image.Rectangle
+		want    func(size image.Point) *faketerm.Terminal
+		wantErr bool
+	}{
+		{
+			desc: "fails on zero capacity",
+			opts: []Option{
+				Capacity(0),
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws nothing without recorded durations",
+			record: func(*PerfTrace) {},
+			canvas: image.Rect(0, 0, 30, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "ignores durations of widgets when tracing frames",
+			record: func(pt *PerfTrace) {
+				pt.Record("widget", time.Second)
+			},
+			canvas: image.Rect(0, 0, 30, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "draws frame durations and percentiles",
+			record: func(pt *PerfTrace) {
+				for _, ms := range []int{1, 2, 3, 4} {
+					pt.Record("", time.Duration(ms)*time.Millisecond)
+				}
+			},
+			canvas: image.Rect(0, 0, 40, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "P50 2.0ms ", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(DefaultP50Color)))
+				testdraw.MustText(c, "P95 4.0ms ", image.Point{10, 0}, draw.TextCellOpts(cell.FgColor(DefaultP95Color)))
+				testdraw.MustText(c, "P99 4.0ms ", image.Point{20, 0}, draw.TextCellOpts(cell.FgColor(DefaultP99Color)))
+				mustDrawChart(c, []float64{1, 2, 3, 4}, 2, 4, 4)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "traces the selected widget",
+			opts: []Option{
+				TraceWidget("widget"),
+			},
+			record: func(pt *PerfTrace) {
+				pt.Record("", time.Second)
+				pt.Record("widget", 2*time.Millisecond)
+				pt.Record("other", time.Second)
+				pt.Record("widget", 4*time.Millisecond)
+			},
+			canvas: image.Rect(0, 0, 40, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "P50 2.0ms ", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(DefaultP50Color)))
+				testdraw.MustText(c, "P95 4.0ms ", image.Point{10, 0}, draw.TextCellOpts(cell.FgColor(DefaultP95Color)))
+				testdraw.MustText(c, "P99 4.0ms ", image.Point{20, 0}, draw.TextCellOpts(cell.FgColor(DefaultP99Color)))
+				mustDrawChart(c, []float64{2, 4}, 2, 4, 4)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "discards the oldest durations over capacity",
+			opts: []Option{
+				Capacity(3),
+			},
+			record: func(pt *PerfTrace) {
+				for _, ms := range []int{9, 1, 2, 3} {
+					pt.Record("", time.Duration(ms)*time.Millisecond)
+				}
+			},
+			canvas: image.Rect(0, 0, 40, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "P50 2.0ms ", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(DefaultP50Color)))
+				testdraw.MustText(c, "P95 3.0ms ", image.Point{10, 0}, draw.TextCellOpts(cell.FgColor(DefaultP95Color)))
+				testdraw.MustText(c, "P99 3.0ms ", image.Point{20, 0}, draw.TextCellOpts(cell.FgColor(DefaultP99Color)))
+				mustDrawChart(c, []float64{1, 2, 3}, 2, 3, 3)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "trims the percentiles to the canvas width",
+			record: func(pt *PerfTrace) {
+				pt.Record("", time.Millisecond)
+			},
+			canvas: image.Rect(0, 0, 15, 10),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "P50 1.0ms ", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(DefaultP50Color)))
+				testdraw.MustText(c, "P95 1", image.Point{10, 0}, draw.TextCellOpts(cell.FgColor(DefaultP95Color)))
+				mustDrawChart(c, []float64{1}, 1, 1, 1)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			pt, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			tc.record(pt)
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := pt.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		desc   string
+		sorted []time.Duration
+		p      float64
+		want   time.Duration
+	}{
+		{
+			desc:   "single value",
+			sorted: []time.Duration{5},
+			p:      50,
+			want:   5,
+		},
+		{
+			desc:   "median of even count",
+			sorted: []time.Duration{1, 2, 3, 4},
+			p:      50,
+			want:   2,
+		},
+		{
+			desc:   "high percentile",
+			sorted: []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			p:      95,
+			want:   10,
+		},
+		{
+			desc:   "zero percentile returns the minimum",
+			sorted: []time.Duration{1, 2, 3},
+			p:      0,
+			want:   1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := percentile(tc.sorted, tc.p); got != tc.want {
+				t.Errorf("percentile(%v, %v) => %v, want %v", tc.sorted, tc.p, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	pt, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := pt.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	pt, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := pt.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	pt, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	lc, err := linechart.New(
+		linechart.YAxisFormattedValues(linechart.ValueFormatterSuffix(1, "ms")),
+	)
+	if err != nil {
+		t.Fatalf("linechart.New => unexpected error: %v", err)
+	}
+	lcMin := lc.Options().MinimumSize
+
+	got := pt.Options()
+	want := widgetapi.Options{
+		MinimumSize: image.Point{lcMin.X, lcMin.Y + 1},
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
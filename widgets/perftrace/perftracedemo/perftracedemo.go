@@ -0,0 +1,121 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary perftracedemo displays a PerfTrace widget that traces the render
+// durations of the dashboard.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/perftrace"
+	"github.com/mum4k/termdash/widgets/sparkline"
+)
+
+// playSparkLine continuously adds values to the SparkLine, so that the
+// dashboard has something to render.
+func playSparkLine(ctx context.Context, sl *sparkline.SparkLine, delay time.Duration) {
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := sl.Add([]int{rand.Intn(100)}); err != nil {
+				panic(err)
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sl, err := sparkline.New()
+	if err != nil {
+		panic(err)
+	}
+	go playSparkLine(ctx, sl, 50*time.Millisecond)
+
+	frames, err := perftrace.New()
+	if err != nil {
+		panic(err)
+	}
+	spark, err := perftrace.New(perftrace.TraceWidget("spark"))
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.ID("spark"),
+				container.Border(linestyle.Light),
+				container.BorderTitle("SparkLine"),
+				container.PlaceWidget(sl),
+			),
+			container.Bottom(
+				container.SplitVertical(
+					container.Left(
+						container.Border(linestyle.Light),
+						container.BorderTitle("Frame render times"),
+						container.PlaceWidget(frames),
+					),
+					container.Right(
+						container.Border(linestyle.Light),
+						container.BorderTitle("SparkLine render times"),
+						container.PlaceWidget(spark),
+					),
+				),
+			),
+			container.SplitPercent(30),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c,
+		termdash.KeyboardSubscriber(quitter),
+		termdash.RedrawInterval(50*time.Millisecond),
+		termdash.AddRenderHook(frames.Record),
+		termdash.AddRenderHook(spark.Record),
+	); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package piechart
+
+// arc.go assists in calculation of points and angles on the circle.
+
+import (
+	"image"
+	"math"
+)
+
+// arc is a part of the circle between two angles in degrees. The arc spans
+// counter-clockwise from the start to the end.
+type arc struct {
+	start int
+	end   int
+}
+
+// ccwArcs returns the arcs that span counter-clockwise from angle a to angle
+// b where a <= b. The returned arcs have angles in range 0 <= angle <= 360 as
+// required by draw.BrailleCircleArcOnly, so an arc that crosses the zero angle
+// is split in two. Returns no arcs if the angles round to the same value.
+func ccwArcs(a, b float64) []arc {
+	start := int(math.Round(a))
+	end := int(math.Round(b))
+	if start >= end {
+		return nil
+	}
+	if end-start >= 360 {
+		return []arc{{0, 360}}
+	}
+
+	// Move the start into range 0 <= start < 360.
+	shift := start / 360 * 360
+	if start < 0 {
+		shift -= 360
+	}
+	start -= shift
+	end -= shift
+	if start == 360 {
+		start, end = 0, end-360
+	}
+
+	if end <= 360 {
+		return []arc{{start, end}}
+	}
+	return []arc{{start, 360}, {0, end - 360}}
+}
+
+// midAndRadius given an area of a braille canvas, determines the mid point in
+// pixels and radius to draw the largest circle that fits.
+func midAndRadius(ar image.Rectangle) (image.Point, int) {
+	mid := image.Point{ar.Dx() / 2, ar.Dy() / 2}
+	if mid.X%2 != 0 {
+		mid.X--
+	}
+	switch mid.Y % 4 {
+	case 0:
+		mid.Y++
+	case 2:
+		mid.Y--
+	case 3:
+		mid.Y -= 2
+	}
+
+	// Calculate radius based on the smaller axis.
+	var radius int
+	if ar.Dx() < ar.Dy() {
+		if mid.X < ar.Dx()/2 {
+			radius = mid.X
+		} else {
+			radius = ar.Dx() - mid.X - 1
+		}
+	} else {
+		if mid.Y < ar.Dy()/2 {
+			radius = mid.Y
+		} else {
+			radius = ar.Dy() - mid.Y - 1
+		}
+	}
+	return mid, radius
+}
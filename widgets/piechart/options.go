@@ -0,0 +1,83 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package piechart
+
+// options.go contains configurable options for PieChart.
+
+import (
+	"fmt"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	showPercentages bool
+	startAngle      int
+}
+
+// clone returns a copy of the options.
+func (o *options) clone() *options {
+	c := *o
+	return &c
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		startAngle: DefaultStartAngle,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if min, max := 0, 360; o.startAngle < min || o.startAngle >= max {
+		return fmt.Errorf("invalid start angle %d, must be in range %d <= angle < %d", o.startAngle, min, max)
+	}
+	return nil
+}
+
+// ShowPercentages displays the percentage each slice represents inside of
+// the slice.
+func ShowPercentages() Option {
+	return option(func(opts *options) {
+		opts.showPercentages = true
+	})
+}
+
+// DefaultStartAngle is the default value for the StartAngle option.
+const DefaultStartAngle = 90
+
+// StartAngle sets the angle in degrees where the first slice starts, the
+// slices follow in the clockwise direction.
+// Valid values are in range 0 <= angle < 360.
+// Angles start at the X axis and grow counter-clockwise.
+func StartAngle(angle int) Option {
+	return option(func(opts *options) {
+		opts.startAngle = angle
+	})
+}
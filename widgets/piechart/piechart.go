@@ -0,0 +1,285 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package piechart implements a widget that displays values as slices of a
+// circle.
+package piechart
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/braille"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/numbers/trig"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// PieSlice is one slice of the pie.
+type PieSlice struct {
+	// Label is the name of the slice displayed in the legend.
+	Label string
+	// Value is the size of the slice, must be zero or positive. Slices with
+	// zero value aren't displayed.
+	Value float64
+	// Color is the color of the slice.
+	Color cell.Color
+}
+
+// legendMarker marks the color of a slice in the legend.
+const legendMarker = '█'
+
+// minPercentSlice is the smallest slice in percent that displays its
+// percentage, smaller slices are too narrow to fit the text.
+const minPercentSlice = 5
+
+// PieChart displays values as slices of a circle with areas proportional to
+// the values. A legend listing the slices with their values is displayed
+// right of the circle.
+//
+// The slices are drawn using braille characters, so the circle looks round
+// regardless of the size of the cells.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type PieChart struct {
+	// slices are the displayed slices, without the slices of zero value.
+	slices []PieSlice
+	// total is the sum of the values of all the slices.
+	total float64
+
+	// mu protects the PieChart.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new PieChart.
+func New(opts ...Option) (*PieChart, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &PieChart{
+		opts: opt,
+	}, nil
+}
+
+// Slices sets the displayed slices, replacing any previously provided.
+// The values are normalized so that their total represents the full circle.
+// Providing no slices clears the PieChart.
+// Provided options override values set when New() was called.
+func (pc *PieChart) Slices(slices []PieSlice, opts ...Option) error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	var sl []PieSlice
+	var total float64
+	for _, s := range slices {
+		if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) || s.Value < 0 {
+			return fmt.Errorf("invalid value %v of slice %q, must be a finite number 0 <= value", s.Value, s.Label)
+		}
+		if s.Value == 0 {
+			continue
+		}
+		sl = append(sl, s)
+		total += s.Value
+	}
+
+	for _, opt := range opts {
+		opt.set(pc.opts)
+	}
+	if err := pc.opts.validate(); err != nil {
+		return err
+	}
+	pc.slices = sl
+	pc.total = total
+	return nil
+}
+
+// legendText returns the text of the legend entry for the slice, which
+// follows the marker of its color.
+func legendText(s PieSlice) string {
+	return fmt.Sprintf("%s %s", s.Label, strconv.FormatFloat(s.Value, 'f', -1, 64))
+}
+
+// legendWidth returns the number of cells needed to display the legend.
+func (pc *PieChart) legendWidth() int {
+	var width int
+	for _, s := range pc.slices {
+		// The marker and a space before the text.
+		if w := runewidth.StringWidth(legendText(s)) + 2; w > width {
+			width = w
+		}
+	}
+	return width
+}
+
+// drawLegend draws the legend into the area, one slice per row.
+// Slices that don't fit the height of the area aren't listed.
+func (pc *PieChart) drawLegend(cvs *canvas.Canvas, ar image.Rectangle) error {
+	for i, s := range pc.slices {
+		y := ar.Min.Y + i
+		if y >= ar.Max.Y {
+			break
+		}
+		start := image.Point{ar.Min.X, y}
+		if err := draw.Text(cvs, string(legendMarker), start, draw.TextCellOpts(cell.FgColor(s.Color))); err != nil {
+			return err
+		}
+		if err := draw.Text(cvs, legendText(s), start.Add(image.Point{2, 0}),
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawPercentages draws the percentage of each slice inside of the slice.
+// The mid point and the radius are in pixels on the braille canvas that
+// starts at the origin of the pie area.
+func (pc *PieChart) drawPercentages(cvs *canvas.Canvas, pieAr image.Rectangle, mid image.Point, r int) error {
+	angle := float64(pc.opts.startAngle)
+	for _, s := range pc.slices {
+		size := s.Value / pc.total * 360
+		midAngle := angle - size/2
+		angle -= size
+
+		percent := s.Value / pc.total * 100
+		if percent < minPercentSlice {
+			continue
+		}
+		t := fmt.Sprintf("%.0f%%", percent)
+		p := trig.CirclePointAtAngle(int(math.Round(midAngle)), mid, r*2/3)
+		width := runewidth.StringWidth(t)
+		start := image.Point{
+			pieAr.Min.X + p.X/braille.ColMult - width/2,
+			pieAr.Min.Y + p.Y/braille.RowMult,
+		}
+		if textAr := image.Rect(start.X, start.Y, start.X+width, start.Y+1); !textAr.In(pieAr) {
+			continue
+		}
+		if err := draw.Text(cvs, t, start, draw.TextCellOpts(
+			cell.FgColor(cell.ColorBlack),
+			cell.BgColor(s.Color),
+		)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Draw draws the PieChart widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (pc *PieChart) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if len(pc.slices) == 0 {
+		return nil
+	}
+
+	ar := cvs.Area()
+	lw := pc.legendWidth()
+	// One column of space between the pie and the legend.
+	pieAr := image.Rect(ar.Min.X, ar.Min.Y, ar.Max.X-lw-1, ar.Max.Y)
+	legendAr := image.Rect(pieAr.Max.X+1, ar.Min.Y, ar.Max.X, ar.Max.Y)
+	if pieAr.Dx() < minSize.X || pieAr.Dy() < minSize.Y {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	bc, err := braille.New(pieAr)
+	if err != nil {
+		return fmt.Errorf("braille.New => %v", err)
+	}
+	mid, r := midAndRadius(bc.Area())
+
+	angle := float64(pc.opts.startAngle)
+	end := angle - 360
+	for i, s := range pc.slices {
+		next := angle - s.Value/pc.total*360
+		if i == len(pc.slices)-1 {
+			// Avoid a gap caused by rounding.
+			next = end
+		}
+		for _, a := range ccwArcs(next, angle) {
+			if err := draw.BrailleCircle(bc, mid, r,
+				draw.BrailleCircleFilled(),
+				draw.BrailleCircleArcOnly(a.start, a.end),
+				draw.BrailleCircleCellOpts(cell.FgColor(s.Color)),
+			); err != nil {
+				return fmt.Errorf("failed to draw the slice %q: %v", s.Label, err)
+			}
+		}
+		angle = next
+	}
+	if err := bc.CopyTo(cvs); err != nil {
+		return err
+	}
+
+	if pc.opts.showPercentages {
+		if err := pc.drawPercentages(cvs, pieAr, mid, r); err != nil {
+			return err
+		}
+	}
+	return pc.drawLegend(cvs, legendAr)
+}
+
+// Keyboard input isn't supported on the PieChart widget.
+func (*PieChart) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the PieChart widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the PieChart widget.
+func (*PieChart) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the PieChart widget doesn't support mouse events")
+}
+
+// minSize is the smallest area we can draw the pie on.
+var minSize = image.Point{3, 3}
+
+// Options implements widgetapi.Widget.Options.
+func (pc *PieChart) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  minSize,
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
+
+// Clone returns a new PieChart with the same options as this one, but
+// without any slices. The clone doesn't share any mutable state with this
+// PieChart.
+// Implements widgetapi.Cloneable.
+func (pc *PieChart) Clone() (widgetapi.Widget, error) {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	return &PieChart{
+		opts: pc.opts.clone(),
+	}, nil
+}
@@ -0,0 +1,399 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package piechart
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/braille/testbraille"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestPieChart(t *testing.T) {
+	tests := []struct {
+		desc          string
+		opts          []Option
+		update        func(*PieChart) error // update gets called before drawing of the widget.
+		canvas        image.Rectangle
+		want          func(size image.Point) *faketerm.Terminal
+		wantNewErr    bool
+		wantUpdateErr bool
+	}{
+		{
+			desc: "fails on start angle too large",
+			opts: []Option{
+				StartAngle(360),
+			},
+			canvas: image.Rect(0, 0, 11, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on negative start angle",
+			opts: []Option{
+				StartAngle(-1),
+			},
+			canvas: image.Rect(0, 0, 11, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantNewErr: true,
+		},
+		{
+			desc:   "fails on a negative value",
+			canvas: image.Rect(0, 0, 11, 5),
+			update: func(pc *PieChart) error {
+				return pc.Slices([]PieSlice{
+					{Label: "a", Value: -1, Color: cell.ColorRed},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "fails on invalid option passed to Slices",
+			canvas: image.Rect(0, 0, 11, 5),
+			update: func(pc *PieChart) error {
+				return pc.Slices([]PieSlice{
+					{Label: "a", Value: 1, Color: cell.ColorRed},
+				}, StartAngle(400))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "draws nothing without slices",
+			canvas: image.Rect(0, 0, 11, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws nothing when all the slices are zero",
+			canvas: image.Rect(0, 0, 11, 5),
+			update: func(pc *PieChart) error {
+				return pc.Slices([]PieSlice{
+					{Label: "a", Value: 0, Color: cell.ColorRed},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "resize needed when the legend leaves no space for the pie",
+			canvas: image.Rect(0, 0, 8, 5),
+			update: func(pc *PieChart) error {
+				return pc.Slices([]PieSlice{
+					{Label: "a", Value: 1, Color: cell.ColorRed},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "single slice is a full circle",
+			canvas: image.Rect(0, 0, 11, 5),
+			update: func(pc *PieChart) error {
+				return pc.Slices([]PieSlice{
+					{Label: "a", Value: 1, Color: cell.ColorRed},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(image.Rect(0, 0, 5, 5))
+				testdraw.MustBrailleCircle(bc, image.Point{4, 9}, 4,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testbraille.MustCopyTo(bc, c)
+				testdraw.MustText(c, "█", image.Point{6, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "a 1", image.Point{8, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "two slices clockwise from the top, zero value slice is omitted",
+			canvas: image.Rect(0, 0, 11, 5),
+			update: func(pc *PieChart) error {
+				return pc.Slices([]PieSlice{
+					{Label: "a", Value: 1, Color: cell.ColorRed},
+					{Label: "b", Value: 0, Color: cell.ColorGreen},
+					{Label: "c", Value: 1, Color: cell.ColorBlue},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(image.Rect(0, 0, 5, 5))
+				testdraw.MustBrailleCircle(bc, image.Point{4, 9}, 4,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleArcOnly(270, 360),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{4, 9}, 4,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleArcOnly(0, 90),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{4, 9}, 4,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleArcOnly(90, 270),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testbraille.MustCopyTo(bc, c)
+				testdraw.MustText(c, "█", image.Point{6, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "a 1", image.Point{8, 0})
+				testdraw.MustText(c, "█", image.Point{6, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testdraw.MustText(c, "c 1", image.Point{8, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "respects the start angle",
+			opts: []Option{
+				StartAngle(0),
+			},
+			canvas: image.Rect(0, 0, 11, 5),
+			update: func(pc *PieChart) error {
+				return pc.Slices([]PieSlice{
+					{Label: "a", Value: 1, Color: cell.ColorRed},
+					{Label: "c", Value: 1, Color: cell.ColorBlue},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(image.Rect(0, 0, 5, 5))
+				testdraw.MustBrailleCircle(bc, image.Point{4, 9}, 4,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleArcOnly(180, 360),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testdraw.MustBrailleCircle(bc, image.Point{4, 9}, 4,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleArcOnly(0, 180),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorBlue)),
+				)
+				testbraille.MustCopyTo(bc, c)
+				testdraw.MustText(c, "█", image.Point{6, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "a 1", image.Point{8, 0})
+				testdraw.MustText(c, "█", image.Point{6, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testdraw.MustText(c, "c 1", image.Point{8, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the percentage inside the slice",
+			opts: []Option{
+				ShowPercentages(),
+			},
+			canvas: image.Rect(0, 0, 20, 10),
+			update: func(pc *PieChart) error {
+				return pc.Slices([]PieSlice{
+					{Label: "a", Value: 1, Color: cell.ColorRed},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(image.Rect(0, 0, 14, 10))
+				mid, r := midAndRadius(bc.Area())
+				testdraw.MustBrailleCircle(bc, mid, r,
+					draw.BrailleCircleFilled(),
+					draw.BrailleCircleCellOpts(cell.FgColor(cell.ColorRed)),
+				)
+				testbraille.MustCopyTo(bc, c)
+				testdraw.MustText(c, "100%", image.Point{5, 7}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorBlack),
+					cell.BgColor(cell.ColorRed),
+				))
+				testdraw.MustText(c, "█", image.Point{15, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "a 1", image.Point{17, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "legend lists only the slices that fit the height",
+			canvas: image.Rect(0, 0, 11, 3),
+			update: func(pc *PieChart) error {
+				return pc.Slices([]PieSlice{
+					{Label: "a", Value: 1, Color: cell.ColorRed},
+					{Label: "b", Value: 1, Color: cell.ColorGreen},
+					{Label: "c", Value: 1, Color: cell.ColorBlue},
+					{Label: "d", Value: 1, Color: cell.ColorYellow},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				bc := testbraille.MustNew(image.Rect(0, 0, 5, 3))
+				mid, r := midAndRadius(bc.Area())
+				for _, a := range []struct {
+					start, end int
+					color      cell.Color
+				}{
+					{0, 90, cell.ColorRed},
+					{270, 360, cell.ColorGreen},
+					{180, 270, cell.ColorBlue},
+					{90, 180, cell.ColorYellow},
+				} {
+					testdraw.MustBrailleCircle(bc, mid, r,
+						draw.BrailleCircleFilled(),
+						draw.BrailleCircleArcOnly(a.start, a.end),
+						draw.BrailleCircleCellOpts(cell.FgColor(a.color)),
+					)
+				}
+				testbraille.MustCopyTo(bc, c)
+				testdraw.MustText(c, "█", image.Point{6, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testdraw.MustText(c, "a 1", image.Point{8, 0})
+				testdraw.MustText(c, "█", image.Point{6, 1}, draw.TextCellOpts(cell.FgColor(cell.ColorGreen)))
+				testdraw.MustText(c, "b 1", image.Point{8, 1})
+				testdraw.MustText(c, "█", image.Point{6, 2}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testdraw.MustText(c, "c 1", image.Point{8, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			pc, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				err := tc.update(pc)
+				if (err != nil) != tc.wantUpdateErr {
+					t.Errorf("update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := pc.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	pc, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := pc.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	pc, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := pc.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	pc, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := pc.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{3, 3},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
+
+func TestClone(t *testing.T) {
+	pc, err := New(
+		ShowPercentages(),
+		StartAngle(45),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := pc.Slices([]PieSlice{{Label: "a", Value: 1}}); err != nil {
+		t.Fatalf("Slices => unexpected error: %v", err)
+	}
+
+	w, err := widgetapi.CloneWidget(pc)
+	if err != nil {
+		t.Fatalf("CloneWidget => unexpected error: %v", err)
+	}
+	got := w.(*PieChart)
+	if diff := pretty.Compare(pc.opts, got.opts); diff != "" {
+		t.Errorf("Clone => unexpected options, diff (-want, +got):\n%s", diff)
+	}
+	if len(got.slices) != 0 {
+		t.Errorf("Clone => got slices %v, want none", got.slices)
+	}
+}
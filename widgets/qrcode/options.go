@@ -0,0 +1,167 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+// options.go contains configurable options for QRCode.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	level      ErrorCorrectionLevel
+	version    int
+	quietZone  int
+	darkColor  cell.Color
+	lightColor cell.Color
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		level:      DefaultErrorCorrection,
+		quietZone:  DefaultQuietZone,
+		darkColor:  DefaultDarkColor,
+		lightColor: DefaultLightColor,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if _, ok := errorCorrectionLevelNames[o.level]; !ok {
+		return fmt.Errorf("unsupported ErrorCorrection %v", o.level)
+	}
+	if o.version != 0 && (o.version < minVersion || o.version > maxVersion) {
+		return fmt.Errorf("invalid Version %d, must be zero or in range %d <= Version <= %d", o.version, minVersion, maxVersion)
+	}
+	if got, min := o.quietZone, 0; got < min {
+		return fmt.Errorf("invalid QuietZone %d, must be %d <= QuietZone", got, min)
+	}
+	return nil
+}
+
+// ErrorCorrectionLevel is the level of error correction of the QR code.
+// Higher levels make the code readable even if parts of it are damaged, but
+// require larger codes for the same content.
+type ErrorCorrectionLevel int
+
+// String implements fmt.Stringer()
+func (ecl ErrorCorrectionLevel) String() string {
+	if n, ok := errorCorrectionLevelNames[ecl]; ok {
+		return n
+	}
+	return "ErrorCorrectionLevelUnknown"
+}
+
+// errorCorrectionLevelNames maps ErrorCorrectionLevel values to human readable
+// names.
+var errorCorrectionLevelNames = map[ErrorCorrectionLevel]string{
+	ErrorCorrectionLow:      "ErrorCorrectionLow",
+	ErrorCorrectionMedium:   "ErrorCorrectionMedium",
+	ErrorCorrectionQuartile: "ErrorCorrectionQuartile",
+	ErrorCorrectionHigh:     "ErrorCorrectionHigh",
+}
+
+const (
+	// ErrorCorrectionLow recovers about 7% of the code.
+	ErrorCorrectionLow ErrorCorrectionLevel = iota
+	// ErrorCorrectionMedium recovers about 15% of the code.
+	ErrorCorrectionMedium
+	// ErrorCorrectionQuartile recovers about 25% of the code.
+	ErrorCorrectionQuartile
+	// ErrorCorrectionHigh recovers about 30% of the code.
+	ErrorCorrectionHigh
+)
+
+// DefaultErrorCorrection is the default value for the ErrorCorrection option.
+const DefaultErrorCorrection = ErrorCorrectionLow
+
+// ErrorCorrection sets the error correction level of the QR code.
+// Defaults to DefaultErrorCorrection.
+func ErrorCorrection(ecl ErrorCorrectionLevel) Option {
+	return option(func(opts *options) {
+		opts.level = ecl
+	})
+}
+
+// The range of supported QR code versions.
+const (
+	minVersion = 1
+	maxVersion = 40
+)
+
+// Version sets a fixed version of the QR code, which determines its size. A
+// code of version v has 17+4*v modules on each side. Must be in range
+// 1 <= v <= 40.
+// If not provided or set to zero, the smallest version that fits the content
+// is used.
+func Version(v int) Option {
+	return option(func(opts *options) {
+		opts.version = v
+	})
+}
+
+// DefaultQuietZone is the default value for the QuietZone option.
+// This is the width recommended by the QR code specification.
+const DefaultQuietZone = 4
+
+// QuietZone sets the width in cells of the light margin drawn around the QR
+// code. Readers need a margin in order to locate the code.
+// Defaults to DefaultQuietZone. Must be a positive or zero integer.
+func QuietZone(cells int) Option {
+	return option(func(opts *options) {
+		opts.quietZone = cells
+	})
+}
+
+// The default colors of the QR code modules.
+const (
+	DefaultDarkColor  = cell.ColorBlack
+	DefaultLightColor = cell.ColorWhite
+)
+
+// DarkColor sets the color of the dark modules of the QR code.
+// Defaults to DefaultDarkColor.
+func DarkColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.darkColor = c
+	})
+}
+
+// LightColor sets the color of the light modules of the QR code and of its
+// quiet zone.
+// Defaults to DefaultLightColor.
+func LightColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.lightColor = c
+	})
+}
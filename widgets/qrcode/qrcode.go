@@ -0,0 +1,192 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package qrcode is a widget that displays a QR code.
+package qrcode
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"rsc.io/qr/coding"
+)
+
+// QRCode displays a QR code, e.g. of an URL or a token.
+//
+// Each module (pixel) of the code occupies one cell. The modules are drawn
+// using full block characters in the dark and light colors, so the code is
+// readable regardless of the background color of the terminal.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type QRCode struct {
+	// code is the encoded content, nil if no content was set.
+	code *coding.Code
+
+	// mu protects the QRCode.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new QRCode.
+func New(opts ...Option) (*QRCode, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &QRCode{
+		opts: opt,
+	}, nil
+}
+
+// SetContent generates a QR code for the provided content and displays it
+// instead of the previous one.
+// Returns an error if the content doesn't fit the QR code with the configured
+// version and error correction level.
+func (qc *QRCode) SetContent(s string) error {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+
+	code, err := encode(s, qc.opts)
+	if err != nil {
+		return err
+	}
+	qc.code = code
+	return nil
+}
+
+// encode encodes the content into a QR code.
+func encode(s string, opts *options) (*coding.Code, error) {
+	// Pick the most compact encoding of the content.
+	var enc coding.Encoding
+	switch {
+	case coding.Num(s).Check() == nil:
+		enc = coding.Num(s)
+	case coding.Alpha(s).Check() == nil:
+		enc = coding.Alpha(s)
+	default:
+		enc = coding.String(s)
+	}
+
+	level := coding.Level(opts.level)
+	first, last := minVersion, maxVersion
+	if opts.version != 0 {
+		first, last = opts.version, opts.version
+	}
+	for v := coding.Version(first); v <= coding.Version(last); v++ {
+		if enc.Bits(v) > v.DataBytes(level)*8 {
+			continue
+		}
+		p, err := coding.NewPlan(v, level, 0)
+		if err != nil {
+			return nil, err
+		}
+		return p.Encode(enc)
+	}
+	return nil, fmt.Errorf("the content of length %d doesn't fit a QR code of version %d with %v", len(s), last, opts.level)
+}
+
+// RequiredSize returns the minimum size of the canvas in cells needed to
+// display the QR code including its quiet zone. Returns a zero size if no
+// content was set.
+func (qc *QRCode) RequiredSize() image.Point {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+
+	return qc.requiredSize()
+}
+
+// requiredSize implements RequiredSize, the caller must hold the mutex.
+func (qc *QRCode) requiredSize() image.Point {
+	if qc.code == nil {
+		return image.Point{}
+	}
+	side := qc.code.Size + 2*qc.opts.quietZone
+	return image.Point{side, side}
+}
+
+// Draw draws the QRCode widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (qc *QRCode) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+
+	if qc.code == nil {
+		return nil
+	}
+
+	ar := cvs.Area()
+	req := qc.requiredSize()
+	if ar.Dx() < req.X || ar.Dy() < req.Y {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	// Center the code including its quiet zone.
+	start := image.Point{(ar.Dx() - req.X) / 2, (ar.Dy() - req.Y) / 2}
+	codeAr := image.Rectangle{Min: start, Max: start.Add(req)}
+	if err := cvs.SetAreaCells(codeAr, '█', cell.FgColor(qc.opts.lightColor)); err != nil {
+		return err
+	}
+
+	codeStart := start.Add(image.Point{qc.opts.quietZone, qc.opts.quietZone})
+	for y := 0; y < qc.code.Size; y++ {
+		for x := 0; x < qc.code.Size; x++ {
+			if !qc.code.Black(x, y) {
+				continue
+			}
+			p := codeStart.Add(image.Point{x, y})
+			if _, err := cvs.SetCell(p, '█', cell.FgColor(qc.opts.darkColor)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Keyboard input isn't supported on the QRCode widget.
+func (*QRCode) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the QRCode widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the QRCode widget.
+func (*QRCode) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the QRCode widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (qc *QRCode) Options() widgetapi.Options {
+	qc.mu.Lock()
+	defer qc.mu.Unlock()
+
+	min := qc.requiredSize()
+	if min.X < 1 {
+		min = image.Point{1, 1}
+	}
+	return widgetapi.Options{
+		MinimumSize:  min,
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
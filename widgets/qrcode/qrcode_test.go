@@ -0,0 +1,372 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package qrcode
+
+import (
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mustDrawCode draws the expected QR code for the content onto the canvas.
+func mustDrawCode(c *canvas.Canvas, content string, start image.Point, opts ...Option) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	code, err := encode(content, o)
+	if err != nil {
+		panic(err)
+	}
+
+	side := code.Size + 2*o.quietZone
+	testcanvas.MustSetAreaCells(c, image.Rect(start.X, start.Y, start.X+side, start.Y+side), '█', cell.FgColor(o.lightColor))
+	for y := 0; y < code.Size; y++ {
+		for x := 0; x < code.Size; x++ {
+			if code.Black(x, y) {
+				p := start.Add(image.Point{x + o.quietZone, y + o.quietZone})
+				testcanvas.MustSetCell(c, p, '█', cell.FgColor(o.darkColor))
+			}
+		}
+	}
+}
+
+func TestQRCode(t *testing.T) {
+	tests := []struct {
+		desc             string
+		opts             []Option
+		update           func(*QRCode) error // update gets called before drawing of the widget.
+		canvas           image.Rectangle
+		want             func(size image.Point) *faketerm.Terminal
+		wantRequiredSize image.Point
+		wantErr          bool
+		wantUpdateErr    bool
+	}{
+		{
+			desc: "fails on unsupported error correction level",
+			opts: []Option{
+				ErrorCorrection(ErrorCorrectionLevel(-1)),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on version too low",
+			opts: []Option{
+				Version(-1),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on version too high",
+			opts: []Option{
+				Version(41),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on negative quiet zone",
+			opts: []Option{
+				QuietZone(-1),
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails when the content doesn't fit the version",
+			opts: []Option{
+				Version(1),
+			},
+			update: func(qc *QRCode) error {
+				return qc.SetContent(strings.Repeat("a", 18))
+			},
+			canvas: image.Rect(0, 0, 1, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc: "draws empty without content",
+			update: func(qc *QRCode) error {
+				return nil
+			},
+			canvas: image.Rect(0, 0, 3, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc: "draws resize needed on canvas too small",
+			update: func(qc *QRCode) error {
+				return qc.SetContent("termdash")
+			},
+			canvas: image.Rect(0, 0, 28, 29),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRequiredSize: image.Point{29, 29},
+		},
+		{
+			desc: "draws the smallest code with the quiet zone",
+			update: func(qc *QRCode) error {
+				return qc.SetContent("termdash")
+			},
+			canvas: image.Rect(0, 0, 29, 29),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawCode(c, "termdash", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRequiredSize: image.Point{29, 29},
+		},
+		{
+			desc: "centers the code",
+			opts: []Option{
+				QuietZone(1),
+			},
+			update: func(qc *QRCode) error {
+				return qc.SetContent("termdash")
+			},
+			canvas: image.Rect(0, 0, 27, 25),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawCode(c, "termdash", image.Point{2, 1}, QuietZone(1))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRequiredSize: image.Point{23, 23},
+		},
+		{
+			desc: "draws a fixed version and colors",
+			opts: []Option{
+				Version(2),
+				QuietZone(0),
+				ErrorCorrection(ErrorCorrectionHigh),
+				DarkColor(cell.ColorBlue),
+				LightColor(cell.ColorYellow),
+			},
+			update: func(qc *QRCode) error {
+				return qc.SetContent("TERMDASH")
+			},
+			canvas: image.Rect(0, 0, 25, 25),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawCode(c, "TERMDASH", image.Point{0, 0},
+					Version(2),
+					QuietZone(0),
+					ErrorCorrection(ErrorCorrectionHigh),
+					DarkColor(cell.ColorBlue),
+					LightColor(cell.ColorYellow),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRequiredSize: image.Point{25, 25},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			qc, err := New(tc.opts...)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			err = tc.update(qc)
+			if (err != nil) != tc.wantUpdateErr {
+				t.Errorf("update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got := qc.RequiredSize(); got != tc.wantRequiredSize {
+				t.Errorf("RequiredSize => %v, want %v", got, tc.wantRequiredSize)
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := qc.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		desc     string
+		content  string
+		opts     []Option
+		wantSize int
+		wantErr  bool
+	}{
+		{
+			desc:     "numeric content",
+			content:  "1234567890",
+			wantSize: 21,
+		},
+		{
+			desc:     "alphanumeric content",
+			content:  "TERMDASH",
+			wantSize: 21,
+		},
+		{
+			desc:     "byte content",
+			content:  "https://github.com/mum4k/termdash",
+			wantSize: 29,
+		},
+		{
+			desc:    "higher error correction needs larger code",
+			content: "https://github.com/mum4k/termdash",
+			opts: []Option{
+				ErrorCorrection(ErrorCorrectionHigh),
+			},
+			wantSize: 33,
+		},
+		{
+			desc:    "content too long for any version",
+			content: strings.Repeat("a", 3000),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			o := newOptions()
+			for _, opt := range tc.opts {
+				opt.set(o)
+			}
+			code, err := encode(tc.content, o)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("encode => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if code.Size != tc.wantSize {
+				t.Errorf("encode => code size %d, want %d", code.Size, tc.wantSize)
+			}
+
+			// The top left finder pattern has a dark border of 7x7 modules.
+			for i := 0; i < 7; i++ {
+				for _, p := range []image.Point{{i, 0}, {0, i}, {i, 6}, {6, i}} {
+					if !code.Black(p.X, p.Y) {
+						t.Errorf("encode => module %v of the finder pattern isn't dark", p)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	qc, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := qc.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyEnter}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	qc, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := qc.Mouse(&terminalapi.Mouse{Button: mouse.ButtonLeft}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		want    widgetapi.Options
+	}{
+		{
+			desc: "minimum size without content",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+		{
+			desc:    "minimum size fits the code",
+			content: "termdash",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{29, 29},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			qc, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if tc.content != "" {
+				if err := qc.SetContent(tc.content); err != nil {
+					t.Fatalf("SetContent => unexpected error: %v", err)
+				}
+			}
+
+			got := qc.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package searchbar
+
+// options.go contains configurable options for SearchBar.
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	onSearch    SearchFn
+	debounce    time.Duration
+	clearButton bool
+	placeholder string
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if min := time.Duration(0); o.debounce < min {
+		return fmt.Errorf("invalid debounce %v, must be %v <= debounce", o.debounce, min)
+	}
+	return nil
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		debounce: DefaultDebounce,
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// SearchFn if provided is called with the content of the search bar after the
+// user changes it.
+//
+// The callback function must be thread-safe as it is called either from the
+// goroutine that delivers the keyboard and mouse events or from a timer
+// goroutine when the calls are debounced.
+type SearchFn func(query string)
+
+// OnSearch sets a function that is called with the query after the user edits
+// the content of the search bar, clears it or presses the Enter key.
+func OnSearch(fn SearchFn) Option {
+	return option(func(opts *options) {
+		opts.onSearch = fn
+	})
+}
+
+// DefaultDebounce is the default value for the Debounce option.
+const DefaultDebounce = 200 * time.Millisecond
+
+// Debounce sets the amount of time the search bar waits after the last edit
+// before calling the SearchFn. Edits made in the meantime restart the wait so
+// that the SearchFn isn't called on every keystroke. Pressing the Enter key
+// calls the SearchFn immediately.
+// A zero duration calls the SearchFn after each edit. The duration cannot be
+// negative.
+// Defaults to DefaultDebounce.
+func Debounce(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.debounce = d
+	})
+}
+
+// ClearButton adds a ✕ button to the right of the input field that clears
+// the search bar when clicked. The button is only visible when the search
+// bar isn't empty.
+func ClearButton() Option {
+	return option(func(opts *options) {
+		opts.clearButton = true
+	})
+}
+
+// Placeholder sets a hint text displayed in the input field when it is empty.
+// Like with the text input widget, the hint disappears when the search bar
+// becomes focused.
+func Placeholder(text string) Option {
+	return option(func(opts *options) {
+		opts.placeholder = text
+	})
+}
@@ -0,0 +1,296 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package searchbar implements a single-row text input that reports the
+// queries typed by the user, e.g. to filter the content of another widget.
+package searchbar
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/textinput"
+)
+
+// clearRune is the rune of the clear button.
+const clearRune = '✕'
+
+// clearWidth is the number of cells reserved for the clear button, one empty
+// cell separates it from the input field.
+const clearWidth = 2
+
+// SearchBar is a single-row text input field that calls the SearchFn with the
+// query as the user edits it. Meant to be placed above a widget whose content
+// the query filters.
+//
+// The Escape key clears the search bar, the Enter key reports the query
+// immediately.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type SearchBar struct {
+	// input is the text input field that holds the query.
+	input *textinput.TextInput
+
+	// query is the content of the input field after the last event.
+	query string
+	// searched is the last query the SearchFn was called with.
+	searched string
+	// timer delays the call to the SearchFn when debouncing.
+	timer *time.Timer
+
+	// forField is the area of the input field during the last call to Draw.
+	forField image.Rectangle
+	// forClear is the area of the clear button during the last call to Draw.
+	forClear image.Rectangle
+
+	// mu protects the SearchBar.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new empty SearchBar.
+func New(opts ...Option) (*SearchBar, error) {
+	opt := newOptions(opts...)
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+
+	var tiOpts []textinput.Option
+	if opt.placeholder != "" {
+		tiOpts = append(tiOpts, textinput.PlaceHolder(opt.placeholder))
+	}
+	input, err := textinput.New(tiOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &SearchBar{
+		input: input,
+		opts:  opt,
+	}, nil
+}
+
+// SetValue replaces the content of the search bar with the value, e.g. to
+// restore a previous query. Doesn't call the SearchFn.
+func (sb *SearchBar) SetValue(value string) error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if err := sb.input.SetText(value); err != nil {
+		return fmt.Errorf("invalid value: %v", err)
+	}
+	sb.stopTimer()
+	sb.query = value
+	sb.searched = value
+	return nil
+}
+
+// Value returns the current content of the search bar.
+func (sb *SearchBar) Value() string {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.query
+}
+
+// stopTimer stops the pending debounced call of the SearchFn if any.
+// Caller must hold sb.mu.
+func (sb *SearchBar) stopTimer() {
+	if sb.timer != nil {
+		sb.timer.Stop()
+		sb.timer = nil
+	}
+}
+
+// changed records the content of the input field after an edit.
+// Returns true if the SearchFn should be called immediately, otherwise the
+// call is scheduled after the debounce duration.
+// Caller must hold sb.mu.
+func (sb *SearchBar) changed() bool {
+	q := sb.input.Read()
+	if q == sb.query {
+		return false
+	}
+	sb.query = q
+
+	sb.stopTimer()
+	if sb.opts.debounce == 0 {
+		return true
+	}
+	sb.timer = time.AfterFunc(sb.opts.debounce, func() {
+		sb.search(false)
+	})
+	return false
+}
+
+// search calls the SearchFn with the current query. Unless forced, the call is
+// skipped if the query didn't change since the last call.
+// Caller must not hold sb.mu.
+func (sb *SearchBar) search(force bool) {
+	sb.mu.Lock()
+	q := sb.query
+	if !force && q == sb.searched {
+		sb.mu.Unlock()
+		return
+	}
+	sb.searched = q
+	sb.mu.Unlock()
+
+	// Mutex must be released when calling the callback.
+	// Users might call container methods from the callback like the
+	// Container.Update, see #205.
+	if sb.opts.onSearch != nil {
+		sb.opts.onSearch(q)
+	}
+}
+
+// clear clears the input field.
+// Caller must hold sb.mu.
+func (sb *SearchBar) clear() bool {
+	// Setting an empty text never fails.
+	_ = sb.input.SetText("")
+	return sb.changed()
+}
+
+// Draw draws the SearchBar widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (sb *SearchBar) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	ar := cvs.Area()
+	sb.forField = ar
+	sb.forClear = image.ZR
+	if sb.opts.clearButton {
+		sb.forField.Max.X -= clearWidth
+		sb.forClear = image.Rect(ar.Max.X-1, ar.Min.Y, ar.Max.X, ar.Min.Y+1)
+	}
+	if min := sb.input.Options().MinimumSize; sb.forField.Dx() < min.X || sb.forField.Dy() < min.Y {
+		sb.forField = image.ZR
+		sb.forClear = image.ZR
+		return draw.ResizeNeeded(cvs)
+	}
+
+	fCvs, err := canvas.New(sb.forField)
+	if err != nil {
+		return err
+	}
+	if err := sb.input.Draw(fCvs, meta); err != nil {
+		return err
+	}
+	if err := fCvs.CopyTo(cvs); err != nil {
+		return err
+	}
+
+	if sb.opts.clearButton && sb.query != "" {
+		if _, err := cvs.SetCell(sb.forClear.Min, clearRune); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyboard processes the keyboard event.
+// Returns true if the SearchFn should be called immediately and whether the
+// call is forced.
+func (sb *SearchBar) keyboard(k *terminalapi.Keyboard) (bool, bool, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	switch k.Key {
+	case keyboard.KeyEnter:
+		sb.stopTimer()
+		return true, true, nil
+
+	case keyboard.KeyEsc:
+		return sb.clear(), false, nil
+
+	default:
+		if err := sb.input.Keyboard(k); err != nil {
+			return false, false, err
+		}
+		return sb.changed(), false, nil
+	}
+}
+
+// Keyboard processes keyboard events.
+// Implements widgetapi.Widget.Keyboard.
+func (sb *SearchBar) Keyboard(k *terminalapi.Keyboard) error {
+	now, force, err := sb.keyboard(k)
+	if err != nil {
+		return err
+	}
+	if now {
+		sb.search(force)
+	}
+	return nil
+}
+
+// mouse processes the mouse event.
+// Returns true if the SearchFn should be called immediately.
+func (sb *SearchBar) mouse(m *terminalapi.Mouse) (bool, error) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	switch {
+	case m.Position.In(sb.forClear):
+		if m.Button != mouse.ButtonLeft || sb.query == "" {
+			return false, nil
+		}
+		return sb.clear(), nil
+
+	case m.Position.In(sb.forField):
+		return false, sb.input.Mouse(&terminalapi.Mouse{
+			Position: m.Position.Sub(sb.forField.Min),
+			Button:   m.Button,
+		})
+	}
+	return false, nil
+}
+
+// Mouse processes mouse events.
+// Implements widgetapi.Widget.Mouse.
+func (sb *SearchBar) Mouse(m *terminalapi.Mouse) error {
+	now, err := sb.mouse(m)
+	if err != nil {
+		return err
+	}
+	if now {
+		sb.search(false)
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (sb *SearchBar) Options() widgetapi.Options {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	opts := sb.input.Options()
+	if sb.opts.clearButton {
+		opts.MinimumSize.X += clearWidth
+		if opts.MaximumSize.X > 0 {
+			opts.MaximumSize.X += clearWidth
+		}
+	}
+	return opts
+}
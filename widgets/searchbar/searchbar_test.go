@@ -0,0 +1,354 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package searchbar
+
+import (
+	"image"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+	"github.com/mum4k/termdash/widgets/textinput"
+)
+
+// searches records the queries reported by the SearchBar.
+type searches struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+// record is a SearchFn that records the query.
+func (s *searches) record(query string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queries = append(s.queries, query)
+}
+
+// get returns the recorded queries.
+func (s *searches) get() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.queries...)
+}
+
+// mustDrawInput draws a text input field with the text onto the area of the
+// terminal.
+func mustDrawInput(ft *faketerm.Terminal, ar image.Rectangle, text string, meta *widgetapi.Meta, opts ...textinput.Option) {
+	ti, err := textinput.New(opts...)
+	if err != nil {
+		panic(err)
+	}
+	if text != "" {
+		if err := ti.SetText(text); err != nil {
+			panic(err)
+		}
+	}
+	c := testcanvas.MustNew(ar)
+	if err := ti.Draw(c, meta); err != nil {
+		panic(err)
+	}
+	testcanvas.MustApply(c, ft)
+}
+
+func TestSearchBar(t *testing.T) {
+	tests := []struct {
+		desc         string
+		opts         []Option
+		value        string
+		canvas       image.Rectangle
+		meta         *widgetapi.Meta
+		events       []terminalapi.Event
+		want         func(size image.Point) *faketerm.Terminal
+		wantSearches []string
+		wantValue    string
+		wantNewErr   bool
+		wantSetErr   bool
+	}{
+		{
+			desc:       "fails on negative debounce",
+			opts:       []Option{Debounce(-1)},
+			canvas:     image.Rect(0, 0, 10, 1),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on invalid value",
+			value:      "a\tb",
+			canvas:     image.Rect(0, 0, 10, 1),
+			wantSetErr: true,
+		},
+		{
+			desc:   "draws the placeholder when empty",
+			opts:   []Option{Placeholder("find")},
+			canvas: image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawInput(ft, image.Rect(0, 0, 10, 1), "", &widgetapi.Meta{}, textinput.PlaceHolder("find"))
+				return ft
+			},
+		},
+		{
+			desc:   "draws the value and the clear button",
+			opts:   []Option{ClearButton()},
+			value:  "abc",
+			canvas: image.Rect(0, 0, 10, 1),
+			meta:   &widgetapi.Meta{Focused: true},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawInput(ft, image.Rect(0, 0, 8, 1), "abc", &widgetapi.Meta{Focused: true})
+				c := testcanvas.MustNew(image.Rect(9, 0, 10, 1))
+				testcanvas.MustSetCell(c, image.Point{0, 0}, '✕')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue: "abc",
+		},
+		{
+			desc:   "hides the clear button when empty",
+			opts:   []Option{ClearButton()},
+			canvas: image.Rect(0, 0, 10, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawInput(ft, image.Rect(0, 0, 8, 1), "", &widgetapi.Meta{})
+				return ft
+			},
+		},
+		{
+			desc:   "draws resize needed when the field doesn't fit",
+			opts:   []Option{ClearButton()},
+			canvas: image.Rect(0, 0, 5, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "reports each edit without debounce",
+			opts:   []Option{Debounce(0)},
+			canvas: image.Rect(0, 0, 10, 1),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: 'a'},
+				&terminalapi.Keyboard{Key: 'b'},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+				&terminalapi.Keyboard{Key: keyboard.KeyBackspace2},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawInput(ft, image.Rect(0, 0, 10, 1), "b", &widgetapi.Meta{})
+				return ft
+			},
+			wantSearches: []string{"a", "ab", "b"},
+			wantValue:    "b",
+		},
+		{
+			desc:   "enter reports the query immediately and escape clears",
+			value:  "x",
+			canvas: image.Rect(0, 0, 10, 1),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Keyboard{Key: keyboard.KeyEsc},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawInput(ft, image.Rect(0, 0, 10, 1), "", &widgetapi.Meta{})
+				return ft
+			},
+			wantSearches: []string{"x", "x", ""},
+		},
+		{
+			desc:   "clicking the clear button clears the search bar",
+			opts:   []Option{Debounce(0), ClearButton()},
+			value:  "abc",
+			canvas: image.Rect(0, 0, 10, 1),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{9, 0}, Button: mouse.ButtonRight},
+				&terminalapi.Mouse{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{9, 0}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				mustDrawInput(ft, image.Rect(0, 0, 8, 1), "", &widgetapi.Meta{})
+				return ft
+			},
+			wantSearches: []string{""},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			var s searches
+			sb, err := New(append(tc.opts, OnSearch(s.record))...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.value != "" {
+				err := sb.SetValue(tc.value)
+				if (err != nil) != tc.wantSetErr {
+					t.Errorf("SetValue => unexpected error: %v, wantSetErr: %v", err, tc.wantSetErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			meta := tc.meta
+			if meta == nil {
+				meta = &widgetapi.Meta{}
+			}
+
+			// Draw once to record the areas for the mouse events.
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := sb.Draw(c, meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					if err := sb.Keyboard(e); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+				case *terminalapi.Mouse:
+					if err := sb.Mouse(e); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := sb.Draw(c, meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			if diff := pretty.Compare(tc.wantSearches, s.get()); diff != "" {
+				t.Errorf("OnSearch => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if got := sb.Value(); got != tc.wantValue {
+				t.Errorf("Value => %q, want %q", got, tc.wantValue)
+			}
+		})
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	searched := make(chan string, 10)
+	sb, err := New(
+		Debounce(10*time.Millisecond),
+		OnSearch(func(query string) {
+			searched <- query
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	for _, r := range "abc" {
+		if err := sb.Keyboard(&terminalapi.Keyboard{Key: keyboard.Key(r)}); err != nil {
+			t.Fatalf("Keyboard => unexpected error: %v", err)
+		}
+	}
+
+	select {
+	case got := <-searched:
+		if want := "abc"; got != want {
+			t.Errorf("OnSearch => %q, want %q", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the debounced OnSearch call")
+	}
+
+	select {
+	case got := <-searched:
+		t.Errorf("OnSearch => unexpected additional call with %q", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want widgetapi.Options
+	}{
+		{
+			desc: "without the clear button",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{4, 1},
+				MaximumSize:  image.Point{0, 1},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc: "with the clear button",
+			opts: []Option{ClearButton()},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{6, 1},
+				MaximumSize:  image.Point{0, 1},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			sb, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			got := sb.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
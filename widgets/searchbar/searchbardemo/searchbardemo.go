@@ -0,0 +1,105 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary searchbardemo displays the SearchBar widget filtering a list.
+// Exist when Ctrl-Q is pressed.
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/searchbar"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+// fruits are the items filtered by the search bar.
+var fruits = []string{
+	"apple", "apricot", "banana", "blackberry", "blueberry", "cherry",
+	"grape", "kiwi", "lemon", "mango", "orange", "peach", "pear", "plum",
+	"raspberry", "strawberry",
+}
+
+// filter displays the fruits that contain the query.
+func filter(txt *text.Text, query string) {
+	txt.Reset()
+	for _, f := range fruits {
+		if !strings.Contains(f, strings.ToLower(query)) {
+			continue
+		}
+		if err := txt.Write(f + "\n"); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	list, err := text.New()
+	if err != nil {
+		panic(err)
+	}
+	filter(list, "")
+
+	sb, err := searchbar.New(
+		searchbar.Placeholder("type to filter the fruits"),
+		searchbar.ClearButton(),
+		searchbar.OnSearch(func(query string) {
+			filter(list, query)
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS ESC TO CLEAR, CTRL-Q TO QUIT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.PlaceWidget(sb),
+			),
+			container.Bottom(
+				container.PlaceWidget(list),
+			),
+			container.SplitFixed(1),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == keyboard.KeyCtrlQ {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -52,6 +52,12 @@ func (o *options) validate() error {
 	return nil
 }
 
+// clone returns a deep copy of the options.
+func (o *options) clone() *options {
+	c := *o
+	return &c
+}
+
 // newOptions returns options with the default values set.
 func newOptions() *options {
 	return &options{
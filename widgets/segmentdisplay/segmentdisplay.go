@@ -310,3 +310,18 @@ func (sd *SegmentDisplay) Options() widgetapi.Options {
 		WantMouse:    widgetapi.MouseScopeNone,
 	}
 }
+
+// Clone returns a new SegmentDisplay with the same options as this one, but without
+// any text. The clone doesn't share any mutable state with this SegmentDisplay.
+// Implements widgetapi.Cloneable.
+func (sd *SegmentDisplay) Clone() (widgetapi.Widget, error) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+
+	clone, err := New()
+	if err != nil {
+		return nil, err
+	}
+	clone.opts = sd.opts.clone()
+	return clone, nil
+}
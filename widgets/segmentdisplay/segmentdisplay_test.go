@@ -1013,3 +1013,48 @@ func TestOptions(t *testing.T) {
 	}
 
 }
+
+func TestClone(t *testing.T) {
+	sd, err := New(
+		AlignHorizontal(align.HorizontalLeft),
+		GapPercent(10),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	w, err := widgetapi.CloneWidget(sd)
+	if err != nil {
+		t.Fatalf("CloneWidget => unexpected error: %v", err)
+	}
+	clone := w.(*SegmentDisplay)
+	if err := clone.Write([]*TextChunk{NewChunk("1")}); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+
+	draw := func() *faketerm.Terminal {
+		c, err := canvas.New(image.Rect(0, 0, 2*segdisp.MinCols, segdisp.MinRows))
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := clone.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		ft, err := faketerm.New(c.Size())
+		if err != nil {
+			t.Fatalf("faketerm.New => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+	want := draw()
+
+	if err := sd.Write([]*TextChunk{NewChunk("23")}, AlignHorizontal(align.HorizontalRight)); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	if diff := faketerm.Diff(want, draw()); diff != "" {
+		t.Errorf("Draw => the clone changed after modifying the original %s", diff)
+	}
+}
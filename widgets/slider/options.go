@@ -0,0 +1,174 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slider
+
+// options.go contains configurable options for Slider.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/runewidth"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	handle      rune
+	handleColor cell.Color
+	focusColor  cell.Color
+	track       rune
+	trackColor  cell.Color
+	valueLabel  bool
+	onChange    ChangeFn
+	onRange     RangeChangeFn
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if got := runewidth.RuneWidth(o.handle); got != 1 {
+		return fmt.Errorf("invalid Handle %q, must be a rune of width one, got width %d", o.handle, got)
+	}
+	if got := runewidth.RuneWidth(o.track); got != 1 {
+		return fmt.Errorf("invalid Track %q, must be a rune of width one, got width %d", o.track, got)
+	}
+	return nil
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		handle:      DefaultHandle,
+		handleColor: cell.ColorNumber(DefaultHandleColorNumber),
+		focusColor:  cell.ColorNumber(DefaultFocusedHandleColorNumber),
+		track:       DefaultTrack,
+		trackColor:  cell.ColorNumber(DefaultTrackColorNumber),
+	}
+}
+
+// DefaultHandle is the default value for the Handle option.
+const DefaultHandle = '█'
+
+// Handle sets the rune that marks the current position on the track.
+// Must be a rune of width one.
+// Defaults to DefaultHandle.
+func Handle(r rune) Option {
+	return option(func(opts *options) {
+		opts.handle = r
+	})
+}
+
+// DefaultHandleColorNumber is the default color number of the handle.
+const DefaultHandleColorNumber = 39
+
+// HandleColor sets the color of the handle.
+// Defaults to the color number DefaultHandleColorNumber.
+func HandleColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.handleColor = c
+	})
+}
+
+// DefaultFocusedHandleColorNumber is the default color number of the focused
+// handle of a RangeSlider.
+const DefaultFocusedHandleColorNumber = 214
+
+// FocusedHandleColor sets the color of the handle of a RangeSlider that is
+// moved by the keyboard, when the RangeSlider is focused.
+// Defaults to the color number DefaultFocusedHandleColorNumber.
+func FocusedHandleColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.focusColor = c
+	})
+}
+
+// DefaultTrack is the default value for the Track option.
+const DefaultTrack = '─'
+
+// Track sets the rune that is used to draw the track the handle moves on.
+// Must be a rune of width one.
+// Defaults to DefaultTrack.
+func Track(r rune) Option {
+	return option(func(opts *options) {
+		opts.track = r
+	})
+}
+
+// DefaultTrackColorNumber is the default color number of the track.
+const DefaultTrackColorNumber = 250
+
+// TrackColor sets the color of the track.
+// Defaults to the color number DefaultTrackColorNumber.
+func TrackColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.trackColor = c
+	})
+}
+
+// ValueLabel tells the slider to display the current value above the handle.
+// The RangeSlider displays the value of each of its handles.
+// The slider then needs two rows of height.
+func ValueLabel() Option {
+	return option(func(opts *options) {
+		opts.valueLabel = true
+	})
+}
+
+// ChangeFn if provided is called with the new value each time the user
+// changes the value of the slider.
+//
+// The callback function must be thread-safe as the keyboard or mouse event
+// that changes the value comes from a separate goroutine.
+type ChangeFn func(value float64) error
+
+// OnChange sets a function that will be called each time the user changes the
+// value of the slider using the keyboard or the mouse.
+// The function isn't called when the value is set by calling SetValue.
+// Only applies to the Slider, see OnRangeChange for the RangeSlider.
+func OnChange(fn ChangeFn) Option {
+	return option(func(opts *options) {
+		opts.onChange = fn
+	})
+}
+
+// RangeChangeFn if provided is called with the new selected range each time
+// the user moves one of the handles of the range slider.
+//
+// The callback function must be thread-safe as the keyboard or mouse event
+// that changes the range comes from a separate goroutine.
+type RangeChangeFn func(min, max float64) error
+
+// OnRangeChange sets a function that will be called each time the user moves
+// one of the handles of the RangeSlider using the keyboard or the mouse.
+// The function isn't called when the range is set by calling SetRange.
+// Only applies to the RangeSlider.
+func OnRangeChange(fn RangeChangeFn) Option {
+	return option(func(opts *options) {
+		opts.onRange = fn
+	})
+}
@@ -0,0 +1,359 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slider
+
+// rangeslider.go contains a slider with two handles.
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// handle identifies one of the handles of the RangeSlider.
+type handle int
+
+const (
+	lowHandle handle = iota
+	highHandle
+)
+
+// RangeSlider allows the user to select a range of values by moving two
+// handles along a horizontal track, the low handle can't move past the high
+// one.
+//
+// When the range slider is focused, the Tab key switches the handle that is
+// moved by the arrow keys. Clicking on the track moves the nearest handle to
+// the mouse, which can then be dragged.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type RangeSlider struct {
+	// vr is the range of the values.
+	vr *valueRange
+	// low and high are the values of the two handles.
+	low, high float64
+
+	// focused is the handle moved by the keyboard.
+	focused handle
+	// dragging indicates that the focused handle is being dragged by the
+	// mouse.
+	dragging bool
+
+	// trackAr is the area of the track as observed on the last call to Draw.
+	trackAr image.Rectangle
+
+	// mu protects the RangeSlider.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// NewRange returns a new RangeSlider with the bounds from DefaultMin to
+// DefaultMax, that selects the entire range.
+func NewRange(opts ...Option) (*RangeSlider, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	vr, err := newValueRange(DefaultMin, DefaultMax, DefaultStep)
+	if err != nil {
+		return nil, err
+	}
+	return &RangeSlider{
+		vr:   vr,
+		low:  vr.min,
+		high: vr.snap(vr.max),
+		opts: opt,
+	}, nil
+}
+
+// SetBounds sets the domain of the values. The min must be less than the max
+// and the step must be a positive number. The selected range is moved to the
+// nearest values within the new bounds.
+func (rs *RangeSlider) SetBounds(min, max, step float64) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	vr, err := newValueRange(min, max, step)
+	if err != nil {
+		return err
+	}
+	rs.vr = vr
+	rs.low = vr.snap(rs.low)
+	rs.high = vr.snap(rs.high)
+	return nil
+}
+
+// Range returns the selected range, i.e. the values of the low and the high
+// handle.
+func (rs *RangeSlider) Range() (min, max float64) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.low, rs.high
+}
+
+// SetRange sets the selected range to the nearest values within the bounds.
+// The min must not be larger than the max.
+// Doesn't call the function provided via the OnRangeChange option.
+func (rs *RangeSlider) SetRange(min, max float64) error {
+	if math.IsNaN(min) || math.IsNaN(max) {
+		return fmt.Errorf("both the min(%v) and the max(%v) of the range must be valid numbers", min, max)
+	}
+	if min > max {
+		return fmt.Errorf("the min(%v) of the range must not be larger than the max(%v)", min, max)
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.low = rs.vr.snap(min)
+	rs.high = rs.vr.snap(max)
+	return nil
+}
+
+// value returns the value of the handle.
+// rs.mu must be held when calling this method.
+func (rs *RangeSlider) value(h handle) float64 {
+	if h == lowHandle {
+		return rs.low
+	}
+	return rs.high
+}
+
+// move moves the handle to the value, without passing the other handle.
+// Returns a bool indicating if the handle moved.
+// rs.mu must be held when calling this method.
+func (rs *RangeSlider) move(h handle, v float64) bool {
+	if h == lowHandle {
+		v = math.Min(v, rs.high)
+	} else {
+		v = math.Max(v, rs.low)
+	}
+	if v == rs.value(h) {
+		return false
+	}
+
+	if h == lowHandle {
+		rs.low = v
+	} else {
+		rs.high = v
+	}
+	return true
+}
+
+// handleColor returns the color of the handle.
+// rs.mu must be held when calling this method.
+func (rs *RangeSlider) handleColor(h handle, meta *widgetapi.Meta) cell.Color {
+	if meta.Focused && h == rs.focused {
+		return rs.opts.focusColor
+	}
+	return rs.opts.handleColor
+}
+
+// Draw draws the RangeSlider widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (rs *RangeSlider) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	ar := cvs.Area()
+	if ar.Dy() < rows(rs.opts) {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	rs.trackAr = trackArea(ar, rs.opts)
+	if err := drawTrack(cvs, rs.trackAr, rs.opts); err != nil {
+		return err
+	}
+
+	lowIdx := rs.vr.cell(rs.low, rs.trackAr.Dx())
+	highIdx := rs.vr.cell(rs.high, rs.trackAr.Dx())
+	// The selected part of the track.
+	for x := rs.trackAr.Min.X + lowIdx + 1; x < rs.trackAr.Min.X+highIdx; x++ {
+		if _, err := cvs.SetCell(image.Point{x, rs.trackAr.Min.Y}, rs.opts.track, cell.FgColor(rs.opts.handleColor)); err != nil {
+			return err
+		}
+	}
+
+	// The focused handle is drawn last so it is visible when the handles
+	// share a cell.
+	handles := []handle{highHandle, lowHandle}
+	if rs.focused == highHandle {
+		handles = []handle{lowHandle, highHandle}
+	}
+	for _, h := range handles {
+		idx := lowIdx
+		if h == highHandle {
+			idx = highIdx
+		}
+		p := image.Point{rs.trackAr.Min.X + idx, rs.trackAr.Min.Y}
+		if _, err := cvs.SetCell(p, rs.opts.handle, cell.FgColor(rs.handleColor(h, meta))); err != nil {
+			return err
+		}
+	}
+
+	if rs.opts.valueLabel {
+		return rs.drawValueLabels(cvs, lowIdx, highIdx)
+	}
+	return nil
+}
+
+// drawValueLabels draws the values of the handles above them, keeping a space
+// between the two labels.
+// rs.mu must be held when calling this method.
+func (rs *RangeSlider) drawValueLabels(cvs *canvas.Canvas, lowIdx, highIdx int) error {
+	lowText := rs.vr.format(rs.low)
+	highText := rs.vr.format(rs.high)
+	lowW := runewidth.StringWidth(lowText)
+	highW := runewidth.StringWidth(highText)
+
+	lowX := labelStart(rs.trackAr, lowIdx, lowW)
+	highX := labelStart(rs.trackAr, highIdx, highW)
+	if lowX+lowW+1 > highX {
+		lowX = highX - lowW - 1
+		if lowX < rs.trackAr.Min.X {
+			lowX = rs.trackAr.Min.X
+			highX = lowX + lowW + 1
+		}
+	}
+
+	if err := drawValueLabel(cvs, rs.trackAr, lowX, lowText); err != nil {
+		return err
+	}
+	if highX >= rs.trackAr.Max.X {
+		// No space left for the second label.
+		return nil
+	}
+	return drawValueLabel(cvs, rs.trackAr, highX, highText)
+}
+
+// keyboard processes the keyboard event and returns a bool indicating if the
+// range changed and the new range.
+func (rs *RangeSlider) keyboard(k *terminalapi.Keyboard) (bool, float64, float64) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if k.Key == keyboard.KeyTab {
+		rs.focused = 1 - rs.focused
+		return false, rs.low, rs.high
+	}
+	v, ok := keyValue(k.Key, rs.value(rs.focused), rs.vr)
+	if !ok {
+		return false, rs.low, rs.high
+	}
+	return rs.move(rs.focused, v), rs.low, rs.high
+}
+
+// onRangeChange calls the function provided via the OnRangeChange option, if
+// any.
+func (rs *RangeSlider) onRangeChange(changed bool, min, max float64) error {
+	if !changed || rs.opts.onRange == nil {
+		return nil
+	}
+	// Mutex must be released when calling the callback.
+	// Users might call container methods from the callback like the
+	// Container.Update, see #205.
+	return rs.opts.onRange(min, max)
+}
+
+// Keyboard processes keyboard events, the arrow keys move the focused handle
+// by one step and the Tab key switches the focused handle.
+// Implements widgetapi.Widget.Keyboard.
+func (rs *RangeSlider) Keyboard(k *terminalapi.Keyboard) error {
+	return rs.onRangeChange(rs.keyboard(k))
+}
+
+// nearest returns the handle nearest to the cell at the index on the track.
+// rs.mu must be held when calling this method.
+func (rs *RangeSlider) nearest(idx int) handle {
+	lowIdx := rs.vr.cell(rs.low, rs.trackAr.Dx())
+	highIdx := rs.vr.cell(rs.high, rs.trackAr.Dx())
+	switch {
+	case lowIdx == highIdx && idx == lowIdx:
+		return rs.focused
+	case lowIdx == highIdx && idx > highIdx:
+		return highHandle
+	case idx-lowIdx < highIdx-idx:
+		return lowHandle
+	case idx-lowIdx > highIdx-idx:
+		return highHandle
+	}
+	return rs.focused
+}
+
+// mouse processes the mouse event and returns a bool indicating if the range
+// changed and the new range.
+func (rs *RangeSlider) mouse(m *terminalapi.Mouse) (bool, float64, float64) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	switch {
+	case m.Button == mouse.ButtonRelease:
+		rs.dragging = false
+		return false, rs.low, rs.high
+
+	case m.Button != mouse.ButtonLeft:
+		return false, rs.low, rs.high
+
+	case !rs.dragging:
+		if !m.Position.In(rs.trackAr) {
+			return false, rs.low, rs.high
+		}
+		rs.focused = rs.nearest(m.Position.X - rs.trackAr.Min.X)
+		rs.dragging = true
+	}
+
+	idx := m.Position.X - rs.trackAr.Min.X
+	if idx < 0 {
+		idx = 0
+	}
+	if max := rs.trackAr.Dx() - 1; idx > max {
+		idx = max
+	}
+	v := rs.vr.atCell(idx, rs.trackAr.Dx())
+	return rs.move(rs.focused, v), rs.low, rs.high
+}
+
+// Mouse processes mouse events, clicking on the track moves the nearest handle
+// under the mouse and dragging keeps moving it until the button is released.
+// Implements widgetapi.Widget.Mouse.
+func (rs *RangeSlider) Mouse(m *terminalapi.Mouse) error {
+	return rs.onRangeChange(rs.mouse(m))
+}
+
+// Options implements widgetapi.Widget.Options.
+func (rs *RangeSlider) Options() widgetapi.Options {
+	// No need to lock, as the options get fixed when NewRange is called.
+	height := rows(rs.opts)
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, height},
+		MaximumSize:  image.Point{0, height},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
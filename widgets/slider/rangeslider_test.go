@@ -0,0 +1,446 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slider
+
+import (
+	"errors"
+	"image"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// rangeTracker tracks the ranges the callback was called with.
+type rangeTracker struct {
+	// wantErr when set to true, makes callback return an error.
+	wantErr bool
+
+	// ranges are the ranges received OnRangeChange.
+	ranges [][2]float64
+
+	// mu protects the tracker.
+	mu sync.Mutex
+}
+
+// change is the callback function called OnRangeChange.
+func (rt *rangeTracker) change(min, max float64) error {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if rt.wantErr {
+		return errors.New("rt.wantErr set to true")
+	}
+	rt.ranges = append(rt.ranges, [2]float64{min, max})
+	return nil
+}
+
+// mustDrawRange draws the track at the row with the handles at the cell
+// indexes, the selected part of the track between them and the high handle in
+// the provided color.
+func mustDrawRange(c *canvas.Canvas, row, width, lowIdx, highIdx int, highColor cell.Color) {
+	opts := newOptions()
+	trackAr := image.Rect(0, row, width, row+1)
+	if err := drawTrack(c, trackAr, opts); err != nil {
+		panic(err)
+	}
+	for x := lowIdx + 1; x < highIdx; x++ {
+		testcanvas.MustSetCell(c, image.Point{x, row}, opts.track, cell.FgColor(opts.handleColor))
+	}
+	testcanvas.MustSetCell(c, image.Point{highIdx, row}, opts.handle, cell.FgColor(highColor))
+	testcanvas.MustSetCell(c, image.Point{lowIdx, row}, opts.handle, cell.FgColor(opts.handleColor))
+}
+
+func TestRangeSlider(t *testing.T) {
+	handleColor := cell.ColorNumber(DefaultHandleColorNumber)
+	focusColor := cell.ColorNumber(DefaultFocusedHandleColorNumber)
+
+	tests := []struct {
+		desc         string
+		callback     *rangeTracker
+		opts         []Option
+		update       func(*RangeSlider) error // update gets called before the events.
+		meta         *widgetapi.Meta
+		events       []terminalapi.Event
+		canvas       image.Rectangle
+		want         func(size image.Point) *faketerm.Terminal
+		wantRange    [2]float64
+		wantCallback [][2]float64
+		wantNewErr   bool
+		wantEventErr bool
+	}{
+		{
+			desc: "fails on a wide handle",
+			opts: []Option{
+				Handle('世'),
+			},
+			canvas: image.Rect(0, 0, 11, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantNewErr: true,
+		},
+		{
+			desc:   "selects the entire range by default",
+			canvas: image.Rect(0, 0, 11, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 0, 11, 0, 10, handleColor)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange: [2]float64{0, 100},
+		},
+		{
+			desc:   "draws the range set",
+			canvas: image.Rect(0, 0, 11, 1),
+			update: func(rs *RangeSlider) error {
+				return rs.SetRange(20, 61)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 0, 11, 2, 6, handleColor)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange: [2]float64{20, 61},
+		},
+		{
+			desc:   "SetBounds moves the range into the new bounds",
+			canvas: image.Rect(0, 0, 11, 1),
+			update: func(rs *RangeSlider) error {
+				return rs.SetBounds(0, 10, 2)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 0, 11, 0, 10, handleColor)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange: [2]float64{0, 10},
+		},
+		{
+			desc:     "arrow keys move the low handle by default",
+			callback: &rangeTracker{},
+			canvas:   image.Rect(0, 0, 11, 1),
+			update: func(rs *RangeSlider) error {
+				return rs.SetBounds(0, 10, 1)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 0, 11, 2, 10, handleColor)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange:    [2]float64{2, 10},
+			wantCallback: [][2]float64{{1, 10}, {2, 10}},
+		},
+		{
+			desc:     "tab switches to the high handle",
+			callback: &rangeTracker{},
+			canvas:   image.Rect(0, 0, 11, 1),
+			meta:     &widgetapi.Meta{Focused: true},
+			update: func(rs *RangeSlider) error {
+				return rs.SetBounds(0, 10, 1)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 0, 11, 0, 9, focusColor)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange:    [2]float64{0, 9},
+			wantCallback: [][2]float64{{0, 9}},
+		},
+		{
+			desc:     "low handle can't pass the high handle",
+			callback: &rangeTracker{},
+			canvas:   image.Rect(0, 0, 11, 1),
+			update: func(rs *RangeSlider) error {
+				if err := rs.SetBounds(0, 10, 1); err != nil {
+					return err
+				}
+				return rs.SetRange(4, 5)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnd},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 0, 11, 5, 5, handleColor)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange:    [2]float64{5, 5},
+			wantCallback: [][2]float64{{5, 5}},
+		},
+		{
+			desc:     "high handle can't pass the low handle",
+			callback: &rangeTracker{},
+			canvas:   image.Rect(0, 0, 11, 1),
+			update: func(rs *RangeSlider) error {
+				return rs.SetRange(40, 50)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyTab},
+				&terminalapi.Keyboard{Key: keyboard.KeyHome},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 0, 11, 4, 4, handleColor)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange:    [2]float64{40, 40},
+			wantCallback: [][2]float64{{40, 40}},
+		},
+		{
+			desc:     "mouse moves the nearest handle and drags it",
+			callback: &rangeTracker{},
+			canvas:   image.Rect(0, 0, 11, 1),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{8, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{3, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{7, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{7, 0}, Button: mouse.ButtonRelease},
+				&terminalapi.Mouse{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 0, 11, 1, 7, handleColor)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange:    [2]float64{10, 70},
+			wantCallback: [][2]float64{{0, 80}, {0, 30}, {0, 70}, {10, 70}},
+		},
+		{
+			desc:     "dragged handle stops at the other handle",
+			callback: &rangeTracker{},
+			canvas:   image.Rect(0, 0, 11, 1),
+			update: func(rs *RangeSlider) error {
+				return rs.SetRange(40, 60)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{4, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{9, 0}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 0, 11, 6, 6, handleColor)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange:    [2]float64{60, 60},
+			wantCallback: [][2]float64{{60, 60}},
+		},
+		{
+			desc:     "forwards the error from the callback",
+			callback: &rangeTracker{wantErr: true},
+			canvas:   image.Rect(0, 0, 11, 1),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 0, 11, 0, 10, handleColor)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange:    [2]float64{1, 100},
+			wantEventErr: true,
+		},
+		{
+			desc: "draws the value labels",
+			opts: []Option{
+				ValueLabel(),
+			},
+			canvas: image.Rect(0, 0, 11, 2),
+			update: func(rs *RangeSlider) error {
+				return rs.SetRange(20, 80)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 1, 11, 2, 8, handleColor)
+				testdraw.MustText(c, "20", image.Point{1, 0})
+				testdraw.MustText(c, "80", image.Point{7, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange: [2]float64{20, 80},
+		},
+		{
+			desc: "value labels don't overlap",
+			opts: []Option{
+				ValueLabel(),
+			},
+			canvas: image.Rect(0, 0, 11, 2),
+			update: func(rs *RangeSlider) error {
+				return rs.SetRange(50, 50)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawRange(c, 1, 11, 5, 5, handleColor)
+				testdraw.MustText(c, "50", image.Point{1, 0})
+				testdraw.MustText(c, "50", image.Point{4, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantRange: [2]float64{50, 50},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.callback != nil {
+				tc.opts = append(tc.opts, OnRangeChange(tc.callback.change))
+			}
+			meta := tc.meta
+			if meta == nil {
+				meta = &widgetapi.Meta{}
+			}
+
+			rs, err := NewRange(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("NewRange => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				if err := tc.update(rs); err != nil {
+					t.Fatalf("update => unexpected error: %v", err)
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// Draw once so mouse events are acceptable.
+			if err := rs.Draw(c, meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for i, ev := range tc.events {
+				var err error
+				switch e := ev.(type) {
+				case *terminalapi.Mouse:
+					err = rs.Mouse(e)
+				case *terminalapi.Keyboard:
+					err = rs.Keyboard(e)
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				// Only the last event in test cases is the one that can fail.
+				if i == len(tc.events)-1 {
+					if (err != nil) != tc.wantEventErr {
+						t.Errorf("event => unexpected error: %v, wantEventErr: %v", err, tc.wantEventErr)
+					}
+				} else if err != nil {
+					t.Fatalf("event => unexpected error: %v", err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := rs.Draw(c, meta); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			min, max := rs.Range()
+			if diff := pretty.Compare(tc.wantRange, [2]float64{min, max}); diff != "" {
+				t.Errorf("Range => unexpected diff (-want, +got):\n%s", diff)
+			}
+			if tc.callback != nil {
+				if diff := pretty.Compare(tc.wantCallback, tc.callback.ranges); diff != "" {
+					t.Errorf("OnRangeChange => unexpected diff (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRangeSliderSetRange(t *testing.T) {
+	tests := []struct {
+		desc    string
+		min     float64
+		max     float64
+		wantErr bool
+	}{
+		{desc: "valid range", min: 1, max: 2},
+		{desc: "empty range", min: 1, max: 1},
+		{desc: "fails when min is larger than max", min: 2, max: 1, wantErr: true},
+		{desc: "fails on NaN", min: math.NaN(), max: 1, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			rs, err := NewRange()
+			if err != nil {
+				t.Fatalf("NewRange => unexpected error: %v", err)
+			}
+			if err := rs.SetRange(tc.min, tc.max); (err != nil) != tc.wantErr {
+				t.Errorf("SetRange => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slider implements a widget that allows the user to select a
+// numeric value by moving a handle along a track.
+package slider
+
+import (
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// The range of the slider unless changed by calling SetRange.
+const (
+	// DefaultMin is the default smallest value.
+	DefaultMin = 0
+	// DefaultMax is the default largest value.
+	DefaultMax = 100
+	// DefaultStep is the default difference between adjacent values.
+	DefaultStep = 1
+)
+
+// Slider allows the user to select a numeric value by moving a handle along a
+// horizontal track.
+//
+// The value is changed with the arrow keys when the slider is focused, or by
+// clicking or dragging the mouse on the track. The Home and the End keys move
+// the handle to the min and the max respectively.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Slider struct {
+	// vr is the range of the values.
+	vr *valueRange
+	// value is the current value.
+	value float64
+
+	// trackAr is the area of the track as observed on the last call to Draw.
+	trackAr image.Rectangle
+
+	// mu protects the Slider.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Slider set to the DefaultMin.
+func New(opts ...Option) (*Slider, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	vr, err := newValueRange(DefaultMin, DefaultMax, DefaultStep)
+	if err != nil {
+		return nil, err
+	}
+	return &Slider{
+		vr:    vr,
+		value: vr.min,
+		opts:  opt,
+	}, nil
+}
+
+// SetRange sets the domain of the values. The min must be less than the max
+// and the step must be a positive number. The current value is moved to the
+// nearest value within the new range.
+func (s *Slider) SetRange(min, max, step float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vr, err := newValueRange(min, max, step)
+	if err != nil {
+		return err
+	}
+	s.vr = vr
+	s.value = vr.snap(s.value)
+	return nil
+}
+
+// Value returns the current value.
+func (s *Slider) Value() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.value
+}
+
+// SetValue sets the current value to the nearest value within the range.
+// Doesn't call the function provided via the OnChange option.
+func (s *Slider) SetValue(v float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.value = s.vr.snap(v)
+}
+
+// rows returns the number of rows the slider needs.
+func rows(opts *options) int {
+	if opts.valueLabel {
+		return 2 // The label above the track.
+	}
+	return 1
+}
+
+// trackArea returns the area of the track within the canvas area.
+func trackArea(ar image.Rectangle, opts *options) image.Rectangle {
+	y := ar.Min.Y + rows(opts) - 1
+	return image.Rect(ar.Min.X, y, ar.Max.X, y+1)
+}
+
+// drawTrack draws the track into its area.
+func drawTrack(cvs *canvas.Canvas, trackAr image.Rectangle, opts *options) error {
+	for x := trackAr.Min.X; x < trackAr.Max.X; x++ {
+		if _, err := cvs.SetCell(image.Point{x, trackAr.Min.Y}, opts.track, cell.FgColor(opts.trackColor)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawHandle draws the handle on the track at the provided cell index.
+func drawHandle(cvs *canvas.Canvas, trackAr image.Rectangle, idx int, opts *options) error {
+	p := image.Point{trackAr.Min.X + idx, trackAr.Min.Y}
+	_, err := cvs.SetCell(p, opts.handle, cell.FgColor(opts.handleColor))
+	return err
+}
+
+// labelStart returns the column where a label of the provided width starts
+// when centered on the cell at the provided index, but kept within the track.
+func labelStart(trackAr image.Rectangle, idx, width int) int {
+	x := trackAr.Min.X + idx - width/2
+	if max := trackAr.Max.X - width; x > max {
+		x = max
+	}
+	if x < trackAr.Min.X {
+		x = trackAr.Min.X
+	}
+	return x
+}
+
+// drawValueLabel draws the text into the row above the track, starting at the
+// provided column.
+func drawValueLabel(cvs *canvas.Canvas, trackAr image.Rectangle, x int, text string) error {
+	return draw.Text(cvs, text, image.Point{x, trackAr.Min.Y - 1},
+		draw.TextMaxX(trackAr.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	)
+}
+
+// Draw draws the Slider widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (s *Slider) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ar := cvs.Area()
+	if ar.Dy() < rows(s.opts) {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	s.trackAr = trackArea(ar, s.opts)
+	if err := drawTrack(cvs, s.trackAr, s.opts); err != nil {
+		return err
+	}
+	idx := s.vr.cell(s.value, s.trackAr.Dx())
+	if err := drawHandle(cvs, s.trackAr, idx, s.opts); err != nil {
+		return err
+	}
+	if s.opts.valueLabel {
+		text := s.vr.format(s.value)
+		x := labelStart(s.trackAr, idx, runewidth.StringWidth(text))
+		return drawValueLabel(cvs, s.trackAr, x, text)
+	}
+	return nil
+}
+
+// keyValue returns the value the key moves the current value to and a bool
+// indicating if the key changes the value at all.
+func keyValue(k keyboard.Key, current float64, vr *valueRange) (float64, bool) {
+	switch k {
+	case keyboard.KeyArrowLeft, keyboard.KeyArrowDown:
+		return vr.snap(current - vr.step), true
+	case keyboard.KeyArrowRight, keyboard.KeyArrowUp:
+		return vr.snap(current + vr.step), true
+	case keyboard.KeyHome:
+		return vr.min, true
+	case keyboard.KeyEnd:
+		return vr.snap(vr.max), true
+	}
+	return current, false
+}
+
+// setValue sets the value and returns a bool indicating if it changed.
+// s.mu must be held when calling this method.
+func (s *Slider) setValue(v float64) bool {
+	if v == s.value {
+		return false
+	}
+	s.value = v
+	return true
+}
+
+// keyboard processes the keyboard event and returns a bool indicating if the
+// value changed and the new value.
+func (s *Slider) keyboard(k *terminalapi.Keyboard) (bool, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := keyValue(k.Key, s.value, s.vr)
+	if !ok {
+		return false, s.value
+	}
+	return s.setValue(v), s.value
+}
+
+// onChange calls the function provided via the OnChange option, if any.
+func (s *Slider) onChange(changed bool, v float64) error {
+	if !changed || s.opts.onChange == nil {
+		return nil
+	}
+	// Mutex must be released when calling the callback.
+	// Users might call container methods from the callback like the
+	// Container.Update, see #205.
+	return s.opts.onChange(v)
+}
+
+// Keyboard processes keyboard events, the arrow keys move the handle by one
+// step.
+// Implements widgetapi.Widget.Keyboard.
+func (s *Slider) Keyboard(k *terminalapi.Keyboard) error {
+	return s.onChange(s.keyboard(k))
+}
+
+// mouse processes the mouse event and returns a bool indicating if the
+// value changed and the new value.
+func (s *Slider) mouse(m *terminalapi.Mouse) (bool, float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m.Button != mouse.ButtonLeft || !m.Position.In(s.trackAr) {
+		return false, s.value
+	}
+	v := s.vr.atCell(m.Position.X-s.trackAr.Min.X, s.trackAr.Dx())
+	return s.setValue(v), s.value
+}
+
+// Mouse processes mouse events, clicking or dragging on the track moves the
+// handle under the mouse.
+// Implements widgetapi.Widget.Mouse.
+func (s *Slider) Mouse(m *terminalapi.Mouse) error {
+	return s.onChange(s.mouse(m))
+}
+
+// Options implements widgetapi.Widget.Options.
+func (s *Slider) Options() widgetapi.Options {
+	// No need to lock, as the options get fixed when New is called.
+	height := rows(s.opts)
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, height},
+		MaximumSize:  image.Point{0, height},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
@@ -0,0 +1,488 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slider
+
+import (
+	"errors"
+	"image"
+	"sync"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// callbackTracker tracks the values the callback was called with.
+type callbackTracker struct {
+	// wantErr when set to true, makes callback return an error.
+	wantErr bool
+
+	// values are the values received OnChange.
+	values []float64
+
+	// mu protects the tracker.
+	mu sync.Mutex
+}
+
+// change is the callback function called OnChange.
+func (ct *callbackTracker) change(v float64) error {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+
+	if ct.wantErr {
+		return errors.New("ct.wantErr set to true")
+	}
+	ct.values = append(ct.values, v)
+	return nil
+}
+
+// mustDrawTrack draws the track at the row with the handle at the cell index.
+func mustDrawTrack(c *canvas.Canvas, row, width, idx int, opts *options) {
+	trackAr := image.Rect(0, row, width, row+1)
+	if err := drawTrack(c, trackAr, opts); err != nil {
+		panic(err)
+	}
+	if err := drawHandle(c, trackAr, idx, opts); err != nil {
+		panic(err)
+	}
+}
+
+func TestSlider(t *testing.T) {
+	tests := []struct {
+		desc         string
+		callback     *callbackTracker
+		opts         []Option
+		update       func(*Slider) error // update gets called before the events.
+		events       []terminalapi.Event
+		canvas       image.Rectangle
+		want         func(size image.Point) *faketerm.Terminal
+		wantValue    float64
+		wantCallback []float64
+		wantNewErr   bool
+		wantEventErr bool
+	}{
+		{
+			desc: "fails on a wide handle",
+			opts: []Option{
+				Handle('世'),
+			},
+			canvas: image.Rect(0, 0, 11, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on a wide track",
+			opts: []Option{
+				Track('世'),
+			},
+			canvas: image.Rect(0, 0, 11, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantNewErr: true,
+		},
+		{
+			desc:   "draws the handle at the min",
+			canvas: image.Rect(0, 0, 11, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 0, 11, 0, newOptions())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue: 0,
+		},
+		{
+			desc:   "draws the handle at the value set",
+			canvas: image.Rect(0, 0, 11, 1),
+			update: func(s *Slider) error {
+				s.SetValue(42)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 0, 11, 4, newOptions())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue: 42,
+		},
+		{
+			desc:   "SetValue clamps and snaps the value",
+			canvas: image.Rect(0, 0, 11, 1),
+			update: func(s *Slider) error {
+				if err := s.SetRange(0, 10, 2); err != nil {
+					return err
+				}
+				s.SetValue(123)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 0, 11, 10, newOptions())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue: 10,
+		},
+		{
+			desc:   "SetRange moves the value into the new range",
+			canvas: image.Rect(0, 0, 11, 1),
+			update: func(s *Slider) error {
+				s.SetValue(50)
+				return s.SetRange(60, 70, 1)
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 0, 11, 0, newOptions())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue: 60,
+		},
+		{
+			desc:     "arrow keys move the handle by one step",
+			callback: &callbackTracker{},
+			canvas:   image.Rect(0, 0, 11, 1),
+			update: func(s *Slider) error {
+				return s.SetRange(0, 10, 1)
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 0, 11, 2, newOptions())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue:    2,
+			wantCallback: []float64{1, 2, 3, 2},
+		},
+		{
+			desc:     "keys don't move the handle past the ends",
+			callback: &callbackTracker{},
+			canvas:   image.Rect(0, 0, 11, 1),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowDown},
+				&terminalapi.Keyboard{Key: keyboard.KeyEnd},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 0, 11, 10, newOptions())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue:    100,
+			wantCallback: []float64{100},
+		},
+		{
+			desc:     "home key moves the handle to the min",
+			callback: &callbackTracker{},
+			canvas:   image.Rect(0, 0, 11, 1),
+			update: func(s *Slider) error {
+				s.SetValue(50)
+				return nil
+			},
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyHome},
+				&terminalapi.Keyboard{Key: 'a'},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 0, 11, 0, newOptions())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue:    0,
+			wantCallback: []float64{0},
+		},
+		{
+			desc:     "mouse click and drag move the handle",
+			callback: &callbackTracker{},
+			canvas:   image.Rect(0, 0, 11, 1),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{3, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{5, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{7, 0}, Button: mouse.ButtonRelease},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 0, 11, 5, newOptions())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue:    50,
+			wantCallback: []float64{30, 50},
+		},
+		{
+			desc:     "ignores mouse events outside of the track",
+			callback: &callbackTracker{},
+			opts: []Option{
+				ValueLabel(),
+			},
+			canvas: image.Rect(0, 0, 11, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{3, 0}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 1, 11, 0, newOptions())
+				testdraw.MustText(c, "0", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue: 0,
+		},
+		{
+			desc:     "forwards the error from the callback",
+			callback: &callbackTracker{wantErr: true},
+			canvas:   image.Rect(0, 0, 11, 1),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowRight},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 0, 11, 0, newOptions())
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue:    1,
+			wantEventErr: true,
+		},
+		{
+			desc: "draws the value label above the handle",
+			opts: []Option{
+				ValueLabel(),
+			},
+			canvas: image.Rect(0, 0, 11, 2),
+			update: func(s *Slider) error {
+				if err := s.SetRange(0, 1, 0.1); err != nil {
+					return err
+				}
+				s.SetValue(0.5)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 1, 11, 5, newOptions())
+				testdraw.MustText(c, "0.5", image.Point{4, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue: 0.5,
+		},
+		{
+			desc: "value label stays within the track",
+			opts: []Option{
+				ValueLabel(),
+			},
+			canvas: image.Rect(0, 0, 11, 2),
+			update: func(s *Slider) error {
+				s.SetValue(100)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				mustDrawTrack(c, 1, 11, 10, newOptions())
+				testdraw.MustText(c, "100", image.Point{8, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue: 100,
+		},
+		{
+			desc: "custom runes and colors",
+			opts: []Option{
+				Handle('o'),
+				HandleColor(cell.ColorRed),
+				Track('='),
+				TrackColor(cell.ColorBlue),
+			},
+			canvas: image.Rect(0, 0, 3, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "o==", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorBlue)))
+				testcanvas.MustSetCell(c, image.Point{0, 0}, 'o', cell.FgColor(cell.ColorRed))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue: 0,
+		},
+		{
+			desc: "resize needed when there is no space for the value label",
+			opts: []Option{
+				ValueLabel(),
+			},
+			canvas: image.Rect(0, 0, 11, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+			wantValue: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.callback != nil {
+				tc.opts = append(tc.opts, OnChange(tc.callback.change))
+			}
+
+			s, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				if err := tc.update(s); err != nil {
+					t.Fatalf("update => unexpected error: %v", err)
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// Draw once so mouse events are acceptable.
+			if err := s.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for i, ev := range tc.events {
+				var err error
+				switch e := ev.(type) {
+				case *terminalapi.Mouse:
+					err = s.Mouse(e)
+				case *terminalapi.Keyboard:
+					err = s.Keyboard(e)
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				// Only the last event in test cases is the one that can fail.
+				if i == len(tc.events)-1 {
+					if (err != nil) != tc.wantEventErr {
+						t.Errorf("event => unexpected error: %v, wantEventErr: %v", err, tc.wantEventErr)
+					}
+				} else if err != nil {
+					t.Fatalf("event => unexpected error: %v", err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := s.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+
+			if got := s.Value(); got != tc.wantValue {
+				t.Errorf("Value => %v, want %v", got, tc.wantValue)
+			}
+			if tc.callback != nil {
+				if diff := pretty.Compare(tc.wantCallback, tc.callback.values); diff != "" {
+					t.Errorf("OnChange => unexpected diff (-want, +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc string
+		opts []Option
+		want widgetapi.Options
+	}{
+		{
+			desc: "track only",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 1},
+				MaximumSize:  image.Point{0, 1},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc: "with the value label",
+			opts: []Option{ValueLabel()},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 2},
+				MaximumSize:  image.Point{0, 2},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if diff := pretty.Compare(tc.want, s.Options()); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary sliderdemo shows the functionality of the slider widgets.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/gauge"
+	"github.com/mum4k/termdash/widgets/slider"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	g, err := gauge.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := g.Percent(0); err != nil {
+		panic(err)
+	}
+
+	s, err := slider.New(
+		slider.ValueLabel(),
+		slider.OnChange(func(v float64) error {
+			return g.Percent(int(v))
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err := s.SetRange(0, 100, 5); err != nil {
+		panic(err)
+	}
+
+	selected, err := text.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := selected.Write("Selected hours 0 to 24"); err != nil {
+		panic(err)
+	}
+	rs, err := slider.NewRange(
+		slider.ValueLabel(),
+		slider.OnRangeChange(func(min, max float64) error {
+			return selected.Write(fmt.Sprintf("Selected hours %v to %v", min, max), text.WriteReplace())
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+	if err := rs.SetBounds(0, 24, 1); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.SplitHorizontal(
+			container.Top(
+				container.PlaceWidget(g),
+			),
+			container.Bottom(
+				container.SplitHorizontal(
+					container.Top(
+						container.Border(linestyle.Light),
+						container.BorderTitle("Use the arrow keys or the mouse"),
+						container.PlaceWidget(s),
+					),
+					container.Bottom(
+						container.SplitHorizontal(
+							container.Top(
+								container.Border(linestyle.Light),
+								container.BorderTitle("Tab switches the handle"),
+								container.PlaceWidget(rs),
+							),
+							container.Bottom(
+								container.PlaceWidget(selected),
+							),
+						),
+					),
+				),
+			),
+		),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slider
+
+// valuerange.go maps values of a slider onto the cells of its track.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// valueRange is the domain of the values a slider can be set to.
+type valueRange struct {
+	min  float64
+	max  float64
+	step float64
+	// decimals is the number of decimal places needed to display the values.
+	decimals int
+}
+
+// newValueRange returns a new valueRange.
+func newValueRange(min, max, step float64) (*valueRange, error) {
+	for _, v := range []float64{min, max, step} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("invalid range min(%v), max(%v), step(%v), all must be finite numbers", min, max, step)
+		}
+	}
+	if min >= max {
+		return nil, fmt.Errorf("invalid range, the min(%v) must be less than the max(%v)", min, max)
+	}
+	if step <= 0 {
+		return nil, fmt.Errorf("invalid range, the step(%v) must be a positive number", step)
+	}
+
+	d := decimals(step)
+	if md := decimals(min); md > d {
+		d = md
+	}
+	return &valueRange{
+		min:      min,
+		max:      max,
+		step:     step,
+		decimals: d,
+	}, nil
+}
+
+// decimals returns the number of decimal places in the shortest
+// representation of the value.
+func decimals(v float64) int {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// snap returns the value nearest to v that is within the range and a whole
+// number of steps away from the min.
+func (vr *valueRange) snap(v float64) float64 {
+	if math.IsNaN(v) || v <= vr.min {
+		return vr.min
+	}
+	steps := math.Round((v - vr.min) / vr.step)
+	res := vr.min + steps*vr.step
+	if res > vr.max {
+		// The max doesn't have to be a whole number of steps away.
+		res = vr.min + math.Floor((vr.max-vr.min)/vr.step)*vr.step
+	}
+	// Remove the errors of the floating point arithmetic, e.g. 0.1+0.2.
+	mult := math.Pow(10, float64(vr.decimals))
+	return math.Round(res*mult) / mult
+}
+
+// format formats the value for display.
+func (vr *valueRange) format(v float64) string {
+	return strconv.FormatFloat(v, 'f', vr.decimals, 64)
+}
+
+// cell returns the index of the cell on a track of the provided width that
+// represents the value.
+func (vr *valueRange) cell(v float64, width int) int {
+	if width <= 1 {
+		return 0
+	}
+	return int(math.Round((v - vr.min) / (vr.max - vr.min) * float64(width-1)))
+}
+
+// atCell returns the value represented by the cell at the provided index on a
+// track of the provided width.
+func (vr *valueRange) atCell(idx, width int) float64 {
+	if width <= 1 {
+		return vr.min
+	}
+	return vr.snap(vr.min + float64(idx)/float64(width-1)*(vr.max-vr.min))
+}
@@ -0,0 +1,213 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slider
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewValueRange(t *testing.T) {
+	tests := []struct {
+		desc    string
+		min     float64
+		max     float64
+		step    float64
+		wantErr bool
+	}{
+		{
+			desc: "valid range",
+			min:  0,
+			max:  1,
+			step: 0.1,
+		},
+		{
+			desc:    "fails when min equals max",
+			min:     1,
+			max:     1,
+			step:    1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails when min is larger than max",
+			min:     2,
+			max:     1,
+			step:    1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on zero step",
+			min:     0,
+			max:     1,
+			step:    0,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on NaN",
+			min:     math.NaN(),
+			max:     1,
+			step:    1,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on infinity",
+			min:     0,
+			max:     math.Inf(1),
+			step:    1,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := newValueRange(tc.min, tc.max, tc.step)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("newValueRange => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSnap(t *testing.T) {
+	tests := []struct {
+		desc string
+		min  float64
+		max  float64
+		step float64
+		v    float64
+		want float64
+	}{
+		{
+			desc: "value on a step",
+			min:  0,
+			max:  10,
+			step: 2,
+			v:    4,
+			want: 4,
+		},
+		{
+			desc: "rounds to the nearest step",
+			min:  0,
+			max:  10,
+			step: 2,
+			v:    4.9,
+			want: 4,
+		},
+		{
+			desc: "steps are counted from the min",
+			min:  1,
+			max:  10,
+			step: 2,
+			v:    4,
+			want: 5,
+		},
+		{
+			desc: "clamps to the min",
+			min:  0,
+			max:  10,
+			step: 1,
+			v:    -5,
+			want: 0,
+		},
+		{
+			desc: "clamps to the last step under the max",
+			min:  0,
+			max:  10,
+			step: 3,
+			v:    20,
+			want: 9,
+		},
+		{
+			desc: "removes floating point errors",
+			min:  0,
+			max:  1,
+			step: 0.1,
+			v:    0.3,
+			want: 0.3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			vr, err := newValueRange(tc.min, tc.max, tc.step)
+			if err != nil {
+				t.Fatalf("newValueRange => unexpected error: %v", err)
+			}
+			if got := vr.snap(tc.v); got != tc.want {
+				t.Errorf("snap(%v) => %v, want %v", tc.v, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCells(t *testing.T) {
+	vr, err := newValueRange(0, 10, 1)
+	if err != nil {
+		t.Fatalf("newValueRange => unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		desc  string
+		v     float64
+		width int
+		want  int
+	}{
+		{desc: "min is the first cell", v: 0, width: 6, want: 0},
+		{desc: "max is the last cell", v: 10, width: 6, want: 5},
+		{desc: "value in the middle", v: 4, width: 6, want: 2},
+		{desc: "track of one cell", v: 10, width: 1, want: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := vr.cell(tc.v, tc.width)
+			if got != tc.want {
+				t.Errorf("cell(%v, %d) => %d, want %d", tc.v, tc.width, got, tc.want)
+			}
+			if tc.width <= 1 {
+				return
+			}
+			if back := vr.atCell(got, tc.width); back != tc.v {
+				t.Errorf("atCell(%d, %d) => %v, want %v", got, tc.width, back, tc.v)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		desc string
+		min  float64
+		step float64
+		v    float64
+		want string
+	}{
+		{desc: "whole steps", min: 0, step: 1, v: 5, want: "5"},
+		{desc: "decimal steps", min: 0, step: 0.25, v: 0.5, want: "0.50"},
+		{desc: "decimal min", min: 0.5, step: 1, v: 1.5, want: "1.5"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			vr, err := newValueRange(tc.min, 10, tc.step)
+			if err != nil {
+				t.Fatalf("newValueRange => unexpected error: %v", err)
+			}
+			if got := vr.format(tc.v); got != tc.want {
+				t.Errorf("format(%v) => %q, want %q", tc.v, got, tc.want)
+			}
+		})
+	}
+}
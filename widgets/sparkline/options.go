@@ -59,6 +59,13 @@ func (o *options) validate() error {
 	return nil
 }
 
+// clone returns a deep copy of the options.
+func (o *options) clone() *options {
+	c := *o
+	c.labelCellOpts = append([]cell.Option(nil), o.labelCellOpts...)
+	return &c
+}
+
 // Label adds a label above the SparkLine.
 func Label(text string, cOpts ...cell.Option) Option {
 	return option(func(opts *options) {
@@ -251,3 +251,15 @@ func (sl *SparkLine) Options() widgetapi.Options {
 		WantMouse:    widgetapi.MouseScopeNone,
 	}
 }
+
+// Clone returns a new SparkLine with the same options as this one, but without
+// any data. The clone doesn't share any mutable state with this SparkLine.
+// Implements widgetapi.Cloneable.
+func (sl *SparkLine) Clone() (widgetapi.Widget, error) {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	return &SparkLine{
+		opts: sl.opts.clone(),
+	}, nil
+}
@@ -570,3 +570,50 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestClone(t *testing.T) {
+	cOpts := []cell.Option{cell.FgColor(cell.ColorRed)}
+	sl, err := New(
+		Label("label", cOpts...),
+		Color(cell.ColorBlue),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	w, err := widgetapi.CloneWidget(sl)
+	if err != nil {
+		t.Fatalf("CloneWidget => unexpected error: %v", err)
+	}
+	clone := w.(*SparkLine)
+	if err := clone.Add([]int{1, 2}); err != nil {
+		t.Fatalf("Add => unexpected error: %v", err)
+	}
+
+	draw := func() *faketerm.Terminal {
+		c, err := canvas.New(image.Rect(0, 0, 5, 3))
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := clone.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		ft, err := faketerm.New(c.Size())
+		if err != nil {
+			t.Fatalf("faketerm.New => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+	want := draw()
+
+	cOpts[0] = cell.FgColor(cell.ColorGreen)
+	if err := sl.Add([]int{8, 0, 8}, Label("other"), Color(cell.ColorRed)); err != nil {
+		t.Fatalf("Add => unexpected error: %v", err)
+	}
+	if diff := faketerm.Diff(want, draw()); diff != "" {
+		t.Errorf("Draw => the clone changed after modifying the original %s", diff)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stepper
+
+// options.go contains configurable options for Stepper.
+
+import (
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	canAdvance  AdvanceFn
+	onComplete  func()
+	activeColor cell.Color
+	doneColor   cell.Color
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		activeColor: cell.ColorNumber(DefaultActiveColorNumber),
+		doneColor:   DefaultDoneColor,
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// AdvanceFn if provided is called with the index of the current step before
+// the Stepper moves past it. The Stepper only advances if the function
+// returns true.
+//
+// The function must be thread-safe as it is called from the goroutine that
+// calls Next.
+type AdvanceFn func(step int) bool
+
+// CanAdvance sets a function that validates the current step before Next
+// moves to the following step or completes the last one.
+func CanAdvance(fn AdvanceFn) Option {
+	return option(func(opts *options) {
+		opts.canAdvance = fn
+	})
+}
+
+// OnComplete sets a function that is called when Next is called on the last
+// step and the step can advance.
+func OnComplete(fn func()) Option {
+	return option(func(opts *options) {
+		opts.onComplete = fn
+	})
+}
+
+// DefaultActiveColorNumber is the default background color of the title of
+// the current step, this is an Xterm color number, see cell.ColorNumber.
+const DefaultActiveColorNumber = 117
+
+// ActiveColor sets the background color of the title of the current step.
+// Defaults to DefaultActiveColorNumber.
+func ActiveColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.activeColor = c
+	})
+}
+
+// DefaultDoneColor is the default color of the titles of the completed steps.
+const DefaultDoneColor = cell.ColorGreen
+
+// DoneColor sets the color of the titles of the steps before the current
+// one. Defaults to DefaultDoneColor.
+func DoneColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.doneColor = c
+	})
+}
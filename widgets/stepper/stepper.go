@@ -0,0 +1,309 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package stepper contains a widget that guides the user through a sequence
+// of steps, displaying one widget per step.
+package stepper
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/private/wrap"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// barHeight is the height of the step indicator in cells.
+const barHeight = 1
+
+// barSeparator separates the step titles in the step indicator.
+const barSeparator = " > "
+
+// step is a single step of the Stepper.
+type step struct {
+	title  string
+	widget widgetapi.Widget
+}
+
+// Stepper displays a step indicator in the top row, e.g.:
+//
+//  1. Setup > 2. Configure > 3. Review
+//
+// and the widget of the current step below it. The current step is
+// highlighted, the completed steps are displayed in a different color. Next
+// and Prev move between the steps, the keyboard and the mouse events are
+// forwarded to the widget of the current step.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Stepper struct {
+	// steps are the registered steps.
+	steps []*step
+	// current is the index of the current step.
+	current int
+	// lastContent is the area of the step widget during the last call to
+	// Draw.
+	lastContent image.Rectangle
+
+	// mu protects the Stepper.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Stepper without any steps.
+func New(opts ...Option) (*Stepper, error) {
+	return &Stepper{
+		opts: newOptions(opts...),
+	}, nil
+}
+
+// AddStep registers a step with the title that displays the widget. The
+// steps are displayed in the order they were added, the first one is current.
+func (s *Stepper) AddStep(title string, w widgetapi.Widget) error {
+	if w == nil {
+		return errors.New("the widget of the step cannot be nil")
+	}
+	if err := wrap.ValidText(title); err != nil {
+		return fmt.Errorf("invalid step title: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, &step{title: title, widget: w})
+	return nil
+}
+
+// Current returns the index of the current step.
+func (s *Stepper) Current() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+// Next moves to the following step if the CanAdvance function allows it.
+// On the last step, Next calls the OnComplete function instead.
+// Returns true if the Stepper advanced or completed.
+func (s *Stepper) Next() bool {
+	s.mu.Lock()
+	cur, n := s.current, len(s.steps)
+	s.mu.Unlock()
+	if n == 0 {
+		return false
+	}
+
+	// Mutex must be released when calling the callbacks, they might call
+	// methods of the Stepper.
+	if fn := s.opts.canAdvance; fn != nil && !fn(cur) {
+		return false
+	}
+	if cur == n-1 {
+		if s.opts.onComplete != nil {
+			s.opts.onComplete()
+		}
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current != cur {
+		// Moved by another call while validating.
+		return false
+	}
+	s.current++
+	return true
+}
+
+// Prev moves to the previous step, returns false on the first step.
+func (s *Stepper) Prev() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.current == 0 {
+		return false
+	}
+	s.current--
+	return true
+}
+
+// drawBar draws the step indicator into the area.
+// Caller must hold s.mu.
+func (s *Stepper) drawBar(cvs *canvas.Canvas, ar image.Rectangle) error {
+	x := ar.Min.X
+	for i, st := range s.steps {
+		if x >= ar.Max.X {
+			break
+		}
+		if i > 0 {
+			if err := draw.Text(cvs, barSeparator, image.Point{x, ar.Min.Y},
+				draw.TextMaxX(ar.Max.X),
+				draw.TextOverrunMode(draw.OverrunModeTrim),
+			); err != nil {
+				return err
+			}
+			x += runewidth.StringWidth(barSeparator)
+			if x >= ar.Max.X {
+				break
+			}
+		}
+
+		var cOpts []cell.Option
+		switch {
+		case i == s.current:
+			cOpts = []cell.Option{cell.FgColor(cell.ColorBlack), cell.BgColor(s.opts.activeColor)}
+		case i < s.current:
+			cOpts = []cell.Option{cell.FgColor(s.opts.doneColor)}
+		}
+		text := fmt.Sprintf("%d. %s", i+1, st.title)
+		if err := draw.Text(cvs, text, image.Point{x, ar.Min.Y},
+			draw.TextCellOpts(cOpts...),
+			draw.TextMaxX(ar.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		); err != nil {
+			return err
+		}
+		x += runewidth.StringWidth(text)
+	}
+	return nil
+}
+
+// drawContent draws the widget of the current step into its area.
+// Caller must hold s.mu.
+func (s *Stepper) drawContent(cvs *canvas.Canvas, ar image.Rectangle, meta *widgetapi.Meta) error {
+	wCvs, err := canvas.New(ar)
+	if err != nil {
+		return err
+	}
+
+	w := s.steps[s.current].widget
+	needSize := image.Point{1, 1}
+	if min := w.Options().MinimumSize; min.X > 0 && min.Y > 0 {
+		needSize = min
+	}
+	if ar.Dx() < needSize.X || ar.Dy() < needSize.Y {
+		if err := draw.ResizeNeeded(wCvs); err != nil {
+			return err
+		}
+		return wCvs.CopyTo(cvs)
+	}
+
+	if err := w.Draw(wCvs, meta); err != nil {
+		return err
+	}
+	return wCvs.CopyTo(cvs)
+}
+
+// Draw draws the Stepper widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (s *Stepper) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastContent = image.ZR
+	if len(s.steps) == 0 {
+		return nil
+	}
+
+	ar := cvs.Area()
+	barAr := image.Rect(ar.Min.X, ar.Min.Y, ar.Max.X, ar.Min.Y+barHeight)
+	if err := s.drawBar(cvs, barAr); err != nil {
+		return err
+	}
+	if ar.Dy() <= barHeight {
+		return nil
+	}
+	s.lastContent = image.Rect(ar.Min.X, barAr.Max.Y, ar.Max.X, ar.Max.Y)
+	if err := s.drawContent(cvs, s.lastContent, meta); err != nil {
+		return fmt.Errorf("failed to draw the widget of step %d: %v", s.current, err)
+	}
+	return nil
+}
+
+// Keyboard forwards the event to the widget of the current step.
+// Implements widgetapi.Widget.Keyboard.
+func (s *Stepper) Keyboard(k *terminalapi.Keyboard) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.steps) == 0 {
+		return nil
+	}
+	if w := s.steps[s.current].widget; w.Options().WantKeyboard != widgetapi.KeyScopeNone {
+		return w.Keyboard(k)
+	}
+	return nil
+}
+
+// Mouse forwards the events that fall below the step indicator to the widget
+// of the current step.
+// Implements widgetapi.Widget.Mouse.
+func (s *Stepper) Mouse(m *terminalapi.Mouse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.steps) == 0 || !m.Position.In(s.lastContent) {
+		return nil
+	}
+	w := s.steps[s.current].widget
+	if w.Options().WantMouse == widgetapi.MouseScopeNone {
+		return nil
+	}
+	return w.Mouse(&terminalapi.Mouse{
+		Position: m.Position.Sub(s.lastContent.Min),
+		Button:   m.Button,
+	})
+}
+
+// Options of the widget.
+// Implements widgetapi.Widget.Options.
+// Reflects the options of the widget of the current step with the minimum
+// height increased by the height of the step indicator.
+func (s *Stepper) Options() widgetapi.Options {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	opts := widgetapi.Options{
+		MinimumSize: image.Point{1, barHeight},
+	}
+	if len(s.steps) == 0 {
+		return opts
+	}
+
+	wOpts := s.steps[s.current].widget.Options()
+	if wOpts.MinimumSize.X > opts.MinimumSize.X {
+		opts.MinimumSize.X = wOpts.MinimumSize.X
+	}
+	if wOpts.MinimumSize.Y > 0 {
+		opts.MinimumSize.Y += wOpts.MinimumSize.Y
+	} else {
+		opts.MinimumSize.Y++
+	}
+	opts.MaximumSize = wOpts.MaximumSize
+	if opts.MaximumSize.Y > 0 {
+		opts.MaximumSize.Y += barHeight
+	}
+	opts.WantKeyboard = wOpts.WantKeyboard
+	if wOpts.WantMouse != widgetapi.MouseScopeNone {
+		// Events outside of the step widget cannot be forwarded with a valid
+		// position.
+		opts.WantMouse = widgetapi.MouseScopeWidget
+	}
+	return opts
+}
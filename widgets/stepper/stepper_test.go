@@ -0,0 +1,377 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stepper
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// mirrorOpts are the options of the widgets of the steps.
+var mirrorOpts = widgetapi.Options{
+	MinimumSize:  image.Point{2, 2},
+	WantKeyboard: widgetapi.KeyScopeFocused,
+	WantMouse:    widgetapi.MouseScopeWidget,
+}
+
+// The cell options of the step titles.
+var (
+	activeOpts = []cell.Option{cell.FgColor(cell.ColorBlack), cell.BgColor(cell.ColorNumber(DefaultActiveColorNumber))}
+	doneOpts   = []cell.Option{cell.FgColor(DefaultDoneColor)}
+)
+
+// stepTitles are the titles of the steps used in the tests.
+var stepTitles = []string{"A", "B", "C"}
+
+func TestStepper(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		steps      int
+		canvas     image.Rectangle
+		next       int
+		prev       int
+		events     []terminalapi.Event
+		want       func(size image.Point) *faketerm.Terminal
+		wantAddErr bool
+	}{
+		{
+			desc:   "draws nothing without steps",
+			canvas: image.Rect(0, 0, 20, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws the bar and the widget of the first step",
+			steps:  3,
+			canvas: image.Rect(0, 0, 20, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "1. A", image.Point{0, 0}, draw.TextCellOpts(activeOpts...))
+				testdraw.MustText(c, " > 2. B > 3. C", image.Point{4, 0})
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 20, 4)), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:   "marks the completed steps",
+			opts:   []Option{ActiveColor(cell.ColorRed), DoneColor(cell.ColorBlue)},
+			steps:  3,
+			canvas: image.Rect(0, 0, 20, 4),
+			next:   2,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				blue := draw.TextCellOpts(cell.FgColor(cell.ColorBlue))
+				testdraw.MustText(c, "1. A", image.Point{0, 0}, blue)
+				testdraw.MustText(c, " > ", image.Point{4, 0})
+				testdraw.MustText(c, "2. B", image.Point{7, 0}, blue)
+				testdraw.MustText(c, " > ", image.Point{11, 0})
+				testdraw.MustText(c, "3. C", image.Point{14, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorBlack), cell.BgColor(cell.ColorRed)))
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 20, 4)), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:   "prev returns to the previous step",
+			steps:  3,
+			canvas: image.Rect(0, 0, 20, 4),
+			next:   2,
+			prev:   3,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "1. A", image.Point{0, 0}, draw.TextCellOpts(activeOpts...))
+				testdraw.MustText(c, " > 2. B > 3. C", image.Point{4, 0})
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 20, 4)), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:   "trims the bar that doesn't fit",
+			steps:  3,
+			canvas: image.Rect(0, 0, 9, 3),
+			next:   1,
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "1. A", image.Point{0, 0}, draw.TextCellOpts(doneOpts...))
+				testdraw.MustText(c, " > ", image.Point{4, 0})
+				testdraw.MustText(c, "2…", image.Point{7, 0}, draw.TextCellOpts(activeOpts...))
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 9, 3)), &widgetapi.Meta{}, mirrorOpts)
+				return ft
+			},
+		},
+		{
+			desc:   "draws resize needed when the step widget doesn't fit",
+			steps:  1,
+			canvas: image.Rect(0, 0, 10, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "1. A", image.Point{0, 0}, draw.TextCellOpts(activeOpts...))
+				testcanvas.MustApply(c, ft)
+
+				wc := testcanvas.MustNew(image.Rect(0, 1, 10, 2))
+				testdraw.MustResizeNeeded(wc)
+				testcanvas.MustApply(wc, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "forwards events to the widget of the current step",
+			steps:  2,
+			canvas: image.Rect(0, 0, 20, 4),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+				&terminalapi.Mouse{Position: image.Point{1, 0}, Button: mouse.ButtonLeft},
+				&terminalapi.Mouse{Position: image.Point{1, 2}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "1. A", image.Point{0, 0}, draw.TextCellOpts(activeOpts...))
+				testdraw.MustText(c, " > 2. B", image.Point{4, 0})
+				testcanvas.MustApply(c, ft)
+
+				fakewidget.MustDraw(ft, testcanvas.MustNew(image.Rect(0, 1, 20, 4)), &widgetapi.Meta{}, mirrorOpts,
+					&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+					&terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft},
+				)
+				return ft
+			},
+		},
+		{
+			desc:       "fails on an invalid title",
+			steps:      1,
+			canvas:     image.Rect(0, 0, 20, 4),
+			wantAddErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			for i := 0; i < tc.steps; i++ {
+				title := stepTitles[i]
+				if tc.wantAddErr {
+					title = "a\tb"
+				}
+				err := s.AddStep(title, fakewidget.New(mirrorOpts))
+				if (err != nil) != tc.wantAddErr {
+					t.Errorf("AddStep => unexpected error: %v, wantAddErr: %v", err, tc.wantAddErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+			for i := 0; i < tc.next; i++ {
+				s.Next()
+			}
+			for i := 0; i < tc.prev; i++ {
+				s.Prev()
+			}
+
+			// Draw once to record the area of the step widget.
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := s.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					if err := s.Keyboard(e); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+				case *terminalapi.Mouse:
+					if err := s.Mouse(e); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := s.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestNextAndPrev(t *testing.T) {
+	var validated []int
+	completed := 0
+	allow := false
+	s, err := New(
+		CanAdvance(func(step int) bool {
+			validated = append(validated, step)
+			return allow || step == 0
+		}),
+		OnComplete(func() {
+			completed++
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	if s.Next() {
+		t.Errorf("Next => true without steps, want false")
+	}
+	if s.Prev() {
+		t.Errorf("Prev => true without steps, want false")
+	}
+	for _, title := range []string{"A", "B"} {
+		if err := s.AddStep(title, fakewidget.New(mirrorOpts)); err != nil {
+			t.Fatalf("AddStep => unexpected error: %v", err)
+		}
+	}
+	if err := s.AddStep("C", nil); err == nil {
+		t.Errorf("AddStep => got nil error for a nil widget, want an error")
+	}
+
+	if !s.Next() {
+		t.Errorf("Next => false on step 0, want true")
+	}
+	if s.Next() {
+		t.Errorf("Next => true on step 1 that cannot advance, want false")
+	}
+	if got, want := s.Current(), 1; got != want {
+		t.Errorf("Current => %d, want %d", got, want)
+	}
+	if completed != 0 {
+		t.Errorf("OnComplete called %d times before the last step advanced, want 0", completed)
+	}
+
+	allow = true
+	if !s.Next() {
+		t.Errorf("Next => false on the last step, want true")
+	}
+	if got, want := s.Current(), 1; got != want {
+		t.Errorf("Current => %d after completion, want %d", got, want)
+	}
+	if completed != 1 {
+		t.Errorf("OnComplete called %d times, want 1", completed)
+	}
+	if diff := pretty.Compare([]int{0, 1, 1}, validated); diff != "" {
+		t.Errorf("CanAdvance called with unexpected steps, diff (-want, +got):\n%s", diff)
+	}
+
+	if !s.Prev() {
+		t.Errorf("Prev => false on step 1, want true")
+	}
+	if s.Prev() {
+		t.Errorf("Prev => true on the first step, want false")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc  string
+		steps []widgetapi.Options
+		want  widgetapi.Options
+	}{
+		{
+			desc: "without steps",
+			want: widgetapi.Options{
+				MinimumSize: image.Point{1, 1},
+			},
+		},
+		{
+			desc:  "reflects the options of the current step",
+			steps: []widgetapi.Options{mirrorOpts, {}},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{2, 3},
+				WantKeyboard: widgetapi.KeyScopeFocused,
+				WantMouse:    widgetapi.MouseScopeWidget,
+			},
+		},
+		{
+			desc: "adds the bar to the maximum height",
+			steps: []widgetapi.Options{
+				{MaximumSize: image.Point{5, 5}},
+			},
+			want: widgetapi.Options{
+				MinimumSize: image.Point{1, 2},
+				MaximumSize: image.Point{5, 6},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			for i, opts := range tc.steps {
+				if err := s.AddStep(stepTitles[i], fakewidget.New(opts)); err != nil {
+					t.Fatalf("AddStep => unexpected error: %v", err)
+				}
+			}
+
+			got := s.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary stepperdemo displays the Stepper widget.
+// Exist when Q is pressed or when the last step is completed.
+package main
+
+import (
+	"context"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/stepper"
+	"github.com/mum4k/termdash/widgets/text"
+)
+
+// newText returns a text widget that displays the message.
+func newText(msg string) *text.Text {
+	txt, err := text.New(text.WrapAtWords())
+	if err != nil {
+		panic(err)
+	}
+	if err := txt.Write(msg); err != nil {
+		panic(err)
+	}
+	return txt
+}
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s, err := stepper.New(
+		stepper.OnComplete(cancel),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	steps := []struct {
+		title string
+		msg   string
+	}{
+		{"Setup", "Welcome to the setup. Press N to move to the next step and P to return to the previous one."},
+		{"Configure", "Nothing to configure here, press N to continue."},
+		{"Review", "Press N to complete the last step and exit the demo."},
+	}
+	for _, st := range steps {
+		if err := s.AddStep(st.title, newText(st.msg)); err != nil {
+			panic(err)
+		}
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(s),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	keys := func(k *terminalapi.Keyboard) {
+		switch k.Key {
+		case 'q', 'Q':
+			cancel()
+		case 'n', 'N':
+			s.Next()
+		case 'p', 'P':
+			s.Prev()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(keys)); err != nil {
+		panic(err)
+	}
+}
@@ -35,6 +35,7 @@ type options struct {
 	wrapMode         wrap.Mode
 	rollContent      bool
 	disableScrolling bool
+	rightToLeft      bool
 	mouseUpButton    mouse.Button
 	mouseDownButton  mouse.Button
 	keyUp            keyboard.Key
@@ -76,6 +77,12 @@ func (o *options) validate() error {
 	return nil
 }
 
+// clone returns a deep copy of the options.
+func (o *options) clone() *options {
+	c := *o
+	return &c
+}
+
 // option implements Option.
 type option func(*options)
 
@@ -119,6 +126,17 @@ func DisableScrolling() Option {
 	})
 }
 
+// RightToLeft configures the text widget so that it displays the text right to
+// left, e.g. for Arabic or Hebrew. Each line starts on the right edge of the
+// widget and the text flows to the left. Mixed bidirectional text isn't
+// supported, lines are mirrored as a whole. If not provided, the text is
+// displayed left to right.
+func RightToLeft() Option {
+	return option(func(opts *options) {
+		opts.rightToLeft = true
+	})
+}
+
 // The default mouse buttons for content scrolling.
 const (
 	DefaultScrollMouseButtonUp   = mouse.ButtonWheelUp
@@ -0,0 +1,57 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package text
+
+// rtl.go contains code that displays the text right to left.
+
+import (
+	"image"
+
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/runewidth"
+)
+
+// mirror copies the content of the src canvas onto the dst canvas with every
+// row mirrored horizontally. Text that was drawn left to right onto the src
+// canvas starts on the right edge of the dst canvas and flows to the left.
+// Full-width runes are kept intact. Both canvases must have the same size.
+func mirror(src, dst *canvas.Canvas) error {
+	size := src.Size()
+	for row := 0; row < size.Y; row++ {
+		for col := 0; col < size.X; {
+			c, err := src.Cell(image.Point{col, row})
+			if err != nil {
+				return err
+			}
+			rw := runewidth.RuneWidth(c.Rune)
+			if rw == 0 {
+				rw = 1
+			}
+			if x := size.X - col - rw; x >= 0 {
+				p := image.Point{x, row}
+				if _, err := dst.SetCell(p, c.Rune, c.Opts); err != nil {
+					return err
+				}
+				for _, r := range c.Combining {
+					if err := dst.SetCellCombine(p, r); err != nil {
+						return err
+					}
+				}
+			}
+			col += rw
+		}
+	}
+	return nil
+}
@@ -183,7 +183,22 @@ func (t *Text) draw(cvs *canvas.Canvas) error {
 			break // Skip all lines falling after (under) the canvas.
 		}
 
+		prev := cur
+		// Indicates if a rune was drawn that combining characters can modify.
+		var drawn bool
 		for _, cell := range line {
+			if buffer.IsCombining(cell.Rune) {
+				if !drawn {
+					// A leading combining character has no rune to modify.
+					continue
+				}
+				// Combining characters modify the previous rune.
+				if err := cvs.SetCellCombine(prev, cell.Rune, cell.Opts); err != nil {
+					return err
+				}
+				continue
+			}
+
 			tr, err := lineTrim(cvs, cur, cell.Rune, t.opts)
 			if err != nil {
 				return err
@@ -197,6 +212,8 @@ func (t *Text) draw(cvs *canvas.Canvas) error {
 			if err != nil {
 				return err
 			}
+			prev = cur
+			drawn = true
 			cur = image.Point{cur.X + cells, cur.Y} // Move within the same line.
 		}
 		cur = image.Point{0, cur.Y + 1} // Move to the next line.
@@ -226,7 +243,18 @@ func (t *Text) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
 		return nil // Nothing to draw if there's no text.
 	}
 
-	if err := t.draw(cvs); err != nil {
+	if t.opts.rightToLeft {
+		ltr, err := canvas.New(cvs.Area())
+		if err != nil {
+			return err
+		}
+		if err := t.draw(ltr); err != nil {
+			return err
+		}
+		if err := mirror(ltr, cvs); err != nil {
+			return err
+		}
+	} else if err := t.draw(cvs); err != nil {
 		return err
 	}
 	t.contentChanged = false
@@ -284,3 +312,17 @@ func (t *Text) Options() widgetapi.Options {
 		WantKeyboard: ks,
 	}
 }
+
+// Clone returns a new Text with the same options as this one, but without
+// any content. The clone doesn't share any mutable state with this Text.
+// Implements widgetapi.Cloneable.
+func (t *Text) Clone() (widgetapi.Widget, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	opt := t.opts.clone()
+	return &Text{
+		scroll: newScrollTracker(opt),
+		opts:   opt,
+	}, nil
+}
@@ -113,6 +113,40 @@ func TestTextDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc:   "draws line with combining characters",
+			canvas: image.Rect(0, 0, 10, 1),
+			writes: func(widget *Text) error {
+				return widget.Write("e\u0301a\u0300")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, 'e')
+				testcanvas.MustSetCellCombine(c, image.Point{0, 0}, '\u0301')
+				testcanvas.MustSetCell(c, image.Point{1, 0}, 'a')
+				testcanvas.MustSetCellCombine(c, image.Point{1, 0}, '\u0300')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "skips combining characters at the start of a line",
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: func(widget *Text) error {
+				return widget.Write("\u0301a\n\u0300b")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testcanvas.MustSetCell(c, image.Point{0, 0}, 'a')
+				testcanvas.MustSetCell(c, image.Point{0, 1}, 'b')
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "multiple writes append",
 			canvas: image.Rect(0, 0, 12, 1),
@@ -248,6 +282,61 @@ func TestTextDraws(t *testing.T) {
 				return ft
 			},
 		},
+		{
+			desc: "draws right to left text starting on the right edge",
+			opts: []Option{
+				RightToLeft(),
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			writes: func(widget *Text) error {
+				return widget.Write("שלום\nمرحبا")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "םולש", image.Point{6, 0})
+				testdraw.MustText(c, "ابحرم", image.Point{5, 1})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws right to left text with full-width runes",
+			opts: []Option{
+				RightToLeft(),
+			},
+			canvas: image.Rect(0, 0, 10, 1),
+			writes: func(widget *Text) error {
+				return widget.Write("你好a")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "a好你", image.Point{5, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "trims long right to left lines on the left edge",
+			opts: []Option{
+				RightToLeft(),
+			},
+			canvas: image.Rect(0, 0, 5, 1),
+			writes: func(widget *Text) error {
+				return widget.Write("אבגדהו")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "…דגבא", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
 		{
 			desc:   "trims content when longer than canvas, no scroll marker on small canvas",
 			canvas: image.Rect(0, 0, 10, 2),
@@ -860,3 +949,54 @@ func TestOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestClone(t *testing.T) {
+	txt, err := New(
+		RollContent(),
+		ScrollKeys(keyboard.KeyArrowUp, keyboard.KeyArrowDown, keyboard.KeyHome, keyboard.KeyEnd),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := txt.Write("a\nb\nc"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+
+	w, err := widgetapi.CloneWidget(txt)
+	if err != nil {
+		t.Fatalf("CloneWidget => unexpected error: %v", err)
+	}
+	clone := w.(*Text)
+	if err := clone.Write("x\ny\nz"); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+
+	draw := func() *faketerm.Terminal {
+		c, err := canvas.New(image.Rect(0, 0, 5, 2))
+		if err != nil {
+			t.Fatalf("canvas.New => unexpected error: %v", err)
+		}
+		if err := clone.Draw(c, &widgetapi.Meta{}); err != nil {
+			t.Fatalf("Draw => unexpected error: %v", err)
+		}
+		ft, err := faketerm.New(c.Size())
+		if err != nil {
+			t.Fatalf("faketerm.New => unexpected error: %v", err)
+		}
+		if err := c.Apply(ft); err != nil {
+			t.Fatalf("Apply => unexpected error: %v", err)
+		}
+		return ft
+	}
+	want := draw()
+
+	if err := txt.Write("d", WriteReplace()); err != nil {
+		t.Fatalf("Write => unexpected error: %v", err)
+	}
+	if err := txt.Keyboard(&terminalapi.Keyboard{Key: keyboard.KeyHome}); err != nil {
+		t.Fatalf("Keyboard => unexpected error: %v", err)
+	}
+	if diff := faketerm.Diff(want, draw()); diff != "" {
+		t.Errorf("Draw => the clone changed after modifying the original %s", diff)
+	}
+}
@@ -329,6 +329,14 @@ func (fe *fieldEditor) reset() {
 	*fe = *newFieldEditor()
 }
 
+// set replaces the content with the text and moves the cursor after it.
+func (fe *fieldEditor) set(text string) {
+	fe.reset()
+	for _, r := range text {
+		fe.insert(r)
+	}
+}
+
 // insert inserts the rune at the current position of the cursor.
 func (fe *fieldEditor) insert(r rune) {
 	rw := runewidth.RuneWidth(r)
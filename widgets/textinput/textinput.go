@@ -16,6 +16,7 @@
 package textinput
 
 import (
+	"fmt"
 	"image"
 	"strings"
 	"sync"
@@ -104,6 +105,21 @@ func (ti *TextInput) ReadAndClear() string {
 	return c
 }
 
+// SetText replaces the content of the text input field with the text and
+// moves the cursor to its end.
+func (ti *TextInput) SetText(text string) error {
+	if text != "" {
+		if err := wrap.ValidText(text); err != nil {
+			return fmt.Errorf("invalid text: %v", err)
+		}
+	}
+
+	ti.mu.Lock()
+	defer ti.mu.Unlock()
+	ti.editor.set(text)
+	return nil
+}
+
 // drawLabel draws the text label in the area.
 func (ti *TextInput) drawLabel(cvs *canvas.Canvas, labelAr image.Rectangle) error {
 	start, err := alignfor.Text(labelAr, ti.opts.label, ti.opts.labelAlign, align.VerticalMiddle)
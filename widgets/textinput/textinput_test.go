@@ -1588,6 +1588,62 @@ func TestTextInputRead(t *testing.T) {
 	}
 }
 
+func TestTextInputSetText(t *testing.T) {
+	tests := []struct {
+		desc    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{
+			desc: "sets the text",
+			text: "abc",
+			want: "abc",
+		},
+		{
+			desc: "clears the text",
+			text: "",
+			want: "",
+		},
+		{
+			desc:    "fails on invalid text",
+			text:    "a\tb",
+			want:    "xy",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ti, err := New()
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			for _, r := range "xy" {
+				if err := ti.Keyboard(&terminalapi.Keyboard{Key: keyboard.Key(r)}); err != nil {
+					t.Fatalf("Keyboard => unexpected error: %v", err)
+				}
+			}
+
+			err = ti.SetText(tc.text)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("SetText => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if got := ti.Read(); got != tc.want {
+				t.Errorf("Read => %q, want %q", got, tc.want)
+			}
+
+			// The cursor is after the text, typing appends.
+			if err := ti.Keyboard(&terminalapi.Keyboard{Key: 'z'}); err != nil {
+				t.Fatalf("Keyboard => unexpected error: %v", err)
+			}
+			if got, want := ti.Read(), tc.want+"z"; got != want {
+				t.Errorf("Read after typing => %q, want %q", got, want)
+			}
+		})
+	}
+}
+
 func TestOptions(t *testing.T) {
 	tests := []struct {
 		desc string
@@ -0,0 +1,69 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ticker
+
+// options.go contains configurable options for Ticker.
+
+import (
+	"fmt"
+	"time"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	speed time.Duration
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		speed: DefaultSpeed,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if got, min := o.speed, time.Duration(1); got < min {
+		return fmt.Errorf("invalid Speed %v, must be %v <= Speed", got, min)
+	}
+	return nil
+}
+
+// DefaultSpeed is the default value for the Speed option.
+const DefaultSpeed = 150 * time.Millisecond
+
+// Speed sets how long it takes the text to move by one cell. Must be a
+// positive duration. The text only moves when the widget is redrawn, so the
+// redraw interval of termdash should be set to at most this value.
+// Defaults to DefaultSpeed.
+func Speed(d time.Duration) Option {
+	return option(func(opts *options) {
+		opts.speed = d
+	})
+}
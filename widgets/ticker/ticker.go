@@ -0,0 +1,198 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ticker implements a widget that scrolls text horizontally.
+package ticker
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/buffer"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// Ticker scrolls a single line of text from right to left.
+//
+// The text moves by one cell each time the configured Speed elapses and loops
+// around once it leaves the canvas. Text shorter than the width of the canvas
+// is padded with spaces, longer text is followed by a single space before it
+// repeats.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Ticker struct {
+	// cells are the cells of the displayed text. A cell that follows a
+	// full-width rune is nil.
+	cells []*buffer.Cell
+
+	// start is the time the text started moving from its initial position.
+	start time.Time
+	// pausedAt is the time the animation was paused, zero when not paused.
+	pausedAt time.Time
+
+	// now returns the current time, used to animate the text.
+	now func() time.Time
+
+	// mu protects the Ticker.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Ticker.
+func New(opts ...Option) (*Ticker, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &Ticker{
+		now:  time.Now,
+		opts: o,
+	}, nil
+}
+
+// SetText replaces the displayed text and restarts the animation with the
+// beginning of the text at the left edge of the canvas. The text must not contain control characters,
+// including newlines. The cell options apply to all the cells of the text.
+func (t *Ticker) SetText(s string, opts ...cell.Option) error {
+	for _, r := range s {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("the provided text %q cannot contain control characters, found: %q", s, r)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var cells []*buffer.Cell
+	for _, c := range buffer.NewCells(s, opts...) {
+		cells = append(cells, c)
+		if runewidth.RuneWidth(c.Rune) == 2 {
+			cells = append(cells, nil)
+		}
+	}
+	t.cells = cells
+	t.start = t.now()
+	if !t.pausedAt.IsZero() {
+		t.pausedAt = t.start
+	}
+	return nil
+}
+
+// Pause stops the animation, the text stays in its current position.
+func (t *Ticker) Pause() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pausedAt.IsZero() {
+		t.pausedAt = t.now()
+	}
+}
+
+// Resume continues the animation from the position where it was paused.
+func (t *Ticker) Resume() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.pausedAt.IsZero() {
+		return
+	}
+	t.start = t.start.Add(t.now().Sub(t.pausedAt))
+	t.pausedAt = time.Time{}
+}
+
+// Paused returns true if the animation is paused.
+func (t *Ticker) Paused() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return !t.pausedAt.IsZero()
+}
+
+// shift returns the number of cells the text moved since the start.
+// Caller must hold t.mu.
+func (t *Ticker) shift() int {
+	at := t.now()
+	if !t.pausedAt.IsZero() {
+		at = t.pausedAt
+	}
+	return int(at.Sub(t.start) / t.opts.speed)
+}
+
+// Draw draws the Ticker widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (t *Ticker) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.cells) == 0 {
+		return nil
+	}
+
+	ar := cvs.Area()
+	loop := len(t.cells) + 1
+	if loop < ar.Dx() {
+		loop = ar.Dx()
+	}
+	first := t.shift() % loop
+
+	for x := ar.Min.X; x < ar.Max.X; x++ {
+		idx := (first + x - ar.Min.X) % loop
+		if idx >= len(t.cells) || t.cells[idx] == nil {
+			continue
+		}
+		c := t.cells[idx]
+		if runewidth.RuneWidth(c.Rune) == 2 && x+1 >= ar.Max.X {
+			// The second half of a full-width rune doesn't fit.
+			break
+		}
+		if _, err := cvs.SetCell(image.Point{x, ar.Min.Y}, c.Rune, c.Opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keyboard input isn't supported on the Ticker widget.
+func (*Ticker) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the Ticker widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the Ticker widget.
+func (*Ticker) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the Ticker widget doesn't support mouse events")
+}
+
+// Options of the widget.
+// Implements widgetapi.Widget.Options.
+func (t *Ticker) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize: image.Point{1, 1},
+		// The text is displayed on exactly one row.
+		MaximumSize:  image.Point{0, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
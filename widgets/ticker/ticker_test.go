@@ -0,0 +1,298 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ticker
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// start is the time when the tests start.
+var start = time.Date(2020, time.March, 14, 13, 37, 42, 0, time.UTC)
+
+func TestTicker(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		// update gets called before drawing of the widget, it can advance the
+		// current time.
+		update     func(*Ticker, *time.Time) error
+		want       func(size image.Point) *faketerm.Terminal
+		wantNewErr bool
+		wantErr    bool
+	}{
+		{
+			desc:       "fails on invalid Speed",
+			opts:       []Option{Speed(0)},
+			wantNewErr: true,
+		},
+		{
+			desc:   "fails on text with control characters",
+			canvas: image.Rect(0, 0, 5, 1),
+			update: func(tk *Ticker, now *time.Time) error {
+				return tk.SetText("a\nb")
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws nothing without text",
+			canvas: image.Rect(0, 0, 5, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws the text at the start",
+			canvas: image.Rect(0, 0, 5, 1),
+			update: func(tk *Ticker, now *time.Time) error {
+				return tk.SetText("ab", cell.FgColor(cell.ColorRed))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "ab", image.Point{0, 0}, draw.TextCellOpts(cell.FgColor(cell.ColorRed)))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "moves the text by one cell each Speed",
+			canvas: image.Rect(0, 0, 5, 1),
+			update: func(tk *Ticker, now *time.Time) error {
+				if err := tk.SetText("ab"); err != nil {
+					return err
+				}
+				*now = now.Add(2*DefaultSpeed + DefaultSpeed/2)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "ab", image.Point{3, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "short text loops around the canvas",
+			opts:   []Option{Speed(time.Second)},
+			canvas: image.Rect(0, 0, 5, 1),
+			update: func(tk *Ticker, now *time.Time) error {
+				if err := tk.SetText("ab"); err != nil {
+					return err
+				}
+				*now = now.Add(4 * time.Second)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "ab", image.Point{1, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "long text is followed by a space",
+			opts:   []Option{Speed(time.Second)},
+			canvas: image.Rect(0, 0, 3, 1),
+			update: func(tk *Ticker, now *time.Time) error {
+				if err := tk.SetText("hello"); err != nil {
+					return err
+				}
+				*now = now.Add(4 * time.Second)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "o", image.Point{0, 0})
+				testdraw.MustText(c, "h", image.Point{2, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "draws full-width runes",
+			canvas: image.Rect(0, 0, 3, 1),
+			update: func(tk *Ticker, now *time.Time) error {
+				return tk.SetText("世")
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "世", image.Point{0, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "doesn't move while paused",
+			opts:   []Option{Speed(time.Second)},
+			canvas: image.Rect(0, 0, 5, 1),
+			update: func(tk *Ticker, now *time.Time) error {
+				if err := tk.SetText("ab"); err != nil {
+					return err
+				}
+				*now = now.Add(time.Second)
+				tk.Pause()
+				*now = now.Add(10 * time.Second)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "b", image.Point{0, 0})
+				testdraw.MustText(c, "a", image.Point{4, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "continues from the paused position after resume",
+			opts:   []Option{Speed(time.Second)},
+			canvas: image.Rect(0, 0, 5, 1),
+			update: func(tk *Ticker, now *time.Time) error {
+				if err := tk.SetText("ab"); err != nil {
+					return err
+				}
+				*now = now.Add(time.Second)
+				tk.Pause()
+				*now = now.Add(10 * time.Second)
+				tk.Resume()
+				*now = now.Add(time.Second)
+				return nil
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustText(c, "ab", image.Point{3, 0})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tk, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			now := start
+			tk.now = func() time.Time { return now }
+			if tc.update != nil {
+				err := tc.update(tk, &now)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("update => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := tk.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestPaused(t *testing.T) {
+	tk, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if tk.Paused() {
+		t.Errorf("Paused => got true for a new ticker, want false")
+	}
+	tk.Pause()
+	if !tk.Paused() {
+		t.Errorf("Paused => got false after Pause, want true")
+	}
+	tk.Resume()
+	if tk.Paused() {
+		t.Errorf("Paused => got true after Resume, want false")
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	tk, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := tk.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	tk, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := tk.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tk, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := tk.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		MaximumSize:  image.Point{0, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treemap
+
+// options.go contains configurable options for Treemap.
+
+import (
+	"errors"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	gradient       []cell.Color
+	labelColor     cell.Color
+	highlightColor cell.Color
+	tooltipColor   cell.Color
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		gradient:       DefaultGradient(),
+		labelColor:     DefaultLabelColor,
+		highlightColor: DefaultHighlightColor,
+		tooltipColor:   DefaultTooltipColor,
+	}
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if len(o.gradient) == 0 {
+		return errors.New("the Gradient must contain at least one color")
+	}
+	return nil
+}
+
+// DefaultGradient returns the default colors for the Gradient option, from blue
+// for the smallest values to red for the largest values.
+func DefaultGradient() []cell.Color {
+	return []cell.Color{
+		cell.ColorRGB6(0, 1, 5),
+		cell.ColorRGB6(0, 3, 3),
+		cell.ColorRGB6(2, 4, 0),
+		cell.ColorRGB6(5, 4, 0),
+		cell.ColorRGB6(5, 1, 0),
+	}
+}
+
+// Gradient sets the colors of the rectangles. The smallest value is displayed
+// in the first color and the largest value in the last color, values in
+// between are displayed in the closest color of the gradient. At least one
+// color must be provided.
+// Defaults to the colors returned by DefaultGradient.
+func Gradient(colors ...cell.Color) Option {
+	return option(func(opts *options) {
+		opts.gradient = colors
+	})
+}
+
+// DefaultLabelColor is the default value for the LabelColor option.
+const DefaultLabelColor = cell.ColorBlack
+
+// LabelColor sets the color of the labels displayed inside the rectangles.
+// Defaults to DefaultLabelColor.
+func LabelColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.labelColor = c
+	})
+}
+
+// DefaultHighlightColor is the default value for the HighlightColor option.
+const DefaultHighlightColor = cell.ColorWhite
+
+// HighlightColor sets the color of the rectangle under the mouse cursor.
+// Defaults to DefaultHighlightColor.
+func HighlightColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.highlightColor = c
+	})
+}
+
+// DefaultTooltipColor is the default value for the TooltipColor option.
+const DefaultTooltipColor = cell.ColorYellow
+
+// TooltipColor sets the background color of the tooltip that displays the
+// full path and the value of the rectangle under the mouse cursor.
+// Defaults to DefaultTooltipColor.
+func TooltipColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.tooltipColor = c
+	})
+}
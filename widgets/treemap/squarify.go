@@ -0,0 +1,107 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treemap
+
+// squarify.go implements the squarified treemap algorithm, see:
+// https://www.win.tue.nl/~vanwijk/stm.pdf
+
+import "math"
+
+// rect is a rectangle with floating point coordinates.
+type rect struct {
+	x, y, w, h float64
+}
+
+// worst returns the highest aspect ratio of the rectangles created when the
+// areas are laid out in a row along a side of the specified length.
+func worst(areas []float64, side float64) float64 {
+	var sum float64
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, a := range areas {
+		sum += a
+		min = math.Min(min, a)
+		max = math.Max(max, a)
+	}
+	side2, sum2 := side*side, sum*sum
+	return math.Max(side2*max/sum2, sum2/(side2*min))
+}
+
+// layoutRow lays the areas out in a row along the shorter side of the
+// rectangle. Returns the rectangles of the areas and the remaining part of
+// the rectangle.
+func layoutRow(areas []float64, r rect) ([]rect, rect) {
+	var sum float64
+	for _, a := range areas {
+		sum += a
+	}
+
+	var res []rect
+	if r.w >= r.h {
+		// A column along the left side.
+		w := sum / r.h
+		y := r.y
+		for _, a := range areas {
+			h := a / w
+			res = append(res, rect{r.x, y, w, h})
+			y += h
+		}
+		return res, rect{r.x + w, r.y, r.w - w, r.h}
+	}
+
+	// A row along the top side.
+	h := sum / r.w
+	x := r.x
+	for _, a := range areas {
+		w := a / h
+		res = append(res, rect{x, r.y, w, h})
+		x += w
+	}
+	return res, rect{r.x, r.y + h, r.w, r.h - h}
+}
+
+// squarify divides the rectangle into rectangles with areas proportional to
+// the values while keeping their aspect ratios close to one.
+// The values must be positive and sorted in descending order. Returns the
+// rectangles in the same order as the values.
+func squarify(values []float64, r rect) []rect {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	if total <= 0 || r.w <= 0 || r.h <= 0 {
+		return nil
+	}
+
+	scale := r.w * r.h / total
+	var row []float64
+	var rects []rect
+	for _, v := range values {
+		a := v * scale
+		side := math.Min(r.w, r.h)
+		if len(row) == 0 || worst(append(row, a), side) <= worst(row, side) {
+			row = append(row, a)
+			continue
+		}
+		var laid []rect
+		laid, r = layoutRow(row, r)
+		rects = append(rects, laid...)
+		row = []float64{a}
+	}
+	if len(row) > 0 {
+		laid, _ := layoutRow(row, r)
+		rects = append(rects, laid...)
+	}
+	return rects
+}
@@ -0,0 +1,82 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treemap
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// roundRects rounds the coordinates of the rectangles to three decimal places.
+func roundRects(rects []rect) []rect {
+	round := func(f float64) float64 {
+		return math.Round(f*1000) / 1000
+	}
+	var res []rect
+	for _, r := range rects {
+		res = append(res, rect{round(r.x), round(r.y), round(r.w), round(r.h)})
+	}
+	return res
+}
+
+func TestSquarify(t *testing.T) {
+	tests := []struct {
+		desc   string
+		values []float64
+		r      rect
+		want   []rect
+	}{
+		{
+			desc: "no values",
+			r:    rect{0, 0, 6, 4},
+		},
+		{
+			desc:   "empty rectangle",
+			values: []float64{1},
+			r:      rect{0, 0, 0, 4},
+		},
+		{
+			desc:   "single value fills the rectangle",
+			values: []float64{5},
+			r:      rect{1, 2, 6, 4},
+			want:   []rect{{1, 2, 6, 4}},
+		},
+		{
+			desc:   "example from the paper",
+			values: []float64{6, 6, 4, 3, 2, 2, 1},
+			r:      rect{0, 0, 6, 4},
+			want: []rect{
+				{0, 0, 3, 2},
+				{0, 2, 3, 2},
+				{3, 0, 1.714, 2.333},
+				{4.714, 0, 1.286, 2.333},
+				{3, 2.333, 1.2, 1.667},
+				{4.2, 2.333, 1.2, 1.667},
+				{5.4, 2.333, 0.6, 1.667},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := roundRects(squarify(tc.values, tc.r))
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("squarify => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package treemap implements a widget that displays hierarchical data as
+// nested rectangles.
+package treemap
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// TreemapNode is a node of the displayed tree.
+type TreemapNode struct {
+	// Label is displayed inside the rectangle of a leaf node and is part of
+	// the path displayed in the tooltip.
+	Label string
+	// Value is the size of a leaf node, must be zero or positive. The value
+	// of a node with children is the sum of the values of its children.
+	Value float64
+	// Children are the child nodes.
+	Children []*TreemapNode
+}
+
+// value returns the value of the node.
+func (tn *TreemapNode) value() float64 {
+	if len(tn.Children) == 0 {
+		return tn.Value
+	}
+	var sum float64
+	for _, c := range tn.Children {
+		sum += c.value()
+	}
+	return sum
+}
+
+// validate validates the node and all of its children.
+func (tn *TreemapNode) validate() error {
+	if tn == nil {
+		return errors.New("the tree cannot contain nil nodes")
+	}
+	if len(tn.Children) == 0 {
+		if math.IsNaN(tn.Value) || math.IsInf(tn.Value, 0) || tn.Value < 0 {
+			return fmt.Errorf("invalid value %v of node %q, must be a finite number 0 <= value", tn.Value, tn.Label)
+		}
+		return nil
+	}
+	for _, c := range tn.Children {
+		if err := c.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// leaf is a leaf node positioned on the canvas.
+type leaf struct {
+	// path are the labels of all the nodes from the root to the leaf.
+	path  []string
+	value float64
+	area  image.Rectangle
+}
+
+// cellAspect is the ratio of the height of a terminal cell to its width.
+// Used to make the rectangles look square rather than the cells.
+const cellAspect = 2
+
+// Treemap displays a tree of values as nested rectangles with areas
+// proportional to the values. The rectangles are placed using the squarified
+// treemap algorithm and colored according to the value of each leaf.
+//
+// Moving the mouse over a rectangle highlights it and displays a tooltip with
+// the full path and the value of the node.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Treemap struct {
+	// root is the root of the displayed tree.
+	root *TreemapNode
+
+	// mouse is the last position of the mouse or image.Point{-1, -1} when
+	// the mouse is outside of the canvas.
+	mouse image.Point
+
+	// mu protects the Treemap.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Treemap.
+func New(opts ...Option) (*Treemap, error) {
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &Treemap{
+		mouse: image.Point{-1, -1},
+		opts:  o,
+	}, nil
+}
+
+// SetData sets the displayed tree. Providing a nil root clears the Treemap.
+// The Treemap keeps a reference to the tree, the tree must not be modified
+// after it was provided, call SetData again with a new tree instead.
+func (tm *Treemap) SetData(root *TreemapNode) error {
+	if root != nil {
+		if err := root.validate(); err != nil {
+			return err
+		}
+	}
+
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.root = root
+	return nil
+}
+
+// layout positions the children of the node in the rectangle and appends the
+// leaf nodes to the leaves.
+func layout(n *TreemapNode, path []string, r rect, leaves []*leaf) []*leaf {
+	path = append(append([]string(nil), path...), n.Label)
+	if len(n.Children) == 0 {
+		return append(leaves, &leaf{
+			path:  path,
+			value: n.Value,
+			area:  toCells(r),
+		})
+	}
+
+	var children []*TreemapNode
+	for _, c := range n.Children {
+		if c.value() > 0 {
+			children = append(children, c)
+		}
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		return children[i].value() > children[j].value()
+	})
+
+	values := make([]float64, len(children))
+	for i, c := range children {
+		values[i] = c.value()
+	}
+	for i, cr := range squarify(values, r) {
+		leaves = layout(children[i], path, cr, leaves)
+	}
+	return leaves
+}
+
+// toCells converts the rectangle from the layout coordinates where cells are
+// square to cells on the canvas.
+func toCells(r rect) image.Rectangle {
+	return image.Rect(
+		int(math.Round(r.x)),
+		int(math.Round(r.y/cellAspect)),
+		int(math.Round(r.x+r.w)),
+		int(math.Round((r.y+r.h)/cellAspect)),
+	)
+}
+
+// color returns the color of the value according to the gradient.
+func (tm *Treemap) color(v, min, max float64) cell.Color {
+	g := tm.opts.gradient
+	if max <= min {
+		return g[len(g)-1]
+	}
+	idx := int(math.Round((v - min) / (max - min) * float64(len(g)-1)))
+	return g[idx]
+}
+
+// drawTooltip draws the path and the value of the leaf next to the mouse
+// cursor.
+// Caller must hold tm.mu.
+func (tm *Treemap) drawTooltip(cvs *canvas.Canvas, l *leaf) error {
+	ar := cvs.Area()
+	text := fmt.Sprintf("%s: %s", strings.Join(l.path, "/"), strconv.FormatFloat(l.value, 'f', -1, 64))
+	width := runewidth.StringWidth(text)
+
+	start := image.Point{tm.mouse.X, tm.mouse.Y + 1}
+	if start.Y >= ar.Max.Y {
+		start.Y = tm.mouse.Y - 1
+	}
+	if start.Y < ar.Min.Y {
+		start.Y = tm.mouse.Y
+	}
+	if start.X+width > ar.Max.X {
+		start.X = ar.Max.X - width
+	}
+	if start.X < ar.Min.X {
+		start.X = ar.Min.X
+	}
+	return draw.Text(cvs, text, start,
+		draw.TextMaxX(ar.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+		draw.TextCellOpts(cell.FgColor(tm.opts.labelColor), cell.BgColor(tm.opts.tooltipColor)),
+	)
+}
+
+// Draw draws the Treemap widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (tm *Treemap) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if tm.root == nil || tm.root.value() == 0 {
+		return nil
+	}
+
+	ar := cvs.Area()
+	r := rect{float64(ar.Min.X), float64(ar.Min.Y * cellAspect), float64(ar.Dx()), float64(ar.Dy() * cellAspect)}
+	var leaves []*leaf
+	if len(tm.root.Children) == 0 {
+		leaves = []*leaf{{path: []string{tm.root.Label}, value: tm.root.Value, area: ar}}
+	} else {
+		// The root itself isn't part of the displayed paths.
+		for _, l := range layout(tm.root, nil, r, nil) {
+			l.path = l.path[1:]
+			leaves = append(leaves, l)
+		}
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, l := range leaves {
+		min = math.Min(min, l.value)
+		max = math.Max(max, l.value)
+	}
+
+	var hovered *leaf
+	for _, l := range leaves {
+		if l.area.Empty() {
+			continue
+		}
+		color := tm.color(l.value, min, max)
+		if tm.mouse.In(l.area) {
+			hovered = l
+			color = tm.opts.highlightColor
+		}
+		if err := draw.Rectangle(cvs, l.area, draw.RectCellOpts(cell.BgColor(color))); err != nil {
+			return err
+		}
+
+		label := l.path[len(l.path)-1]
+		if label == "" {
+			continue
+		}
+		if err := draw.Text(cvs, label, l.area.Min,
+			draw.TextMaxX(l.area.Max.X),
+			draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			draw.TextCellOpts(cell.FgColor(tm.opts.labelColor), cell.BgColor(color)),
+		); err != nil {
+			return err
+		}
+	}
+
+	if hovered != nil {
+		return tm.drawTooltip(cvs, hovered)
+	}
+	return nil
+}
+
+// Keyboard input isn't supported on the Treemap widget.
+func (*Treemap) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the Treemap widget doesn't support keyboard events")
+}
+
+// Mouse tracks the position of the mouse cursor.
+// Implements widgetapi.Widget.Mouse.
+func (tm *Treemap) Mouse(m *terminalapi.Mouse) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	tm.mouse = m.Position
+	return nil
+}
+
+// Options of the widget.
+// Implements widgetapi.Widget.Options.
+func (tm *Treemap) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		// Mouse events outside of the canvas clear the highlight.
+		WantMouse: widgetapi.MouseScopeGlobal,
+	}
+}
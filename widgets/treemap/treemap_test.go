@@ -0,0 +1,271 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package treemap
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// testTree returns a tree with two leaves, "a" with value two and "b" with
+// value one.
+func testTree() *TreemapNode {
+	return &TreemapNode{
+		Label: "root",
+		Children: []*TreemapNode{
+			{Label: "b", Value: 1},
+			{Label: "a", Value: 2},
+		},
+	}
+}
+
+func TestTreemap(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		canvas image.Rectangle
+		// update gets called before drawing of the widget.
+		update     func(*Treemap) error
+		want       func(size image.Point) *faketerm.Terminal
+		wantNewErr bool
+		wantErr    bool
+	}{
+		{
+			desc:       "fails on empty gradient",
+			opts:       []Option{Gradient()},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on negative value",
+			update: func(tm *Treemap) error {
+				return tm.SetData(&TreemapNode{
+					Children: []*TreemapNode{{Value: -1}},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			desc: "fails on nil child",
+			update: func(tm *Treemap) error {
+				return tm.SetData(&TreemapNode{
+					Children: []*TreemapNode{nil},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			desc:   "draws nothing without data",
+			canvas: image.Rect(0, 0, 3, 2),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws nothing when all values are zero",
+			canvas: image.Rect(0, 0, 3, 2),
+			update: func(tm *Treemap) error {
+				return tm.SetData(&TreemapNode{
+					Children: []*TreemapNode{{Label: "a"}},
+				})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "a single leaf fills the canvas",
+			opts:   []Option{Gradient(cell.ColorRed)},
+			canvas: image.Rect(0, 0, 3, 2),
+			update: func(tm *Treemap) error {
+				return tm.SetData(&TreemapNode{Label: "a", Value: 1})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustRectangle(c, c.Area(), draw.RectCellOpts(cell.BgColor(cell.ColorRed)))
+				testdraw.MustText(c, "a", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+					cell.BgColor(cell.ColorRed),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws rectangles proportional to the values",
+			opts: []Option{
+				Gradient(cell.ColorBlue, cell.ColorRed),
+				LabelColor(cell.ColorWhite),
+			},
+			canvas: image.Rect(0, 0, 3, 2),
+			update: func(tm *Treemap) error {
+				return tm.SetData(testTree())
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustRectangle(c, image.Rect(0, 0, 3, 1), draw.RectCellOpts(cell.BgColor(cell.ColorRed)))
+				testdraw.MustText(c, "a", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorWhite),
+					cell.BgColor(cell.ColorRed),
+				))
+				testdraw.MustRectangle(c, image.Rect(0, 1, 3, 2), draw.RectCellOpts(cell.BgColor(cell.ColorBlue)))
+				testdraw.MustText(c, "b", image.Point{0, 1}, draw.TextCellOpts(
+					cell.FgColor(cell.ColorWhite),
+					cell.BgColor(cell.ColorBlue),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "highlights the node under the mouse and displays the tooltip",
+			opts: []Option{
+				Gradient(cell.ColorBlue, cell.ColorRed),
+				HighlightColor(cell.ColorGreen),
+				TooltipColor(cell.ColorMagenta),
+			},
+			canvas: image.Rect(0, 0, 10, 2),
+			update: func(tm *Treemap) error {
+				if err := tm.SetData(&TreemapNode{
+					Children: []*TreemapNode{
+						{Label: "dir", Children: []*TreemapNode{{Label: "a", Value: 1}}},
+					},
+				}); err != nil {
+					return err
+				}
+				return tm.Mouse(&terminalapi.Mouse{Position: image.Point{1, 0}})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustRectangle(c, c.Area(), draw.RectCellOpts(cell.BgColor(cell.ColorGreen)))
+				testdraw.MustText(c, "a", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+					cell.BgColor(cell.ColorGreen),
+				))
+				testdraw.MustText(c, "dir/a: 1", image.Point{1, 1}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+					cell.BgColor(cell.ColorMagenta),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "mouse outside of the canvas removes the highlight",
+			opts:   []Option{Gradient(cell.ColorRed)},
+			canvas: image.Rect(0, 0, 3, 2),
+			update: func(tm *Treemap) error {
+				if err := tm.SetData(&TreemapNode{Label: "a", Value: 1}); err != nil {
+					return err
+				}
+				if err := tm.Mouse(&terminalapi.Mouse{Position: image.Point{1, 0}}); err != nil {
+					return err
+				}
+				return tm.Mouse(&terminalapi.Mouse{Position: image.Point{-1, -1}})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustRectangle(c, c.Area(), draw.RectCellOpts(cell.BgColor(cell.ColorRed)))
+				testdraw.MustText(c, "a", image.Point{0, 0}, draw.TextCellOpts(
+					cell.FgColor(DefaultLabelColor),
+					cell.BgColor(cell.ColorRed),
+				))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			tm, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				err := tc.update(tm)
+				if (err != nil) != tc.wantErr {
+					t.Errorf("update => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := tm.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	tm, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := tm.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tm, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := tm.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeGlobal,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Binary treemapdemo displays the Treemap widget.
+// Exist when 'q' is pressed.
+package main
+
+import (
+	"context"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/linestyle"
+	"github.com/mum4k/termdash/terminal/termbox"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgets/treemap"
+)
+
+func main() {
+	t, err := termbox.New()
+	if err != nil {
+		panic(err)
+	}
+	defer t.Close()
+
+	tm, err := treemap.New()
+	if err != nil {
+		panic(err)
+	}
+	if err := tm.SetData(&treemap.TreemapNode{
+		Label: "disk",
+		Children: []*treemap.TreemapNode{
+			{
+				Label: "home",
+				Children: []*treemap.TreemapNode{
+					{Label: "photos", Value: 120},
+					{Label: "music", Value: 80},
+					{Label: "documents", Value: 15},
+				},
+			},
+			{
+				Label: "usr",
+				Children: []*treemap.TreemapNode{
+					{Label: "lib", Value: 60},
+					{Label: "bin", Value: 20},
+					{Label: "share", Value: 35},
+				},
+			},
+			{Label: "var", Value: 40},
+			{Label: "tmp", Value: 5},
+		},
+	}); err != nil {
+		panic(err)
+	}
+
+	c, err := container.New(
+		t,
+		container.Border(linestyle.Light),
+		container.BorderTitle("PRESS Q TO QUIT"),
+		container.PlaceWidget(tm),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	quitter := func(k *terminalapi.Keyboard) {
+		if k.Key == 'q' || k.Key == 'Q' {
+			cancel()
+		}
+	}
+
+	if err := termdash.Run(ctx, t, c, termdash.KeyboardSubscriber(quitter)); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waterfall
+
+// options.go contains configurable options for Waterfall.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/draw"
+)
+
+// Option is used to provide options.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options holds the provided options.
+type options struct {
+	barChar       rune
+	barWidth      int
+	barGap        int
+	increaseColor cell.Color
+	decreaseColor cell.Color
+	totalColor    cell.Color
+	runningTotal  bool
+	isTotalBar    []bool
+	labels        []string
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if got, min := o.barWidth, 0; got < min {
+		return fmt.Errorf("invalid BarWidth %d, must be %d <= BarWidth", got, min)
+	}
+	if got, min := o.barGap, 0; got < min {
+		return fmt.Errorf("invalid BarGap %d, must be %d <= BarGap", got, min)
+	}
+	return nil
+}
+
+// clone returns a deep copy of the options.
+func (o *options) clone() *options {
+	c := *o
+	c.isTotalBar = append([]bool(nil), o.isTotalBar...)
+	c.labels = append([]string(nil), o.labels...)
+	return &c
+}
+
+// newOptions returns options with the default values set.
+func newOptions() *options {
+	return &options{
+		barChar:       DefaultChar,
+		barGap:        DefaultBarGap,
+		increaseColor: DefaultIncreaseColor,
+		decreaseColor: DefaultDecreaseColor,
+		totalColor:    DefaultTotalColor,
+	}
+}
+
+// DefaultChar is the default value for the Char option.
+const DefaultChar = draw.DefaultRectChar
+
+// Char sets the rune that is used when drawing the rectangle representing the
+// bars.
+func Char(ch rune) Option {
+	return option(func(opts *options) {
+		opts.barChar = ch
+	})
+}
+
+// BarWidth sets the width of the bars. If not set, or set to zero, the bars
+// use all the space available to the widget. Must be a positive or zero
+// integer.
+func BarWidth(width int) Option {
+	return option(func(opts *options) {
+		opts.barWidth = width
+	})
+}
+
+// DefaultBarGap is the default value for the BarGap option.
+const DefaultBarGap = 1
+
+// BarGap sets the width of the space between the bars.
+// Must be a positive or zero integer.
+// Defaults to DefaultBarGap.
+func BarGap(width int) Option {
+	return option(func(opts *options) {
+		opts.barGap = width
+	})
+}
+
+// DefaultIncreaseColor is the default value for the IncreaseColor option.
+const DefaultIncreaseColor = cell.ColorGreen
+
+// IncreaseColor sets the color of the bars that display positive values.
+// Defaults to DefaultIncreaseColor.
+func IncreaseColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.increaseColor = c
+	})
+}
+
+// DefaultDecreaseColor is the default value for the DecreaseColor option.
+const DefaultDecreaseColor = cell.ColorRed
+
+// DecreaseColor sets the color of the bars that display negative values.
+// Defaults to DefaultDecreaseColor.
+func DecreaseColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.decreaseColor = c
+	})
+}
+
+// DefaultTotalColor is the default value for the TotalColor option.
+const DefaultTotalColor = cell.ColorBlue
+
+// TotalColor sets the color of the total bars, see IsTotalBar.
+// Defaults to DefaultTotalColor.
+func TotalColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.totalColor = c
+	})
+}
+
+// RunningTotal tells the waterfall chart to draw a dashed horizontal line at
+// the cumulative total reached by each bar, connecting it to the following
+// bar.
+func RunningTotal() Option {
+	return option(func(opts *options) {
+		opts.runningTotal = true
+	})
+}
+
+// IsTotalBar marks the bars that display the subtotal or total of all the
+// preceding values. Total bars span from zero to the running total and the
+// values provided for them are ignored.
+// The first supplied bool applies to the bar displaying the first value.
+// If not specified, the corresponding bar (or all the bars) aren't total bars.
+func IsTotalBar(isTotal []bool) Option {
+	return option(func(opts *options) {
+		// Copy to avoid external modifications. See #174.
+		opts.isTotalBar = make([]bool, len(isTotal))
+		copy(opts.isTotalBar, isTotal)
+	})
+}
+
+// Labels sets the labels displayed under each bar,
+// Bars are created on a call to Values(), each value ends up in its own Bar.
+// The first supplied label applies to the bar displaying the first value.
+// If not specified, the corresponding bar (or all the bars) don't have a
+// label.
+func Labels(labels []string) Option {
+	return option(func(opts *options) {
+		// Copy to avoid external modifications. See #174.
+		opts.labels = make([]string, len(labels))
+		copy(opts.labels, labels)
+	})
+}
@@ -0,0 +1,347 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package waterfall implements a widget that draws bars displaying how the
+// values add up to a cumulative total.
+package waterfall
+
+import (
+	"errors"
+	"image"
+	"math"
+	"sync"
+
+	"github.com/mum4k/termdash/align"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/alignfor"
+	"github.com/mum4k/termdash/private/area"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// runningTotalChar is used to draw the dashed lines at the running totals.
+const runningTotalChar = '╌'
+
+// Waterfall displays bars where each bar starts where the previous bar
+// ended, showing how the values add up to the cumulative total.
+//
+// Bars displaying positive values go up, bars displaying negative values go
+// down. Selected bars can display the subtotal or total by spanning from zero,
+// see the IsTotalBar option. Each bar can have a text label under it.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type Waterfall struct {
+	// values are the values provided on a call to Values().
+	values []int
+
+	// mu protects the Waterfall.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new Waterfall chart.
+func New(opts ...Option) (*Waterfall, error) {
+	opt := newOptions()
+	for _, o := range opts {
+		o.set(opt)
+	}
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	return &Waterfall{
+		opts: opt,
+	}, nil
+}
+
+// span is the range of values displayed by one bar.
+type span struct {
+	// from is the value where the bar starts.
+	from int
+	// to is the value where the bar ends, i.e. the running total after the
+	// bar.
+	to int
+	// color is the color of the bar.
+	color cell.Color
+}
+
+// isTotalBar safely determines if the i-th bar is a total bar.
+func (w *Waterfall) isTotalBar(i int) bool {
+	return len(w.opts.isTotalBar) > i && w.opts.isTotalBar[i]
+}
+
+// spans returns the spans of all the bars and the smallest and the largest
+// value among them including zero.
+func (w *Waterfall) spans() ([]span, int, int) {
+	var (
+		spans    []span
+		total    int
+		min, max int
+	)
+	for i, v := range w.values {
+		var s span
+		switch {
+		case w.isTotalBar(i):
+			s = span{from: 0, to: total, color: w.opts.totalColor}
+		case v < 0:
+			s = span{from: total, to: total + v, color: w.opts.decreaseColor}
+		default:
+			s = span{from: total, to: total + v, color: w.opts.increaseColor}
+		}
+		total = s.to
+		spans = append(spans, s)
+
+		for _, e := range []int{s.from, s.to} {
+			if e < min {
+				min = e
+			}
+			if e > max {
+				max = e
+			}
+		}
+	}
+	return spans, min, max
+}
+
+// Draw draws the Waterfall widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (w *Waterfall) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	needAr, err := area.FromSize(w.minSize())
+	if err != nil {
+		return err
+	}
+	if !needAr.In(cvs.Area()) {
+		return draw.ResizeNeeded(cvs)
+	}
+
+	spans, min, max := w.spans()
+	rects := make([]image.Rectangle, len(spans))
+	for i, s := range spans {
+		rects[i] = w.barRect(cvs, i, s, min, max)
+	}
+
+	// The running totals are drawn first, so the bars they pass through
+	// are drawn over them.
+	if w.opts.runningTotal {
+		for i := 0; i < len(spans)-1; i++ {
+			if err := w.drawRunningTotal(cvs, spans[i], rects[i], rects[i+1]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, s := range spans {
+		r := rects[i]
+		if r.Dy() > 0 { // Value might be so small so that the rectangle is zero.
+			if err := draw.Rectangle(cvs, r,
+				draw.RectCellOpts(cell.BgColor(s.color)),
+				draw.RectChar(w.opts.barChar),
+			); err != nil {
+				return err
+			}
+		}
+
+		if l := w.label(i); l != "" {
+			if err := w.drawLabel(cvs, r, l); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// drawRunningTotal draws a dashed horizontal line at the running total of the
+// bar at barAr, reaching until the end of the following bar at nextAr.
+// Nothing is drawn for bars that are too small to be visible.
+func (w *Waterfall) drawRunningTotal(cvs *canvas.Canvas, s span, barAr, nextAr image.Rectangle) error {
+	if barAr.Dy() == 0 {
+		return nil
+	}
+	y := barAr.Min.Y
+	if s.to < s.from {
+		// Bars that display negative values end at the bottom.
+		y = barAr.Max.Y - 1
+	}
+	for x := barAr.Max.X; x < nextAr.Max.X; x++ {
+		if _, err := cvs.SetCell(image.Point{x, y}, runningTotalChar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// drawLabel draws the label under the bar at barAr.
+func (w *Waterfall) drawLabel(cvs *canvas.Canvas, barAr image.Rectangle, label string) error {
+	ar := cvs.Area()
+	labelAr := image.Rect(barAr.Min.X, ar.Max.Y-1, barAr.Max.X, ar.Max.Y)
+	start, err := alignfor.Text(labelAr, label, align.HorizontalCenter, align.VerticalBottom)
+	if err != nil {
+		return err
+	}
+	return draw.Text(cvs, label, start,
+		draw.TextMaxX(labelAr.Max.X),
+		draw.TextOverrunMode(draw.OverrunModeThreeDot),
+	)
+}
+
+// barWidth determines the width of a single bar based on options and the canvas.
+func (w *Waterfall) barWidth(cvs *canvas.Canvas) int {
+	if len(w.values) == 0 {
+		return 0 // No width when we have no values.
+	}
+
+	if w.opts.barWidth >= 1 {
+		// Prefer width set via the options.
+		return w.opts.barWidth
+	}
+
+	gaps := len(w.values) - 1
+	gapW := gaps * w.opts.barGap
+	rem := cvs.Area().Dx() - gapW
+	return rem / len(w.values)
+}
+
+// graphMaxY returns the row under the area where the bars are drawn.
+func (w *Waterfall) graphMaxY(cvs *canvas.Canvas) int {
+	maxY := cvs.Area().Max.Y
+	if len(w.opts.labels) > 0 {
+		// One line for the bar labels.
+		maxY--
+	}
+	return maxY
+}
+
+// barRect returns a rectangle that represents the i-th bar on the canvas that
+// displays the span of values. The min and the max are the smallest and the
+// largest values displayed by any of the bars.
+func (w *Waterfall) barRect(cvs *canvas.Canvas, i int, s span, min, max int) image.Rectangle {
+	bw := w.barWidth(cvs)
+	minX := bw * i
+	if i > 0 {
+		minX += w.opts.barGap * i
+	}
+	maxX := minX + bw
+
+	maxY := w.graphMaxY(cvs)
+	if min == max {
+		return image.Rect(minX, maxY, maxX, maxY)
+	}
+	available := maxY - cvs.Area().Min.Y
+	// row returns the row of the edge between cells that represents the value.
+	row := func(v int) int {
+		ratio := float64(max-v) / float64(max-min)
+		return cvs.Area().Min.Y + int(math.Round(float64(available)*ratio))
+	}
+
+	top, bottom := s.to, s.from
+	if top < bottom {
+		top, bottom = bottom, top
+	}
+	return image.Rect(minX, row(top), maxX, row(bottom))
+}
+
+// label safely determines the label for the i-th bar.
+// Labels are optional and don't have to be specified for all the bars.
+func (w *Waterfall) label(i int) string {
+	if len(w.opts.labels) > i {
+		return w.opts.labels[i]
+	}
+	return ""
+}
+
+// Values sets the values to be displayed by the Waterfall.
+// Each value ends up in its own bar, starting at the running total of all the
+// preceding values.
+// Provided options override values set when New() was called.
+func (w *Waterfall) Values(values []int, opts ...Option) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, opt := range opts {
+		opt.set(w.opts)
+	}
+	if err := w.opts.validate(); err != nil {
+		return err
+	}
+	// Copy to avoid external modifications. See #174.
+	w.values = make([]int, len(values))
+	copy(w.values, values)
+	return nil
+}
+
+// Keyboard input isn't supported on the Waterfall widget.
+func (*Waterfall) Keyboard(k *terminalapi.Keyboard) error {
+	return errors.New("the Waterfall widget doesn't support keyboard events")
+}
+
+// Mouse input isn't supported on the Waterfall widget.
+func (*Waterfall) Mouse(m *terminalapi.Mouse) error {
+	return errors.New("the Waterfall widget doesn't support mouse events")
+}
+
+// Options implements widgetapi.Widget.Options.
+func (w *Waterfall) Options() widgetapi.Options {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return widgetapi.Options{
+		MinimumSize:  w.minSize(),
+		WantKeyboard: widgetapi.KeyScopeNone,
+		WantMouse:    widgetapi.MouseScopeNone,
+	}
+}
+
+// Clone returns a new Waterfall with the same options as this one, but
+// without any values. The clone doesn't share any mutable state with this
+// Waterfall.
+// Implements widgetapi.Cloneable.
+func (w *Waterfall) Clone() (widgetapi.Widget, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return &Waterfall{
+		opts: w.opts.clone(),
+	}, nil
+}
+
+// minBarWidth determines the minimum possible width of a bar based on the
+// options.
+func (w *Waterfall) minBarWidth() int {
+	if w.opts.barWidth < 1 {
+		return 1 // At least one char for the bar itself.
+	}
+	return w.opts.barWidth
+}
+
+// minSize determines the minimum required size of the canvas.
+func (w *Waterfall) minSize() image.Point {
+	bars := len(w.values)
+	if bars == 0 {
+		return image.Point{1, 1}
+	}
+
+	minHeight := 1 // At least one character vertically to display the bar.
+	if len(w.opts.labels) > 0 {
+		minHeight++ // One line for the labels.
+	}
+
+	minWidth := bars*w.minBarWidth() + (bars-1)*w.opts.barGap
+	return image.Point{minWidth, minHeight}
+}
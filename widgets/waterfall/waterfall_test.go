@@ -0,0 +1,379 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waterfall
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestWaterfall(t *testing.T) {
+	tests := []struct {
+		desc          string
+		opts          []Option
+		update        func(*Waterfall) error // update gets called before drawing of the widget.
+		canvas        image.Rectangle
+		want          func(size image.Point) *faketerm.Terminal
+		wantNewErr    bool
+		wantUpdateErr bool
+	}{
+		{
+			desc: "fails on negative bar width",
+			opts: []Option{
+				BarWidth(-1),
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantNewErr: true,
+		},
+		{
+			desc: "fails on negative bar gap",
+			opts: []Option{
+				BarGap(-1),
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantNewErr: true,
+		},
+		{
+			desc:   "fails on invalid option passed to Values",
+			canvas: image.Rect(0, 0, 5, 4),
+			update: func(w *Waterfall) error {
+				return w.Values([]int{1}, BarGap(-1))
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+			wantUpdateErr: true,
+		},
+		{
+			desc:   "draws nothing without values",
+			canvas: image.Rect(0, 0, 5, 4),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "draws nothing when all the values are zero",
+			canvas: image.Rect(0, 0, 5, 4),
+			update: func(w *Waterfall) error {
+				return w.Values([]int{0, 0})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:   "resize needed when the bars don't fit",
+			canvas: image.Rect(0, 0, 4, 4),
+			update: func(w *Waterfall) error {
+				return w.Values([]int{1, 2, 3})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustResizeNeeded(c)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "each bar starts where the previous ended",
+			canvas: image.Rect(0, 0, 5, 4),
+			update: func(w *Waterfall) error {
+				return w.Values([]int{2, -1, 1})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 4),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultIncreaseColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 0, 3, 2),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultDecreaseColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 0, 5, 2),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultIncreaseColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:   "negative running total goes below zero",
+			canvas: image.Rect(0, 0, 3, 4),
+			update: func(w *Waterfall) error {
+				return w.Values([]int{1, -4})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 1),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultIncreaseColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 0, 3, 4),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultDecreaseColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "total bars span from zero and ignore their value",
+			opts: []Option{
+				IsTotalBar([]bool{false, false, true}),
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			update: func(w *Waterfall) error {
+				return w.Values([]int{2, -1, 100})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 4),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultIncreaseColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 0, 3, 2),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultDecreaseColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 2, 5, 4),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultTotalColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws the running totals",
+			opts: []Option{
+				RunningTotal(),
+				IsTotalBar([]bool{false, false, true}),
+			},
+			canvas: image.Rect(0, 0, 5, 4),
+			update: func(w *Waterfall) error {
+				return w.Values([]int{2, -1, 0})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testcanvas.MustSetCell(c, image.Point{1, 0}, runningTotalChar)
+				testcanvas.MustSetCell(c, image.Point{3, 1}, runningTotalChar)
+				testcanvas.MustSetCell(c, image.Point{4, 1}, runningTotalChar)
+				testdraw.MustRectangle(c, image.Rect(0, 0, 1, 4),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultIncreaseColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(2, 0, 3, 2),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultDecreaseColor)),
+				)
+				testdraw.MustRectangle(c, image.Rect(4, 2, 5, 4),
+					draw.RectChar(DefaultChar),
+					draw.RectCellOpts(cell.BgColor(DefaultTotalColor)),
+				)
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc: "draws labels and custom colors",
+			opts: []Option{
+				Labels([]string{"a", "bcd"}),
+				BarWidth(2),
+				Char('x'),
+				IncreaseColor(cell.ColorYellow),
+				DecreaseColor(cell.ColorMagenta),
+			},
+			canvas: image.Rect(0, 0, 6, 3),
+			update: func(w *Waterfall) error {
+				return w.Values([]int{2, -2})
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+				testdraw.MustRectangle(c, image.Rect(0, 0, 2, 2),
+					draw.RectChar('x'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorYellow)),
+				)
+				testdraw.MustRectangle(c, image.Rect(3, 0, 5, 2),
+					draw.RectChar('x'),
+					draw.RectCellOpts(cell.BgColor(cell.ColorMagenta)),
+				)
+				testdraw.MustText(c, "a", image.Point{0, 2})
+				testdraw.MustText(c, "b…", image.Point{3, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			w, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tc.update != nil {
+				err := tc.update(w)
+				if (err != nil) != tc.wantUpdateErr {
+					t.Errorf("update => unexpected error: %v, wantUpdateErr: %v", err, tc.wantUpdateErr)
+				}
+				if err != nil {
+					return
+				}
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := w.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestKeyboard(t *testing.T) {
+	w, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := w.Keyboard(&terminalapi.Keyboard{}); err == nil {
+		t.Errorf("Keyboard => got nil err, wanted one")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	w, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := w.Mouse(&terminalapi.Mouse{}); err == nil {
+		t.Errorf("Mouse => got nil err, wanted one")
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		desc   string
+		opts   []Option
+		values []int
+		want   widgetapi.Options
+	}{
+		{
+			desc: "minimum size for no values",
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{1, 1},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+		{
+			desc:   "minimum size for values with labels",
+			opts:   []Option{Labels([]string{"a"}), BarWidth(2)},
+			values: []int{1, 2},
+			want: widgetapi.Options{
+				MinimumSize:  image.Point{5, 2},
+				WantKeyboard: widgetapi.KeyScopeNone,
+				WantMouse:    widgetapi.MouseScopeNone,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			w, err := New(tc.opts...)
+			if err != nil {
+				t.Fatalf("New => unexpected error: %v", err)
+			}
+			if err := w.Values(tc.values); err != nil {
+				t.Fatalf("Values => unexpected error: %v", err)
+			}
+
+			got := w.Options()
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	w, err := New(
+		Labels([]string{"a"}),
+		IsTotalBar([]bool{true}),
+	)
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+	if err := w.Values([]int{1}); err != nil {
+		t.Fatalf("Values => unexpected error: %v", err)
+	}
+
+	cw, err := widgetapi.CloneWidget(w)
+	if err != nil {
+		t.Fatalf("CloneWidget => unexpected error: %v", err)
+	}
+	got := cw.(*Waterfall)
+	if diff := pretty.Compare(w.opts, got.opts); diff != "" {
+		t.Errorf("Clone => unexpected options, diff (-want, +got):\n%s", diff)
+	}
+	if len(got.values) != 0 {
+		t.Errorf("Clone => got values %v, want none", got.values)
+	}
+
+	w.opts.labels[0] = "b"
+	if want := "a"; got.opts.labels[0] != want {
+		t.Errorf("Clone => the clone shares labels with the original, got %q, want %q", got.opts.labels[0], want)
+	}
+}
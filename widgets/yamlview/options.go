@@ -0,0 +1,124 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yamlview
+
+// options.go contains configurable options for YAMLView.
+
+import (
+	"fmt"
+
+	"github.com/mum4k/termdash/cell"
+)
+
+// Option is used to provide options to New().
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// options stores the provided options.
+type options struct {
+	foldDepth     int
+	keyColor      cell.Color
+	stringColor   cell.Color
+	numberColor   cell.Color
+	selectedColor cell.Color
+}
+
+// newOptions returns a new options instance.
+func newOptions(opts ...Option) *options {
+	opt := &options{
+		foldDepth:     DefaultFoldDepth,
+		keyColor:      DefaultKeyColor,
+		stringColor:   DefaultStringColor,
+		numberColor:   DefaultNumberColor,
+		selectedColor: cell.ColorNumber(DefaultSelectedColorNumber),
+	}
+	for _, o := range opts {
+		o.set(opt)
+	}
+	return opt
+}
+
+// validate validates the provided options.
+func (o *options) validate() error {
+	if o.foldDepth < 1 {
+		return fmt.Errorf("invalid FoldDepth(%d), must be a positive number", o.foldDepth)
+	}
+	return nil
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// DefaultFoldDepth is the default value for the FoldDepth option.
+const DefaultFoldDepth = 2
+
+// FoldDepth sets the depth from which mappings and sequences are initially
+// displayed folded. The entries of the top level mapping or sequence are at
+// depth one. Must be a positive number. Defaults to DefaultFoldDepth.
+func FoldDepth(depth int) Option {
+	return option(func(opts *options) {
+		opts.foldDepth = depth
+	})
+}
+
+// The default colors of the keys and the scalar values.
+const (
+	DefaultKeyColor    = cell.ColorBlue
+	DefaultStringColor = cell.ColorGreen
+	DefaultNumberColor = cell.ColorYellow
+)
+
+// KeyColor sets the color of the mapping keys.
+// Defaults to DefaultKeyColor.
+func KeyColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.keyColor = c
+	})
+}
+
+// StringColor sets the color of the string values.
+// Defaults to DefaultStringColor.
+func StringColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.stringColor = c
+	})
+}
+
+// NumberColor sets the color of the numeric values.
+// Defaults to DefaultNumberColor.
+func NumberColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.numberColor = c
+	})
+}
+
+// DefaultSelectedColorNumber is the default background color of the selected
+// line, this is an Xterm color number, see cell.ColorNumber.
+const DefaultSelectedColorNumber = 238
+
+// SelectedColor sets the background color of the selected line.
+// Defaults to DefaultSelectedColorNumber.
+func SelectedColor(c cell.Color) Option {
+	return option(func(opts *options) {
+		opts.selectedColor = c
+	})
+}
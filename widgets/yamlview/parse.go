@@ -0,0 +1,389 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yamlview
+
+// parse.go contains a parser of a subset of YAML.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// nodeKind is the kind of a node in the parsed document.
+type nodeKind int
+
+const (
+	nodeScalar nodeKind = iota
+	nodeMapping
+	nodeSequence
+)
+
+// node is a node in the parsed document.
+type node struct {
+	kind nodeKind
+	// value is the value of a scalar node.
+	value string
+	// quoted indicates that the scalar value was quoted.
+	quoted bool
+	// children are the entries of a mapping or the items of a sequence.
+	children []*child
+	// folded indicates that the children of a mapping or a sequence are
+	// hidden.
+	folded bool
+}
+
+// child is an entry of a mapping or an item of a sequence.
+type child struct {
+	// key is the key of a mapping entry, empty for sequence items.
+	key  string
+	node *node
+}
+
+// srcLine is a single line of the source document.
+type srcLine struct {
+	// num is the one-based line number used in errors.
+	num int
+	// indent is the number of leading spaces.
+	indent int
+	// text is the line without the indentation.
+	text string
+}
+
+// blank determines if the line is empty or only contains a comment.
+func (l srcLine) blank() bool {
+	return l.text == "" || strings.HasPrefix(l.text, "#")
+}
+
+// isItem determines if the line starts a sequence item.
+func (l srcLine) isItem() bool {
+	return l.text == "-" || strings.HasPrefix(l.text, "- ")
+}
+
+// parser parses the lines of a document.
+type parser struct {
+	lines []srcLine
+	// pos is the index of the current line.
+	pos int
+}
+
+// parse parses a YAML document. Supports block mappings and sequences,
+// plain, quoted and block scalars and comments. Flow collections, anchors and
+// tags are kept as plain scalar values. Returns a nil node for an empty
+// document.
+func parse(doc string) (*node, error) {
+	p := &parser{}
+	for i, text := range strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimLeft(text, " ")
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs cannot be used for indentation", i+1)
+		}
+		l := srcLine{
+			num:    i + 1,
+			indent: len(text) - len(trimmed),
+			text:   strings.TrimRight(trimmed, " "),
+		}
+		if l.indent == 0 && (l.text == "---" || l.text == "...") {
+			// Document start and end markers.
+			continue
+		}
+		p.lines = append(p.lines, l)
+	}
+
+	if !p.skipBlank() {
+		return nil, nil
+	}
+	n, err := p.parseBlock(p.lines[p.pos].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.skipBlank() {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].num)
+	}
+	return n, nil
+}
+
+// skipBlank moves to the next line that isn't blank.
+// Returns false if there are no more lines.
+func (p *parser) skipBlank() bool {
+	for ; p.pos < len(p.lines); p.pos++ {
+		if !p.lines[p.pos].blank() {
+			return true
+		}
+	}
+	return false
+}
+
+// parseBlock parses a node whose lines start at the current line and have
+// the specified indentation.
+func (p *parser) parseBlock(indent int) (*node, error) {
+	if p.lines[p.pos].isItem() {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+// next returns the next line that isn't blank and whether there is one.
+func (p *parser) next() (srcLine, bool) {
+	if !p.skipBlank() {
+		return srcLine{}, false
+	}
+	return p.lines[p.pos], true
+}
+
+// parseSequence parses the items of a sequence with the indentation.
+func (p *parser) parseSequence(indent int) (*node, error) {
+	n := &node{kind: nodeSequence}
+	for {
+		l, ok := p.next()
+		if !ok || l.indent < indent {
+			return n, nil
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", l.num)
+		}
+		if !l.isItem() {
+			return nil, fmt.Errorf("line %d: expected a sequence item starting with \"- \"", l.num)
+		}
+
+		rest := strings.TrimLeft(l.text[1:], " ")
+		if rest == "" || rest[0] == '#' {
+			p.pos++
+			item, err := p.parseValue(indent, false)
+			if err != nil {
+				return nil, err
+			}
+			n.children = append(n.children, &child{node: item})
+			continue
+		}
+
+		// The content of the item continues on the same line, parse it as if
+		// it started on its own line indented at its column.
+		col := l.indent + len(l.text) - len(rest)
+		p.lines[p.pos] = srcLine{num: l.num, indent: col, text: rest}
+		item, err := p.parseBlock(col)
+		if err != nil {
+			return nil, err
+		}
+		n.children = append(n.children, &child{node: item})
+	}
+}
+
+// parseMapping parses the entries of a mapping with the indentation. A single
+// line without a key is parsed as a scalar.
+func (p *parser) parseMapping(indent int) (*node, error) {
+	n := &node{kind: nodeMapping}
+	for {
+		l, ok := p.next()
+		if !ok || l.indent < indent {
+			return n, nil
+		}
+		if l.indent > indent {
+			return nil, fmt.Errorf("line %d: unexpected indentation", l.num)
+		}
+
+		key, rest, found := splitKey(l.text)
+		if !found {
+			if len(n.children) == 0 {
+				p.pos++
+				s, err := parseScalar(l.text, l.num)
+				if err != nil {
+					return nil, err
+				}
+				if next, ok := p.next(); ok && next.indent >= indent {
+					return nil, fmt.Errorf("line %d: expected the end of the scalar value started on line %d", next.num, l.num)
+				}
+				return s, nil
+			}
+			return nil, fmt.Errorf("line %d: expected a mapping entry in the form \"key: value\"", l.num)
+		}
+		k, err := unquote(key, l.num)
+		if err != nil {
+			return nil, err
+		}
+
+		p.pos++
+		var v *node
+		switch rest = stripComment(rest); {
+		case rest == "":
+			v, err = p.parseValue(indent, true)
+		case isBlockScalar(rest):
+			v = p.parseBlockScalar(indent, rest)
+		default:
+			v, err = parseScalar(rest, l.num)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.children = append(n.children, &child{key: k, node: v})
+	}
+}
+
+// parseValue parses a value that starts on the line after its key or its
+// sequence item marker, parent is the indentation of that line. A sequence
+// can have the same indentation as the key of a mapping entry. Returns an
+// empty scalar if there is no such value.
+func (p *parser) parseValue(parent int, inMapping bool) (*node, error) {
+	l, ok := p.next()
+	switch {
+	case ok && l.indent > parent:
+		return p.parseBlock(l.indent)
+	case ok && inMapping && l.indent == parent && l.isItem():
+		return p.parseSequence(parent)
+	default:
+		return &node{kind: nodeScalar}, nil
+	}
+}
+
+// isBlockScalar determines if the value starts a literal or folded block
+// scalar.
+func isBlockScalar(value string) bool {
+	switch value {
+	case "|", "|-", "|+", ">", ">-", ">+":
+		return true
+	}
+	return false
+}
+
+// parseBlockScalar parses the lines of a literal or folded block scalar that
+// are indented more than the parent. The indicator is the value that started
+// the block.
+func (p *parser) parseBlockScalar(parent int, indicator string) *node {
+	var lines []string
+	indent := -1
+	for ; p.pos < len(p.lines); p.pos++ {
+		l := p.lines[p.pos]
+		if l.text == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if l.indent <= parent {
+			break
+		}
+		if indent < 0 {
+			indent = l.indent
+		}
+		lines = append(lines, strings.Repeat(" ", l.indent-indent)+l.text)
+	}
+
+	// Trailing empty lines are governed by the chomping indicator.
+	content := len(lines)
+	for content > 0 && lines[content-1] == "" {
+		content--
+	}
+	sep := "\n"
+	if indicator[0] == '>' {
+		sep = " "
+	}
+	value := strings.Join(lines[:content], sep)
+	switch {
+	case strings.HasSuffix(indicator, "-"):
+	case strings.HasSuffix(indicator, "+"):
+		value += strings.Repeat("\n", len(lines)-content+1)
+	case content > 0:
+		value += "\n"
+	}
+	return &node{kind: nodeScalar, value: value, quoted: true}
+}
+
+// parseScalar parses a plain or quoted scalar value that is on the line with
+// the number.
+func parseScalar(text string, num int) (*node, error) {
+	text = stripComment(text)
+	v, err := unquote(text, num)
+	if err != nil {
+		return nil, err
+	}
+	return &node{
+		kind:   nodeScalar,
+		value:  v,
+		quoted: strings.HasPrefix(text, `"`) || strings.HasPrefix(text, "'"),
+	}, nil
+}
+
+// unquote returns the value of a double or single quoted text. Other text is
+// returned unchanged.
+func unquote(text string, num int) (string, error) {
+	switch {
+	case strings.HasPrefix(text, `"`):
+		v, err := strconv.Unquote(text)
+		if err != nil {
+			return "", fmt.Errorf("line %d: invalid double quoted value %s: %v", num, text, err)
+		}
+		return v, nil
+
+	case strings.HasPrefix(text, "'"):
+		if len(text) < 2 || !strings.HasSuffix(text, "'") {
+			return "", fmt.Errorf("line %d: unterminated single quoted value %s", num, text)
+		}
+		return strings.ReplaceAll(text[1:len(text)-1], "''", "'"), nil
+
+	default:
+		return text, nil
+	}
+}
+
+// quoteEnd returns the index just past the quoted text that starts at the
+// beginning of s or -1 if the quote isn't terminated.
+func quoteEnd(s string) int {
+	q := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case q == '"' && s[i] == '\\':
+			i++
+		case q == '\'' && s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == q:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// splitKey splits the text of a mapping entry into the key and the rest of
+// the line after the colon. Returns false if the text isn't a mapping entry.
+func splitKey(text string) (key, rest string, found bool) {
+	start := 0
+	if text != "" && (text[0] == '"' || text[0] == '\'') {
+		if start = quoteEnd(text); start < 0 {
+			return "", "", false
+		}
+	}
+	for i := start; i < len(text); i++ {
+		if text[i] == '#' && i > 0 && text[i-1] == ' ' {
+			return "", "", false
+		}
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return strings.TrimRight(text[:i], " "), strings.TrimLeft(text[i+1:], " "), true
+		}
+	}
+	return "", "", false
+}
+
+// stripComment removes a trailing comment from a value.
+func stripComment(value string) string {
+	start := 0
+	if value != "" && (value[0] == '"' || value[0] == '\'') {
+		if start = quoteEnd(value); start < 0 {
+			return value
+		}
+	}
+	for i := start; i < len(value); i++ {
+		if value[i] == '#' && (i == 0 || value[i-1] == ' ') {
+			return strings.TrimRight(value[:i], " ")
+		}
+	}
+	return value
+}
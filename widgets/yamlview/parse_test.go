@@ -0,0 +1,194 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yamlview
+
+import (
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+)
+
+// scalarNode returns a plain scalar node.
+func scalarNode(v string) *node {
+	return &node{kind: nodeScalar, value: v}
+}
+
+// quotedNode returns a quoted scalar node.
+func quotedNode(v string) *node {
+	return &node{kind: nodeScalar, value: v, quoted: true}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		desc    string
+		doc     string
+		want    *node
+		wantErr bool
+	}{
+		{
+			desc: "empty document",
+			doc:  "# only a comment\n\n",
+		},
+		{
+			desc: "top level scalar",
+			doc:  "--- \nhello",
+			want: scalarNode("hello"),
+		},
+		{
+			desc: "mapping with scalars",
+			doc: `
+name: "web" # the name
+port: 8080
+path: 'it''s'
+url: http://host:80
+empty:
+`,
+			want: &node{
+				kind: nodeMapping,
+				children: []*child{
+					{key: "name", node: quotedNode("web")},
+					{key: "port", node: scalarNode("8080")},
+					{key: "path", node: quotedNode("it's")},
+					{key: "url", node: scalarNode("http://host:80")},
+					{key: "empty", node: scalarNode("")},
+				},
+			},
+		},
+		{
+			desc: "nested mappings and sequences",
+			doc: `
+server:
+  hosts:
+    - a
+    - b
+  "tls key": x
+list:
+- one
+- key: v
+  other: w
+-
+  - nested
+`,
+			want: &node{
+				kind: nodeMapping,
+				children: []*child{
+					{key: "server", node: &node{
+						kind: nodeMapping,
+						children: []*child{
+							{key: "hosts", node: &node{
+								kind: nodeSequence,
+								children: []*child{
+									{node: scalarNode("a")},
+									{node: scalarNode("b")},
+								},
+							}},
+							{key: "tls key", node: scalarNode("x")},
+						},
+					}},
+					{key: "list", node: &node{
+						kind: nodeSequence,
+						children: []*child{
+							{node: scalarNode("one")},
+							{node: &node{
+								kind: nodeMapping,
+								children: []*child{
+									{key: "key", node: scalarNode("v")},
+									{key: "other", node: scalarNode("w")},
+								},
+							}},
+							{node: &node{
+								kind: nodeSequence,
+								children: []*child{
+									{node: scalarNode("nested")},
+								},
+							}},
+						},
+					}},
+				},
+			},
+		},
+		{
+			desc: "block scalars",
+			doc: `
+literal: |
+  a
+    b
+
+folded: >-
+  c
+  d
+next: e
+`,
+			want: &node{
+				kind: nodeMapping,
+				children: []*child{
+					{key: "literal", node: quotedNode("a\n  b\n")},
+					{key: "folded", node: quotedNode("c d")},
+					{key: "next", node: scalarNode("e")},
+				},
+			},
+		},
+		{
+			desc:    "fails on tab indentation",
+			doc:     "a:\n\tb: c",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on unexpected indentation",
+			doc:     "a: b\n  c: d",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on line without a key in a mapping",
+			doc:     "a: b\nc",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on sequence item in a mapping",
+			doc:     "a: b\n- c",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on mapping entry in a sequence",
+			doc:     "- a\nb: c",
+			wantErr: true,
+		},
+		{
+			desc:    "fails on invalid double quoted value",
+			doc:     `a: "b`,
+			wantErr: true,
+		},
+		{
+			desc:    "fails on unterminated single quoted value",
+			doc:     "a: 'b",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := parse(tc.doc)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("parse => unexpected error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := pretty.Compare(tc.want, got); diff != "" {
+				t.Errorf("parse => unexpected diff (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package yamlview contains a widget that displays a YAML document with
+// foldable mappings and sequences.
+package yamlview
+
+import (
+	"image"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/runewidth"
+	"github.com/mum4k/termdash/private/wrap"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// line is a single displayed line of the document.
+type line struct {
+	// level is the indentation level of the line.
+	level int
+	// c is the mapping entry or the sequence item displayed on the line.
+	c *child
+}
+
+// indentWidth is the number of cells each indentation level occupies.
+const indentWidth = 2
+
+// foldable determines if the line displays a non-empty mapping or sequence.
+func (l line) foldable() bool {
+	return l.c.node.kind != nodeScalar && len(l.c.node.children) > 0
+}
+
+// YAMLView displays a YAML document with syntax coloring.
+//
+// Mappings and sequences deeper than the FoldDepth option are initially
+// folded and displayed as {...} or [...]. The arrow and the page keys move
+// the selected line, the Enter key or a mouse click folds or expands the
+// selected mapping or sequence.
+//
+// Implements widgetapi.Widget. This object is thread-safe.
+type YAMLView struct {
+	// root is the root of the parsed document.
+	root *node
+	// lines are the currently displayed lines.
+	lines []line
+
+	// selected is the index of the selected line.
+	selected int
+	// offset is the index of the first visible line.
+	offset int
+	// height is the height of the canvas during the last Draw.
+	height int
+
+	// mu protects the YAMLView.
+	mu sync.Mutex
+
+	// opts are the provided options.
+	opts *options
+}
+
+// New returns a new YAMLView.
+func New(opts ...Option) (*YAMLView, error) {
+	o := newOptions(opts...)
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	return &YAMLView{
+		opts: o,
+	}, nil
+}
+
+// SetContent parses and displays the YAML document, replacing any previous
+// document. Supports block mappings and sequences, plain, quoted and block
+// scalars and comments. Flow collections like [a, b], anchors and tags are
+// displayed as plain values. Returns an error if the document cannot be
+// parsed, the previous document remains displayed in that case.
+func (yv *YAMLView) SetContent(yamlStr string) error {
+	root, err := parse(yamlStr)
+	if err != nil {
+		return err
+	}
+
+	yv.mu.Lock()
+	defer yv.mu.Unlock()
+	fold(root, 0, yv.opts.foldDepth)
+	yv.root = root
+	yv.selected = 0
+	yv.offset = 0
+	yv.flatten()
+	return nil
+}
+
+// fold folds the mappings and sequences at or below the depth.
+func fold(n *node, depth, foldDepth int) {
+	if n == nil {
+		return
+	}
+	n.folded = n.kind != nodeScalar && depth >= foldDepth
+	for _, c := range n.children {
+		fold(c.node, depth+1, foldDepth)
+	}
+}
+
+// flatten updates the displayed lines from the document.
+// The caller must hold yv.mu.
+func (yv *YAMLView) flatten() {
+	yv.lines = nil
+	switch {
+	case yv.root == nil:
+	case yv.root.kind == nodeScalar:
+		yv.lines = append(yv.lines, line{c: &child{node: yv.root}})
+	default:
+		yv.addLines(yv.root, 0)
+	}
+}
+
+// addLines adds lines for the children of the node at the level.
+// The caller must hold yv.mu.
+func (yv *YAMLView) addLines(n *node, level int) {
+	for _, c := range n.children {
+		yv.lines = append(yv.lines, line{level: level, c: c})
+		if c.node.kind != nodeScalar && !c.node.folded {
+			yv.addLines(c.node, level+1)
+		}
+	}
+}
+
+// toggle folds or expands the selected line.
+// The caller must hold yv.mu.
+func (yv *YAMLView) toggle() {
+	if yv.selected >= len(yv.lines) || !yv.lines[yv.selected].foldable() {
+		return
+	}
+	n := yv.lines[yv.selected].c.node
+	n.folded = !n.folded
+	yv.flatten()
+}
+
+// moveSelection moves the selected line by the number of lines.
+// The caller must hold yv.mu.
+func (yv *YAMLView) moveSelection(by int) {
+	yv.selected += by
+	if yv.selected >= len(yv.lines) {
+		yv.selected = len(yv.lines) - 1
+	}
+	if yv.selected < 0 {
+		yv.selected = 0
+	}
+}
+
+// segment is a part of a line drawn with the same cell options.
+type segment struct {
+	text  string
+	cOpts []cell.Option
+}
+
+// segments returns the segments of the line without the indentation.
+// The caller must hold yv.mu.
+func (yv *YAMLView) segments(l line) []segment {
+	var res []segment
+	if l.c.key == "" && l.c.node != yv.root {
+		res = append(res, segment{text: "- "})
+	}
+	if l.c.key != "" {
+		res = append(res,
+			segment{text: displayable(l.c.key), cOpts: []cell.Option{cell.FgColor(yv.opts.keyColor)}},
+			segment{text: ": "},
+		)
+	}
+
+	n := l.c.node
+	switch {
+	case n.kind == nodeScalar && n.value != "":
+		var cOpts []cell.Option
+		if c, ok := yv.scalarColor(n); ok {
+			cOpts = []cell.Option{cell.FgColor(c)}
+		}
+		res = append(res, segment{text: displayable(n.value), cOpts: cOpts})
+	case n.kind == nodeMapping && len(n.children) == 0:
+		res = append(res, segment{text: "{}"})
+	case n.kind == nodeMapping && n.folded:
+		res = append(res, segment{text: "{...}"})
+	case n.kind == nodeSequence && len(n.children) == 0:
+		res = append(res, segment{text: "[]"})
+	case n.kind == nodeSequence && n.folded:
+		res = append(res, segment{text: "[...]"})
+	}
+	return res
+}
+
+// scalarColor returns the color of the scalar value. Returns false for
+// booleans and nulls that use the default color.
+func (yv *YAMLView) scalarColor(n *node) (cell.Color, bool) {
+	if n.quoted {
+		return yv.opts.stringColor, true
+	}
+	switch strings.ToLower(n.value) {
+	case "true", "false", "null", "~":
+		return 0, false
+	}
+	if _, err := strconv.ParseFloat(strings.ReplaceAll(n.value, "_", ""), 64); err == nil {
+		return yv.opts.numberColor, true
+	}
+	return yv.opts.stringColor, true
+}
+
+// displayable returns the text if it can be drawn or its quoted form that
+// escapes newlines and other control characters.
+func displayable(text string) string {
+	if err := wrap.ValidText(text); err != nil {
+		return strconv.Quote(text)
+	}
+	return text
+}
+
+// Draw draws the YAMLView widget onto the canvas.
+// Implements widgetapi.Widget.Draw.
+func (yv *YAMLView) Draw(cvs *canvas.Canvas, meta *widgetapi.Meta) error {
+	yv.mu.Lock()
+	defer yv.mu.Unlock()
+
+	ar := cvs.Area()
+	yv.height = ar.Dy()
+	// Keep the selected line visible.
+	if yv.selected < yv.offset {
+		yv.offset = yv.selected
+	}
+	if yv.selected >= yv.offset+yv.height {
+		yv.offset = yv.selected - yv.height + 1
+	}
+	if max := len(yv.lines) - yv.height; yv.offset > max && max >= 0 {
+		yv.offset = max
+	}
+
+	for i := yv.offset; i < len(yv.lines) && i-yv.offset < ar.Dy(); i++ {
+		y := ar.Min.Y + i - yv.offset
+		l := yv.lines[i]
+		var selOpts []cell.Option
+		if i == yv.selected {
+			selOpts = []cell.Option{cell.BgColor(yv.opts.selectedColor)}
+			if err := cvs.SetAreaCells(image.Rect(ar.Min.X, y, ar.Max.X, y+1), ' ', selOpts...); err != nil {
+				return err
+			}
+		}
+
+		x := ar.Min.X + l.level*indentWidth
+		for _, s := range yv.segments(l) {
+			if x >= ar.Max.X {
+				break
+			}
+			cOpts := append(append([]cell.Option(nil), s.cOpts...), selOpts...)
+			if err := draw.Text(cvs, s.text, image.Point{x, y},
+				draw.TextCellOpts(cOpts...),
+				draw.TextMaxX(ar.Max.X),
+				draw.TextOverrunMode(draw.OverrunModeThreeDot),
+			); err != nil {
+				return err
+			}
+			x += runewidth.StringWidth(s.text)
+		}
+	}
+	return nil
+}
+
+// Keyboard moves the selected line and folds or expands it.
+// Implements widgetapi.Widget.Keyboard.
+func (yv *YAMLView) Keyboard(k *terminalapi.Keyboard) error {
+	yv.mu.Lock()
+	defer yv.mu.Unlock()
+
+	switch k.Key {
+	case keyboard.KeyArrowUp:
+		yv.moveSelection(-1)
+	case keyboard.KeyArrowDown:
+		yv.moveSelection(1)
+	case keyboard.KeyPgUp:
+		yv.moveSelection(-yv.height)
+	case keyboard.KeyPgDn:
+		yv.moveSelection(yv.height)
+	case keyboard.KeyEnter:
+		yv.toggle()
+	}
+	return nil
+}
+
+// Mouse selects the clicked line and folds or expands it, the mouse wheel
+// moves the selected line.
+// Implements widgetapi.Widget.Mouse.
+func (yv *YAMLView) Mouse(m *terminalapi.Mouse) error {
+	yv.mu.Lock()
+	defer yv.mu.Unlock()
+
+	switch m.Button {
+	case mouse.ButtonLeft:
+		if i := yv.offset + m.Position.Y; i < len(yv.lines) {
+			yv.selected = i
+			yv.toggle()
+		}
+	case mouse.ButtonWheelUp:
+		yv.moveSelection(-1)
+	case mouse.ButtonWheelDown:
+		yv.moveSelection(1)
+	}
+	return nil
+}
+
+// Options implements widgetapi.Widget.Options.
+func (yv *YAMLView) Options() widgetapi.Options {
+	return widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+}
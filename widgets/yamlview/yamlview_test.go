@@ -0,0 +1,301 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package yamlview
+
+import (
+	"image"
+	"testing"
+
+	"github.com/kylelemons/godebug/pretty"
+	"github.com/mum4k/termdash/cell"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/draw"
+	"github.com/mum4k/termdash/private/draw/testdraw"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+// testDoc is a YAML document used in the tests.
+const testDoc = `
+name: web
+server:
+  port: 80
+  tls:
+    cert: x
+hosts:
+  - a
+`
+
+// selected are the cell options of the background of the selected line.
+var selected = cell.BgColor(cell.ColorNumber(DefaultSelectedColorNumber))
+
+// colored returns text options with the foreground color and the extra cell
+// options.
+func colored(c cell.Color, cOpts ...cell.Option) draw.TextOption {
+	return draw.TextCellOpts(append([]cell.Option{cell.FgColor(c)}, cOpts...)...)
+}
+
+// mustSelect highlights the line as selected.
+func mustSelect(c *canvas.Canvas, y int) {
+	testcanvas.MustSetAreaCells(c, image.Rect(0, y, c.Area().Dx(), y+1), ' ', selected)
+}
+
+func TestYAMLView(t *testing.T) {
+	tests := []struct {
+		desc       string
+		opts       []Option
+		content    string
+		canvas     image.Rectangle
+		events     []terminalapi.Event
+		want       func(size image.Point) *faketerm.Terminal
+		wantNewErr bool
+		wantSetErr bool
+	}{
+		{
+			desc:       "fails on invalid FoldDepth",
+			opts:       []Option{FoldDepth(0)},
+			canvas:     image.Rect(0, 0, 20, 5),
+			wantNewErr: true,
+		},
+		{
+			desc:       "fails on invalid document",
+			content:    "a: b\n  c: d",
+			canvas:     image.Rect(0, 0, 20, 5),
+			wantSetErr: true,
+		},
+		{
+			desc:   "draws nothing without a document",
+			canvas: image.Rect(0, 0, 20, 5),
+			want: func(size image.Point) *faketerm.Terminal {
+				return faketerm.MustNew(size)
+			},
+		},
+		{
+			desc:    "draws a top level scalar",
+			content: `"a\tb"`,
+			canvas:  image.Rect(0, 0, 20, 1),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustSelect(c, 0)
+				testdraw.MustText(c, `"a\tb"`, image.Point{0, 0}, colored(DefaultStringColor, selected))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "folds deep mappings by default",
+			content: testDoc,
+			canvas:  image.Rect(0, 0, 20, 6),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustSelect(c, 0)
+				testdraw.MustText(c, "name", image.Point{0, 0}, colored(DefaultKeyColor, selected))
+				testdraw.MustText(c, ": ", image.Point{4, 0}, draw.TextCellOpts(selected))
+				testdraw.MustText(c, "web", image.Point{6, 0}, colored(DefaultStringColor, selected))
+				testdraw.MustText(c, "server", image.Point{0, 1}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": ", image.Point{6, 1})
+				testdraw.MustText(c, "port", image.Point{2, 2}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": ", image.Point{6, 2})
+				testdraw.MustText(c, "80", image.Point{8, 2}, colored(DefaultNumberColor))
+				testdraw.MustText(c, "tls", image.Point{2, 3}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": {...}", image.Point{5, 3})
+				testdraw.MustText(c, "hosts", image.Point{0, 4}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": ", image.Point{5, 4})
+				testdraw.MustText(c, "- ", image.Point{2, 5})
+				testdraw.MustText(c, "a", image.Point{4, 5}, colored(DefaultStringColor))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "custom fold depth and colors",
+			opts:    []Option{FoldDepth(1), KeyColor(cell.ColorRed), SelectedColor(cell.ColorWhite)},
+			content: testDoc,
+			canvas:  image.Rect(0, 0, 20, 3),
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				sel := cell.BgColor(cell.ColorWhite)
+				testcanvas.MustSetAreaCells(c, image.Rect(0, 0, 20, 1), ' ', sel)
+				testdraw.MustText(c, "name", image.Point{0, 0}, colored(cell.ColorRed, sel))
+				testdraw.MustText(c, ": ", image.Point{4, 0}, draw.TextCellOpts(sel))
+				testdraw.MustText(c, "web", image.Point{6, 0}, colored(DefaultStringColor, sel))
+				testdraw.MustText(c, "server", image.Point{0, 1}, colored(cell.ColorRed))
+				testdraw.MustText(c, ": {...}", image.Point{6, 1})
+				testdraw.MustText(c, "hosts", image.Point{0, 2}, colored(cell.ColorRed))
+				testdraw.MustText(c, ": [...]", image.Point{5, 2})
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "enter expands the selected line",
+			opts:    []Option{FoldDepth(1)},
+			content: "a:\n  b: 1\n",
+			canvas:  image.Rect(0, 0, 20, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyEnter},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				mustSelect(c, 0)
+				testdraw.MustText(c, "a", image.Point{0, 0}, colored(DefaultKeyColor, selected))
+				testdraw.MustText(c, ": ", image.Point{1, 0}, draw.TextCellOpts(selected))
+				testdraw.MustText(c, "b", image.Point{2, 1}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": ", image.Point{3, 1})
+				testdraw.MustText(c, "1", image.Point{5, 1}, colored(DefaultNumberColor))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "clicking on a line selects and folds it",
+			content: "x: true\na:\n  b: 1\n",
+			canvas:  image.Rect(0, 0, 20, 3),
+			events: []terminalapi.Event{
+				&terminalapi.Mouse{Position: image.Point{0, 1}, Button: mouse.ButtonLeft},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "x", image.Point{0, 0}, colored(DefaultKeyColor))
+				testdraw.MustText(c, ": true", image.Point{1, 0})
+				mustSelect(c, 1)
+				testdraw.MustText(c, "a", image.Point{0, 1}, colored(DefaultKeyColor, selected))
+				testdraw.MustText(c, ": {...}", image.Point{1, 1}, draw.TextCellOpts(selected))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+		{
+			desc:    "scrolls to keep the selected line visible",
+			content: "- 1\n- 2\n- 3\n- 4\n",
+			canvas:  image.Rect(0, 0, 10, 2),
+			events: []terminalapi.Event{
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyPgDn},
+				&terminalapi.Keyboard{Key: keyboard.KeyArrowUp},
+				&terminalapi.Mouse{Button: mouse.ButtonWheelDown},
+			},
+			want: func(size image.Point) *faketerm.Terminal {
+				ft := faketerm.MustNew(size)
+				c := testcanvas.MustNew(ft.Area())
+
+				testdraw.MustText(c, "- ", image.Point{0, 0})
+				testdraw.MustText(c, "3", image.Point{2, 0}, colored(DefaultNumberColor))
+				mustSelect(c, 1)
+				testdraw.MustText(c, "- ", image.Point{0, 1}, draw.TextCellOpts(selected))
+				testdraw.MustText(c, "4", image.Point{2, 1}, colored(DefaultNumberColor, selected))
+				testcanvas.MustApply(c, ft)
+				return ft
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			yv, err := New(tc.opts...)
+			if (err != nil) != tc.wantNewErr {
+				t.Errorf("New => unexpected error: %v, wantNewErr: %v", err, tc.wantNewErr)
+			}
+			if err != nil {
+				return
+			}
+
+			err = yv.SetContent(tc.content)
+			if (err != nil) != tc.wantSetErr {
+				t.Errorf("SetContent => unexpected error: %v, wantSetErr: %v", err, tc.wantSetErr)
+			}
+			if err != nil {
+				return
+			}
+
+			c, err := canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			// The first draw determines the page size used when scrolling.
+			if err := yv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			for _, ev := range tc.events {
+				switch e := ev.(type) {
+				case *terminalapi.Keyboard:
+					if err := yv.Keyboard(e); err != nil {
+						t.Fatalf("Keyboard => unexpected error: %v", err)
+					}
+				case *terminalapi.Mouse:
+					if err := yv.Mouse(e); err != nil {
+						t.Fatalf("Mouse => unexpected error: %v", err)
+					}
+				default:
+					t.Fatalf("unsupported event type: %T", ev)
+				}
+				if err := yv.Draw(c, &widgetapi.Meta{}); err != nil {
+					t.Fatalf("Draw => unexpected error: %v", err)
+				}
+			}
+
+			c, err = canvas.New(tc.canvas)
+			if err != nil {
+				t.Fatalf("canvas.New => unexpected error: %v", err)
+			}
+			if err := yv.Draw(c, &widgetapi.Meta{}); err != nil {
+				t.Fatalf("Draw => unexpected error: %v", err)
+			}
+
+			got, err := faketerm.New(c.Size())
+			if err != nil {
+				t.Fatalf("faketerm.New => unexpected error: %v", err)
+			}
+			if err := c.Apply(got); err != nil {
+				t.Fatalf("Apply => unexpected error: %v", err)
+			}
+			if diff := faketerm.Diff(tc.want(c.Size()), got); diff != "" {
+				t.Errorf("Draw => %v", diff)
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	yv, err := New()
+	if err != nil {
+		t.Fatalf("New => unexpected error: %v", err)
+	}
+
+	got := yv.Options()
+	want := widgetapi.Options{
+		MinimumSize:  image.Point{1, 1},
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+	if diff := pretty.Compare(want, got); diff != "" {
+		t.Errorf("Options => unexpected diff (-want, +got):\n%s", diff)
+	}
+}
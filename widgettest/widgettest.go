@@ -0,0 +1,166 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package widgettest provides helpers for integration tests of widgets.
+package widgettest
+
+import (
+	"context"
+	"image"
+	"sync"
+	"testing"
+
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/event/eventqueue"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+)
+
+// EventInjector provides a fake terminal for termdash and injects synthetic
+// input events into it. Allows to wait until termdash redraws the terminal
+// in reaction to the events.
+//
+// Use it with the termdash.Controller, periodic redraws could otherwise
+// complete a frame before termdash processes the injected event.
+//
+// This object is thread-safe.
+type EventInjector struct {
+	// t is the test that uses the injector.
+	t testing.TB
+
+	// ft is the fake terminal.
+	ft *faketerm.Terminal
+
+	// eq is the queue of events termdash reads from the fake terminal.
+	eq *eventqueue.Unbound
+
+	// frames is the number of frames flushed to the terminal.
+	frames int
+	// injectedAt is the value of frames when the last event was injected.
+	injectedAt int
+	// flushed gets closed and replaced each time a frame is flushed.
+	flushed chan struct{}
+
+	// mu protects the fields above.
+	mu sync.Mutex
+}
+
+// NewEventInjector returns a new EventInjector with a fake terminal of the
+// specified size. Fails the test if the terminal cannot be created.
+func NewEventInjector(t testing.TB, size image.Point) *EventInjector {
+	t.Helper()
+
+	eq := eventqueue.New()
+	ft, err := faketerm.New(size, faketerm.WithEventQueue(eq))
+	if err != nil {
+		t.Fatalf("faketerm.New => unexpected error: %v", err)
+	}
+	return &EventInjector{
+		t:       t,
+		ft:      ft,
+		eq:      eq,
+		flushed: make(chan struct{}),
+	}
+}
+
+// Terminal returns the terminal that should be provided to termdash.
+func (ei *EventInjector) Terminal() terminalapi.Terminal {
+	return &terminal{
+		Terminal: ei.ft,
+		ei:       ei,
+	}
+}
+
+// String returns the content of the terminal as of the last completed frame.
+// Implements fmt.Stringer.
+func (ei *EventInjector) String() string {
+	return ei.ft.String()
+}
+
+// inject queues the event for termdash.
+func (ei *EventInjector) inject(ev terminalapi.Event) {
+	ei.mu.Lock()
+	defer ei.mu.Unlock()
+
+	ei.injectedAt = ei.frames
+	ei.eq.Push(ev)
+}
+
+// SendKey injects a keyboard event with the key.
+func (ei *EventInjector) SendKey(k keyboard.Key) {
+	ei.inject(&terminalapi.Keyboard{Key: k})
+}
+
+// SendMouse injects a mouse event with the button at the position.
+func (ei *EventInjector) SendMouse(pos image.Point, button mouse.Button) {
+	ei.inject(&terminalapi.Mouse{Position: pos, Button: button})
+}
+
+// SendResize resizes the terminal to width w and height h cells.
+// Termdash doesn't redraw in reaction to resize events, the new size is used
+// on the next redraw.
+func (ei *EventInjector) SendResize(w, h int) {
+	ei.inject(&terminalapi.Resize{Size: image.Point{w, h}})
+}
+
+// WaitForRedraw blocks until a frame is flushed to the terminal after the
+// last injected event. Fails the test if the context expires first.
+func (ei *EventInjector) WaitForRedraw(ctx context.Context) {
+	ei.t.Helper()
+
+	for {
+		ei.mu.Lock()
+		done := ei.frames > ei.injectedAt
+		flushed := ei.flushed
+		ei.mu.Unlock()
+		if done {
+			return
+		}
+
+		select {
+		case <-flushed:
+		case <-ctx.Done():
+			ei.t.Fatalf("WaitForRedraw => the terminal wasn't redrawn after the injected event: %v", ctx.Err())
+			return
+		}
+	}
+}
+
+// frameFlushed records that a frame was flushed to the terminal.
+func (ei *EventInjector) frameFlushed() {
+	ei.mu.Lock()
+	defer ei.mu.Unlock()
+
+	ei.frames++
+	close(ei.flushed)
+	ei.flushed = make(chan struct{})
+}
+
+// terminal is the fake terminal that reports flushed frames to the
+// EventInjector.
+type terminal struct {
+	*faketerm.Terminal
+
+	ei *EventInjector
+}
+
+// Flush implements terminalapi.Terminal.Flush.
+func (t *terminal) Flush() error {
+	if err := t.Terminal.Flush(); err != nil {
+		return err
+	}
+	t.ei.frameFlushed()
+	return nil
+}
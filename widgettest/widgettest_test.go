@@ -0,0 +1,125 @@
+// Copyright 2020 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package widgettest
+
+import (
+	"context"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/mum4k/termdash"
+	"github.com/mum4k/termdash/container"
+	"github.com/mum4k/termdash/keyboard"
+	"github.com/mum4k/termdash/mouse"
+	"github.com/mum4k/termdash/private/canvas/testcanvas"
+	"github.com/mum4k/termdash/private/faketerm"
+	"github.com/mum4k/termdash/private/fakewidget"
+	"github.com/mum4k/termdash/terminal/terminalapi"
+	"github.com/mum4k/termdash/widgetapi"
+)
+
+func TestEventInjector(t *testing.T) {
+	wOpts := widgetapi.Options{
+		WantKeyboard: widgetapi.KeyScopeFocused,
+		WantMouse:    widgetapi.MouseScopeWidget,
+	}
+
+	tests := []struct {
+		desc string
+		size image.Point
+		// inject injects events and returns the expected last event received
+		// by the widget.
+		inject func(ei *EventInjector, ctrl *termdash.Controller) terminalapi.Event
+		// wantSize is the expected size of the terminal after the redraw.
+		wantSize image.Point
+	}{
+		{
+			desc: "waits for redraw after a keyboard event",
+			size: image.Point{40, 10},
+			inject: func(ei *EventInjector, _ *termdash.Controller) terminalapi.Event {
+				ei.SendKey(keyboard.KeyEnter)
+				return &terminalapi.Keyboard{Key: keyboard.KeyEnter}
+			},
+			wantSize: image.Point{40, 10},
+		},
+		{
+			desc: "waits for redraw after a mouse event",
+			size: image.Point{40, 10},
+			inject: func(ei *EventInjector, _ *termdash.Controller) terminalapi.Event {
+				ei.SendMouse(image.Point{1, 1}, mouse.ButtonLeft)
+				return &terminalapi.Mouse{Position: image.Point{1, 1}, Button: mouse.ButtonLeft}
+			},
+			wantSize: image.Point{40, 10},
+		},
+		{
+			desc: "resizes the terminal",
+			size: image.Point{40, 10},
+			inject: func(ei *EventInjector, ctrl *termdash.Controller) terminalapi.Event {
+				ei.SendResize(30, 5)
+				// Wait until the terminal is resized, termdash doesn't
+				// redraw on resize events.
+				deadline := time.Now().Add(5 * time.Second)
+				for ei.ft.Size() != (image.Point{30, 5}) && time.Now().Before(deadline) {
+					time.Sleep(time.Millisecond)
+				}
+				if err := ctrl.Redraw(); err != nil {
+					t.Fatalf("Redraw => unexpected error: %v", err)
+				}
+				return nil
+			},
+			wantSize: image.Point{30, 5},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ei := NewEventInjector(t, tc.size)
+			cont, err := container.New(
+				ei.Terminal(),
+				container.PlaceWidget(fakewidget.New(wOpts)),
+			)
+			if err != nil {
+				t.Fatalf("container.New => unexpected error: %v", err)
+			}
+			ctrl, err := termdash.NewController(ei.Terminal(), cont)
+			if err != nil {
+				t.Fatalf("termdash.NewController => unexpected error: %v", err)
+			}
+			defer ctrl.Close()
+
+			wantEv := tc.inject(ei, ctrl)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			ei.WaitForRedraw(ctx)
+
+			want := faketerm.MustNew(tc.wantSize)
+			var events []terminalapi.Event
+			if wantEv != nil {
+				events = append(events, wantEv)
+			}
+			fakewidget.MustDraw(
+				want,
+				testcanvas.MustNew(want.Area()),
+				&widgetapi.Meta{Focused: true},
+				wOpts,
+				events...,
+			)
+			if diff := faketerm.Diff(want, ei.ft); diff != "" {
+				t.Errorf("WaitForRedraw => %v", diff)
+			}
+		})
+	}
+}